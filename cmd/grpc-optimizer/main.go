@@ -0,0 +1,16 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	rpc "github.com/llm-inferno/optimizer/grpc-server"
+)
+
+// create and run a (statefull) gRPC Optimizer server
+func main() {
+	if err := rpc.NewServer().Run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
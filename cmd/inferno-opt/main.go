@@ -0,0 +1,334 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"text/tabwriter"
+
+	"github.com/llm-inferno/optimizer/pkg/config"
+	"github.com/llm-inferno/optimizer/pkg/core"
+	"github.com/llm-inferno/optimizer/pkg/manager"
+	"github.com/llm-inferno/optimizer/pkg/solver"
+)
+
+// exit codes
+const (
+	exitError       = 1 // invalid arguments or data, or optimization error
+	exitUnallocated = 2 // some servers not given an allocation
+)
+
+// names of data files in a data directory
+const (
+	accFileName = "accelerator-data.json"
+	capFileName = "capacity-data.json"
+	modFileName = "model-data.json"
+	svcFileName = "serviceclass-data.json"
+	srvFileName = "server-data.json"
+	optFileName = "optimizer-data.json"
+)
+
+// solution, with differences from a prior solution by server name (JSON output with a prior solution)
+type solutionWithDiff struct {
+	Solution *config.AllocationSolution           `json:"solution"`
+	Diff     map[string]config.AllocationDiffData `json:"diff"`
+}
+
+// optimize a system offline, given its data in files, writing the solution to stdout
+//   - data in a single (JSON or YAML) SystemData file (-system), with all its sections, or else in six files
+//     in a directory (-dir), each overridden by a file argument (e.g. -servers)
+//   - the optimizer spec of the data is overridden by an optimizer file and by algorithm, objective,
+//     saturation policy, and debug arguments
+//   - exit code is 2 if any server is not given an allocation, 1 on errors
+func main() {
+	systemFile := flag.String("system", "", "file of all system data (SystemData), instead of separate data files")
+	dir := flag.String("dir", ".", "directory of separate data files with default names")
+	accFile := flag.String("accelerators", "", "file of accelerator data (default <dir>/"+accFileName+")")
+	capFile := flag.String("capacity", "", "file of capacity data (default <dir>/"+capFileName+")")
+	modFile := flag.String("models", "", "file of model data (default <dir>/"+modFileName+")")
+	svcFile := flag.String("serviceclasses", "", "file of service class data (default <dir>/"+svcFileName+")")
+	srvFile := flag.String("servers", "", "file of server data (default <dir>/"+srvFileName+")")
+	optFile := flag.String("optimizer", "", "file of optimizer data (default <dir>/"+optFileName+", or from system data)")
+	algorithm := flag.String("algorithm", "", "algorithm solving the allocation problem, overriding the optimizer spec")
+	objective := flag.String("objective", "", "metric minimized by the value function, overriding the optimizer spec")
+	saturationPolicy := flag.String("saturation-policy", "", "allocation policy under saturation, overriding the optimizer spec")
+	debug := flag.Bool("debug", false, "record decisions of the greedy algorithm in the solution metadata")
+	priorFile := flag.String("prior", "", "file of a prior solution to report differences from")
+	output := flag.String("output", "json", "output format: json or table")
+	flag.Parse()
+
+	if *output != "json" && *output != "table" {
+		fail(fmt.Errorf("unknown output format %q", *output))
+	}
+
+	var spec config.SystemSpec
+	if *systemFile != "" {
+		bytes, err := os.ReadFile(*systemFile)
+		if err != nil {
+			fail(err)
+		}
+		d, err := config.SystemFromFileData(*systemFile, bytes)
+		if err != nil {
+			fail(err)
+		}
+		spec = d.Spec
+	} else {
+		if err := readSpecs(&spec, *dir, *accFile, *capFile, *modFile, *svcFile, *srvFile); err != nil {
+			fail(err)
+		}
+		if *optFile == "" {
+			*optFile = filepath.Join(*dir, optFileName)
+		}
+	}
+	if *optFile != "" {
+		d, err := readData(*optFile, config.OptimizerData{})
+		if err != nil {
+			fail(err)
+		}
+		spec.Optimizer = *d
+	}
+	optimizerSpec := &spec.Optimizer.Spec
+	if *algorithm != "" {
+		optimizerSpec.Algorithm = *algorithm
+	}
+	if *objective != "" {
+		optimizerSpec.Objective = *objective
+	}
+	if *saturationPolicy != "" {
+		optimizerSpec.SaturationPolicy = *saturationPolicy
+	}
+	if *debug {
+		optimizerSpec.Debug = true
+	}
+	if err := spec.Validate(); err != nil {
+		fail(err)
+	}
+
+	var prior *config.AllocationSolution
+	if *priorFile != "" {
+		d, err := readData(*priorFile, config.AllocationSolution{})
+		if err != nil {
+			fail(err)
+		}
+		prior = d
+	}
+
+	solution, err := optimize(&spec)
+	if err != nil {
+		fail(err)
+	}
+	var diff map[string]config.AllocationDiffData
+	if prior != nil {
+		diff = solutionDiff(prior, solution)
+	}
+
+	if *output == "table" {
+		writeTable(os.Stdout, solution, diff)
+	} else if err := writeJSON(os.Stdout, solution, diff, prior != nil); err != nil {
+		fail(err)
+	}
+	if len(solution.Unallocated) > 0 {
+		os.Exit(exitUnallocated)
+	}
+}
+
+// print an error and exit
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "inferno-opt:", err)
+	os.Exit(exitError)
+}
+
+// read a JSON or YAML data file
+func readData[T any](fileName string, t T) (*T, error) {
+	bytes, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	return config.FromFileData(fileName, bytes, t)
+}
+
+// read separate data files into a system spec, files not given having default names in a directory
+func readSpecs(spec *config.SystemSpec, dir, accFile, capFile, modFile, svcFile, srvFile string) error {
+	fileName := func(name, defaultName string) string {
+		if name != "" {
+			return name
+		}
+		return filepath.Join(dir, defaultName)
+	}
+	acc, err := readData(fileName(accFile, accFileName), config.AcceleratorData{})
+	if err != nil {
+		return err
+	}
+	capacity, err := readData(fileName(capFile, capFileName), config.CapacityData{})
+	if err != nil {
+		return err
+	}
+	mod, err := readData(fileName(modFile, modFileName), config.ModelData{})
+	if err != nil {
+		return err
+	}
+	svc, err := readData(fileName(svcFile, svcFileName), config.ServiceClassData{})
+	if err != nil {
+		return err
+	}
+	srv, err := readData(fileName(srvFile, srvFileName), config.ServerData{})
+	if err != nil {
+		return err
+	}
+	spec.Accelerators = *acc
+	spec.Capacity = *capacity
+	spec.Models = *mod
+	spec.ServiceClasses = *svc
+	spec.Servers = *srv
+	return nil
+}
+
+// optimize a system given its spec, returning its solution
+func optimize(spec *config.SystemSpec) (*config.AllocationSolution, error) {
+	system := core.NewSystem()
+	optimizerSpec, err := system.SetFromSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+	optimizer := solver.NewOptimizerFromSpec(optimizerSpec)
+	manager := manager.NewManager(system, optimizer)
+	if optimizerSpec.OptimizeForPeak {
+		system.SetPeakLoads()
+	}
+	system.Calculate()
+	if err := manager.Optimize(context.Background()); err != nil {
+		return nil, fmt.Errorf("optimization error: %w", err)
+	}
+	return system.GenerateSolution(), nil
+}
+
+// differences between allocations of a prior and a new solution, for servers with changed allocations
+func solutionDiff(prior, solution *config.AllocationSolution) map[string]config.AllocationDiffData {
+	diff := make(map[string]config.AllocationDiffData)
+	names := slices.Collect(maps.Keys(prior.Spec))
+	names = append(names, slices.Collect(maps.Keys(solution.Spec))...)
+	for _, name := range names {
+		var oldAlloc, newAlloc *core.Allocation
+		if data, exists := prior.Spec[name]; exists {
+			oldAlloc = core.AllocationFromData(&data)
+		}
+		if data, exists := solution.Spec[name]; exists {
+			newAlloc = core.AllocationFromData(&data)
+		}
+		if d := core.CreateAllocationDiff(oldAlloc, newAlloc); d != nil && d.Changed() {
+			diff[name] = *d.AllocationDiffData()
+		}
+	}
+	return diff
+}
+
+// write a solution as JSON, with differences from a prior solution if any
+func writeJSON(w io.Writer, solution *config.AllocationSolution, diff map[string]config.AllocationDiffData, withDiff bool) error {
+	var v any = solution
+	if withDiff {
+		v = solutionWithDiff{Solution: solution, Diff: diff}
+	}
+	bytes, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(bytes))
+	return err
+}
+
+// write a solution as human-readable tables, with differences from a prior solution if any
+func writeTable(w io.Writer, solution *config.AllocationSolution, diff map[string]config.AllocationDiffData) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "SERVER\tACCELERATOR\tREPLICAS\tBATCH\tCOST\tPOWER\tITL\tTTFT\tNOTES")
+	for _, name := range slices.Sorted(maps.Keys(solution.Spec)) {
+		alloc := solution.Spec[name]
+		accelerator := alloc.Accelerator
+		replicas := fmt.Sprint(alloc.NumReplicas)
+		if alloc.Secondary != nil {
+			accelerator += "+" + alloc.Secondary.Accelerator
+			replicas += fmt.Sprintf("+%d", alloc.Secondary.NumReplicas)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%.2f\t%.1f\t%.2f\t%.2f\t%s\n", name, accelerator, replicas, alloc.MaxBatch,
+			alloc.Cost, alloc.Power, alloc.ITLAverage, alloc.TTFTAverage, allocationNotes(&alloc))
+	}
+	costUnit := "per " + solution.CostPeriod
+	if solution.Currency != "" {
+		costUnit = solution.Currency + " " + costUnit
+	}
+	fmt.Fprintf(tw, "TOTAL\t\t\t\t%.2f\t%.1f\t\t\t%s\n", solution.TotalCost, solution.TotalPower, costUnit)
+	tw.Flush()
+
+	if len(solution.Unallocated) > 0 {
+		fmt.Fprintln(w)
+		tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "UNALLOCATED\tREASON")
+		for _, status := range solution.Unallocated {
+			fmt.Fprintf(tw, "%s\t%s\n", status.Name, status.Reason)
+		}
+		tw.Flush()
+	}
+	for _, list := range []struct {
+		title string
+		names []string
+	}{
+		{"SCALED TO ZERO", solution.ScaledToZero},
+		{"ESTIMATED", solution.Estimated},
+		{"DRAINING", solution.Draining},
+	} {
+		if len(list.names) > 0 {
+			fmt.Fprintf(w, "\n%s: %v\n", list.title, list.names)
+		}
+	}
+
+	if trace := solution.Metadata.Trace; len(trace) > 0 {
+		fmt.Fprintln(w)
+		tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "STEP\tPHASE\tSERVER\tACTION\tINDEX\tACCELERATOR\tREPLICAS\tUNITS\tAVAILABLE\tREASON")
+		for _, d := range trace {
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%d\t%s\t%d\t%d\t%d\t%s\n", d.Step, d.Phase, d.Server, d.Action, d.Index,
+				d.Accelerator, d.NumReplicas, d.Units, d.AvailableUnits, d.Reason)
+		}
+		tw.Flush()
+	}
+
+	if diff != nil {
+		fmt.Fprintln(w)
+		tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "CHANGED\tACCELERATOR\tREPLICAS\tCOST DIFF")
+		for _, name := range slices.Sorted(maps.Keys(diff)) {
+			d := diff[name]
+			fmt.Fprintf(tw, "%s\t%s -> %s\t%d -> %d\t%+.2f\n", name, d.OldAccelerator, d.NewAccelerator,
+				d.OldNumReplicas, d.NewNumReplicas, d.CostDiff)
+		}
+		tw.Flush()
+	}
+}
+
+// notes on an allocation, e.g. degraded
+func allocationNotes(alloc *config.AllocationData) string {
+	notes := ""
+	for _, note := range []struct {
+		set  bool
+		name string
+	}{
+		{alloc.Degraded, "degraded"},
+		{alloc.Idle, "idle"},
+		{alloc.Estimated, "estimated"},
+		{alloc.AboveMaxRho, fmt.Sprintf("rho=%.2f", alloc.Rho)},
+		{alloc.WarmReplicas > 0, fmt.Sprintf("warm=%d", alloc.WarmReplicas)},
+	} {
+		if note.set {
+			if notes != "" {
+				notes += ","
+			}
+			notes += note.name
+		}
+	}
+	return notes
+}
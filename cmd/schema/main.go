@@ -0,0 +1,27 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/llm-inferno/optimizer/pkg/config"
+	rest "github.com/llm-inferno/optimizer/rest-server"
+)
+
+// generate JSON Schemas of the config data types, by type name
+//   - the OpenAPI document of the (statefull) REST server instead, with -openapi argument
+func main() {
+	var doc any
+	if len(os.Args) > 1 && os.Args[1] == "-openapi" {
+		doc = rest.NewStateFullServer().OpenAPI()
+	} else {
+		doc = config.JSONSchema()
+	}
+	bytes, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Println(string(bytes))
+}
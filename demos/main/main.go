@@ -1,15 +1,14 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 
-	"github.com/llm-inferno/optimizer/pkg/config"
 	"github.com/llm-inferno/optimizer/pkg/core"
 	"github.com/llm-inferno/optimizer/pkg/manager"
 	"github.com/llm-inferno/optimizer/pkg/solver"
-	"github.com/llm-inferno/optimizer/pkg/utils"
 )
 
 func main() {
@@ -18,87 +17,23 @@ func main() {
 		size = os.Args[1]
 	}
 	prefix := "../../sample-data/" + size + "/"
-	fn_acc := prefix + "accelerator-data.json"
-	fn_cap := prefix + "capacity-data.json"
-	fn_mod := prefix + "model-data.json"
-	fn_svc := prefix + "serviceclass-data.json"
-	fn_srv := prefix + "server-data.json"
-	fn_opt := prefix + "optimizer-data.json"
-	fn_sol := prefix + "solution-data.json"
-
-	system := core.NewSystem()
-
-	bytes_acc, err_acc := os.ReadFile(fn_acc)
-	if err_acc != nil {
-		fmt.Println(err_acc)
-	}
-	if d, err := utils.FromDataToSpec(bytes_acc, config.AcceleratorData{}); err == nil {
-		system.SetAcceleratorsFromSpec(d)
-	} else {
-		fmt.Println(err)
-		return
-	}
-
-	bytes_cap, err_cap := os.ReadFile(fn_cap)
-	if err_cap != nil {
-		fmt.Println(err_cap)
-	}
-	if d, err := utils.FromDataToSpec(bytes_cap, config.CapacityData{}); err == nil {
-		system.SetCapacityFromSpec(d)
-	} else {
-		fmt.Println(err)
-		return
-	}
-
-	bytes_mod, err_mod := os.ReadFile(fn_mod)
-	if err_mod != nil {
-		fmt.Println(err_mod)
-	}
-	if d, err := utils.FromDataToSpec(bytes_mod, config.ModelData{}); err == nil {
-		system.SetModelsFromSpec(d)
-	} else {
-		fmt.Println(err)
-		return
-	}
-
-	bytes_svc, err_svc := os.ReadFile(fn_svc)
-	if err_svc != nil {
-		fmt.Println(err_svc)
-	}
-	if d, err := utils.FromDataToSpec(bytes_svc, config.ServiceClassData{}); err == nil {
-		system.SetServiceClassesFromSpec(d)
-	} else {
-		fmt.Println(err)
-		return
-	}
-
-	bytes_srv, err_srv := os.ReadFile(fn_srv)
-	if err_srv != nil {
-		fmt.Println(err_srv)
-	}
-	if d, err := utils.FromDataToSpec(bytes_srv, config.ServerData{}); err == nil {
-		system.SetServersFromSpec(d)
-	} else {
-		fmt.Println(err)
-		return
+	fn_sys := prefix + "system-data.json"
+	if len(os.Args) > 2 {
+		fn_sys = os.Args[2]
 	}
+	fn_sol := prefix + "solution-data.json"
 
-	var optimizer *solver.Optimizer
-	bytes_opt, err_opt := os.ReadFile(fn_opt)
-	if err_opt != nil {
-		fmt.Println(err_acc)
-	}
-	if d, err := utils.FromDataToSpec(bytes_opt, config.OptimizerData{}); err == nil {
-		optimizer = solver.NewOptimizerFromSpec(&d.Spec)
-	} else {
+	system, optimizerSpec, err := core.NewSystemFromFile(fn_sys)
+	if err != nil {
 		fmt.Println(err)
 		return
 	}
+	optimizer := solver.NewOptimizerFromSpec(optimizerSpec)
 
 	manager := manager.NewManager(system, optimizer)
 
 	system.Calculate()
-	if err := manager.Optimize(); err != nil {
+	if err := manager.Optimize(context.Background()); err != nil {
 		fmt.Println(err)
 		return
 	}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 
@@ -8,7 +9,6 @@ import (
 	"github.com/llm-inferno/optimizer/pkg/core"
 	"github.com/llm-inferno/optimizer/pkg/manager"
 	"github.com/llm-inferno/optimizer/pkg/solver"
-	"github.com/llm-inferno/optimizer/pkg/utils"
 )
 
 func main() {
@@ -30,7 +30,7 @@ func main() {
 	if err_acc != nil {
 		fmt.Println(err_acc)
 	}
-	if d, err := utils.FromDataToSpec(bytes_acc, config.AcceleratorData{}); err == nil {
+	if d, err := config.FromFileData(fn_acc, bytes_acc, config.AcceleratorData{}); err == nil {
 		system.SetAcceleratorsFromSpec(d)
 	} else {
 		fmt.Println(err)
@@ -41,7 +41,7 @@ func main() {
 	if err_cap != nil {
 		fmt.Println(err_cap)
 	}
-	if d, err := utils.FromDataToSpec(bytes_cap, config.CapacityData{}); err == nil {
+	if d, err := config.FromFileData(fn_cap, bytes_cap, config.CapacityData{}); err == nil {
 		system.SetCapacityFromSpec(d)
 	} else {
 		fmt.Println(err)
@@ -52,8 +52,11 @@ func main() {
 	if err_mod != nil {
 		fmt.Println(err_mod)
 	}
-	if d, err := utils.FromDataToSpec(bytes_mod, config.ModelData{}); err == nil {
-		system.SetModelsFromSpec(d)
+	if d, err := config.FromFileData(fn_mod, bytes_mod, config.ModelData{}); err == nil {
+		if err := system.SetModelsFromSpec(d); err != nil {
+			fmt.Println(err)
+			return
+		}
 	} else {
 		fmt.Println(err)
 		return
@@ -63,7 +66,7 @@ func main() {
 	if err_svc != nil {
 		fmt.Println(err_svc)
 	}
-	if d, err := utils.FromDataToSpec(bytes_svc, config.ServiceClassData{}); err == nil {
+	if d, err := config.FromFileData(fn_svc, bytes_svc, config.ServiceClassData{}); err == nil {
 		system.SetServiceClassesFromSpec(d)
 	} else {
 		fmt.Println(err)
@@ -74,7 +77,7 @@ func main() {
 	if err_srv != nil {
 		fmt.Println(err_srv)
 	}
-	if d, err := utils.FromDataToSpec(bytes_srv, config.ServerData{}); err == nil {
+	if d, err := config.FromFileData(fn_srv, bytes_srv, config.ServerData{}); err == nil {
 		system.SetServersFromSpec(d)
 	} else {
 		fmt.Println(err)
@@ -86,7 +89,7 @@ func main() {
 	if err_opt != nil {
 		fmt.Println(err_acc)
 	}
-	if d, err := utils.FromDataToSpec(bytes_opt, config.OptimizerData{}); err == nil {
+	if d, err := config.FromFileData(fn_opt, bytes_opt, config.OptimizerData{}); err == nil {
 		optimizer = solver.NewOptimizerFromSpec(&d.Spec)
 	} else {
 		fmt.Println(err)
@@ -96,7 +99,7 @@ func main() {
 	manager := manager.NewManager(system, optimizer)
 
 	system.Calculate()
-	if err := manager.Optimize(); err != nil {
+	if err := manager.Optimize(context.Background()); err != nil {
 		fmt.Println(err)
 		return
 	}
@@ -131,13 +134,18 @@ func main() {
 	server.SetLoad(&newLoad)
 
 	// scale allocation
-	allocAfter, inc := allocBefore.Scale(serverName)
+	allocAfter, inc, action, err := allocBefore.Scale(system, serverName, allocBefore.NumReplicas())
+	if err != nil {
+		fmt.Println(err)
+	}
 	fmt.Println("AllocAfter: ", allocAfter)
-	fmt.Println("Inc: ", inc)
+	fmt.Println("Inc: ", inc, action)
 
 	// reallocate
 	var gName string
-	allocAfter, gName = allocBefore.ReAllocate(serverName)
+	if allocAfter, gName, err = allocBefore.ReAllocate(system, serverName); err != nil {
+		fmt.Println(err)
+	}
 	fmt.Println("AllocAfter: ", allocAfter)
 	fmt.Println("gName: ", gName)
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"math/rand/v2"
@@ -10,7 +11,6 @@ import (
 	"github.com/llm-inferno/optimizer/pkg/core"
 	"github.com/llm-inferno/optimizer/pkg/manager"
 	"github.com/llm-inferno/optimizer/pkg/solver"
-	"github.com/llm-inferno/optimizer/pkg/utils"
 )
 
 func main() {
@@ -32,7 +32,7 @@ func main() {
 	if err_acc != nil {
 		fmt.Println(err_acc)
 	}
-	if d, err := utils.FromDataToSpec(bytes_acc, config.AcceleratorData{}); err == nil {
+	if d, err := config.FromFileData(fn_acc, bytes_acc, config.AcceleratorData{}); err == nil {
 		system.SetAcceleratorsFromSpec(d)
 	} else {
 		fmt.Println(err)
@@ -43,7 +43,7 @@ func main() {
 	if err_cap != nil {
 		fmt.Println(err_cap)
 	}
-	if d, err := utils.FromDataToSpec(bytes_cap, config.CapacityData{}); err == nil {
+	if d, err := config.FromFileData(fn_cap, bytes_cap, config.CapacityData{}); err == nil {
 		system.SetCapacityFromSpec(d)
 	} else {
 		fmt.Println(err)
@@ -54,8 +54,11 @@ func main() {
 	if err_mod != nil {
 		fmt.Println(err_mod)
 	}
-	if d, err := utils.FromDataToSpec(bytes_mod, config.ModelData{}); err == nil {
-		system.SetModelsFromSpec(d)
+	if d, err := config.FromFileData(fn_mod, bytes_mod, config.ModelData{}); err == nil {
+		if err := system.SetModelsFromSpec(d); err != nil {
+			fmt.Println(err)
+			return
+		}
 	} else {
 		fmt.Println(err)
 		return
@@ -65,7 +68,7 @@ func main() {
 	if err_svc != nil {
 		fmt.Println(err_svc)
 	}
-	if d, err := utils.FromDataToSpec(bytes_svc, config.ServiceClassData{}); err == nil {
+	if d, err := config.FromFileData(fn_svc, bytes_svc, config.ServiceClassData{}); err == nil {
 		system.SetServiceClassesFromSpec(d)
 	} else {
 		fmt.Println(err)
@@ -76,7 +79,7 @@ func main() {
 	if err_srv != nil {
 		fmt.Println(err_srv)
 	}
-	if d, err := utils.FromDataToSpec(bytes_srv, config.ServerData{}); err == nil {
+	if d, err := config.FromFileData(fn_srv, bytes_srv, config.ServerData{}); err == nil {
 		system.SetServersFromSpec(d)
 	} else {
 		fmt.Println(err)
@@ -88,7 +91,7 @@ func main() {
 	if err_opt != nil {
 		fmt.Println(err_acc)
 	}
-	if d, err := utils.FromDataToSpec(bytes_opt, config.OptimizerData{}); err == nil {
+	if d, err := config.FromFileData(fn_opt, bytes_opt, config.OptimizerData{}); err == nil {
 		optimizer = solver.NewOptimizerFromSpec(&d.Spec)
 	} else {
 		fmt.Println(err)
@@ -98,7 +101,7 @@ func main() {
 	manager := manager.NewManager(system, optimizer)
 
 	system.Calculate()
-	if err := manager.Optimize(); err != nil {
+	if err := manager.Optimize(context.Background()); err != nil {
 		fmt.Println(err)
 		return
 	}
@@ -145,7 +148,7 @@ func main() {
 	}
 
 	system.Calculate()
-	if err := manager.Optimize(); err != nil {
+	if err := manager.Optimize(context.Background()); err != nil {
 		fmt.Println(err)
 		return
 	}
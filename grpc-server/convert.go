@@ -0,0 +1,599 @@
+package rpc
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/llm-inferno/optimizer/grpc-server/optimizerpb"
+	"github.com/llm-inferno/optimizer/pkg/config"
+)
+
+// Conversions between protobuf messages and the data types of package config they mirror
+//   - a nil message converts to the zero value of its data type
+
+// convert each element of a slice (nil if empty)
+func convertAll[T any, U any](s []T, convert func(T) U) []U {
+	if len(s) == 0 {
+		return nil
+	}
+	u := make([]U, len(s))
+	for i, t := range s {
+		u[i] = convert(t)
+	}
+	return u
+}
+
+// convert each value of a map (nil if empty)
+func convertMap[T any, U any](m map[string]T, convert func(T) U) map[string]U {
+	if len(m) == 0 {
+		return nil
+	}
+	u := make(map[string]U, len(m))
+	for _, k := range slices.Sorted(maps.Keys(m)) {
+		u[k] = convert(m[k])
+	}
+	return u
+}
+
+// accelerators
+
+func acceleratorDataFromProto(m *optimizerpb.AcceleratorData) config.AcceleratorData {
+	return config.AcceleratorData{Spec: convertAll(m.GetAccelerators(), acceleratorSpecFromProto)}
+}
+
+func acceleratorDataToProto(d *config.AcceleratorData) *optimizerpb.AcceleratorData {
+	return &optimizerpb.AcceleratorData{Accelerators: convertAll(d.Spec, acceleratorSpecToProto)}
+}
+
+func acceleratorSpecFromProto(m *optimizerpb.AcceleratorSpec) config.AcceleratorSpec {
+	power := m.GetPower()
+	return config.AcceleratorSpec{
+		Name:         m.GetName(),
+		Type:         m.GetType(),
+		Multiplicity: int(m.GetMultiplicity()),
+		Slices:       int(m.GetSlices()),
+		MemSize:      int(m.GetMemSize()),
+		MemBW:        int(m.GetMemBw()),
+		Power: config.PowerSpec{
+			Idle:     int(power.GetIdle()),
+			Full:     int(power.GetFull()),
+			MidPower: int(power.GetMidPower()),
+			MidUtil:  power.GetMidUtil(),
+		},
+		Cost:               m.GetCost(),
+		Currency:           m.GetCurrency(),
+		CostPeriod:         m.GetCostPeriod(),
+		Interruptible:      m.GetInterruptible(),
+		ReclaimRisk:        m.GetReclaimRisk(),
+		WarmupSeconds:      m.GetWarmupSeconds(),
+		Draining:           m.GetDraining(),
+		RelativeThroughput: m.GetRelativeThroughput(),
+	}
+}
+
+func acceleratorSpecToProto(d config.AcceleratorSpec) *optimizerpb.AcceleratorSpec {
+	return &optimizerpb.AcceleratorSpec{
+		Name:         d.Name,
+		Type:         d.Type,
+		Multiplicity: int32(d.Multiplicity),
+		Slices:       int32(d.Slices),
+		MemSize:      int32(d.MemSize),
+		MemBw:        int32(d.MemBW),
+		Power: &optimizerpb.PowerSpec{
+			Idle:     int32(d.Power.Idle),
+			Full:     int32(d.Power.Full),
+			MidPower: int32(d.Power.MidPower),
+			MidUtil:  d.Power.MidUtil,
+		},
+		Cost:               d.Cost,
+		Currency:           d.Currency,
+		CostPeriod:         d.CostPeriod,
+		Interruptible:      d.Interruptible,
+		ReclaimRisk:        d.ReclaimRisk,
+		WarmupSeconds:      d.WarmupSeconds,
+		Draining:           d.Draining,
+		RelativeThroughput: d.RelativeThroughput,
+	}
+}
+
+// capacities
+
+func capacityDataFromProto(m *optimizerpb.CapacityData) config.CapacityData {
+	return config.CapacityData{Count: convertAll(m.GetCount(), acceleratorCountFromProto)}
+}
+
+func capacityDataToProto(d *config.CapacityData) *optimizerpb.CapacityData {
+	return &optimizerpb.CapacityData{Count: convertAll(d.Count, acceleratorCountToProto)}
+}
+
+func acceleratorCountFromProto(m *optimizerpb.AcceleratorCount) config.AcceleratorCount {
+	return config.AcceleratorCount{
+		Type:          m.GetType(),
+		Count:         int(m.GetCount()),
+		Partitions:    int(m.GetPartitions()),
+		MaxPowerWatts: m.GetMaxPowerWatts(),
+		UnitsPerNode:  int(m.GetUnitsPerNode()),
+	}
+}
+
+func acceleratorCountToProto(d config.AcceleratorCount) *optimizerpb.AcceleratorCount {
+	return &optimizerpb.AcceleratorCount{
+		Type:          d.Type,
+		Count:         int32(d.Count),
+		Partitions:    int32(d.Partitions),
+		MaxPowerWatts: d.MaxPowerWatts,
+		UnitsPerNode:  int32(d.UnitsPerNode),
+	}
+}
+
+// models
+
+func modelDataFromProto(m *optimizerpb.ModelData) config.ModelData {
+	return config.ModelData{PerfData: convertAll(m.GetModels(), perfDataFromProto)}
+}
+
+func modelDataToProto(d *config.ModelData) *optimizerpb.ModelData {
+	return &optimizerpb.ModelData{Models: convertAll(d.PerfData, perfDataToProto)}
+}
+
+func perfDataFromProto(m *optimizerpb.ModelAcceleratorPerfData) config.ModelAcceleratorPerfData {
+	return config.ModelAcceleratorPerfData{
+		Name:         m.GetName(),
+		Acc:          m.GetAcc(),
+		AccCount:     int(m.GetAccCount()),
+		MaxBatchSize: int(m.GetMaxBatchSize()),
+		AtTokens:     int(m.GetAtTokens()),
+		DecodeParms: config.DecodeParms{
+			Alpha: m.GetDecodeParms().GetAlpha(),
+			Beta:  m.GetDecodeParms().GetBeta(),
+		},
+		PrefillParms: config.PrefillParms{
+			Gamma: m.GetPrefillParms().GetGamma(),
+			Delta: m.GetPrefillParms().GetDelta(),
+		},
+		TPDegree:      int(m.GetTpDegree()),
+		TPScaling:     m.GetTpScaling(),
+		UnitCost:      m.GetUnitCost(),
+		WarmupSeconds: m.GetWarmupSeconds(),
+		SharedWeights: m.GetSharedWeights(),
+		SharedCost:    m.GetSharedCost(),
+		FitRSquared:   m.GetFitRSquared(),
+		BatchWindow:   m.GetBatchWindow(),
+	}
+}
+
+func perfDataToProto(d config.ModelAcceleratorPerfData) *optimizerpb.ModelAcceleratorPerfData {
+	return &optimizerpb.ModelAcceleratorPerfData{
+		Name:         d.Name,
+		Acc:          d.Acc,
+		AccCount:     int32(d.AccCount),
+		MaxBatchSize: int32(d.MaxBatchSize),
+		AtTokens:     int32(d.AtTokens),
+		DecodeParms: &optimizerpb.DecodeParms{
+			Alpha: d.DecodeParms.Alpha,
+			Beta:  d.DecodeParms.Beta,
+		},
+		PrefillParms: &optimizerpb.PrefillParms{
+			Gamma: d.PrefillParms.Gamma,
+			Delta: d.PrefillParms.Delta,
+		},
+		TpDegree:      int32(d.TPDegree),
+		TpScaling:     d.TPScaling,
+		UnitCost:      d.UnitCost,
+		WarmupSeconds: d.WarmupSeconds,
+		SharedWeights: d.SharedWeights,
+		SharedCost:    d.SharedCost,
+		FitRSquared:   d.FitRSquared,
+		BatchWindow:   d.BatchWindow,
+	}
+}
+
+// service classes
+
+func serviceClassDataFromProto(m *optimizerpb.ServiceClassData) config.ServiceClassData {
+	return config.ServiceClassData{Spec: convertAll(m.GetServiceClasses(), serviceClassSpecFromProto)}
+}
+
+func serviceClassDataToProto(d *config.ServiceClassData) *optimizerpb.ServiceClassData {
+	return &optimizerpb.ServiceClassData{ServiceClasses: convertAll(d.Spec, serviceClassSpecToProto)}
+}
+
+func serviceClassSpecFromProto(m *optimizerpb.ServiceClassSpec) config.ServiceClassSpec {
+	spec := config.ServiceClassSpec{
+		Name:         m.GetName(),
+		Priority:     int(m.GetPriority()),
+		Weight:       m.GetWeight(),
+		ModelTargets: convertAll(m.GetModelTargets(), modelTargetFromProto),
+		Reservations: convertMap(m.GetReservations(), func(n int32) int { return int(n) }),
+	}
+	if m.GetDefaultTarget() != nil {
+		target := modelTargetFromProto(m.GetDefaultTarget())
+		spec.DefaultTarget = &target
+	}
+	return spec
+}
+
+func serviceClassSpecToProto(d config.ServiceClassSpec) *optimizerpb.ServiceClassSpec {
+	m := &optimizerpb.ServiceClassSpec{
+		Name:         d.Name,
+		Priority:     int32(d.Priority),
+		Weight:       d.Weight,
+		ModelTargets: convertAll(d.ModelTargets, modelTargetToProto),
+		Reservations: convertMap(d.Reservations, func(n int) int32 { return int32(n) }),
+	}
+	if d.DefaultTarget != nil {
+		m.DefaultTarget = modelTargetToProto(*d.DefaultTarget)
+	}
+	return m
+}
+
+func modelTargetFromProto(m *optimizerpb.ModelTarget) config.ModelTarget {
+	return config.ModelTarget{
+		Model:            m.GetModel(),
+		SLO_ITL:          config.Msec(m.GetSloItl()),
+		SLO_TTFT:         config.Msec(m.GetSloTtft()),
+		SLO_TPS:          config.TokensPerSec(m.GetSloTps()),
+		SLO_ITL_P99:      config.Msec(m.GetSloItlP99()),
+		SLO_TTFT_P99:     config.Msec(m.GetSloTtftP99()),
+		SLO_MaxDropRate:  m.GetSloMaxDropRate(),
+		SLO_WaitFraction: m.GetSloWaitFraction(),
+	}
+}
+
+func modelTargetToProto(d config.ModelTarget) *optimizerpb.ModelTarget {
+	return &optimizerpb.ModelTarget{
+		Model:           d.Model,
+		SloItl:          float32(d.SLO_ITL),
+		SloTtft:         float32(d.SLO_TTFT),
+		SloTps:          float32(d.SLO_TPS),
+		SloItlP99:       float32(d.SLO_ITL_P99),
+		SloTtftP99:      float32(d.SLO_TTFT_P99),
+		SloMaxDropRate:  d.SLO_MaxDropRate,
+		SloWaitFraction: d.SLO_WaitFraction,
+	}
+}
+
+// servers
+
+func serverDataFromProto(m *optimizerpb.ServerData) config.ServerData {
+	return config.ServerData{Spec: convertAll(m.GetServers(), serverSpecFromProto)}
+}
+
+func serverDataToProto(d *config.ServerData) *optimizerpb.ServerData {
+	return &optimizerpb.ServerData{Servers: convertAll(d.Spec, serverSpecToProto)}
+}
+
+func serverSpecFromProto(m *optimizerpb.ServerSpec) config.ServerSpec {
+	spec := config.ServerSpec{
+		Name:                m.GetName(),
+		Class:               m.GetClass(),
+		Model:               m.GetModel(),
+		KeepAccelerator:     m.GetKeepAccelerator(),
+		AllowedAccelerators: m.GetAllowedAccelerators(),
+		DeniedAccelerators:  m.GetDeniedAccelerators(),
+		MinNumReplicas:      int(m.GetMinNumReplicas()),
+		WarmReplicas:        int(m.GetWarmReplicas()),
+		MaxNumReplicas:      int(m.GetMaxNumReplicas()),
+		ReplicaCapPolicy:    m.GetReplicaCapPolicy(),
+		ShardFactor:         int(m.GetShardFactor()),
+		MaxBatchSize:        int(m.GetMaxBatchSize()),
+		OptimizeBatch:       m.GetOptimizeBatch(),
+		QueueModel:          m.GetQueueModel(),
+		CurrentAlloc:        allocationDataFromProto(m.GetCurrentAlloc()),
+		DesiredAlloc:        allocationDataFromProto(m.GetDesiredAlloc()),
+		LoadProfile: convertAll(m.GetLoadProfile(), func(w *optimizerpb.LoadWindow) config.LoadWindow {
+			return config.LoadWindow{Window: w.GetWindow(), Load: serverLoadFromProto(w.GetLoad())}
+		}),
+		Adapters: convertAll(m.GetAdapters(), func(a *optimizerpb.AdapterSpec) config.AdapterSpec {
+			return config.AdapterSpec{Name: a.GetName(), Load: serverLoadFromProto(a.GetLoad())}
+		}),
+	}
+	if low := m.GetLowPriority(); low != nil {
+		spec.LowPriority = &config.LowPrioritySpec{Class: low.GetClass(), Load: serverLoadFromProto(low.GetLoad())}
+	}
+	if m.GetPinnedAllocation() != nil {
+		pinned := allocationDataFromProto(m.GetPinnedAllocation())
+		spec.PinnedAllocation = &pinned
+	}
+	return spec
+}
+
+func serverSpecToProto(d config.ServerSpec) *optimizerpb.ServerSpec {
+	m := &optimizerpb.ServerSpec{
+		Name:                d.Name,
+		Class:               d.Class,
+		Model:               d.Model,
+		KeepAccelerator:     d.KeepAccelerator,
+		AllowedAccelerators: d.AllowedAccelerators,
+		DeniedAccelerators:  d.DeniedAccelerators,
+		MinNumReplicas:      int32(d.MinNumReplicas),
+		WarmReplicas:        int32(d.WarmReplicas),
+		MaxNumReplicas:      int32(d.MaxNumReplicas),
+		ReplicaCapPolicy:    d.ReplicaCapPolicy,
+		ShardFactor:         int32(d.ShardFactor),
+		MaxBatchSize:        int32(d.MaxBatchSize),
+		OptimizeBatch:       d.OptimizeBatch,
+		QueueModel:          d.QueueModel,
+		CurrentAlloc:        allocationDataToProto(d.CurrentAlloc),
+		DesiredAlloc:        allocationDataToProto(d.DesiredAlloc),
+		LoadProfile: convertAll(d.LoadProfile, func(w config.LoadWindow) *optimizerpb.LoadWindow {
+			return &optimizerpb.LoadWindow{Window: w.Window, Load: serverLoadToProto(w.Load)}
+		}),
+		Adapters: convertAll(d.Adapters, func(a config.AdapterSpec) *optimizerpb.AdapterSpec {
+			return &optimizerpb.AdapterSpec{Name: a.Name, Load: serverLoadToProto(a.Load)}
+		}),
+	}
+	if d.LowPriority != nil {
+		m.LowPriority = &optimizerpb.LowPrioritySpec{Class: d.LowPriority.Class, Load: serverLoadToProto(d.LowPriority.Load)}
+	}
+	if d.PinnedAllocation != nil {
+		m.PinnedAllocation = allocationDataToProto(*d.PinnedAllocation)
+	}
+	return m
+}
+
+func serverLoadFromProto(m *optimizerpb.ServerLoadSpec) config.ServerLoadSpec {
+	return config.ServerLoadSpec{
+		ArrivalRate:  m.GetArrivalRate(),
+		AvgInTokens:  int(m.GetAvgInTokens()),
+		AvgOutTokens: int(m.GetAvgOutTokens()),
+		ArrivalCOV:   m.GetArrivalCov(),
+		ServiceCOV:   m.GetServiceCov(),
+	}
+}
+
+func serverLoadToProto(d config.ServerLoadSpec) *optimizerpb.ServerLoadSpec {
+	return &optimizerpb.ServerLoadSpec{
+		ArrivalRate:  d.ArrivalRate,
+		AvgInTokens:  int32(d.AvgInTokens),
+		AvgOutTokens: int32(d.AvgOutTokens),
+		ArrivalCov:   d.ArrivalCOV,
+		ServiceCov:   d.ServiceCOV,
+	}
+}
+
+func allocationDataFromProto(m *optimizerpb.AllocationData) config.AllocationData {
+	data := config.AllocationData{
+		Accelerator:          m.GetAccelerator(),
+		NumReplicas:          int(m.GetNumReplicas()),
+		MaxBatch:             int(m.GetMaxBatch()),
+		Cost:                 m.GetCost(),
+		Power:                m.GetPower(),
+		ITLAverage:           m.GetItlAverage(),
+		TTFTAverage:          m.GetTtftAverage(),
+		ITLP99:               m.GetItlP99(),
+		TTFTP99:              m.GetTtftP99(),
+		DropRate:             m.GetDropRate(),
+		Rho:                  m.GetRho(),
+		Load:                 serverLoadFromProto(m.GetLoad()),
+		RequestedReplicas:    int(m.GetRequestedReplicas()),
+		Degraded:             m.GetDegraded(),
+		Idle:                 m.GetIdle(),
+		Estimated:            m.GetEstimated(),
+		AboveMaxRho:          m.GetAboveMaxRho(),
+		WarmReplicas:         int(m.GetWarmReplicas()),
+		WarmCost:             m.GetWarmCost(),
+		CostPerMillionTokens: m.GetCostPerMillionTokens(),
+	}
+	if low := m.GetLowPriority(); low != nil {
+		data.LowPriority = &config.StreamLatencyData{TTFTAverage: low.GetTtftAverage(), TTFTP99: low.GetTtftP99()}
+	}
+	if m.GetSecondary() != nil {
+		secondary := allocationDataFromProto(m.GetSecondary())
+		data.Secondary = &secondary
+	}
+	return data
+}
+
+func allocationDataToProto(d config.AllocationData) *optimizerpb.AllocationData {
+	m := &optimizerpb.AllocationData{
+		Accelerator:          d.Accelerator,
+		NumReplicas:          int32(d.NumReplicas),
+		MaxBatch:             int32(d.MaxBatch),
+		Cost:                 d.Cost,
+		Power:                d.Power,
+		ItlAverage:           d.ITLAverage,
+		TtftAverage:          d.TTFTAverage,
+		ItlP99:               d.ITLP99,
+		TtftP99:              d.TTFTP99,
+		DropRate:             d.DropRate,
+		Rho:                  d.Rho,
+		Load:                 serverLoadToProto(d.Load),
+		RequestedReplicas:    int32(d.RequestedReplicas),
+		Degraded:             d.Degraded,
+		Idle:                 d.Idle,
+		Estimated:            d.Estimated,
+		AboveMaxRho:          d.AboveMaxRho,
+		WarmReplicas:         int32(d.WarmReplicas),
+		WarmCost:             d.WarmCost,
+		CostPerMillionTokens: d.CostPerMillionTokens,
+	}
+	if d.LowPriority != nil {
+		m.LowPriority = &optimizerpb.StreamLatencyData{TtftAverage: d.LowPriority.TTFTAverage, TtftP99: d.LowPriority.TTFTP99}
+	}
+	if d.Secondary != nil {
+		m.Secondary = allocationDataToProto(*d.Secondary)
+	}
+	return m
+}
+
+// optimizer and system
+
+func optimizerSpecFromProto(m *optimizerpb.OptimizerSpec) config.OptimizerSpec {
+	return config.OptimizerSpec{
+		Unlimited:              m.GetUnlimited(),
+		Heterogeneous:          m.GetHeterogeneous(),
+		Algorithm:              m.GetAlgorithm(),
+		MILPSolver:             m.GetMilpSolver(),
+		UseCplex:               m.GetUseCplex(),
+		MILPTimeBudget:         int(m.GetMilpTimeBudget()),
+		PostOptimize:           m.GetPostOptimize(),
+		ChurnBudget:            m.GetChurnBudget(),
+		DelayedBestEffort:      m.GetDelayedBestEffort(),
+		AllowMixedAccelerators: m.GetAllowMixedAccelerators(),
+		SaturationPolicy:       m.GetSaturationPolicy(),
+		RoundRobinStep:         m.GetRoundRobinStep(),
+		OptimizeForPeak:        m.GetOptimizeForPeak(),
+		ScaleToZero:            m.GetScaleToZero(),
+		AllowEstimatedPerf:     m.GetAllowEstimatedPerf(),
+		TieBreak:               m.GetTieBreak(),
+		MaxThroughput:          m.GetMaxThroughput(),
+		MaxTotalCost:           m.GetMaxTotalCost(),
+		AdmissionControl:       m.GetAdmissionControl(),
+		MaxTotalPowerWatts:     m.GetMaxTotalPowerWatts(),
+		SpotPolicy:             m.GetSpotPolicy(),
+		CriticalPriority:       int(m.GetCriticalPriority()),
+		ValueFunction:          m.GetValueFunction(),
+		Objective:              m.GetObjective(),
+		ConsolidationTolerance: m.GetConsolidationTolerance(),
+		HeadroomTolerance:      m.GetHeadroomTolerance(),
+		Seed:                   m.GetSeed(),
+		Debug:                  m.GetDebug(),
+	}
+}
+
+func optimizerSpecToProto(d *config.OptimizerSpec) *optimizerpb.OptimizerSpec {
+	return &optimizerpb.OptimizerSpec{
+		Unlimited:              d.Unlimited,
+		Heterogeneous:          d.Heterogeneous,
+		Algorithm:              d.Algorithm,
+		MilpSolver:             d.MILPSolver,
+		UseCplex:               d.UseCplex,
+		MilpTimeBudget:         int32(d.MILPTimeBudget),
+		PostOptimize:           d.PostOptimize,
+		ChurnBudget:            d.ChurnBudget,
+		DelayedBestEffort:      d.DelayedBestEffort,
+		AllowMixedAccelerators: d.AllowMixedAccelerators,
+		SaturationPolicy:       d.SaturationPolicy,
+		RoundRobinStep:         d.RoundRobinStep,
+		OptimizeForPeak:        d.OptimizeForPeak,
+		ScaleToZero:            d.ScaleToZero,
+		AllowEstimatedPerf:     d.AllowEstimatedPerf,
+		TieBreak:               d.TieBreak,
+		MaxThroughput:          d.MaxThroughput,
+		MaxTotalCost:           d.MaxTotalCost,
+		AdmissionControl:       d.AdmissionControl,
+		MaxTotalPowerWatts:     d.MaxTotalPowerWatts,
+		SpotPolicy:             d.SpotPolicy,
+		CriticalPriority:       int32(d.CriticalPriority),
+		ValueFunction:          d.ValueFunction,
+		Objective:              d.Objective,
+		ConsolidationTolerance: d.ConsolidationTolerance,
+		HeadroomTolerance:      d.HeadroomTolerance,
+		Seed:                   d.Seed,
+		Debug:                  d.Debug,
+	}
+}
+
+func systemDataFromProto(m *optimizerpb.SystemData) config.SystemData {
+	spec := m.GetSystem()
+	return config.SystemData{Spec: config.SystemSpec{
+		Accelerators:   acceleratorDataFromProto(spec.GetAcceleratorData()),
+		Models:         modelDataFromProto(spec.GetModelData()),
+		ServiceClasses: serviceClassDataFromProto(spec.GetServiceClassData()),
+		Servers:        serverDataFromProto(spec.GetServerData()),
+		Optimizer:      config.OptimizerData{Spec: optimizerSpecFromProto(spec.GetOptimizerData().GetOptimizer())},
+		Capacity:       capacityDataFromProto(spec.GetCapacityData()),
+	}}
+}
+
+func systemDataToProto(d *config.SystemData) *optimizerpb.SystemData {
+	return &optimizerpb.SystemData{System: &optimizerpb.SystemSpec{
+		AcceleratorData:  acceleratorDataToProto(&d.Spec.Accelerators),
+		ModelData:        modelDataToProto(&d.Spec.Models),
+		ServiceClassData: serviceClassDataToProto(&d.Spec.ServiceClasses),
+		ServerData:       serverDataToProto(&d.Spec.Servers),
+		OptimizerData:    &optimizerpb.OptimizerData{Optimizer: optimizerSpecToProto(&d.Spec.Optimizer.Spec)},
+		CapacityData:     capacityDataToProto(&d.Spec.Capacity),
+	}}
+}
+
+// solutions and transitions
+
+func allocationSolutionToProto(d *config.AllocationSolution) *optimizerpb.AllocationSolution {
+	return &optimizerpb.AllocationSolution{
+		Allocations:  convertMap(d.Spec, allocationDataToProto),
+		Unallocated:  convertAll(d.Unallocated, serverStatusToProto),
+		Degraded:     d.Degraded,
+		Idle:         d.Idle,
+		ScaledToZero: d.ScaledToZero,
+		TotalPower:   d.TotalPower,
+		Metadata:     solutionMetadataToProto(&d.Metadata),
+		Estimated:    d.Estimated,
+		TotalCost:    d.TotalCost,
+		Currency:     d.Currency,
+		CostPeriod:   d.CostPeriod,
+		WarmCost:     d.WarmCost,
+		Draining:     d.Draining,
+		Pinned: convertAll(d.Pinned, func(p config.PinnedStatus) *optimizerpb.PinnedStatus {
+			return &optimizerpb.PinnedStatus{
+				Name:      p.Name,
+				Compliant: p.Compliant,
+				Saturated: p.Saturated,
+				Targets: convertAll(p.Targets, func(t config.SLOCompliance) *optimizerpb.SLOCompliance {
+					return &optimizerpb.SLOCompliance{Name: t.Name, Target: t.Target, Achieved: t.Achieved, Met: t.Met}
+				}),
+			}
+		}),
+		CostPerMillionTokens: d.CostPerMillionTokens,
+	}
+}
+
+func solutionMetadataToProto(d *config.SolutionMetadata) *optimizerpb.SolutionMetadata {
+	return &optimizerpb.SolutionMetadata{
+		ImprovingMoves:         int32(d.ImprovingMoves),
+		KeptAllocations:        int32(d.KeptAllocations),
+		TimedOut:               d.TimedOut,
+		AcceleratorTypesBefore: int32(d.AcceleratorTypesBefore),
+		AcceleratorTypesAfter:  int32(d.AcceleratorTypesAfter),
+		PowerHeadroom:          d.PowerHeadroom,
+		MinHeadroom:            d.MinHeadroom,
+		AddedReplicas:          int32(d.AddedReplicas),
+		Trace: convertAll(d.Trace, func(g config.GreedyDecision) *optimizerpb.GreedyDecision {
+			return &optimizerpb.GreedyDecision{
+				Step:           int32(g.Step),
+				Phase:          g.Phase,
+				Server:         g.Server,
+				Action:         g.Action,
+				Index:          int32(g.Index),
+				Accelerator:    g.Accelerator,
+				Type:           g.Type,
+				NumReplicas:    int32(g.NumReplicas),
+				Units:          int32(g.Units),
+				AvailableUnits: int32(g.AvailableUnits),
+				Power:          g.Power,
+				Value:          g.Value,
+				Reason:         g.Reason,
+				Delta:          g.Delta,
+				Position:       int32(g.Position),
+			}
+		}),
+		Admitted: d.Admitted,
+		Shed:     convertAll(d.Shed, serverStatusToProto),
+	}
+}
+
+func serverStatusToProto(d config.ServerStatus) *optimizerpb.ServerStatus {
+	return &optimizerpb.ServerStatus{Name: d.Name, Reason: d.Reason}
+}
+
+func applyResultToProto(d *config.ApplyResult) *optimizerpb.ApplyResult {
+	transition := func(t config.TransitionData) *optimizerpb.TransitionData {
+		return &optimizerpb.TransitionData{
+			Name:    t.Name,
+			Penalty: t.Penalty,
+			Diff: &optimizerpb.AllocationDiffData{
+				OldAccelerator: t.Diff.OldAccelerator,
+				NewAccelerator: t.Diff.NewAccelerator,
+				OldNumReplicas: int32(t.Diff.OldNumReplicas),
+				NewNumReplicas: int32(t.Diff.NewNumReplicas),
+				CostDiff:       t.Diff.CostDiff,
+			},
+		}
+	}
+	return &optimizerpb.ApplyResult{
+		Applied: convertAll(d.Applied, transition),
+		Pending: convertAll(d.Pending, transition),
+		Failed:  convertAll(d.Failed, serverStatusToProto),
+	}
+}
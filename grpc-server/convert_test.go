@@ -0,0 +1,38 @@
+package rpc
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/llm-inferno/optimizer/pkg/config"
+	"google.golang.org/protobuf/proto"
+)
+
+// System data converted to its protobuf message, through the wire, and back is unchanged, including nested and
+// optional data
+func TestSystemDataRoundTrip(t *testing.T) {
+	data := testSystemData()
+	spec := &data.Spec
+	spec.ServiceClasses.Spec[0].Reservations = map[string]int{"A100": 2}
+	spec.ServiceClasses.Spec[0].ModelTargets[0].SLO_TTFT_P99 = 2000
+	server := &spec.Servers.Spec[0]
+	server.AllowedAccelerators = []string{"A100", "G2"}
+	server.LoadProfile = []config.LoadWindow{{Window: "peak", Load: config.ServerLoadSpec{ArrivalRate: 1200}}}
+	server.CurrentAlloc.Accelerator, server.CurrentAlloc.NumReplicas = "A100", 2
+	server.CurrentAlloc.Secondary = &config.AllocationData{Accelerator: "G2", NumReplicas: 1}
+	spec.Capacity.Count[0].Partitions = 7
+	spec.Optimizer.Spec = config.OptimizerSpec{Algorithm: "greedy", SaturationPolicy: "RoundRobin", Seed: 1 << 40,
+		MILPTimeBudget: 500, Objective: "consolidate"}
+
+	bytes, err := proto.Marshal(systemDataToProto(data))
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	m := systemDataToProto(&config.SystemData{})
+	if err := proto.Unmarshal(bytes, m); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if got := systemDataFromProto(m); !reflect.DeepEqual(&got, data) {
+		t.Errorf("round trip changed data\ngot:  %+v\nwant: %+v", got, *data)
+	}
+}
@@ -0,0 +1,24 @@
+package rpc
+
+import "time"
+
+/**
+ * Environment variables
+ */
+
+// gRPC server env names
+const GrpcHostEnvName = "INFERNO_GRPC_HOST"
+const GrpcPortEnvName = "INFERNO_GRPC_PORT"
+
+// max time of an optimization, as a duration string (e.g. "30s"), as with the REST server
+const MaxOptimizationTimeEnvName = "INFERNO_MAX_OPTIMIZATION_TIME"
+
+/**
+ * Parameters
+ */
+
+// default port of the gRPC server
+const DefaultGrpcPort = "50051"
+
+// default max time of an optimization, after which a partial solution is returned
+const DefaultMaxOptimizationTime = 60 * time.Second
@@ -0,0 +1,10 @@
+// Package rpc is a gRPC server of the optimizer, mirroring the statefull REST server.
+//
+// The service and its messages are defined in optimizer.proto, with messages mirroring the data types
+// of package config. Go stubs are generated into package optimizerpb by internal/protogen, which compiles
+// the proto file and runs the protoc-gen-go and protoc-gen-go-grpc plugins at the versions required in
+// go.mod. Calls fail with status codes InvalidArgument (invalid data), NotFound (unknown names), and
+// Internal (optimization errors).
+package rpc
+
+//go:generate go run ./internal/protogen github.com/llm-inferno/optimizer/grpc-server optimizer.proto
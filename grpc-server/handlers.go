@@ -0,0 +1,357 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/llm-inferno/optimizer/grpc-server/optimizerpb"
+	"github.com/llm-inferno/optimizer/pkg/config"
+	"github.com/llm-inferno/optimizer/pkg/core"
+	"github.com/llm-inferno/optimizer/pkg/manager"
+	"github.com/llm-inferno/optimizer/pkg/solver"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Handlers for gRPC calls, as the handlers of the statefull REST server
+
+// data of a request which validates itself
+type validator interface {
+	Validate() error
+}
+
+// validate data of a request, if it validates itself, followed by additional checks, if any
+//   - on failure, the error is an invalid argument with the error message
+func validate(obj any, checks ...func() error) error {
+	var err error
+	if v, ok := obj.(validator); ok {
+		err = v.Validate()
+	}
+	for _, check := range checks {
+		if err != nil {
+			break
+		}
+		err = check()
+	}
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return nil
+}
+
+// error of an item not found
+func notFound(kind string, name string) error {
+	return status.Error(codes.NotFound, kind+" "+name+" not found")
+}
+
+// accelerators
+
+func (s *Server) SetAccelerators(ctx context.Context, in *optimizerpb.AcceleratorData) (*optimizerpb.AcceleratorData, error) {
+	system := s.getSystem()
+	acceleratorData := acceleratorDataFromProto(in)
+	if err := validate(&acceleratorData,
+		func() error { return system.CheckAcceleratorCurrencies(acceleratorData.Spec...) }); err != nil {
+		return nil, err
+	}
+	system.SetAcceleratorsFromSpec(&acceleratorData)
+	return acceleratorDataToProto(&acceleratorData), nil
+}
+
+func (s *Server) GetAccelerators(ctx context.Context, in *optimizerpb.Empty) (*optimizerpb.AcceleratorData, error) {
+	return acceleratorDataToProto(&s.getSystem().Snapshot().Spec.Accelerators), nil
+}
+
+func (s *Server) GetAccelerator(ctx context.Context, in *optimizerpb.NameRequest) (*optimizerpb.AcceleratorSpec, error) {
+	name := in.GetName()
+	accelerators := s.getSystem().Snapshot().Spec.Accelerators.Spec
+	i := slices.IndexFunc(accelerators, func(acc config.AcceleratorSpec) bool { return acc.Name == name })
+	if i < 0 {
+		return nil, notFound("accelerator", name)
+	}
+	return acceleratorSpecToProto(accelerators[i]), nil
+}
+
+func (s *Server) AddAccelerator(ctx context.Context, in *optimizerpb.AcceleratorSpec) (*optimizerpb.AcceleratorSpec, error) {
+	system := s.getSystem()
+	acc := acceleratorSpecFromProto(in)
+	if err := validate(&acc, func() error { return system.CheckAcceleratorCurrencies(acc) }); err != nil {
+		return nil, err
+	}
+	system.AddAcceleratorFromSpec(acc)
+	return acceleratorSpecToProto(acc), nil
+}
+
+func (s *Server) RemoveAccelerator(ctx context.Context, in *optimizerpb.NameRequest) (*optimizerpb.AcceleratorSpec, error) {
+	system := s.getSystem()
+	name := in.GetName()
+	acc := system.Accelerator(name)
+	if err := system.RemoveAccelerator(name); err != nil {
+		return nil, notFound("accelerator", name)
+	}
+	if _, exists := system.Capacity(acc.Type()); exists && system.CheckAcceleratorTypes(acc.Type()) != nil {
+		fmt.Printf("warning: capacity of accelerator type %s still set, with no accelerator of the type \n", acc.Type())
+	}
+	return acceleratorSpecToProto(*acc.Spec()), nil
+}
+
+// capacities of accelerator types
+
+func (s *Server) SetCapacities(ctx context.Context, in *optimizerpb.CapacityData) (*optimizerpb.CapacityData, error) {
+	system := s.getSystem()
+	capacityData := capacityDataFromProto(in)
+	if err := validate(&capacityData, func() error {
+		types := make([]string, len(capacityData.Count))
+		for i, count := range capacityData.Count {
+			types[i] = count.Type
+		}
+		return system.CheckAcceleratorTypes(types...)
+	}); err != nil {
+		return nil, err
+	}
+	system.SetCapacityFromSpec(&capacityData)
+	return capacityDataToProto(&capacityData), nil
+}
+
+func (s *Server) GetCapacities(ctx context.Context, in *optimizerpb.Empty) (*optimizerpb.CapacityData, error) {
+	return capacityDataToProto(&s.getSystem().Snapshot().Spec.Capacity), nil
+}
+
+func (s *Server) GetCapacity(ctx context.Context, in *optimizerpb.NameRequest) (*optimizerpb.AcceleratorCount, error) {
+	t := in.GetName()
+	counts := s.getSystem().Snapshot().Spec.Capacity.Count
+	i := slices.IndexFunc(counts, func(count config.AcceleratorCount) bool { return count.Type == t })
+	if i < 0 {
+		return nil, notFound("capacity for", t)
+	}
+	return acceleratorCountToProto(counts[i]), nil
+}
+
+func (s *Server) SetCapacity(ctx context.Context, in *optimizerpb.AcceleratorCount) (*optimizerpb.AcceleratorCount, error) {
+	system := s.getSystem()
+	count := acceleratorCountFromProto(in)
+	if err := validate(&count, func() error { return system.CheckAcceleratorTypes(count.Type) }); err != nil {
+		return nil, err
+	}
+	system.SetCountFromSpec(count)
+	return acceleratorCountToProto(count), nil
+}
+
+func (s *Server) RemoveCapacity(ctx context.Context, in *optimizerpb.NameRequest) (*optimizerpb.AcceleratorCount, error) {
+	system := s.getSystem()
+	t := in.GetName()
+	cap, _ := system.Capacity(t)
+	if !system.RemoveCapacity(t) {
+		return nil, notFound("accelerator type", t)
+	}
+	return acceleratorCountToProto(config.AcceleratorCount{Type: t, Count: cap}), nil
+}
+
+// models
+
+func (s *Server) SetModels(ctx context.Context, in *optimizerpb.ModelData) (*optimizerpb.ModelData, error) {
+	system := s.getSystem()
+	modelData := modelDataFromProto(in)
+	if err := validate(&modelData); err != nil {
+		return nil, err
+	}
+	if err := system.SetModelsFromSpec(&modelData); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return modelDataToProto(&modelData), nil
+}
+
+func (s *Server) GetModels(ctx context.Context, in *optimizerpb.Empty) (*optimizerpb.ModelNames, error) {
+	return &optimizerpb.ModelNames{Names: s.getSystem().Snapshot().Models}, nil
+}
+
+func (s *Server) GetModel(ctx context.Context, in *optimizerpb.NameRequest) (*optimizerpb.ModelData, error) {
+	name := in.GetName()
+	snapshot := s.getSystem().Snapshot()
+	if !slices.Contains(snapshot.Models, name) {
+		return nil, notFound("model", name)
+	}
+	modelData := config.ModelData{PerfData: make([]config.ModelAcceleratorPerfData, 0)}
+	for _, perfData := range snapshot.Spec.Models.PerfData {
+		if perfData.Name == name {
+			modelData.PerfData = append(modelData.PerfData, perfData)
+		}
+	}
+	return modelDataToProto(&modelData), nil
+}
+
+func (s *Server) AddModel(ctx context.Context, in *optimizerpb.NameRequest) (*optimizerpb.Empty, error) {
+	s.getSystem().AddModel(in.GetName())
+	return &optimizerpb.Empty{}, nil
+}
+
+func (s *Server) RemoveModel(ctx context.Context, in *optimizerpb.NameRequest) (*optimizerpb.Empty, error) {
+	name := in.GetName()
+	if err := s.getSystem().RemoveModel(name); err != nil {
+		return nil, notFound("model", name)
+	}
+	return &optimizerpb.Empty{}, nil
+}
+
+// service classes
+
+func (s *Server) SetServiceClasses(ctx context.Context, in *optimizerpb.ServiceClassData) (*optimizerpb.ServiceClassData, error) {
+	serviceClassData := serviceClassDataFromProto(in)
+	if err := validate(&serviceClassData); err != nil {
+		return nil, err
+	}
+	s.getSystem().SetServiceClassesFromSpec(&serviceClassData)
+	return serviceClassDataToProto(&serviceClassData), nil
+}
+
+func (s *Server) GetServiceClasses(ctx context.Context, in *optimizerpb.Empty) (*optimizerpb.ServiceClassData, error) {
+	return serviceClassDataToProto(&s.getSystem().Snapshot().Spec.ServiceClasses), nil
+}
+
+func (s *Server) GetServiceClass(ctx context.Context, in *optimizerpb.NameRequest) (*optimizerpb.ServiceClassSpec, error) {
+	name := in.GetName()
+	svcs := s.getSystem().Snapshot().Spec.ServiceClasses.Spec
+	i := slices.IndexFunc(svcs, func(svc config.ServiceClassSpec) bool { return svc.Name == name })
+	if i < 0 {
+		return nil, notFound("service class", name)
+	}
+	return serviceClassSpecToProto(svcs[i]), nil
+}
+
+// add a service class with its model targets (replace if already exists)
+func (s *Server) AddServiceClass(ctx context.Context, in *optimizerpb.ServiceClassSpec) (*optimizerpb.ServiceClassSpec, error) {
+	system := s.getSystem()
+	svcSpec := serviceClassSpecFromProto(in)
+	if err := validate(&svcSpec); err != nil {
+		return nil, err
+	}
+	system.SetServiceClassesFromSpec(&config.ServiceClassData{Spec: []config.ServiceClassSpec{svcSpec}})
+	return serviceClassSpecToProto(system.ServiceClass(svcSpec.Name).Spec()), nil
+}
+
+func (s *Server) RemoveServiceClass(ctx context.Context, in *optimizerpb.NameRequest) (*optimizerpb.ServiceClassSpec, error) {
+	system := s.getSystem()
+	name := in.GetName()
+	spec, err := system.RemoveServiceClass(name)
+	if err != nil {
+		return nil, notFound("service class", name)
+	}
+	return serviceClassSpecToProto(*spec), nil
+}
+
+// servers
+
+func (s *Server) SetServers(ctx context.Context, in *optimizerpb.ServerData) (*optimizerpb.ServerData, error) {
+	serverData := serverDataFromProto(in)
+	if err := validate(&serverData); err != nil {
+		return nil, err
+	}
+	s.getSystem().SetServersFromSpec(&serverData)
+	return serverDataToProto(&serverData), nil
+}
+
+func (s *Server) GetServers(ctx context.Context, in *optimizerpb.Empty) (*optimizerpb.ServerData, error) {
+	return serverDataToProto(&s.getSystem().Snapshot().Spec.Servers), nil
+}
+
+func (s *Server) GetServer(ctx context.Context, in *optimizerpb.NameRequest) (*optimizerpb.ServerSpec, error) {
+	name := in.GetName()
+	servers := s.getSystem().Snapshot().Spec.Servers.Spec
+	i := slices.IndexFunc(servers, func(server config.ServerSpec) bool { return server.Name == name })
+	if i < 0 {
+		return nil, notFound("server", name)
+	}
+	return serverSpecToProto(servers[i]), nil
+}
+
+func (s *Server) AddServer(ctx context.Context, in *optimizerpb.ServerSpec) (*optimizerpb.ServerSpec, error) {
+	system := s.getSystem()
+	server := serverSpecFromProto(in)
+	if err := validate(&server, func() error { return system.CheckServerReferences(&server) }); err != nil {
+		return nil, err
+	}
+	system.AddServerFromSpec(server)
+	return serverSpecToProto(server), nil
+}
+
+func (s *Server) RemoveServer(ctx context.Context, in *optimizerpb.NameRequest) (*optimizerpb.ServerSpec, error) {
+	system := s.getSystem()
+	name := in.GetName()
+	server := system.Server(name)
+	if err := system.RemoveServer(name); err != nil {
+		return nil, notFound("server", name)
+	}
+	return serverSpecToProto(*server.Spec()), nil
+}
+
+// optimization
+
+// optimize the current system given an optimizer spec
+func (s *Server) Optimize(ctx context.Context, in *optimizerpb.OptimizerSpec) (*optimizerpb.AllocationSolution, error) {
+	system := s.getSystem()
+	optimizerSpec := optimizerSpecFromProto(in)
+	if err := validate(&optimizerSpec); err != nil {
+		return nil, err
+	}
+	s.optimizeMutex.Lock()
+	defer s.optimizeMutex.Unlock()
+	solution, err := s.optimizeSystem(ctx, system, &optimizerSpec)
+	if err != nil {
+		return nil, err
+	}
+	return allocationSolutionToProto(solution), nil
+}
+
+// replace the current system with one given all its data, and optimize it
+func (s *Server) OptimizeOne(ctx context.Context, in *optimizerpb.SystemData) (*optimizerpb.AllocationSolution, error) {
+	systemData := systemDataFromProto(in)
+	if err := validate(&systemData); err != nil {
+		return nil, err
+	}
+	s.optimizeMutex.Lock()
+	defer s.optimizeMutex.Unlock()
+
+	// start with fresh system
+	system := core.NewSystem()
+	optimizerSpec, err := system.SetFromSpec(&systemData.Spec)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	s.setSystem(system)
+	solution, err := s.optimizeSystem(ctx, system, optimizerSpec)
+	if err != nil {
+		return nil, err
+	}
+	return allocationSolutionToProto(solution), nil
+}
+
+// optimize a system given an optimizer spec, returning its solution
+//   - the optimization is bounded by the context of the call and the max optimization time of the server
+//   - a partial solution is returned if the optimization timed out, as noted in its metadata
+func (s *Server) optimizeSystem(ctx context.Context, system *core.System,
+	optimizerSpec *config.OptimizerSpec) (*config.AllocationSolution, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.maxOptimizationTime)
+	defer cancel()
+	manager := manager.NewManager(system, solver.NewOptimizerFromSpec(optimizerSpec))
+	if optimizerSpec.OptimizeForPeak {
+		system.SetPeakLoads()
+	}
+	// a timeout calculating allocations is also returned by the optimization, as the context remains done
+	system.CalculateContext(ctx)
+	if err := manager.Optimize(ctx); err != nil && !errors.Is(err, core.ErrTimeout) {
+		return nil, status.Errorf(codes.Internal, "optimization error: %v", err)
+	}
+	return system.GenerateSolution(), nil
+}
+
+// apply desired allocations of all servers
+func (s *Server) ApplyAllocation(ctx context.Context, in *optimizerpb.Empty) (*optimizerpb.ApplyResult, error) {
+	return applyResultToProto(s.getSystem().ApplyDesiredAllocs(0)), nil
+}
+
+// apply desired allocations of servers in ascending order of transition penalty, up to a max number of transitions
+//   - servers failing to apply their desired allocations are listed in the result, the call does not fail
+func (s *Server) Apply(ctx context.Context, in *optimizerpb.ApplyRequest) (*optimizerpb.ApplyResult, error) {
+	return applyResultToProto(s.getSystem().ApplyDesiredAllocs(int(in.GetMaxConcurrentTransitions()))), nil
+}
@@ -0,0 +1,9 @@
+//go:build tools
+
+package main
+
+// plugins run by protogen, required in go.mod at the versions generating the stubs
+import (
+	_ "google.golang.org/grpc/cmd/protoc-gen-go-grpc"
+	_ "google.golang.org/protobuf/cmd/protoc-gen-go"
+)
@@ -0,0 +1,4046 @@
+// gRPC interface of the optimizer, mirroring the statefull REST server
+//   - messages mirror the data types of package config, with the same field names (in snake case)
+//   - float32 fields are floats, int fields are int32
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.5
+// 	protoc        (unknown)
+// source: optimizer.proto
+
+package optimizerpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Empty struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Empty) Reset() {
+	*x = Empty{}
+	mi := &file_optimizer_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Empty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Empty) ProtoMessage() {}
+
+func (x *Empty) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
+func (*Empty) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{0}
+}
+
+// name of an accelerator, accelerator type, model, service class, or server
+type NameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NameRequest) Reset() {
+	*x = NameRequest{}
+	mi := &file_optimizer_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NameRequest) ProtoMessage() {}
+
+func (x *NameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NameRequest.ProtoReflect.Descriptor instead.
+func (*NameRequest) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *NameRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ModelNames struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Names         []string               `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModelNames) Reset() {
+	*x = ModelNames{}
+	mi := &file_optimizer_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModelNames) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModelNames) ProtoMessage() {}
+
+func (x *ModelNames) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModelNames.ProtoReflect.Descriptor instead.
+func (*ModelNames) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ModelNames) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+type SystemData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	System        *SystemSpec            `protobuf:"bytes,1,opt,name=system,proto3" json:"system,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SystemData) Reset() {
+	*x = SystemData{}
+	mi := &file_optimizer_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SystemData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemData) ProtoMessage() {}
+
+func (x *SystemData) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemData.ProtoReflect.Descriptor instead.
+func (*SystemData) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SystemData) GetSystem() *SystemSpec {
+	if x != nil {
+		return x.System
+	}
+	return nil
+}
+
+type SystemSpec struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	AcceleratorData  *AcceleratorData       `protobuf:"bytes,1,opt,name=accelerator_data,json=acceleratorData,proto3" json:"accelerator_data,omitempty"`
+	ModelData        *ModelData             `protobuf:"bytes,2,opt,name=model_data,json=modelData,proto3" json:"model_data,omitempty"`
+	ServiceClassData *ServiceClassData      `protobuf:"bytes,3,opt,name=service_class_data,json=serviceClassData,proto3" json:"service_class_data,omitempty"`
+	ServerData       *ServerData            `protobuf:"bytes,4,opt,name=server_data,json=serverData,proto3" json:"server_data,omitempty"`
+	OptimizerData    *OptimizerData         `protobuf:"bytes,5,opt,name=optimizer_data,json=optimizerData,proto3" json:"optimizer_data,omitempty"`
+	CapacityData     *CapacityData          `protobuf:"bytes,6,opt,name=capacity_data,json=capacityData,proto3" json:"capacity_data,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SystemSpec) Reset() {
+	*x = SystemSpec{}
+	mi := &file_optimizer_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SystemSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemSpec) ProtoMessage() {}
+
+func (x *SystemSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemSpec.ProtoReflect.Descriptor instead.
+func (*SystemSpec) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SystemSpec) GetAcceleratorData() *AcceleratorData {
+	if x != nil {
+		return x.AcceleratorData
+	}
+	return nil
+}
+
+func (x *SystemSpec) GetModelData() *ModelData {
+	if x != nil {
+		return x.ModelData
+	}
+	return nil
+}
+
+func (x *SystemSpec) GetServiceClassData() *ServiceClassData {
+	if x != nil {
+		return x.ServiceClassData
+	}
+	return nil
+}
+
+func (x *SystemSpec) GetServerData() *ServerData {
+	if x != nil {
+		return x.ServerData
+	}
+	return nil
+}
+
+func (x *SystemSpec) GetOptimizerData() *OptimizerData {
+	if x != nil {
+		return x.OptimizerData
+	}
+	return nil
+}
+
+func (x *SystemSpec) GetCapacityData() *CapacityData {
+	if x != nil {
+		return x.CapacityData
+	}
+	return nil
+}
+
+type AcceleratorData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Accelerators  []*AcceleratorSpec     `protobuf:"bytes,1,rep,name=accelerators,proto3" json:"accelerators,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceleratorData) Reset() {
+	*x = AcceleratorData{}
+	mi := &file_optimizer_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceleratorData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceleratorData) ProtoMessage() {}
+
+func (x *AcceleratorData) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceleratorData.ProtoReflect.Descriptor instead.
+func (*AcceleratorData) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AcceleratorData) GetAccelerators() []*AcceleratorSpec {
+	if x != nil {
+		return x.Accelerators
+	}
+	return nil
+}
+
+type AcceleratorSpec struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Name               string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type               string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Multiplicity       int32                  `protobuf:"varint,3,opt,name=multiplicity,proto3" json:"multiplicity,omitempty"`
+	Slices             int32                  `protobuf:"varint,4,opt,name=slices,proto3" json:"slices,omitempty"`
+	MemSize            int32                  `protobuf:"varint,5,opt,name=mem_size,json=memSize,proto3" json:"mem_size,omitempty"`
+	MemBw              int32                  `protobuf:"varint,6,opt,name=mem_bw,json=memBw,proto3" json:"mem_bw,omitempty"`
+	Power              *PowerSpec             `protobuf:"bytes,7,opt,name=power,proto3" json:"power,omitempty"`
+	Cost               float32                `protobuf:"fixed32,8,opt,name=cost,proto3" json:"cost,omitempty"`
+	Interruptible      bool                   `protobuf:"varint,9,opt,name=interruptible,proto3" json:"interruptible,omitempty"`
+	ReclaimRisk        float32                `protobuf:"fixed32,10,opt,name=reclaim_risk,json=reclaimRisk,proto3" json:"reclaim_risk,omitempty"`
+	WarmupSeconds      float32                `protobuf:"fixed32,11,opt,name=warmup_seconds,json=warmupSeconds,proto3" json:"warmup_seconds,omitempty"`
+	RelativeThroughput float32                `protobuf:"fixed32,12,opt,name=relative_throughput,json=relativeThroughput,proto3" json:"relative_throughput,omitempty"`
+	Currency           string                 `protobuf:"bytes,13,opt,name=currency,proto3" json:"currency,omitempty"`
+	CostPeriod         string                 `protobuf:"bytes,14,opt,name=cost_period,json=costPeriod,proto3" json:"cost_period,omitempty"`
+	Draining           bool                   `protobuf:"varint,15,opt,name=draining,proto3" json:"draining,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *AcceleratorSpec) Reset() {
+	*x = AcceleratorSpec{}
+	mi := &file_optimizer_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceleratorSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceleratorSpec) ProtoMessage() {}
+
+func (x *AcceleratorSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceleratorSpec.ProtoReflect.Descriptor instead.
+func (*AcceleratorSpec) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AcceleratorSpec) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AcceleratorSpec) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *AcceleratorSpec) GetMultiplicity() int32 {
+	if x != nil {
+		return x.Multiplicity
+	}
+	return 0
+}
+
+func (x *AcceleratorSpec) GetSlices() int32 {
+	if x != nil {
+		return x.Slices
+	}
+	return 0
+}
+
+func (x *AcceleratorSpec) GetMemSize() int32 {
+	if x != nil {
+		return x.MemSize
+	}
+	return 0
+}
+
+func (x *AcceleratorSpec) GetMemBw() int32 {
+	if x != nil {
+		return x.MemBw
+	}
+	return 0
+}
+
+func (x *AcceleratorSpec) GetPower() *PowerSpec {
+	if x != nil {
+		return x.Power
+	}
+	return nil
+}
+
+func (x *AcceleratorSpec) GetCost() float32 {
+	if x != nil {
+		return x.Cost
+	}
+	return 0
+}
+
+func (x *AcceleratorSpec) GetInterruptible() bool {
+	if x != nil {
+		return x.Interruptible
+	}
+	return false
+}
+
+func (x *AcceleratorSpec) GetReclaimRisk() float32 {
+	if x != nil {
+		return x.ReclaimRisk
+	}
+	return 0
+}
+
+func (x *AcceleratorSpec) GetWarmupSeconds() float32 {
+	if x != nil {
+		return x.WarmupSeconds
+	}
+	return 0
+}
+
+func (x *AcceleratorSpec) GetRelativeThroughput() float32 {
+	if x != nil {
+		return x.RelativeThroughput
+	}
+	return 0
+}
+
+func (x *AcceleratorSpec) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *AcceleratorSpec) GetCostPeriod() string {
+	if x != nil {
+		return x.CostPeriod
+	}
+	return ""
+}
+
+func (x *AcceleratorSpec) GetDraining() bool {
+	if x != nil {
+		return x.Draining
+	}
+	return false
+}
+
+type PowerSpec struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Idle          int32                  `protobuf:"varint,1,opt,name=idle,proto3" json:"idle,omitempty"`
+	Full          int32                  `protobuf:"varint,2,opt,name=full,proto3" json:"full,omitempty"`
+	MidPower      int32                  `protobuf:"varint,3,opt,name=mid_power,json=midPower,proto3" json:"mid_power,omitempty"`
+	MidUtil       float32                `protobuf:"fixed32,4,opt,name=mid_util,json=midUtil,proto3" json:"mid_util,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PowerSpec) Reset() {
+	*x = PowerSpec{}
+	mi := &file_optimizer_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PowerSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PowerSpec) ProtoMessage() {}
+
+func (x *PowerSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PowerSpec.ProtoReflect.Descriptor instead.
+func (*PowerSpec) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PowerSpec) GetIdle() int32 {
+	if x != nil {
+		return x.Idle
+	}
+	return 0
+}
+
+func (x *PowerSpec) GetFull() int32 {
+	if x != nil {
+		return x.Full
+	}
+	return 0
+}
+
+func (x *PowerSpec) GetMidPower() int32 {
+	if x != nil {
+		return x.MidPower
+	}
+	return 0
+}
+
+func (x *PowerSpec) GetMidUtil() float32 {
+	if x != nil {
+		return x.MidUtil
+	}
+	return 0
+}
+
+type CapacityData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         []*AcceleratorCount    `protobuf:"bytes,1,rep,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CapacityData) Reset() {
+	*x = CapacityData{}
+	mi := &file_optimizer_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CapacityData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CapacityData) ProtoMessage() {}
+
+func (x *CapacityData) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CapacityData.ProtoReflect.Descriptor instead.
+func (*CapacityData) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CapacityData) GetCount() []*AcceleratorCount {
+	if x != nil {
+		return x.Count
+	}
+	return nil
+}
+
+type AcceleratorCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Partitions    int32                  `protobuf:"varint,3,opt,name=partitions,proto3" json:"partitions,omitempty"`
+	MaxPowerWatts float32                `protobuf:"fixed32,4,opt,name=max_power_watts,json=maxPowerWatts,proto3" json:"max_power_watts,omitempty"`
+	UnitsPerNode  int32                  `protobuf:"varint,5,opt,name=units_per_node,json=unitsPerNode,proto3" json:"units_per_node,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceleratorCount) Reset() {
+	*x = AcceleratorCount{}
+	mi := &file_optimizer_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceleratorCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceleratorCount) ProtoMessage() {}
+
+func (x *AcceleratorCount) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceleratorCount.ProtoReflect.Descriptor instead.
+func (*AcceleratorCount) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *AcceleratorCount) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *AcceleratorCount) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *AcceleratorCount) GetPartitions() int32 {
+	if x != nil {
+		return x.Partitions
+	}
+	return 0
+}
+
+func (x *AcceleratorCount) GetMaxPowerWatts() float32 {
+	if x != nil {
+		return x.MaxPowerWatts
+	}
+	return 0
+}
+
+func (x *AcceleratorCount) GetUnitsPerNode() int32 {
+	if x != nil {
+		return x.UnitsPerNode
+	}
+	return 0
+}
+
+type ModelData struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	Models        []*ModelAcceleratorPerfData `protobuf:"bytes,1,rep,name=models,proto3" json:"models,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModelData) Reset() {
+	*x = ModelData{}
+	mi := &file_optimizer_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModelData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModelData) ProtoMessage() {}
+
+func (x *ModelData) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModelData.ProtoReflect.Descriptor instead.
+func (*ModelData) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ModelData) GetModels() []*ModelAcceleratorPerfData {
+	if x != nil {
+		return x.Models
+	}
+	return nil
+}
+
+type ModelAcceleratorPerfData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Acc           string                 `protobuf:"bytes,2,opt,name=acc,proto3" json:"acc,omitempty"`
+	AccCount      int32                  `protobuf:"varint,3,opt,name=acc_count,json=accCount,proto3" json:"acc_count,omitempty"`
+	MaxBatchSize  int32                  `protobuf:"varint,4,opt,name=max_batch_size,json=maxBatchSize,proto3" json:"max_batch_size,omitempty"`
+	AtTokens      int32                  `protobuf:"varint,5,opt,name=at_tokens,json=atTokens,proto3" json:"at_tokens,omitempty"`
+	DecodeParms   *DecodeParms           `protobuf:"bytes,6,opt,name=decode_parms,json=decodeParms,proto3" json:"decode_parms,omitempty"`
+	PrefillParms  *PrefillParms          `protobuf:"bytes,7,opt,name=prefill_parms,json=prefillParms,proto3" json:"prefill_parms,omitempty"`
+	TpDegree      int32                  `protobuf:"varint,8,opt,name=tp_degree,json=tpDegree,proto3" json:"tp_degree,omitempty"`
+	TpScaling     float32                `protobuf:"fixed32,9,opt,name=tp_scaling,json=tpScaling,proto3" json:"tp_scaling,omitempty"`
+	UnitCost      float32                `protobuf:"fixed32,10,opt,name=unit_cost,json=unitCost,proto3" json:"unit_cost,omitempty"`
+	WarmupSeconds float32                `protobuf:"fixed32,11,opt,name=warmup_seconds,json=warmupSeconds,proto3" json:"warmup_seconds,omitempty"`
+	SharedWeights bool                   `protobuf:"varint,12,opt,name=shared_weights,json=sharedWeights,proto3" json:"shared_weights,omitempty"`
+	SharedCost    float32                `protobuf:"fixed32,13,opt,name=shared_cost,json=sharedCost,proto3" json:"shared_cost,omitempty"`
+	FitRSquared   float32                `protobuf:"fixed32,14,opt,name=fit_r_squared,json=fitRSquared,proto3" json:"fit_r_squared,omitempty"`
+	BatchWindow   float32                `protobuf:"fixed32,15,opt,name=batch_window,json=batchWindow,proto3" json:"batch_window,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModelAcceleratorPerfData) Reset() {
+	*x = ModelAcceleratorPerfData{}
+	mi := &file_optimizer_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModelAcceleratorPerfData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModelAcceleratorPerfData) ProtoMessage() {}
+
+func (x *ModelAcceleratorPerfData) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModelAcceleratorPerfData.ProtoReflect.Descriptor instead.
+func (*ModelAcceleratorPerfData) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ModelAcceleratorPerfData) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ModelAcceleratorPerfData) GetAcc() string {
+	if x != nil {
+		return x.Acc
+	}
+	return ""
+}
+
+func (x *ModelAcceleratorPerfData) GetAccCount() int32 {
+	if x != nil {
+		return x.AccCount
+	}
+	return 0
+}
+
+func (x *ModelAcceleratorPerfData) GetMaxBatchSize() int32 {
+	if x != nil {
+		return x.MaxBatchSize
+	}
+	return 0
+}
+
+func (x *ModelAcceleratorPerfData) GetAtTokens() int32 {
+	if x != nil {
+		return x.AtTokens
+	}
+	return 0
+}
+
+func (x *ModelAcceleratorPerfData) GetDecodeParms() *DecodeParms {
+	if x != nil {
+		return x.DecodeParms
+	}
+	return nil
+}
+
+func (x *ModelAcceleratorPerfData) GetPrefillParms() *PrefillParms {
+	if x != nil {
+		return x.PrefillParms
+	}
+	return nil
+}
+
+func (x *ModelAcceleratorPerfData) GetTpDegree() int32 {
+	if x != nil {
+		return x.TpDegree
+	}
+	return 0
+}
+
+func (x *ModelAcceleratorPerfData) GetTpScaling() float32 {
+	if x != nil {
+		return x.TpScaling
+	}
+	return 0
+}
+
+func (x *ModelAcceleratorPerfData) GetUnitCost() float32 {
+	if x != nil {
+		return x.UnitCost
+	}
+	return 0
+}
+
+func (x *ModelAcceleratorPerfData) GetWarmupSeconds() float32 {
+	if x != nil {
+		return x.WarmupSeconds
+	}
+	return 0
+}
+
+func (x *ModelAcceleratorPerfData) GetSharedWeights() bool {
+	if x != nil {
+		return x.SharedWeights
+	}
+	return false
+}
+
+func (x *ModelAcceleratorPerfData) GetSharedCost() float32 {
+	if x != nil {
+		return x.SharedCost
+	}
+	return 0
+}
+
+func (x *ModelAcceleratorPerfData) GetFitRSquared() float32 {
+	if x != nil {
+		return x.FitRSquared
+	}
+	return 0
+}
+
+func (x *ModelAcceleratorPerfData) GetBatchWindow() float32 {
+	if x != nil {
+		return x.BatchWindow
+	}
+	return 0
+}
+
+type DecodeParms struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Alpha         float32                `protobuf:"fixed32,1,opt,name=alpha,proto3" json:"alpha,omitempty"`
+	Beta          float32                `protobuf:"fixed32,2,opt,name=beta,proto3" json:"beta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DecodeParms) Reset() {
+	*x = DecodeParms{}
+	mi := &file_optimizer_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DecodeParms) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecodeParms) ProtoMessage() {}
+
+func (x *DecodeParms) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecodeParms.ProtoReflect.Descriptor instead.
+func (*DecodeParms) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DecodeParms) GetAlpha() float32 {
+	if x != nil {
+		return x.Alpha
+	}
+	return 0
+}
+
+func (x *DecodeParms) GetBeta() float32 {
+	if x != nil {
+		return x.Beta
+	}
+	return 0
+}
+
+type PrefillParms struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Gamma         float32                `protobuf:"fixed32,1,opt,name=gamma,proto3" json:"gamma,omitempty"`
+	Delta         float32                `protobuf:"fixed32,2,opt,name=delta,proto3" json:"delta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PrefillParms) Reset() {
+	*x = PrefillParms{}
+	mi := &file_optimizer_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PrefillParms) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrefillParms) ProtoMessage() {}
+
+func (x *PrefillParms) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrefillParms.ProtoReflect.Descriptor instead.
+func (*PrefillParms) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PrefillParms) GetGamma() float32 {
+	if x != nil {
+		return x.Gamma
+	}
+	return 0
+}
+
+func (x *PrefillParms) GetDelta() float32 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+type ServiceClassData struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ServiceClasses []*ServiceClassSpec    `protobuf:"bytes,1,rep,name=service_classes,json=serviceClasses,proto3" json:"service_classes,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ServiceClassData) Reset() {
+	*x = ServiceClassData{}
+	mi := &file_optimizer_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceClassData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceClassData) ProtoMessage() {}
+
+func (x *ServiceClassData) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceClassData.ProtoReflect.Descriptor instead.
+func (*ServiceClassData) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ServiceClassData) GetServiceClasses() []*ServiceClassSpec {
+	if x != nil {
+		return x.ServiceClasses
+	}
+	return nil
+}
+
+type ServiceClassSpec struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Priority      int32                  `protobuf:"varint,2,opt,name=priority,proto3" json:"priority,omitempty"`
+	Weight        float32                `protobuf:"fixed32,3,opt,name=weight,proto3" json:"weight,omitempty"`
+	ModelTargets  []*ModelTarget         `protobuf:"bytes,4,rep,name=model_targets,json=modelTargets,proto3" json:"model_targets,omitempty"`
+	Reservations  map[string]int32       `protobuf:"bytes,5,rep,name=reservations,proto3" json:"reservations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	DefaultTarget *ModelTarget           `protobuf:"bytes,6,opt,name=default_target,json=defaultTarget,proto3" json:"default_target,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServiceClassSpec) Reset() {
+	*x = ServiceClassSpec{}
+	mi := &file_optimizer_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceClassSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceClassSpec) ProtoMessage() {}
+
+func (x *ServiceClassSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceClassSpec.ProtoReflect.Descriptor instead.
+func (*ServiceClassSpec) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ServiceClassSpec) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ServiceClassSpec) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *ServiceClassSpec) GetWeight() float32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *ServiceClassSpec) GetModelTargets() []*ModelTarget {
+	if x != nil {
+		return x.ModelTargets
+	}
+	return nil
+}
+
+func (x *ServiceClassSpec) GetReservations() map[string]int32 {
+	if x != nil {
+		return x.Reservations
+	}
+	return nil
+}
+
+func (x *ServiceClassSpec) GetDefaultTarget() *ModelTarget {
+	if x != nil {
+		return x.DefaultTarget
+	}
+	return nil
+}
+
+type ModelTarget struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Model           string                 `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
+	SloItl          float32                `protobuf:"fixed32,2,opt,name=slo_itl,json=sloItl,proto3" json:"slo_itl,omitempty"`
+	SloTtft         float32                `protobuf:"fixed32,3,opt,name=slo_ttft,json=sloTtft,proto3" json:"slo_ttft,omitempty"`
+	SloTps          float32                `protobuf:"fixed32,4,opt,name=slo_tps,json=sloTps,proto3" json:"slo_tps,omitempty"`
+	SloItlP99       float32                `protobuf:"fixed32,5,opt,name=slo_itl_p99,json=sloItlP99,proto3" json:"slo_itl_p99,omitempty"`
+	SloTtftP99      float32                `protobuf:"fixed32,6,opt,name=slo_ttft_p99,json=sloTtftP99,proto3" json:"slo_ttft_p99,omitempty"`
+	SloMaxDropRate  float32                `protobuf:"fixed32,7,opt,name=slo_max_drop_rate,json=sloMaxDropRate,proto3" json:"slo_max_drop_rate,omitempty"`
+	SloWaitFraction float32                `protobuf:"fixed32,8,opt,name=slo_wait_fraction,json=sloWaitFraction,proto3" json:"slo_wait_fraction,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ModelTarget) Reset() {
+	*x = ModelTarget{}
+	mi := &file_optimizer_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModelTarget) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModelTarget) ProtoMessage() {}
+
+func (x *ModelTarget) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModelTarget.ProtoReflect.Descriptor instead.
+func (*ModelTarget) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ModelTarget) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *ModelTarget) GetSloItl() float32 {
+	if x != nil {
+		return x.SloItl
+	}
+	return 0
+}
+
+func (x *ModelTarget) GetSloTtft() float32 {
+	if x != nil {
+		return x.SloTtft
+	}
+	return 0
+}
+
+func (x *ModelTarget) GetSloTps() float32 {
+	if x != nil {
+		return x.SloTps
+	}
+	return 0
+}
+
+func (x *ModelTarget) GetSloItlP99() float32 {
+	if x != nil {
+		return x.SloItlP99
+	}
+	return 0
+}
+
+func (x *ModelTarget) GetSloTtftP99() float32 {
+	if x != nil {
+		return x.SloTtftP99
+	}
+	return 0
+}
+
+func (x *ModelTarget) GetSloMaxDropRate() float32 {
+	if x != nil {
+		return x.SloMaxDropRate
+	}
+	return 0
+}
+
+func (x *ModelTarget) GetSloWaitFraction() float32 {
+	if x != nil {
+		return x.SloWaitFraction
+	}
+	return 0
+}
+
+type ServerData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Servers       []*ServerSpec          `protobuf:"bytes,1,rep,name=servers,proto3" json:"servers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServerData) Reset() {
+	*x = ServerData{}
+	mi := &file_optimizer_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServerData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerData) ProtoMessage() {}
+
+func (x *ServerData) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerData.ProtoReflect.Descriptor instead.
+func (*ServerData) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ServerData) GetServers() []*ServerSpec {
+	if x != nil {
+		return x.Servers
+	}
+	return nil
+}
+
+type ServerSpec struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Name                string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Class               string                 `protobuf:"bytes,2,opt,name=class,proto3" json:"class,omitempty"`
+	Model               string                 `protobuf:"bytes,3,opt,name=model,proto3" json:"model,omitempty"`
+	KeepAccelerator     bool                   `protobuf:"varint,4,opt,name=keep_accelerator,json=keepAccelerator,proto3" json:"keep_accelerator,omitempty"`
+	AllowedAccelerators []string               `protobuf:"bytes,5,rep,name=allowed_accelerators,json=allowedAccelerators,proto3" json:"allowed_accelerators,omitempty"`
+	DeniedAccelerators  []string               `protobuf:"bytes,6,rep,name=denied_accelerators,json=deniedAccelerators,proto3" json:"denied_accelerators,omitempty"`
+	MinNumReplicas      int32                  `protobuf:"varint,7,opt,name=min_num_replicas,json=minNumReplicas,proto3" json:"min_num_replicas,omitempty"`
+	MaxNumReplicas      int32                  `protobuf:"varint,8,opt,name=max_num_replicas,json=maxNumReplicas,proto3" json:"max_num_replicas,omitempty"`
+	ReplicaCapPolicy    string                 `protobuf:"bytes,9,opt,name=replica_cap_policy,json=replicaCapPolicy,proto3" json:"replica_cap_policy,omitempty"`
+	ShardFactor         int32                  `protobuf:"varint,10,opt,name=shard_factor,json=shardFactor,proto3" json:"shard_factor,omitempty"`
+	MaxBatchSize        int32                  `protobuf:"varint,11,opt,name=max_batch_size,json=maxBatchSize,proto3" json:"max_batch_size,omitempty"`
+	OptimizeBatch       bool                   `protobuf:"varint,12,opt,name=optimize_batch,json=optimizeBatch,proto3" json:"optimize_batch,omitempty"`
+	QueueModel          string                 `protobuf:"bytes,13,opt,name=queue_model,json=queueModel,proto3" json:"queue_model,omitempty"`
+	CurrentAlloc        *AllocationData        `protobuf:"bytes,14,opt,name=current_alloc,json=currentAlloc,proto3" json:"current_alloc,omitempty"`
+	DesiredAlloc        *AllocationData        `protobuf:"bytes,15,opt,name=desired_alloc,json=desiredAlloc,proto3" json:"desired_alloc,omitempty"`
+	LoadProfile         []*LoadWindow          `protobuf:"bytes,16,rep,name=load_profile,json=loadProfile,proto3" json:"load_profile,omitempty"`
+	Adapters            []*AdapterSpec         `protobuf:"bytes,17,rep,name=adapters,proto3" json:"adapters,omitempty"`
+	WarmReplicas        int32                  `protobuf:"varint,18,opt,name=warm_replicas,json=warmReplicas,proto3" json:"warm_replicas,omitempty"`
+	LowPriority         *LowPrioritySpec       `protobuf:"bytes,19,opt,name=low_priority,json=lowPriority,proto3" json:"low_priority,omitempty"`
+	PinnedAllocation    *AllocationData        `protobuf:"bytes,20,opt,name=pinned_allocation,json=pinnedAllocation,proto3" json:"pinned_allocation,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ServerSpec) Reset() {
+	*x = ServerSpec{}
+	mi := &file_optimizer_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServerSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerSpec) ProtoMessage() {}
+
+func (x *ServerSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerSpec.ProtoReflect.Descriptor instead.
+func (*ServerSpec) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ServerSpec) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ServerSpec) GetClass() string {
+	if x != nil {
+		return x.Class
+	}
+	return ""
+}
+
+func (x *ServerSpec) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *ServerSpec) GetKeepAccelerator() bool {
+	if x != nil {
+		return x.KeepAccelerator
+	}
+	return false
+}
+
+func (x *ServerSpec) GetAllowedAccelerators() []string {
+	if x != nil {
+		return x.AllowedAccelerators
+	}
+	return nil
+}
+
+func (x *ServerSpec) GetDeniedAccelerators() []string {
+	if x != nil {
+		return x.DeniedAccelerators
+	}
+	return nil
+}
+
+func (x *ServerSpec) GetMinNumReplicas() int32 {
+	if x != nil {
+		return x.MinNumReplicas
+	}
+	return 0
+}
+
+func (x *ServerSpec) GetMaxNumReplicas() int32 {
+	if x != nil {
+		return x.MaxNumReplicas
+	}
+	return 0
+}
+
+func (x *ServerSpec) GetReplicaCapPolicy() string {
+	if x != nil {
+		return x.ReplicaCapPolicy
+	}
+	return ""
+}
+
+func (x *ServerSpec) GetShardFactor() int32 {
+	if x != nil {
+		return x.ShardFactor
+	}
+	return 0
+}
+
+func (x *ServerSpec) GetMaxBatchSize() int32 {
+	if x != nil {
+		return x.MaxBatchSize
+	}
+	return 0
+}
+
+func (x *ServerSpec) GetOptimizeBatch() bool {
+	if x != nil {
+		return x.OptimizeBatch
+	}
+	return false
+}
+
+func (x *ServerSpec) GetQueueModel() string {
+	if x != nil {
+		return x.QueueModel
+	}
+	return ""
+}
+
+func (x *ServerSpec) GetCurrentAlloc() *AllocationData {
+	if x != nil {
+		return x.CurrentAlloc
+	}
+	return nil
+}
+
+func (x *ServerSpec) GetDesiredAlloc() *AllocationData {
+	if x != nil {
+		return x.DesiredAlloc
+	}
+	return nil
+}
+
+func (x *ServerSpec) GetLoadProfile() []*LoadWindow {
+	if x != nil {
+		return x.LoadProfile
+	}
+	return nil
+}
+
+func (x *ServerSpec) GetAdapters() []*AdapterSpec {
+	if x != nil {
+		return x.Adapters
+	}
+	return nil
+}
+
+func (x *ServerSpec) GetWarmReplicas() int32 {
+	if x != nil {
+		return x.WarmReplicas
+	}
+	return 0
+}
+
+func (x *ServerSpec) GetLowPriority() *LowPrioritySpec {
+	if x != nil {
+		return x.LowPriority
+	}
+	return nil
+}
+
+func (x *ServerSpec) GetPinnedAllocation() *AllocationData {
+	if x != nil {
+		return x.PinnedAllocation
+	}
+	return nil
+}
+
+type AdapterSpec struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Load          *ServerLoadSpec        `protobuf:"bytes,2,opt,name=load,proto3" json:"load,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdapterSpec) Reset() {
+	*x = AdapterSpec{}
+	mi := &file_optimizer_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdapterSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdapterSpec) ProtoMessage() {}
+
+func (x *AdapterSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdapterSpec.ProtoReflect.Descriptor instead.
+func (*AdapterSpec) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *AdapterSpec) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AdapterSpec) GetLoad() *ServerLoadSpec {
+	if x != nil {
+		return x.Load
+	}
+	return nil
+}
+
+type LowPrioritySpec struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Class         string                 `protobuf:"bytes,1,opt,name=class,proto3" json:"class,omitempty"`
+	Load          *ServerLoadSpec        `protobuf:"bytes,2,opt,name=load,proto3" json:"load,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LowPrioritySpec) Reset() {
+	*x = LowPrioritySpec{}
+	mi := &file_optimizer_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LowPrioritySpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LowPrioritySpec) ProtoMessage() {}
+
+func (x *LowPrioritySpec) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LowPrioritySpec.ProtoReflect.Descriptor instead.
+func (*LowPrioritySpec) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *LowPrioritySpec) GetClass() string {
+	if x != nil {
+		return x.Class
+	}
+	return ""
+}
+
+func (x *LowPrioritySpec) GetLoad() *ServerLoadSpec {
+	if x != nil {
+		return x.Load
+	}
+	return nil
+}
+
+type LoadWindow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Window        string                 `protobuf:"bytes,1,opt,name=window,proto3" json:"window,omitempty"`
+	Load          *ServerLoadSpec        `protobuf:"bytes,2,opt,name=load,proto3" json:"load,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoadWindow) Reset() {
+	*x = LoadWindow{}
+	mi := &file_optimizer_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoadWindow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoadWindow) ProtoMessage() {}
+
+func (x *LoadWindow) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoadWindow.ProtoReflect.Descriptor instead.
+func (*LoadWindow) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *LoadWindow) GetWindow() string {
+	if x != nil {
+		return x.Window
+	}
+	return ""
+}
+
+func (x *LoadWindow) GetLoad() *ServerLoadSpec {
+	if x != nil {
+		return x.Load
+	}
+	return nil
+}
+
+type ServerLoadSpec struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ArrivalRate   float32                `protobuf:"fixed32,1,opt,name=arrival_rate,json=arrivalRate,proto3" json:"arrival_rate,omitempty"`
+	AvgInTokens   int32                  `protobuf:"varint,2,opt,name=avg_in_tokens,json=avgInTokens,proto3" json:"avg_in_tokens,omitempty"`
+	AvgOutTokens  int32                  `protobuf:"varint,3,opt,name=avg_out_tokens,json=avgOutTokens,proto3" json:"avg_out_tokens,omitempty"`
+	ArrivalCov    float32                `protobuf:"fixed32,4,opt,name=arrival_cov,json=arrivalCov,proto3" json:"arrival_cov,omitempty"`
+	ServiceCov    float32                `protobuf:"fixed32,5,opt,name=service_cov,json=serviceCov,proto3" json:"service_cov,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServerLoadSpec) Reset() {
+	*x = ServerLoadSpec{}
+	mi := &file_optimizer_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServerLoadSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerLoadSpec) ProtoMessage() {}
+
+func (x *ServerLoadSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerLoadSpec.ProtoReflect.Descriptor instead.
+func (*ServerLoadSpec) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ServerLoadSpec) GetArrivalRate() float32 {
+	if x != nil {
+		return x.ArrivalRate
+	}
+	return 0
+}
+
+func (x *ServerLoadSpec) GetAvgInTokens() int32 {
+	if x != nil {
+		return x.AvgInTokens
+	}
+	return 0
+}
+
+func (x *ServerLoadSpec) GetAvgOutTokens() int32 {
+	if x != nil {
+		return x.AvgOutTokens
+	}
+	return 0
+}
+
+func (x *ServerLoadSpec) GetArrivalCov() float32 {
+	if x != nil {
+		return x.ArrivalCov
+	}
+	return 0
+}
+
+func (x *ServerLoadSpec) GetServiceCov() float32 {
+	if x != nil {
+		return x.ServiceCov
+	}
+	return 0
+}
+
+type AllocationData struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Accelerator          string                 `protobuf:"bytes,1,opt,name=accelerator,proto3" json:"accelerator,omitempty"`
+	NumReplicas          int32                  `protobuf:"varint,2,opt,name=num_replicas,json=numReplicas,proto3" json:"num_replicas,omitempty"`
+	MaxBatch             int32                  `protobuf:"varint,3,opt,name=max_batch,json=maxBatch,proto3" json:"max_batch,omitempty"`
+	Cost                 float32                `protobuf:"fixed32,4,opt,name=cost,proto3" json:"cost,omitempty"`
+	Power                float32                `protobuf:"fixed32,5,opt,name=power,proto3" json:"power,omitempty"`
+	ItlAverage           float32                `protobuf:"fixed32,6,opt,name=itl_average,json=itlAverage,proto3" json:"itl_average,omitempty"`
+	TtftAverage          float32                `protobuf:"fixed32,7,opt,name=ttft_average,json=ttftAverage,proto3" json:"ttft_average,omitempty"`
+	ItlP99               float32                `protobuf:"fixed32,8,opt,name=itl_p99,json=itlP99,proto3" json:"itl_p99,omitempty"`
+	TtftP99              float32                `protobuf:"fixed32,9,opt,name=ttft_p99,json=ttftP99,proto3" json:"ttft_p99,omitempty"`
+	DropRate             float32                `protobuf:"fixed32,10,opt,name=drop_rate,json=dropRate,proto3" json:"drop_rate,omitempty"`
+	Load                 *ServerLoadSpec        `protobuf:"bytes,11,opt,name=load,proto3" json:"load,omitempty"`
+	RequestedReplicas    int32                  `protobuf:"varint,12,opt,name=requested_replicas,json=requestedReplicas,proto3" json:"requested_replicas,omitempty"`
+	Degraded             bool                   `protobuf:"varint,13,opt,name=degraded,proto3" json:"degraded,omitempty"`
+	Idle                 bool                   `protobuf:"varint,14,opt,name=idle,proto3" json:"idle,omitempty"`
+	Secondary            *AllocationData        `protobuf:"bytes,15,opt,name=secondary,proto3" json:"secondary,omitempty"`
+	Estimated            bool                   `protobuf:"varint,16,opt,name=estimated,proto3" json:"estimated,omitempty"`
+	WarmReplicas         int32                  `protobuf:"varint,17,opt,name=warm_replicas,json=warmReplicas,proto3" json:"warm_replicas,omitempty"`
+	WarmCost             float32                `protobuf:"fixed32,18,opt,name=warm_cost,json=warmCost,proto3" json:"warm_cost,omitempty"`
+	LowPriority          *StreamLatencyData     `protobuf:"bytes,19,opt,name=low_priority,json=lowPriority,proto3" json:"low_priority,omitempty"`
+	Rho                  float32                `protobuf:"fixed32,20,opt,name=rho,proto3" json:"rho,omitempty"`
+	AboveMaxRho          bool                   `protobuf:"varint,21,opt,name=above_max_rho,json=aboveMaxRho,proto3" json:"above_max_rho,omitempty"`
+	CostPerMillionTokens float32                `protobuf:"fixed32,22,opt,name=cost_per_million_tokens,json=costPerMillionTokens,proto3" json:"cost_per_million_tokens,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *AllocationData) Reset() {
+	*x = AllocationData{}
+	mi := &file_optimizer_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AllocationData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AllocationData) ProtoMessage() {}
+
+func (x *AllocationData) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AllocationData.ProtoReflect.Descriptor instead.
+func (*AllocationData) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *AllocationData) GetAccelerator() string {
+	if x != nil {
+		return x.Accelerator
+	}
+	return ""
+}
+
+func (x *AllocationData) GetNumReplicas() int32 {
+	if x != nil {
+		return x.NumReplicas
+	}
+	return 0
+}
+
+func (x *AllocationData) GetMaxBatch() int32 {
+	if x != nil {
+		return x.MaxBatch
+	}
+	return 0
+}
+
+func (x *AllocationData) GetCost() float32 {
+	if x != nil {
+		return x.Cost
+	}
+	return 0
+}
+
+func (x *AllocationData) GetPower() float32 {
+	if x != nil {
+		return x.Power
+	}
+	return 0
+}
+
+func (x *AllocationData) GetItlAverage() float32 {
+	if x != nil {
+		return x.ItlAverage
+	}
+	return 0
+}
+
+func (x *AllocationData) GetTtftAverage() float32 {
+	if x != nil {
+		return x.TtftAverage
+	}
+	return 0
+}
+
+func (x *AllocationData) GetItlP99() float32 {
+	if x != nil {
+		return x.ItlP99
+	}
+	return 0
+}
+
+func (x *AllocationData) GetTtftP99() float32 {
+	if x != nil {
+		return x.TtftP99
+	}
+	return 0
+}
+
+func (x *AllocationData) GetDropRate() float32 {
+	if x != nil {
+		return x.DropRate
+	}
+	return 0
+}
+
+func (x *AllocationData) GetLoad() *ServerLoadSpec {
+	if x != nil {
+		return x.Load
+	}
+	return nil
+}
+
+func (x *AllocationData) GetRequestedReplicas() int32 {
+	if x != nil {
+		return x.RequestedReplicas
+	}
+	return 0
+}
+
+func (x *AllocationData) GetDegraded() bool {
+	if x != nil {
+		return x.Degraded
+	}
+	return false
+}
+
+func (x *AllocationData) GetIdle() bool {
+	if x != nil {
+		return x.Idle
+	}
+	return false
+}
+
+func (x *AllocationData) GetSecondary() *AllocationData {
+	if x != nil {
+		return x.Secondary
+	}
+	return nil
+}
+
+func (x *AllocationData) GetEstimated() bool {
+	if x != nil {
+		return x.Estimated
+	}
+	return false
+}
+
+func (x *AllocationData) GetWarmReplicas() int32 {
+	if x != nil {
+		return x.WarmReplicas
+	}
+	return 0
+}
+
+func (x *AllocationData) GetWarmCost() float32 {
+	if x != nil {
+		return x.WarmCost
+	}
+	return 0
+}
+
+func (x *AllocationData) GetLowPriority() *StreamLatencyData {
+	if x != nil {
+		return x.LowPriority
+	}
+	return nil
+}
+
+func (x *AllocationData) GetRho() float32 {
+	if x != nil {
+		return x.Rho
+	}
+	return 0
+}
+
+func (x *AllocationData) GetAboveMaxRho() bool {
+	if x != nil {
+		return x.AboveMaxRho
+	}
+	return false
+}
+
+func (x *AllocationData) GetCostPerMillionTokens() float32 {
+	if x != nil {
+		return x.CostPerMillionTokens
+	}
+	return 0
+}
+
+type StreamLatencyData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TtftAverage   float32                `protobuf:"fixed32,1,opt,name=ttft_average,json=ttftAverage,proto3" json:"ttft_average,omitempty"`
+	TtftP99       float32                `protobuf:"fixed32,2,opt,name=ttft_p99,json=ttftP99,proto3" json:"ttft_p99,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamLatencyData) Reset() {
+	*x = StreamLatencyData{}
+	mi := &file_optimizer_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamLatencyData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamLatencyData) ProtoMessage() {}
+
+func (x *StreamLatencyData) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamLatencyData.ProtoReflect.Descriptor instead.
+func (*StreamLatencyData) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *StreamLatencyData) GetTtftAverage() float32 {
+	if x != nil {
+		return x.TtftAverage
+	}
+	return 0
+}
+
+func (x *StreamLatencyData) GetTtftP99() float32 {
+	if x != nil {
+		return x.TtftP99
+	}
+	return 0
+}
+
+type OptimizerData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Optimizer     *OptimizerSpec         `protobuf:"bytes,1,opt,name=optimizer,proto3" json:"optimizer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OptimizerData) Reset() {
+	*x = OptimizerData{}
+	mi := &file_optimizer_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OptimizerData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OptimizerData) ProtoMessage() {}
+
+func (x *OptimizerData) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OptimizerData.ProtoReflect.Descriptor instead.
+func (*OptimizerData) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *OptimizerData) GetOptimizer() *OptimizerSpec {
+	if x != nil {
+		return x.Optimizer
+	}
+	return nil
+}
+
+type OptimizerSpec struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	Unlimited              bool                   `protobuf:"varint,1,opt,name=unlimited,proto3" json:"unlimited,omitempty"`
+	Heterogeneous          bool                   `protobuf:"varint,2,opt,name=heterogeneous,proto3" json:"heterogeneous,omitempty"`
+	Algorithm              string                 `protobuf:"bytes,3,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	MilpSolver             bool                   `protobuf:"varint,4,opt,name=milp_solver,json=milpSolver,proto3" json:"milp_solver,omitempty"`
+	UseCplex               bool                   `protobuf:"varint,5,opt,name=use_cplex,json=useCplex,proto3" json:"use_cplex,omitempty"`
+	MilpTimeBudget         int32                  `protobuf:"varint,6,opt,name=milp_time_budget,json=milpTimeBudget,proto3" json:"milp_time_budget,omitempty"`
+	PostOptimize           bool                   `protobuf:"varint,7,opt,name=post_optimize,json=postOptimize,proto3" json:"post_optimize,omitempty"`
+	ChurnBudget            float32                `protobuf:"fixed32,8,opt,name=churn_budget,json=churnBudget,proto3" json:"churn_budget,omitempty"`
+	DelayedBestEffort      bool                   `protobuf:"varint,9,opt,name=delayed_best_effort,json=delayedBestEffort,proto3" json:"delayed_best_effort,omitempty"`
+	AllowMixedAccelerators bool                   `protobuf:"varint,10,opt,name=allow_mixed_accelerators,json=allowMixedAccelerators,proto3" json:"allow_mixed_accelerators,omitempty"`
+	SaturationPolicy       string                 `protobuf:"bytes,11,opt,name=saturation_policy,json=saturationPolicy,proto3" json:"saturation_policy,omitempty"`
+	OptimizeForPeak        bool                   `protobuf:"varint,12,opt,name=optimize_for_peak,json=optimizeForPeak,proto3" json:"optimize_for_peak,omitempty"`
+	ScaleToZero            bool                   `protobuf:"varint,13,opt,name=scale_to_zero,json=scaleToZero,proto3" json:"scale_to_zero,omitempty"`
+	TieBreak               string                 `protobuf:"bytes,14,opt,name=tie_break,json=tieBreak,proto3" json:"tie_break,omitempty"`
+	MaxThroughput          bool                   `protobuf:"varint,15,opt,name=max_throughput,json=maxThroughput,proto3" json:"max_throughput,omitempty"`
+	MaxTotalCost           float32                `protobuf:"fixed32,16,opt,name=max_total_cost,json=maxTotalCost,proto3" json:"max_total_cost,omitempty"`
+	SpotPolicy             string                 `protobuf:"bytes,17,opt,name=spot_policy,json=spotPolicy,proto3" json:"spot_policy,omitempty"`
+	CriticalPriority       int32                  `protobuf:"varint,18,opt,name=critical_priority,json=criticalPriority,proto3" json:"critical_priority,omitempty"`
+	ValueFunction          string                 `protobuf:"bytes,19,opt,name=value_function,json=valueFunction,proto3" json:"value_function,omitempty"`
+	Objective              string                 `protobuf:"bytes,20,opt,name=objective,proto3" json:"objective,omitempty"`
+	ConsolidationTolerance float32                `protobuf:"fixed32,21,opt,name=consolidation_tolerance,json=consolidationTolerance,proto3" json:"consolidation_tolerance,omitempty"`
+	Seed                   int64                  `protobuf:"varint,22,opt,name=seed,proto3" json:"seed,omitempty"`
+	AllowEstimatedPerf     bool                   `protobuf:"varint,23,opt,name=allow_estimated_perf,json=allowEstimatedPerf,proto3" json:"allow_estimated_perf,omitempty"`
+	MaxTotalPowerWatts     float32                `protobuf:"fixed32,24,opt,name=max_total_power_watts,json=maxTotalPowerWatts,proto3" json:"max_total_power_watts,omitempty"`
+	Debug                  bool                   `protobuf:"varint,25,opt,name=debug,proto3" json:"debug,omitempty"`
+	HeadroomTolerance      float32                `protobuf:"fixed32,26,opt,name=headroom_tolerance,json=headroomTolerance,proto3" json:"headroom_tolerance,omitempty"`
+	AdmissionControl       bool                   `protobuf:"varint,27,opt,name=admission_control,json=admissionControl,proto3" json:"admission_control,omitempty"`
+	RoundRobinStep         string                 `protobuf:"bytes,28,opt,name=round_robin_step,json=roundRobinStep,proto3" json:"round_robin_step,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *OptimizerSpec) Reset() {
+	*x = OptimizerSpec{}
+	mi := &file_optimizer_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OptimizerSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OptimizerSpec) ProtoMessage() {}
+
+func (x *OptimizerSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OptimizerSpec.ProtoReflect.Descriptor instead.
+func (*OptimizerSpec) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *OptimizerSpec) GetUnlimited() bool {
+	if x != nil {
+		return x.Unlimited
+	}
+	return false
+}
+
+func (x *OptimizerSpec) GetHeterogeneous() bool {
+	if x != nil {
+		return x.Heterogeneous
+	}
+	return false
+}
+
+func (x *OptimizerSpec) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *OptimizerSpec) GetMilpSolver() bool {
+	if x != nil {
+		return x.MilpSolver
+	}
+	return false
+}
+
+func (x *OptimizerSpec) GetUseCplex() bool {
+	if x != nil {
+		return x.UseCplex
+	}
+	return false
+}
+
+func (x *OptimizerSpec) GetMilpTimeBudget() int32 {
+	if x != nil {
+		return x.MilpTimeBudget
+	}
+	return 0
+}
+
+func (x *OptimizerSpec) GetPostOptimize() bool {
+	if x != nil {
+		return x.PostOptimize
+	}
+	return false
+}
+
+func (x *OptimizerSpec) GetChurnBudget() float32 {
+	if x != nil {
+		return x.ChurnBudget
+	}
+	return 0
+}
+
+func (x *OptimizerSpec) GetDelayedBestEffort() bool {
+	if x != nil {
+		return x.DelayedBestEffort
+	}
+	return false
+}
+
+func (x *OptimizerSpec) GetAllowMixedAccelerators() bool {
+	if x != nil {
+		return x.AllowMixedAccelerators
+	}
+	return false
+}
+
+func (x *OptimizerSpec) GetSaturationPolicy() string {
+	if x != nil {
+		return x.SaturationPolicy
+	}
+	return ""
+}
+
+func (x *OptimizerSpec) GetOptimizeForPeak() bool {
+	if x != nil {
+		return x.OptimizeForPeak
+	}
+	return false
+}
+
+func (x *OptimizerSpec) GetScaleToZero() bool {
+	if x != nil {
+		return x.ScaleToZero
+	}
+	return false
+}
+
+func (x *OptimizerSpec) GetTieBreak() string {
+	if x != nil {
+		return x.TieBreak
+	}
+	return ""
+}
+
+func (x *OptimizerSpec) GetMaxThroughput() bool {
+	if x != nil {
+		return x.MaxThroughput
+	}
+	return false
+}
+
+func (x *OptimizerSpec) GetMaxTotalCost() float32 {
+	if x != nil {
+		return x.MaxTotalCost
+	}
+	return 0
+}
+
+func (x *OptimizerSpec) GetSpotPolicy() string {
+	if x != nil {
+		return x.SpotPolicy
+	}
+	return ""
+}
+
+func (x *OptimizerSpec) GetCriticalPriority() int32 {
+	if x != nil {
+		return x.CriticalPriority
+	}
+	return 0
+}
+
+func (x *OptimizerSpec) GetValueFunction() string {
+	if x != nil {
+		return x.ValueFunction
+	}
+	return ""
+}
+
+func (x *OptimizerSpec) GetObjective() string {
+	if x != nil {
+		return x.Objective
+	}
+	return ""
+}
+
+func (x *OptimizerSpec) GetConsolidationTolerance() float32 {
+	if x != nil {
+		return x.ConsolidationTolerance
+	}
+	return 0
+}
+
+func (x *OptimizerSpec) GetSeed() int64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+func (x *OptimizerSpec) GetAllowEstimatedPerf() bool {
+	if x != nil {
+		return x.AllowEstimatedPerf
+	}
+	return false
+}
+
+func (x *OptimizerSpec) GetMaxTotalPowerWatts() float32 {
+	if x != nil {
+		return x.MaxTotalPowerWatts
+	}
+	return 0
+}
+
+func (x *OptimizerSpec) GetDebug() bool {
+	if x != nil {
+		return x.Debug
+	}
+	return false
+}
+
+func (x *OptimizerSpec) GetHeadroomTolerance() float32 {
+	if x != nil {
+		return x.HeadroomTolerance
+	}
+	return 0
+}
+
+func (x *OptimizerSpec) GetAdmissionControl() bool {
+	if x != nil {
+		return x.AdmissionControl
+	}
+	return false
+}
+
+func (x *OptimizerSpec) GetRoundRobinStep() string {
+	if x != nil {
+		return x.RoundRobinStep
+	}
+	return ""
+}
+
+type AllocationSolution struct {
+	state                protoimpl.MessageState     `protogen:"open.v1"`
+	Allocations          map[string]*AllocationData `protobuf:"bytes,1,rep,name=allocations,proto3" json:"allocations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Unallocated          []*ServerStatus            `protobuf:"bytes,2,rep,name=unallocated,proto3" json:"unallocated,omitempty"`
+	Degraded             []string                   `protobuf:"bytes,3,rep,name=degraded,proto3" json:"degraded,omitempty"`
+	Idle                 []string                   `protobuf:"bytes,4,rep,name=idle,proto3" json:"idle,omitempty"`
+	ScaledToZero         []string                   `protobuf:"bytes,5,rep,name=scaled_to_zero,json=scaledToZero,proto3" json:"scaled_to_zero,omitempty"`
+	TotalPower           float32                    `protobuf:"fixed32,6,opt,name=total_power,json=totalPower,proto3" json:"total_power,omitempty"`
+	Metadata             *SolutionMetadata          `protobuf:"bytes,7,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Estimated            []string                   `protobuf:"bytes,8,rep,name=estimated,proto3" json:"estimated,omitempty"`
+	TotalCost            float32                    `protobuf:"fixed32,9,opt,name=total_cost,json=totalCost,proto3" json:"total_cost,omitempty"`
+	Currency             string                     `protobuf:"bytes,10,opt,name=currency,proto3" json:"currency,omitempty"`
+	CostPeriod           string                     `protobuf:"bytes,11,opt,name=cost_period,json=costPeriod,proto3" json:"cost_period,omitempty"`
+	WarmCost             float32                    `protobuf:"fixed32,12,opt,name=warm_cost,json=warmCost,proto3" json:"warm_cost,omitempty"`
+	Draining             []string                   `protobuf:"bytes,13,rep,name=draining,proto3" json:"draining,omitempty"`
+	CostPerMillionTokens float32                    `protobuf:"fixed32,14,opt,name=cost_per_million_tokens,json=costPerMillionTokens,proto3" json:"cost_per_million_tokens,omitempty"`
+	Pinned               []*PinnedStatus            `protobuf:"bytes,15,rep,name=pinned,proto3" json:"pinned,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *AllocationSolution) Reset() {
+	*x = AllocationSolution{}
+	mi := &file_optimizer_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AllocationSolution) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AllocationSolution) ProtoMessage() {}
+
+func (x *AllocationSolution) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AllocationSolution.ProtoReflect.Descriptor instead.
+func (*AllocationSolution) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *AllocationSolution) GetAllocations() map[string]*AllocationData {
+	if x != nil {
+		return x.Allocations
+	}
+	return nil
+}
+
+func (x *AllocationSolution) GetUnallocated() []*ServerStatus {
+	if x != nil {
+		return x.Unallocated
+	}
+	return nil
+}
+
+func (x *AllocationSolution) GetDegraded() []string {
+	if x != nil {
+		return x.Degraded
+	}
+	return nil
+}
+
+func (x *AllocationSolution) GetIdle() []string {
+	if x != nil {
+		return x.Idle
+	}
+	return nil
+}
+
+func (x *AllocationSolution) GetScaledToZero() []string {
+	if x != nil {
+		return x.ScaledToZero
+	}
+	return nil
+}
+
+func (x *AllocationSolution) GetTotalPower() float32 {
+	if x != nil {
+		return x.TotalPower
+	}
+	return 0
+}
+
+func (x *AllocationSolution) GetMetadata() *SolutionMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *AllocationSolution) GetEstimated() []string {
+	if x != nil {
+		return x.Estimated
+	}
+	return nil
+}
+
+func (x *AllocationSolution) GetTotalCost() float32 {
+	if x != nil {
+		return x.TotalCost
+	}
+	return 0
+}
+
+func (x *AllocationSolution) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *AllocationSolution) GetCostPeriod() string {
+	if x != nil {
+		return x.CostPeriod
+	}
+	return ""
+}
+
+func (x *AllocationSolution) GetWarmCost() float32 {
+	if x != nil {
+		return x.WarmCost
+	}
+	return 0
+}
+
+func (x *AllocationSolution) GetDraining() []string {
+	if x != nil {
+		return x.Draining
+	}
+	return nil
+}
+
+func (x *AllocationSolution) GetCostPerMillionTokens() float32 {
+	if x != nil {
+		return x.CostPerMillionTokens
+	}
+	return 0
+}
+
+func (x *AllocationSolution) GetPinned() []*PinnedStatus {
+	if x != nil {
+		return x.Pinned
+	}
+	return nil
+}
+
+type PinnedStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Compliant     bool                   `protobuf:"varint,2,opt,name=compliant,proto3" json:"compliant,omitempty"`
+	Saturated     bool                   `protobuf:"varint,3,opt,name=saturated,proto3" json:"saturated,omitempty"`
+	Targets       []*SLOCompliance       `protobuf:"bytes,4,rep,name=targets,proto3" json:"targets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PinnedStatus) Reset() {
+	*x = PinnedStatus{}
+	mi := &file_optimizer_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PinnedStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinnedStatus) ProtoMessage() {}
+
+func (x *PinnedStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinnedStatus.ProtoReflect.Descriptor instead.
+func (*PinnedStatus) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *PinnedStatus) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PinnedStatus) GetCompliant() bool {
+	if x != nil {
+		return x.Compliant
+	}
+	return false
+}
+
+func (x *PinnedStatus) GetSaturated() bool {
+	if x != nil {
+		return x.Saturated
+	}
+	return false
+}
+
+func (x *PinnedStatus) GetTargets() []*SLOCompliance {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+type SLOCompliance struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Target        float32                `protobuf:"fixed32,2,opt,name=target,proto3" json:"target,omitempty"`
+	Achieved      float32                `protobuf:"fixed32,3,opt,name=achieved,proto3" json:"achieved,omitempty"`
+	Met           bool                   `protobuf:"varint,4,opt,name=met,proto3" json:"met,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SLOCompliance) Reset() {
+	*x = SLOCompliance{}
+	mi := &file_optimizer_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SLOCompliance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SLOCompliance) ProtoMessage() {}
+
+func (x *SLOCompliance) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SLOCompliance.ProtoReflect.Descriptor instead.
+func (*SLOCompliance) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *SLOCompliance) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SLOCompliance) GetTarget() float32 {
+	if x != nil {
+		return x.Target
+	}
+	return 0
+}
+
+func (x *SLOCompliance) GetAchieved() float32 {
+	if x != nil {
+		return x.Achieved
+	}
+	return 0
+}
+
+func (x *SLOCompliance) GetMet() bool {
+	if x != nil {
+		return x.Met
+	}
+	return false
+}
+
+type SolutionMetadata struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	ImprovingMoves         int32                  `protobuf:"varint,1,opt,name=improving_moves,json=improvingMoves,proto3" json:"improving_moves,omitempty"`
+	KeptAllocations        int32                  `protobuf:"varint,2,opt,name=kept_allocations,json=keptAllocations,proto3" json:"kept_allocations,omitempty"`
+	TimedOut               bool                   `protobuf:"varint,3,opt,name=timed_out,json=timedOut,proto3" json:"timed_out,omitempty"`
+	AcceleratorTypesBefore int32                  `protobuf:"varint,4,opt,name=accelerator_types_before,json=acceleratorTypesBefore,proto3" json:"accelerator_types_before,omitempty"`
+	AcceleratorTypesAfter  int32                  `protobuf:"varint,5,opt,name=accelerator_types_after,json=acceleratorTypesAfter,proto3" json:"accelerator_types_after,omitempty"`
+	PowerHeadroom          float32                `protobuf:"fixed32,6,opt,name=power_headroom,json=powerHeadroom,proto3" json:"power_headroom,omitempty"`
+	MinHeadroom            float32                `protobuf:"fixed32,7,opt,name=min_headroom,json=minHeadroom,proto3" json:"min_headroom,omitempty"`
+	AddedReplicas          int32                  `protobuf:"varint,8,opt,name=added_replicas,json=addedReplicas,proto3" json:"added_replicas,omitempty"`
+	Trace                  []*GreedyDecision      `protobuf:"bytes,9,rep,name=trace,proto3" json:"trace,omitempty"`
+	Admitted               []string               `protobuf:"bytes,10,rep,name=admitted,proto3" json:"admitted,omitempty"`
+	Shed                   []*ServerStatus        `protobuf:"bytes,11,rep,name=shed,proto3" json:"shed,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *SolutionMetadata) Reset() {
+	*x = SolutionMetadata{}
+	mi := &file_optimizer_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SolutionMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SolutionMetadata) ProtoMessage() {}
+
+func (x *SolutionMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SolutionMetadata.ProtoReflect.Descriptor instead.
+func (*SolutionMetadata) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *SolutionMetadata) GetImprovingMoves() int32 {
+	if x != nil {
+		return x.ImprovingMoves
+	}
+	return 0
+}
+
+func (x *SolutionMetadata) GetKeptAllocations() int32 {
+	if x != nil {
+		return x.KeptAllocations
+	}
+	return 0
+}
+
+func (x *SolutionMetadata) GetTimedOut() bool {
+	if x != nil {
+		return x.TimedOut
+	}
+	return false
+}
+
+func (x *SolutionMetadata) GetAcceleratorTypesBefore() int32 {
+	if x != nil {
+		return x.AcceleratorTypesBefore
+	}
+	return 0
+}
+
+func (x *SolutionMetadata) GetAcceleratorTypesAfter() int32 {
+	if x != nil {
+		return x.AcceleratorTypesAfter
+	}
+	return 0
+}
+
+func (x *SolutionMetadata) GetPowerHeadroom() float32 {
+	if x != nil {
+		return x.PowerHeadroom
+	}
+	return 0
+}
+
+func (x *SolutionMetadata) GetMinHeadroom() float32 {
+	if x != nil {
+		return x.MinHeadroom
+	}
+	return 0
+}
+
+func (x *SolutionMetadata) GetAddedReplicas() int32 {
+	if x != nil {
+		return x.AddedReplicas
+	}
+	return 0
+}
+
+func (x *SolutionMetadata) GetTrace() []*GreedyDecision {
+	if x != nil {
+		return x.Trace
+	}
+	return nil
+}
+
+func (x *SolutionMetadata) GetAdmitted() []string {
+	if x != nil {
+		return x.Admitted
+	}
+	return nil
+}
+
+func (x *SolutionMetadata) GetShed() []*ServerStatus {
+	if x != nil {
+		return x.Shed
+	}
+	return nil
+}
+
+type GreedyDecision struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Step           int32                  `protobuf:"varint,1,opt,name=step,proto3" json:"step,omitempty"`
+	Phase          string                 `protobuf:"bytes,2,opt,name=phase,proto3" json:"phase,omitempty"`
+	Server         string                 `protobuf:"bytes,3,opt,name=server,proto3" json:"server,omitempty"`
+	Action         string                 `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
+	Index          int32                  `protobuf:"varint,5,opt,name=index,proto3" json:"index,omitempty"`
+	Accelerator    string                 `protobuf:"bytes,6,opt,name=accelerator,proto3" json:"accelerator,omitempty"`
+	Type           string                 `protobuf:"bytes,7,opt,name=type,proto3" json:"type,omitempty"`
+	NumReplicas    int32                  `protobuf:"varint,8,opt,name=num_replicas,json=numReplicas,proto3" json:"num_replicas,omitempty"`
+	Units          int32                  `protobuf:"varint,9,opt,name=units,proto3" json:"units,omitempty"`
+	AvailableUnits int32                  `protobuf:"varint,10,opt,name=available_units,json=availableUnits,proto3" json:"available_units,omitempty"`
+	Power          float32                `protobuf:"fixed32,11,opt,name=power,proto3" json:"power,omitempty"`
+	Value          float32                `protobuf:"fixed32,12,opt,name=value,proto3" json:"value,omitempty"`
+	Reason         string                 `protobuf:"bytes,13,opt,name=reason,proto3" json:"reason,omitempty"`
+	Delta          float32                `protobuf:"fixed32,14,opt,name=delta,proto3" json:"delta,omitempty"`
+	Position       int32                  `protobuf:"varint,15,opt,name=position,proto3" json:"position,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GreedyDecision) Reset() {
+	*x = GreedyDecision{}
+	mi := &file_optimizer_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GreedyDecision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GreedyDecision) ProtoMessage() {}
+
+func (x *GreedyDecision) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GreedyDecision.ProtoReflect.Descriptor instead.
+func (*GreedyDecision) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GreedyDecision) GetStep() int32 {
+	if x != nil {
+		return x.Step
+	}
+	return 0
+}
+
+func (x *GreedyDecision) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *GreedyDecision) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+func (x *GreedyDecision) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *GreedyDecision) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *GreedyDecision) GetAccelerator() string {
+	if x != nil {
+		return x.Accelerator
+	}
+	return ""
+}
+
+func (x *GreedyDecision) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *GreedyDecision) GetNumReplicas() int32 {
+	if x != nil {
+		return x.NumReplicas
+	}
+	return 0
+}
+
+func (x *GreedyDecision) GetUnits() int32 {
+	if x != nil {
+		return x.Units
+	}
+	return 0
+}
+
+func (x *GreedyDecision) GetAvailableUnits() int32 {
+	if x != nil {
+		return x.AvailableUnits
+	}
+	return 0
+}
+
+func (x *GreedyDecision) GetPower() float32 {
+	if x != nil {
+		return x.Power
+	}
+	return 0
+}
+
+func (x *GreedyDecision) GetValue() float32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *GreedyDecision) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *GreedyDecision) GetDelta() float32 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+func (x *GreedyDecision) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+type ServerStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServerStatus) Reset() {
+	*x = ServerStatus{}
+	mi := &file_optimizer_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServerStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerStatus) ProtoMessage() {}
+
+func (x *ServerStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerStatus.ProtoReflect.Descriptor instead.
+func (*ServerStatus) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ServerStatus) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ServerStatus) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ApplyRequest struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	MaxConcurrentTransitions int32                  `protobuf:"varint,1,opt,name=max_concurrent_transitions,json=maxConcurrentTransitions,proto3" json:"max_concurrent_transitions,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *ApplyRequest) Reset() {
+	*x = ApplyRequest{}
+	mi := &file_optimizer_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyRequest) ProtoMessage() {}
+
+func (x *ApplyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyRequest.ProtoReflect.Descriptor instead.
+func (*ApplyRequest) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ApplyRequest) GetMaxConcurrentTransitions() int32 {
+	if x != nil {
+		return x.MaxConcurrentTransitions
+	}
+	return 0
+}
+
+type TransitionData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Penalty       float32                `protobuf:"fixed32,2,opt,name=penalty,proto3" json:"penalty,omitempty"`
+	Diff          *AllocationDiffData    `protobuf:"bytes,3,opt,name=diff,proto3" json:"diff,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransitionData) Reset() {
+	*x = TransitionData{}
+	mi := &file_optimizer_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransitionData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransitionData) ProtoMessage() {}
+
+func (x *TransitionData) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransitionData.ProtoReflect.Descriptor instead.
+func (*TransitionData) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *TransitionData) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TransitionData) GetPenalty() float32 {
+	if x != nil {
+		return x.Penalty
+	}
+	return 0
+}
+
+func (x *TransitionData) GetDiff() *AllocationDiffData {
+	if x != nil {
+		return x.Diff
+	}
+	return nil
+}
+
+type AllocationDiffData struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OldAccelerator string                 `protobuf:"bytes,1,opt,name=old_accelerator,json=oldAccelerator,proto3" json:"old_accelerator,omitempty"`
+	NewAccelerator string                 `protobuf:"bytes,2,opt,name=new_accelerator,json=newAccelerator,proto3" json:"new_accelerator,omitempty"`
+	OldNumReplicas int32                  `protobuf:"varint,3,opt,name=old_num_replicas,json=oldNumReplicas,proto3" json:"old_num_replicas,omitempty"`
+	NewNumReplicas int32                  `protobuf:"varint,4,opt,name=new_num_replicas,json=newNumReplicas,proto3" json:"new_num_replicas,omitempty"`
+	CostDiff       float32                `protobuf:"fixed32,5,opt,name=cost_diff,json=costDiff,proto3" json:"cost_diff,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AllocationDiffData) Reset() {
+	*x = AllocationDiffData{}
+	mi := &file_optimizer_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AllocationDiffData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AllocationDiffData) ProtoMessage() {}
+
+func (x *AllocationDiffData) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AllocationDiffData.ProtoReflect.Descriptor instead.
+func (*AllocationDiffData) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *AllocationDiffData) GetOldAccelerator() string {
+	if x != nil {
+		return x.OldAccelerator
+	}
+	return ""
+}
+
+func (x *AllocationDiffData) GetNewAccelerator() string {
+	if x != nil {
+		return x.NewAccelerator
+	}
+	return ""
+}
+
+func (x *AllocationDiffData) GetOldNumReplicas() int32 {
+	if x != nil {
+		return x.OldNumReplicas
+	}
+	return 0
+}
+
+func (x *AllocationDiffData) GetNewNumReplicas() int32 {
+	if x != nil {
+		return x.NewNumReplicas
+	}
+	return 0
+}
+
+func (x *AllocationDiffData) GetCostDiff() float32 {
+	if x != nil {
+		return x.CostDiff
+	}
+	return 0
+}
+
+type ApplyResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Applied       []*TransitionData      `protobuf:"bytes,1,rep,name=applied,proto3" json:"applied,omitempty"`
+	Pending       []*TransitionData      `protobuf:"bytes,2,rep,name=pending,proto3" json:"pending,omitempty"`
+	Failed        []*ServerStatus        `protobuf:"bytes,3,rep,name=failed,proto3" json:"failed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyResult) Reset() {
+	*x = ApplyResult{}
+	mi := &file_optimizer_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyResult) ProtoMessage() {}
+
+func (x *ApplyResult) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyResult.ProtoReflect.Descriptor instead.
+func (*ApplyResult) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ApplyResult) GetApplied() []*TransitionData {
+	if x != nil {
+		return x.Applied
+	}
+	return nil
+}
+
+func (x *ApplyResult) GetPending() []*TransitionData {
+	if x != nil {
+		return x.Pending
+	}
+	return nil
+}
+
+func (x *ApplyResult) GetFailed() []*ServerStatus {
+	if x != nil {
+		return x.Failed
+	}
+	return nil
+}
+
+var File_optimizer_proto protoreflect.FileDescriptor
+
+var file_optimizer_proto_rawDesc = string([]byte{
+	0x0a, 0x0f, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x14, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d,
+	0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x22, 0x21, 0x0a, 0x0b, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x22, 0x22, 0x0a, 0x0a, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x4e, 0x61, 0x6d, 0x65,
+	0x73, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x22, 0x46, 0x0a, 0x0a, 0x53, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x44, 0x61, 0x74, 0x61, 0x12, 0x38, 0x0a, 0x06, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e,
+	0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x79, 0x73,
+	0x74, 0x65, 0x6d, 0x53, 0x70, 0x65, 0x63, 0x52, 0x06, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x22,
+	0xcc, 0x03, 0x0a, 0x0a, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x70, 0x65, 0x63, 0x12, 0x50,
+	0x0a, 0x10, 0x61, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x5f, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72,
+	0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x41, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x44, 0x61, 0x74, 0x61, 0x52,
+	0x0f, 0x61, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x44, 0x61, 0x74, 0x61,
+	0x12, 0x3e, 0x0a, 0x0a, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f,
+	0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x64, 0x65,
+	0x6c, 0x44, 0x61, 0x74, 0x61, 0x52, 0x09, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x44, 0x61, 0x74, 0x61,
+	0x12, 0x54, 0x0a, 0x12, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x63, 0x6c, 0x61, 0x73,
+	0x73, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x69,
+	0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6c, 0x61, 0x73, 0x73,
+	0x44, 0x61, 0x74, 0x61, 0x52, 0x10, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6c, 0x61,
+	0x73, 0x73, 0x44, 0x61, 0x74, 0x61, 0x12, 0x41, 0x0a, 0x0b, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x69, 0x6e,
+	0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x52, 0x0a, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x12, 0x4a, 0x0a, 0x0e, 0x6f, 0x70, 0x74,
+	0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x23, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69,
+	0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a,
+	0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x52, 0x0d, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65,
+	0x72, 0x44, 0x61, 0x74, 0x61, 0x12, 0x47, 0x0a, 0x0d, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74,
+	0x79, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x69,
+	0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x44, 0x61, 0x74, 0x61,
+	0x52, 0x0c, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x44, 0x61, 0x74, 0x61, 0x22, 0x5c,
+	0x0a, 0x0f, 0x41, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x44, 0x61, 0x74,
+	0x61, 0x12, 0x49, 0x0a, 0x0c, 0x61, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e,
+	0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41,
+	0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x70, 0x65, 0x63, 0x52, 0x0c,
+	0x61, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x22, 0xec, 0x03, 0x0a,
+	0x0f, 0x41, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x70, 0x65, 0x63,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x6d, 0x75, 0x6c, 0x74,
+	0x69, 0x70, 0x6c, 0x69, 0x63, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c,
+	0x6d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x69, 0x63, 0x69, 0x74, 0x79, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x6c, 0x69, 0x63, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x6c,
+	0x69, 0x63, 0x65, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x65, 0x6d, 0x5f, 0x73, 0x69, 0x7a, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6d, 0x65, 0x6d, 0x53, 0x69, 0x7a, 0x65, 0x12,
+	0x15, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x5f, 0x62, 0x77, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x05, 0x6d, 0x65, 0x6d, 0x42, 0x77, 0x12, 0x35, 0x0a, 0x05, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e,
+	0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f, 0x77,
+	0x65, 0x72, 0x53, 0x70, 0x65, 0x63, 0x52, 0x05, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x12, 0x12, 0x0a,
+	0x04, 0x63, 0x6f, 0x73, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x02, 0x52, 0x04, 0x63, 0x6f, 0x73,
+	0x74, 0x12, 0x24, 0x0a, 0x0d, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x72, 0x75, 0x70, 0x74, 0x69, 0x62,
+	0x6c, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x72,
+	0x75, 0x70, 0x74, 0x69, 0x62, 0x6c, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x63, 0x6c, 0x61,
+	0x69, 0x6d, 0x5f, 0x72, 0x69, 0x73, 0x6b, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0b, 0x72,
+	0x65, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x52, 0x69, 0x73, 0x6b, 0x12, 0x25, 0x0a, 0x0e, 0x77, 0x61,
+	0x72, 0x6d, 0x75, 0x70, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x02, 0x52, 0x0d, 0x77, 0x61, 0x72, 0x6d, 0x75, 0x70, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x73, 0x12, 0x2f, 0x0a, 0x13, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x74, 0x68,
+	0x72, 0x6f, 0x75, 0x67, 0x68, 0x70, 0x75, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x02, 0x52, 0x12,
+	0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x54, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x70,
+	0x75, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x0d,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x1f,
+	0x0a, 0x0b, 0x63, 0x6f, 0x73, 0x74, 0x5f, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x0e, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x73, 0x74, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x12,
+	0x1a, 0x0a, 0x08, 0x64, 0x72, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x0f, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x08, 0x64, 0x72, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x22, 0x6b, 0x0a, 0x09, 0x50,
+	0x6f, 0x77, 0x65, 0x72, 0x53, 0x70, 0x65, 0x63, 0x12, 0x12, 0x0a, 0x04, 0x69, 0x64, 0x6c, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x69, 0x64, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x66, 0x75, 0x6c, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x66, 0x75, 0x6c, 0x6c,
+	0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x69, 0x64, 0x5f, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x08, 0x6d, 0x69, 0x64, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x12, 0x19, 0x0a,
+	0x08, 0x6d, 0x69, 0x64, 0x5f, 0x75, 0x74, 0x69, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x02, 0x52,
+	0x07, 0x6d, 0x69, 0x64, 0x55, 0x74, 0x69, 0x6c, 0x22, 0x4c, 0x0a, 0x0c, 0x43, 0x61, 0x70, 0x61,
+	0x63, 0x69, 0x74, 0x79, 0x44, 0x61, 0x74, 0x61, 0x12, 0x3c, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e,
+	0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41,
+	0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52,
+	0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xaa, 0x01, 0x0a, 0x10, 0x41, 0x63, 0x63, 0x65, 0x6c,
+	0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x74, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6d, 0x61, 0x78, 0x5f, 0x70, 0x6f, 0x77,
+	0x65, 0x72, 0x5f, 0x77, 0x61, 0x74, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0d,
+	0x6d, 0x61, 0x78, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x57, 0x61, 0x74, 0x74, 0x73, 0x12, 0x24, 0x0a,
+	0x0e, 0x75, 0x6e, 0x69, 0x74, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x75, 0x6e, 0x69, 0x74, 0x73, 0x50, 0x65, 0x72, 0x4e,
+	0x6f, 0x64, 0x65, 0x22, 0x53, 0x0a, 0x09, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x44, 0x61, 0x74, 0x61,
+	0x12, 0x46, 0x0a, 0x06, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x2e, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d,
+	0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x41, 0x63, 0x63,
+	0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x50, 0x65, 0x72, 0x66, 0x44, 0x61, 0x74, 0x61,
+	0x52, 0x06, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x22, 0xbe, 0x04, 0x0a, 0x18, 0x4d, 0x6f, 0x64,
+	0x65, 0x6c, 0x41, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x50, 0x65, 0x72,
+	0x66, 0x44, 0x61, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x63, 0x63,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x61, 0x63, 0x63, 0x12, 0x1b, 0x0a, 0x09, 0x61,
+	0x63, 0x63, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
+	0x61, 0x63, 0x63, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x24, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f,
+	0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0c, 0x6d, 0x61, 0x78, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1b,
+	0x0a, 0x09, 0x61, 0x74, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x08, 0x61, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x12, 0x44, 0x0a, 0x0c, 0x64,
+	0x65, 0x63, 0x6f, 0x64, 0x65, 0x5f, 0x70, 0x61, 0x72, 0x6d, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x21, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69,
+	0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x63, 0x6f, 0x64, 0x65, 0x50,
+	0x61, 0x72, 0x6d, 0x73, 0x52, 0x0b, 0x64, 0x65, 0x63, 0x6f, 0x64, 0x65, 0x50, 0x61, 0x72, 0x6d,
+	0x73, 0x12, 0x47, 0x0a, 0x0d, 0x70, 0x72, 0x65, 0x66, 0x69, 0x6c, 0x6c, 0x5f, 0x70, 0x61, 0x72,
+	0x6d, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72,
+	0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x50, 0x72, 0x65, 0x66, 0x69, 0x6c, 0x6c, 0x50, 0x61, 0x72, 0x6d, 0x73, 0x52, 0x0c, 0x70, 0x72,
+	0x65, 0x66, 0x69, 0x6c, 0x6c, 0x50, 0x61, 0x72, 0x6d, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x70,
+	0x5f, 0x64, 0x65, 0x67, 0x72, 0x65, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x74,
+	0x70, 0x44, 0x65, 0x67, 0x72, 0x65, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x70, 0x5f, 0x73, 0x63,
+	0x61, 0x6c, 0x69, 0x6e, 0x67, 0x18, 0x09, 0x20, 0x01, 0x28, 0x02, 0x52, 0x09, 0x74, 0x70, 0x53,
+	0x63, 0x61, 0x6c, 0x69, 0x6e, 0x67, 0x12, 0x1b, 0x0a, 0x09, 0x75, 0x6e, 0x69, 0x74, 0x5f, 0x63,
+	0x6f, 0x73, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x02, 0x52, 0x08, 0x75, 0x6e, 0x69, 0x74, 0x43,
+	0x6f, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x77, 0x61, 0x72, 0x6d, 0x75, 0x70, 0x5f, 0x73, 0x65,
+	0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0d, 0x77, 0x61, 0x72,
+	0x6d, 0x75, 0x70, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x68,
+	0x61, 0x72, 0x65, 0x64, 0x5f, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x73, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0d, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x73, 0x74,
+	0x18, 0x0d, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0a, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x43, 0x6f,
+	0x73, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x66, 0x69, 0x74, 0x5f, 0x72, 0x5f, 0x73, 0x71, 0x75, 0x61,
+	0x72, 0x65, 0x64, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0b, 0x66, 0x69, 0x74, 0x52, 0x53,
+	0x71, 0x75, 0x61, 0x72, 0x65, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f,
+	0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0b, 0x62, 0x61,
+	0x74, 0x63, 0x68, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x22, 0x37, 0x0a, 0x0b, 0x44, 0x65, 0x63,
+	0x6f, 0x64, 0x65, 0x50, 0x61, 0x72, 0x6d, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02, 0x52, 0x05, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x12, 0x12,
+	0x0a, 0x04, 0x62, 0x65, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x04, 0x62, 0x65,
+	0x74, 0x61, 0x22, 0x3a, 0x0a, 0x0c, 0x50, 0x72, 0x65, 0x66, 0x69, 0x6c, 0x6c, 0x50, 0x61, 0x72,
+	0x6d, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x61, 0x6d, 0x6d, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x02, 0x52, 0x05, 0x67, 0x61, 0x6d, 0x6d, 0x61, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x65, 0x6c, 0x74,
+	0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x05, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x22, 0x63,
+	0x0a, 0x10, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x44, 0x61,
+	0x74, 0x61, 0x12, 0x4f, 0x0a, 0x0f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x63, 0x6c,
+	0x61, 0x73, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x69, 0x6e,
+	0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x53,
+	0x70, 0x65, 0x63, 0x52, 0x0e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6c, 0x61, 0x73,
+	0x73, 0x65, 0x73, 0x22, 0x8b, 0x03, 0x0a, 0x10, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43,
+	0x6c, 0x61, 0x73, 0x73, 0x53, 0x70, 0x65, 0x63, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
+	0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x12, 0x46, 0x0a, 0x0d, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e,
+	0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4d,
+	0x6f, 0x64, 0x65, 0x6c, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x0c, 0x6d, 0x6f, 0x64, 0x65,
+	0x6c, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x12, 0x5c, 0x0a, 0x0c, 0x72, 0x65, 0x73, 0x65,
+	0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x38,
+	0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6c, 0x61,
+	0x73, 0x73, 0x53, 0x70, 0x65, 0x63, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x48, 0x0a, 0x0e, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c,
+	0x74, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21,
+	0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x54, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x52, 0x0d, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x1a, 0x3f, 0x0a, 0x11, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x22, 0x89, 0x02, 0x0a, 0x0b, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x54, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x17, 0x0a, 0x07, 0x73, 0x6c, 0x6f, 0x5f, 0x69,
+	0x74, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x06, 0x73, 0x6c, 0x6f, 0x49, 0x74, 0x6c,
+	0x12, 0x19, 0x0a, 0x08, 0x73, 0x6c, 0x6f, 0x5f, 0x74, 0x74, 0x66, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x02, 0x52, 0x07, 0x73, 0x6c, 0x6f, 0x54, 0x74, 0x66, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x73,
+	0x6c, 0x6f, 0x5f, 0x74, 0x70, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x02, 0x52, 0x06, 0x73, 0x6c,
+	0x6f, 0x54, 0x70, 0x73, 0x12, 0x1e, 0x0a, 0x0b, 0x73, 0x6c, 0x6f, 0x5f, 0x69, 0x74, 0x6c, 0x5f,
+	0x70, 0x39, 0x39, 0x18, 0x05, 0x20, 0x01, 0x28, 0x02, 0x52, 0x09, 0x73, 0x6c, 0x6f, 0x49, 0x74,
+	0x6c, 0x50, 0x39, 0x39, 0x12, 0x20, 0x0a, 0x0c, 0x73, 0x6c, 0x6f, 0x5f, 0x74, 0x74, 0x66, 0x74,
+	0x5f, 0x70, 0x39, 0x39, 0x18, 0x06, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0a, 0x73, 0x6c, 0x6f, 0x54,
+	0x74, 0x66, 0x74, 0x50, 0x39, 0x39, 0x12, 0x29, 0x0a, 0x11, 0x73, 0x6c, 0x6f, 0x5f, 0x6d, 0x61,
+	0x78, 0x5f, 0x64, 0x72, 0x6f, 0x70, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x02, 0x52, 0x0e, 0x73, 0x6c, 0x6f, 0x4d, 0x61, 0x78, 0x44, 0x72, 0x6f, 0x70, 0x52, 0x61, 0x74,
+	0x65, 0x12, 0x2a, 0x0a, 0x11, 0x73, 0x6c, 0x6f, 0x5f, 0x77, 0x61, 0x69, 0x74, 0x5f, 0x66, 0x72,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0f, 0x73, 0x6c,
+	0x6f, 0x57, 0x61, 0x69, 0x74, 0x46, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x48, 0x0a,
+	0x0a, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x12, 0x3a, 0x0a, 0x07, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x69,
+	0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x70, 0x65, 0x63, 0x52, 0x07,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x22, 0xca, 0x07, 0x0a, 0x0a, 0x53, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x53, 0x70, 0x65, 0x63, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6c,
+	0x61, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x6c, 0x61, 0x73, 0x73,
+	0x12, 0x14, 0x0a, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x29, 0x0a, 0x10, 0x6b, 0x65, 0x65, 0x70, 0x5f, 0x61,
+	0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0f, 0x6b, 0x65, 0x65, 0x70, 0x41, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x6f,
+	0x72, 0x12, 0x31, 0x0a, 0x14, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x5f, 0x61, 0x63, 0x63,
+	0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x13, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x41, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61,
+	0x74, 0x6f, 0x72, 0x73, 0x12, 0x2f, 0x0a, 0x13, 0x64, 0x65, 0x6e, 0x69, 0x65, 0x64, 0x5f, 0x61,
+	0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x12, 0x64, 0x65, 0x6e, 0x69, 0x65, 0x64, 0x41, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72,
+	0x61, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x6d, 0x69, 0x6e, 0x5f, 0x6e, 0x75, 0x6d,
+	0x5f, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0e, 0x6d, 0x69, 0x6e, 0x4e, 0x75, 0x6d, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x12,
+	0x28, 0x0a, 0x10, 0x6d, 0x61, 0x78, 0x5f, 0x6e, 0x75, 0x6d, 0x5f, 0x72, 0x65, 0x70, 0x6c, 0x69,
+	0x63, 0x61, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x6d, 0x61, 0x78, 0x4e, 0x75,
+	0x6d, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x72, 0x65, 0x70,
+	0x6c, 0x69, 0x63, 0x61, 0x5f, 0x63, 0x61, 0x70, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x43, 0x61,
+	0x70, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x64,
+	0x5f, 0x66, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x73,
+	0x68, 0x61, 0x72, 0x64, 0x46, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x24, 0x0a, 0x0e, 0x6d, 0x61,
+	0x78, 0x5f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0c, 0x6d, 0x61, 0x78, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x69, 0x7a, 0x65,
+	0x12, 0x25, 0x0a, 0x0e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x5f, 0x62, 0x61, 0x74,
+	0x63, 0x68, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69,
+	0x7a, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x1f, 0x0a, 0x0b, 0x71, 0x75, 0x65, 0x75, 0x65,
+	0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x71, 0x75,
+	0x65, 0x75, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x49, 0x0a, 0x0d, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x74, 0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x24, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69,
+	0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x0c, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x41, 0x6c,
+	0x6c, 0x6f, 0x63, 0x12, 0x49, 0x0a, 0x0d, 0x64, 0x65, 0x73, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x61,
+	0x6c, 0x6c, 0x6f, 0x63, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x69, 0x6e, 0x66,
+	0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x61,
+	0x52, 0x0c, 0x64, 0x65, 0x73, 0x69, 0x72, 0x65, 0x64, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x12, 0x43,
+	0x0a, 0x0c, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x10,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f,
+	0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x61, 0x64,
+	0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x52, 0x0b, 0x6c, 0x6f, 0x61, 0x64, 0x50, 0x72, 0x6f, 0x66,
+	0x69, 0x6c, 0x65, 0x12, 0x3d, 0x0a, 0x08, 0x61, 0x64, 0x61, 0x70, 0x74, 0x65, 0x72, 0x73, 0x18,
+	0x11, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e,
+	0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x61,
+	0x70, 0x74, 0x65, 0x72, 0x53, 0x70, 0x65, 0x63, 0x52, 0x08, 0x61, 0x64, 0x61, 0x70, 0x74, 0x65,
+	0x72, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x77, 0x61, 0x72, 0x6d, 0x5f, 0x72, 0x65, 0x70, 0x6c, 0x69,
+	0x63, 0x61, 0x73, 0x18, 0x12, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x77, 0x61, 0x72, 0x6d, 0x52,
+	0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x12, 0x48, 0x0a, 0x0c, 0x6c, 0x6f, 0x77, 0x5f, 0x70,
+	0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e,
+	0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x77, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79,
+	0x53, 0x70, 0x65, 0x63, 0x52, 0x0b, 0x6c, 0x6f, 0x77, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74,
+	0x79, 0x12, 0x51, 0x0a, 0x11, 0x70, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x5f, 0x61, 0x6c, 0x6c, 0x6f,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x69,
+	0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61,
+	0x74, 0x61, 0x52, 0x10, 0x70, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x22, 0x5b, 0x0a, 0x0b, 0x41, 0x64, 0x61, 0x70, 0x74, 0x65, 0x72, 0x53,
+	0x70, 0x65, 0x63, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x38, 0x0a, 0x04, 0x6c, 0x6f, 0x61, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e,
+	0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x4c, 0x6f, 0x61, 0x64, 0x53, 0x70, 0x65, 0x63, 0x52, 0x04, 0x6c, 0x6f, 0x61,
+	0x64, 0x22, 0x61, 0x0a, 0x0f, 0x4c, 0x6f, 0x77, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79,
+	0x53, 0x70, 0x65, 0x63, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x12, 0x38, 0x0a, 0x04, 0x6c, 0x6f,
+	0x61, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72,
+	0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4c, 0x6f, 0x61, 0x64, 0x53, 0x70, 0x65, 0x63, 0x52, 0x04,
+	0x6c, 0x6f, 0x61, 0x64, 0x22, 0x5e, 0x0a, 0x0a, 0x4c, 0x6f, 0x61, 0x64, 0x57, 0x69, 0x6e, 0x64,
+	0x6f, 0x77, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x12, 0x38, 0x0a, 0x04, 0x6c, 0x6f,
+	0x61, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72,
+	0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4c, 0x6f, 0x61, 0x64, 0x53, 0x70, 0x65, 0x63, 0x52, 0x04,
+	0x6c, 0x6f, 0x61, 0x64, 0x22, 0xbf, 0x01, 0x0a, 0x0e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4c,
+	0x6f, 0x61, 0x64, 0x53, 0x70, 0x65, 0x63, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x72, 0x72, 0x69, 0x76,
+	0x61, 0x6c, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0b, 0x61,
+	0x72, 0x72, 0x69, 0x76, 0x61, 0x6c, 0x52, 0x61, 0x74, 0x65, 0x12, 0x22, 0x0a, 0x0d, 0x61, 0x76,
+	0x67, 0x5f, 0x69, 0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0b, 0x61, 0x76, 0x67, 0x49, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x12, 0x24,
+	0x0a, 0x0e, 0x61, 0x76, 0x67, 0x5f, 0x6f, 0x75, 0x74, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x61, 0x76, 0x67, 0x4f, 0x75, 0x74, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x72, 0x72, 0x69, 0x76, 0x61, 0x6c, 0x5f,
+	0x63, 0x6f, 0x76, 0x18, 0x04, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0a, 0x61, 0x72, 0x72, 0x69, 0x76,
+	0x61, 0x6c, 0x43, 0x6f, 0x76, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x5f, 0x63, 0x6f, 0x76, 0x18, 0x05, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0a, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x43, 0x6f, 0x76, 0x22, 0xa7, 0x06, 0x0a, 0x0e, 0x41, 0x6c, 0x6c, 0x6f, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x12, 0x20, 0x0a, 0x0b, 0x61, 0x63, 0x63,
+	0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x61, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x6e,
+	0x75, 0x6d, 0x5f, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0b, 0x6e, 0x75, 0x6d, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x12, 0x1b,
+	0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x63,
+	0x6f, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x02, 0x52, 0x04, 0x63, 0x6f, 0x73, 0x74, 0x12,
+	0x14, 0x0a, 0x05, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x02, 0x52, 0x05,
+	0x70, 0x6f, 0x77, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x74, 0x6c, 0x5f, 0x61, 0x76, 0x65,
+	0x72, 0x61, 0x67, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0a, 0x69, 0x74, 0x6c, 0x41,
+	0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x74, 0x66, 0x74, 0x5f, 0x61,
+	0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0b, 0x74, 0x74,
+	0x66, 0x74, 0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x69, 0x74, 0x6c,
+	0x5f, 0x70, 0x39, 0x39, 0x18, 0x08, 0x20, 0x01, 0x28, 0x02, 0x52, 0x06, 0x69, 0x74, 0x6c, 0x50,
+	0x39, 0x39, 0x12, 0x19, 0x0a, 0x08, 0x74, 0x74, 0x66, 0x74, 0x5f, 0x70, 0x39, 0x39, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x02, 0x52, 0x07, 0x74, 0x74, 0x66, 0x74, 0x50, 0x39, 0x39, 0x12, 0x1b, 0x0a,
+	0x09, 0x64, 0x72, 0x6f, 0x70, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x02,
+	0x52, 0x08, 0x64, 0x72, 0x6f, 0x70, 0x52, 0x61, 0x74, 0x65, 0x12, 0x38, 0x0a, 0x04, 0x6c, 0x6f,
+	0x61, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72,
+	0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4c, 0x6f, 0x61, 0x64, 0x53, 0x70, 0x65, 0x63, 0x52, 0x04,
+	0x6c, 0x6f, 0x61, 0x64, 0x12, 0x2d, 0x0a, 0x12, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65,
+	0x64, 0x5f, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x11, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x69,
+	0x63, 0x61, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x67, 0x72, 0x61, 0x64, 0x65, 0x64, 0x18,
+	0x0d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x64, 0x65, 0x67, 0x72, 0x61, 0x64, 0x65, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x69, 0x64, 0x6c, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x69,
+	0x64, 0x6c, 0x65, 0x12, 0x42, 0x0a, 0x09, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x61, 0x72, 0x79,
+	0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f,
+	0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6c,
+	0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x09, 0x73, 0x65,
+	0x63, 0x6f, 0x6e, 0x64, 0x61, 0x72, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x73, 0x74, 0x69, 0x6d,
+	0x61, 0x74, 0x65, 0x64, 0x18, 0x10, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x65, 0x73, 0x74, 0x69,
+	0x6d, 0x61, 0x74, 0x65, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x77, 0x61, 0x72, 0x6d, 0x5f, 0x72, 0x65,
+	0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x18, 0x11, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x77, 0x61,
+	0x72, 0x6d, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x77, 0x61,
+	0x72, 0x6d, 0x5f, 0x63, 0x6f, 0x73, 0x74, 0x18, 0x12, 0x20, 0x01, 0x28, 0x02, 0x52, 0x08, 0x77,
+	0x61, 0x72, 0x6d, 0x43, 0x6f, 0x73, 0x74, 0x12, 0x4a, 0x0a, 0x0c, 0x6c, 0x6f, 0x77, 0x5f, 0x70,
+	0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e,
+	0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4c, 0x61, 0x74, 0x65, 0x6e,
+	0x63, 0x79, 0x44, 0x61, 0x74, 0x61, 0x52, 0x0b, 0x6c, 0x6f, 0x77, 0x50, 0x72, 0x69, 0x6f, 0x72,
+	0x69, 0x74, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x68, 0x6f, 0x18, 0x14, 0x20, 0x01, 0x28, 0x02,
+	0x52, 0x03, 0x72, 0x68, 0x6f, 0x12, 0x22, 0x0a, 0x0d, 0x61, 0x62, 0x6f, 0x76, 0x65, 0x5f, 0x6d,
+	0x61, 0x78, 0x5f, 0x72, 0x68, 0x6f, 0x18, 0x15, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x61, 0x62,
+	0x6f, 0x76, 0x65, 0x4d, 0x61, 0x78, 0x52, 0x68, 0x6f, 0x12, 0x35, 0x0a, 0x17, 0x63, 0x6f, 0x73,
+	0x74, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x6d, 0x69, 0x6c, 0x6c, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x73, 0x18, 0x16, 0x20, 0x01, 0x28, 0x02, 0x52, 0x14, 0x63, 0x6f, 0x73, 0x74,
+	0x50, 0x65, 0x72, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x6f, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73,
+	0x22, 0x51, 0x0a, 0x11, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63,
+	0x79, 0x44, 0x61, 0x74, 0x61, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x74, 0x66, 0x74, 0x5f, 0x61, 0x76,
+	0x65, 0x72, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0b, 0x74, 0x74, 0x66,
+	0x74, 0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x74, 0x74, 0x66, 0x74,
+	0x5f, 0x70, 0x39, 0x39, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x07, 0x74, 0x74, 0x66, 0x74,
+	0x50, 0x39, 0x39, 0x22, 0x52, 0x0a, 0x0d, 0x4f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72,
+	0x44, 0x61, 0x74, 0x61, 0x12, 0x41, 0x0a, 0x09, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e,
+	0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4f,
+	0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x53, 0x70, 0x65, 0x63, 0x52, 0x09, 0x6f, 0x70,
+	0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x22, 0xd3, 0x08, 0x0a, 0x0d, 0x4f, 0x70, 0x74, 0x69,
+	0x6d, 0x69, 0x7a, 0x65, 0x72, 0x53, 0x70, 0x65, 0x63, 0x12, 0x1c, 0x0a, 0x09, 0x75, 0x6e, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x75, 0x6e,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x64, 0x12, 0x24, 0x0a, 0x0d, 0x68, 0x65, 0x74, 0x65, 0x72,
+	0x6f, 0x67, 0x65, 0x6e, 0x65, 0x6f, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d,
+	0x68, 0x65, 0x74, 0x65, 0x72, 0x6f, 0x67, 0x65, 0x6e, 0x65, 0x6f, 0x75, 0x73, 0x12, 0x1c, 0x0a,
+	0x09, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x1f, 0x0a, 0x0b, 0x6d,
+	0x69, 0x6c, 0x70, 0x5f, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0a, 0x6d, 0x69, 0x6c, 0x70, 0x53, 0x6f, 0x6c, 0x76, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09,
+	0x75, 0x73, 0x65, 0x5f, 0x63, 0x70, 0x6c, 0x65, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x75, 0x73, 0x65, 0x43, 0x70, 0x6c, 0x65, 0x78, 0x12, 0x28, 0x0a, 0x10, 0x6d, 0x69, 0x6c,
+	0x70, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x62, 0x75, 0x64, 0x67, 0x65, 0x74, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0e, 0x6d, 0x69, 0x6c, 0x70, 0x54, 0x69, 0x6d, 0x65, 0x42, 0x75, 0x64,
+	0x67, 0x65, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x6f, 0x73, 0x74, 0x5f, 0x6f, 0x70, 0x74, 0x69,
+	0x6d, 0x69, 0x7a, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x70, 0x6f, 0x73, 0x74,
+	0x4f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x68, 0x75, 0x72,
+	0x6e, 0x5f, 0x62, 0x75, 0x64, 0x67, 0x65, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0b,
+	0x63, 0x68, 0x75, 0x72, 0x6e, 0x42, 0x75, 0x64, 0x67, 0x65, 0x74, 0x12, 0x2e, 0x0a, 0x13, 0x64,
+	0x65, 0x6c, 0x61, 0x79, 0x65, 0x64, 0x5f, 0x62, 0x65, 0x73, 0x74, 0x5f, 0x65, 0x66, 0x66, 0x6f,
+	0x72, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x65,
+	0x64, 0x42, 0x65, 0x73, 0x74, 0x45, 0x66, 0x66, 0x6f, 0x72, 0x74, 0x12, 0x38, 0x0a, 0x18, 0x61,
+	0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x6d, 0x69, 0x78, 0x65, 0x64, 0x5f, 0x61, 0x63, 0x63, 0x65, 0x6c,
+	0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x16, 0x61,
+	0x6c, 0x6c, 0x6f, 0x77, 0x4d, 0x69, 0x78, 0x65, 0x64, 0x41, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72,
+	0x61, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x73, 0x61, 0x74, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x10, 0x73, 0x61, 0x74, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x12, 0x2a, 0x0a, 0x11, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x5f, 0x66,
+	0x6f, 0x72, 0x5f, 0x70, 0x65, 0x61, 0x6b, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x6f,
+	0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x46, 0x6f, 0x72, 0x50, 0x65, 0x61, 0x6b, 0x12, 0x22,
+	0x0a, 0x0d, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x5f, 0x74, 0x6f, 0x5f, 0x7a, 0x65, 0x72, 0x6f, 0x18,
+	0x0d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x54, 0x6f, 0x5a, 0x65,
+	0x72, 0x6f, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x69, 0x65, 0x5f, 0x62, 0x72, 0x65, 0x61, 0x6b, 0x18,
+	0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x69, 0x65, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x12,
+	0x25, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x74, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x70, 0x75,
+	0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x6d, 0x61, 0x78, 0x54, 0x68, 0x72, 0x6f,
+	0x75, 0x67, 0x68, 0x70, 0x75, 0x74, 0x12, 0x24, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x73, 0x74, 0x18, 0x10, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0c,
+	0x6d, 0x61, 0x78, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b,
+	0x73, 0x70, 0x6f, 0x74, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x11, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x73, 0x70, 0x6f, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x2b, 0x0a,
+	0x11, 0x63, 0x72, 0x69, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x5f, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69,
+	0x74, 0x79, 0x18, 0x12, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x63, 0x72, 0x69, 0x74, 0x69, 0x63,
+	0x61, 0x6c, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x25, 0x0a, 0x0e, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x5f, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x13, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0d, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x18, 0x14,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x12,
+	0x37, 0x0a, 0x17, 0x63, 0x6f, 0x6e, 0x73, 0x6f, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x74, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x15, 0x20, 0x01, 0x28, 0x02,
+	0x52, 0x16, 0x63, 0x6f, 0x6e, 0x73, 0x6f, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54,
+	0x6f, 0x6c, 0x65, 0x72, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x65, 0x64,
+	0x18, 0x16, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x65, 0x65, 0x64, 0x12, 0x30, 0x0a, 0x14,
+	0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64, 0x5f,
+	0x70, 0x65, 0x72, 0x66, 0x18, 0x17, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x61, 0x6c, 0x6c, 0x6f,
+	0x77, 0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64, 0x50, 0x65, 0x72, 0x66, 0x12, 0x31,
+	0x0a, 0x15, 0x6d, 0x61, 0x78, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x70, 0x6f, 0x77, 0x65,
+	0x72, 0x5f, 0x77, 0x61, 0x74, 0x74, 0x73, 0x18, 0x18, 0x20, 0x01, 0x28, 0x02, 0x52, 0x12, 0x6d,
+	0x61, 0x78, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x57, 0x61, 0x74, 0x74,
+	0x73, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x65, 0x62, 0x75, 0x67, 0x18, 0x19, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x05, 0x64, 0x65, 0x62, 0x75, 0x67, 0x12, 0x2d, 0x0a, 0x12, 0x68, 0x65, 0x61, 0x64, 0x72,
+	0x6f, 0x6f, 0x6d, 0x5f, 0x74, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x1a, 0x20,
+	0x01, 0x28, 0x02, 0x52, 0x11, 0x68, 0x65, 0x61, 0x64, 0x72, 0x6f, 0x6f, 0x6d, 0x54, 0x6f, 0x6c,
+	0x65, 0x72, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x61, 0x64, 0x6d, 0x69, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x18, 0x1b, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x10, 0x61, 0x64, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x74,
+	0x72, 0x6f, 0x6c, 0x12, 0x28, 0x0a, 0x10, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x5f, 0x72, 0x6f, 0x62,
+	0x69, 0x6e, 0x5f, 0x73, 0x74, 0x65, 0x70, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x72,
+	0x6f, 0x75, 0x6e, 0x64, 0x52, 0x6f, 0x62, 0x69, 0x6e, 0x53, 0x74, 0x65, 0x70, 0x22, 0xfe, 0x05,
+	0x0a, 0x12, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x6f, 0x6c, 0x75,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x5b, 0x0a, 0x0b, 0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x39, 0x2e, 0x69, 0x6e, 0x66, 0x65,
+	0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x6f, 0x6c, 0x75, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x44, 0x0a, 0x0b, 0x75, 0x6e, 0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x65, 0x64,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f,
+	0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x0b, 0x75, 0x6e, 0x61, 0x6c,
+	0x6c, 0x6f, 0x63, 0x61, 0x74, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x67, 0x72, 0x61,
+	0x64, 0x65, 0x64, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x67, 0x72, 0x61,
+	0x64, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x69, 0x64, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x04, 0x69, 0x64, 0x6c, 0x65, 0x12, 0x24, 0x0a, 0x0e, 0x73, 0x63, 0x61, 0x6c, 0x65,
+	0x64, 0x5f, 0x74, 0x6f, 0x5f, 0x7a, 0x65, 0x72, 0x6f, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0c, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x64, 0x54, 0x6f, 0x5a, 0x65, 0x72, 0x6f, 0x12, 0x1f, 0x0a,
+	0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x02, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x12, 0x42,
+	0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x26, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d,
+	0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64, 0x18,
+	0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64,
+	0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x73, 0x74, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x02, 0x52, 0x09, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x73, 0x74, 0x12,
+	0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x63,
+	0x6f, 0x73, 0x74, 0x5f, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x63, 0x6f, 0x73, 0x74, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x12, 0x1b, 0x0a, 0x09,
+	0x77, 0x61, 0x72, 0x6d, 0x5f, 0x63, 0x6f, 0x73, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x02, 0x52,
+	0x08, 0x77, 0x61, 0x72, 0x6d, 0x43, 0x6f, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x72, 0x61,
+	0x69, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x64, 0x72, 0x61,
+	0x69, 0x6e, 0x69, 0x6e, 0x67, 0x12, 0x35, 0x0a, 0x17, 0x63, 0x6f, 0x73, 0x74, 0x5f, 0x70, 0x65,
+	0x72, 0x5f, 0x6d, 0x69, 0x6c, 0x6c, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73,
+	0x18, 0x0e, 0x20, 0x01, 0x28, 0x02, 0x52, 0x14, 0x63, 0x6f, 0x73, 0x74, 0x50, 0x65, 0x72, 0x4d,
+	0x69, 0x6c, 0x6c, 0x69, 0x6f, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x12, 0x3a, 0x0a, 0x06,
+	0x70, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x69,
+	0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x06, 0x70, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x1a, 0x64, 0x0a, 0x10, 0x41, 0x6c, 0x6c, 0x6f,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x3a,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e,
+	0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44,
+	0x61, 0x74, 0x61, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x9d,
+	0x01, 0x0a, 0x0c, 0x50, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x69, 0x61, 0x6e, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x69, 0x61, 0x6e,
+	0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x61, 0x74, 0x75, 0x72, 0x61, 0x74, 0x65, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x73, 0x61, 0x74, 0x75, 0x72, 0x61, 0x74, 0x65, 0x64, 0x12,
+	0x3d, 0x0a, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x23, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d,
+	0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x4c, 0x4f, 0x43, 0x6f, 0x6d, 0x70, 0x6c,
+	0x69, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x22, 0x69,
+	0x0a, 0x0d, 0x53, 0x4c, 0x4f, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x69, 0x61, 0x6e, 0x63, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x02, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x61,
+	0x63, 0x68, 0x69, 0x65, 0x76, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x08, 0x61,
+	0x63, 0x68, 0x69, 0x65, 0x76, 0x65, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x65, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x6d, 0x65, 0x74, 0x22, 0xf6, 0x03, 0x0a, 0x10, 0x53, 0x6f,
+	0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x27,
+	0x0a, 0x0f, 0x69, 0x6d, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x6e, 0x67, 0x5f, 0x6d, 0x6f, 0x76, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x69, 0x6d, 0x70, 0x72, 0x6f, 0x76, 0x69,
+	0x6e, 0x67, 0x4d, 0x6f, 0x76, 0x65, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x6b, 0x65, 0x70, 0x74, 0x5f,
+	0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0f, 0x6b, 0x65, 0x70, 0x74, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x64, 0x5f, 0x6f, 0x75, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x64, 0x4f, 0x75, 0x74, 0x12,
+	0x38, 0x0a, 0x18, 0x61, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x5f, 0x74,
+	0x79, 0x70, 0x65, 0x73, 0x5f, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x16, 0x61, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x54, 0x79,
+	0x70, 0x65, 0x73, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x12, 0x36, 0x0a, 0x17, 0x61, 0x63, 0x63,
+	0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x5f, 0x61,
+	0x66, 0x74, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x15, 0x61, 0x63, 0x63, 0x65,
+	0x6c, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x54, 0x79, 0x70, 0x65, 0x73, 0x41, 0x66, 0x74, 0x65,
+	0x72, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x72,
+	0x6f, 0x6f, 0x6d, 0x18, 0x06, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0d, 0x70, 0x6f, 0x77, 0x65, 0x72,
+	0x48, 0x65, 0x61, 0x64, 0x72, 0x6f, 0x6f, 0x6d, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x69, 0x6e, 0x5f,
+	0x68, 0x65, 0x61, 0x64, 0x72, 0x6f, 0x6f, 0x6d, 0x18, 0x07, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0b,
+	0x6d, 0x69, 0x6e, 0x48, 0x65, 0x61, 0x64, 0x72, 0x6f, 0x6f, 0x6d, 0x12, 0x25, 0x0a, 0x0e, 0x61,
+	0x64, 0x64, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0d, 0x61, 0x64, 0x64, 0x65, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63,
+	0x61, 0x73, 0x12, 0x3a, 0x0a, 0x05, 0x74, 0x72, 0x61, 0x63, 0x65, 0x18, 0x09, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x24, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69,
+	0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x72, 0x65, 0x65, 0x64, 0x79, 0x44,
+	0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x74, 0x72, 0x61, 0x63, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x61, 0x64, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x08, 0x61, 0x64, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x12, 0x36, 0x0a, 0x04, 0x73, 0x68,
+	0x65, 0x64, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72,
+	0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x04, 0x73, 0x68,
+	0x65, 0x64, 0x22, 0x8e, 0x03, 0x0a, 0x0e, 0x47, 0x72, 0x65, 0x65, 0x64, 0x79, 0x44, 0x65, 0x63,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x74, 0x65, 0x70, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x04, 0x73, 0x74, 0x65, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x68, 0x61,
+	0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x68, 0x61, 0x73, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x20, 0x0a, 0x0b, 0x61, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72,
+	0x61, 0x74, 0x6f, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x63, 0x63, 0x65,
+	0x6c, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6e,
+	0x75, 0x6d, 0x5f, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0b, 0x6e, 0x75, 0x6d, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x12, 0x14,
+	0x0a, 0x05, 0x75, 0x6e, 0x69, 0x74, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x75,
+	0x6e, 0x69, 0x74, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c,
+	0x65, 0x5f, 0x75, 0x6e, 0x69, 0x74, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x61,
+	0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x02, 0x52, 0x05, 0x70, 0x6f,
+	0x77, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x02, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x02,
+	0x52, 0x05, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x22, 0x3a, 0x0a, 0x0c, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22,
+	0x4c, 0x0a, 0x0c, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x3c, 0x0a, 0x1a, 0x6d, 0x61, 0x78, 0x5f, 0x63, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
+	0x74, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x18, 0x6d, 0x61, 0x78, 0x43, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x7c, 0x0a,
+	0x0e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x65, 0x6e, 0x61, 0x6c, 0x74, 0x79, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x02, 0x52, 0x07, 0x70, 0x65, 0x6e, 0x61, 0x6c, 0x74, 0x79, 0x12, 0x3c, 0x0a,
+	0x04, 0x64, 0x69, 0x66, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x69, 0x6e,
+	0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x69, 0x66,
+	0x66, 0x44, 0x61, 0x74, 0x61, 0x52, 0x04, 0x64, 0x69, 0x66, 0x66, 0x22, 0xd7, 0x01, 0x0a, 0x12,
+	0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x69, 0x66, 0x66, 0x44, 0x61,
+	0x74, 0x61, 0x12, 0x27, 0x0a, 0x0f, 0x6f, 0x6c, 0x64, 0x5f, 0x61, 0x63, 0x63, 0x65, 0x6c, 0x65,
+	0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6f, 0x6c, 0x64,
+	0x41, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x27, 0x0a, 0x0f, 0x6e,
+	0x65, 0x77, 0x5f, 0x61, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6e, 0x65, 0x77, 0x41, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72,
+	0x61, 0x74, 0x6f, 0x72, 0x12, 0x28, 0x0a, 0x10, 0x6f, 0x6c, 0x64, 0x5f, 0x6e, 0x75, 0x6d, 0x5f,
+	0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e,
+	0x6f, 0x6c, 0x64, 0x4e, 0x75, 0x6d, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x12, 0x28,
+	0x0a, 0x10, 0x6e, 0x65, 0x77, 0x5f, 0x6e, 0x75, 0x6d, 0x5f, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63,
+	0x61, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x6e, 0x65, 0x77, 0x4e, 0x75, 0x6d,
+	0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6f, 0x73, 0x74,
+	0x5f, 0x64, 0x69, 0x66, 0x66, 0x18, 0x05, 0x20, 0x01, 0x28, 0x02, 0x52, 0x08, 0x63, 0x6f, 0x73,
+	0x74, 0x44, 0x69, 0x66, 0x66, 0x22, 0xc9, 0x01, 0x0a, 0x0b, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x3e, 0x0a, 0x07, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f,
+	0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x07, 0x61, 0x70,
+	0x70, 0x6c, 0x69, 0x65, 0x64, 0x12, 0x3e, 0x0a, 0x07, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f,
+	0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x07, 0x70, 0x65,
+	0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x3a, 0x0a, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e,
+	0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65,
+	0x64, 0x32, 0xf6, 0x13, 0x0a, 0x09, 0x4f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x12,
+	0x5f, 0x0a, 0x0f, 0x53, 0x65, 0x74, 0x41, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x6f,
+	0x72, 0x73, 0x12, 0x25, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74,
+	0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63, 0x63, 0x65, 0x6c, 0x65,
+	0x72, 0x61, 0x74, 0x6f, 0x72, 0x44, 0x61, 0x74, 0x61, 0x1a, 0x25, 0x2e, 0x69, 0x6e, 0x66, 0x65,
+	0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x41, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x44, 0x61, 0x74, 0x61,
+	0x12, 0x55, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x41, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74,
+	0x6f, 0x72, 0x73, 0x12, 0x1b, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70,
+	0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x1a, 0x25, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d,
+	0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61,
+	0x74, 0x6f, 0x72, 0x44, 0x61, 0x74, 0x61, 0x12, 0x5a, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x41, 0x63,
+	0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x21, 0x2e, 0x69, 0x6e, 0x66, 0x65,
+	0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x69,
+	0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x53,
+	0x70, 0x65, 0x63, 0x12, 0x5e, 0x0a, 0x0e, 0x41, 0x64, 0x64, 0x41, 0x63, 0x63, 0x65, 0x6c, 0x65,
+	0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x25, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e,
+	0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63, 0x63,
+	0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x70, 0x65, 0x63, 0x1a, 0x25, 0x2e, 0x69,
+	0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x53,
+	0x70, 0x65, 0x63, 0x12, 0x5d, 0x0a, 0x11, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x41, 0x63, 0x63,
+	0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x21, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72,
+	0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x4e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x69, 0x6e,
+	0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x41, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x70,
+	0x65, 0x63, 0x12, 0x57, 0x0a, 0x0d, 0x53, 0x65, 0x74, 0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74,
+	0x69, 0x65, 0x73, 0x12, 0x22, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70,
+	0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x70, 0x61, 0x63,
+	0x69, 0x74, 0x79, 0x44, 0x61, 0x74, 0x61, 0x1a, 0x22, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e,
+	0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x44, 0x61, 0x74, 0x61, 0x12, 0x50, 0x0a, 0x0d, 0x47,
+	0x65, 0x74, 0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x1b, 0x2e, 0x69,
+	0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x22, 0x2e, 0x69, 0x6e, 0x66, 0x65,
+	0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x44, 0x61, 0x74, 0x61, 0x12, 0x58, 0x0a,
+	0x0b, 0x47, 0x65, 0x74, 0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x12, 0x21, 0x2e, 0x69,
+	0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x26, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69,
+	0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74,
+	0x6f, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x5d, 0x0a, 0x0b, 0x53, 0x65, 0x74, 0x43, 0x61,
+	0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x12, 0x26, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f,
+	0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63,
+	0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x1a, 0x26,
+	0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x6f,
+	0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x5b, 0x0a, 0x0e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65,
+	0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x12, 0x21, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72,
+	0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x4e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x69, 0x6e,
+	0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x41, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x4d, 0x0a, 0x09, 0x53, 0x65, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x73,
+	0x12, 0x1f, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d,
+	0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x44, 0x61, 0x74,
+	0x61, 0x1a, 0x1f, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69,
+	0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x44, 0x61,
+	0x74, 0x61, 0x12, 0x4a, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x12,
+	0x1b, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69,
+	0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x20, 0x2e, 0x69,
+	0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x4e,
+	0x0a, 0x08, 0x47, 0x65, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x21, 0x2e, 0x69, 0x6e, 0x66,
+	0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e,
+	0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x44, 0x61, 0x74, 0x61, 0x12, 0x4a,
+	0x0a, 0x08, 0x41, 0x64, 0x64, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x21, 0x2e, 0x69, 0x6e, 0x66,
+	0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e,
+	0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x4d, 0x0a, 0x0b, 0x52, 0x65,
+	0x6d, 0x6f, 0x76, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x21, 0x2e, 0x69, 0x6e, 0x66, 0x65,
+	0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x69,
+	0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x63, 0x0a, 0x11, 0x53, 0x65, 0x74,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x65, 0x73, 0x12, 0x26,
+	0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6c, 0x61,
+	0x73, 0x73, 0x44, 0x61, 0x74, 0x61, 0x1a, 0x26, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f,
+	0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x44, 0x61, 0x74, 0x61, 0x12, 0x58,
+	0x0a, 0x11, 0x47, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6c, 0x61, 0x73,
+	0x73, 0x65, 0x73, 0x12, 0x1b, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70,
+	0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x1a, 0x26, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d,
+	0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43,
+	0x6c, 0x61, 0x73, 0x73, 0x44, 0x61, 0x74, 0x61, 0x12, 0x5c, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x12, 0x21, 0x2e, 0x69, 0x6e,
+	0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26,
+	0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6c, 0x61,
+	0x73, 0x73, 0x53, 0x70, 0x65, 0x63, 0x12, 0x61, 0x0a, 0x0f, 0x41, 0x64, 0x64, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x12, 0x26, 0x2e, 0x69, 0x6e, 0x66, 0x65,
+	0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x53, 0x70, 0x65,
+	0x63, 0x1a, 0x26, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69,
+	0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x43, 0x6c, 0x61, 0x73, 0x73, 0x53, 0x70, 0x65, 0x63, 0x12, 0x5f, 0x0a, 0x12, 0x52, 0x65, 0x6d,
+	0x6f, 0x76, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x12,
+	0x21, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69,
+	0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x26, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74,
+	0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x53, 0x70, 0x65, 0x63, 0x12, 0x50, 0x0a, 0x0a, 0x53, 0x65,
+	0x74, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x12, 0x20, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72,
+	0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x1a, 0x20, 0x2e, 0x69, 0x6e, 0x66,
+	0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x12, 0x4b, 0x0a, 0x0a,
+	0x47, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x12, 0x1b, 0x2e, 0x69, 0x6e, 0x66,
+	0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x20, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e,
+	0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x12, 0x50, 0x0a, 0x09, 0x47, 0x65, 0x74,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x21, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f,
+	0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x61,
+	0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x69, 0x6e, 0x66, 0x65,
+	0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x70, 0x65, 0x63, 0x12, 0x4f, 0x0a, 0x09, 0x41,
+	0x64, 0x64, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x20, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72,
+	0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x70, 0x65, 0x63, 0x1a, 0x20, 0x2e, 0x69, 0x6e, 0x66,
+	0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x70, 0x65, 0x63, 0x12, 0x53, 0x0a, 0x0c,
+	0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x21, 0x2e, 0x69,
+	0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x20, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69,
+	0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x70, 0x65,
+	0x63, 0x12, 0x59, 0x0a, 0x08, 0x4f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x12, 0x23, 0x2e,
+	0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x53, 0x70,
+	0x65, 0x63, 0x1a, 0x28, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74,
+	0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x53, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x59, 0x0a, 0x0b,
+	0x4f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x4f, 0x6e, 0x65, 0x12, 0x20, 0x2e, 0x69, 0x6e,
+	0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x44, 0x61, 0x74, 0x61, 0x1a, 0x28, 0x2e,
+	0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53,
+	0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x51, 0x0a, 0x0f, 0x41, 0x70, 0x70, 0x6c, 0x79,
+	0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x2e, 0x69, 0x6e, 0x66,
+	0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x21, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e,
+	0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41,
+	0x70, 0x70, 0x6c, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x4e, 0x0a, 0x05, 0x41, 0x70,
+	0x70, 0x6c, 0x79, 0x12, 0x22, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e, 0x6f, 0x2e, 0x6f, 0x70,
+	0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x79,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x6e,
+	0x6f, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41,
+	0x70, 0x70, 0x6c, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x42, 0x3a, 0x5a, 0x38, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6c, 0x6c, 0x6d, 0x2d, 0x69, 0x6e, 0x66,
+	0x65, 0x72, 0x6e, 0x6f, 0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2f, 0x67,
+	0x72, 0x70, 0x63, 0x2d, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6d,
+	0x69, 0x7a, 0x65, 0x72, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+})
+
+var (
+	file_optimizer_proto_rawDescOnce sync.Once
+	file_optimizer_proto_rawDescData []byte
+)
+
+func file_optimizer_proto_rawDescGZIP() []byte {
+	file_optimizer_proto_rawDescOnce.Do(func() {
+		file_optimizer_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_optimizer_proto_rawDesc), len(file_optimizer_proto_rawDesc)))
+	})
+	return file_optimizer_proto_rawDescData
+}
+
+var file_optimizer_proto_msgTypes = make([]protoimpl.MessageInfo, 39)
+var file_optimizer_proto_goTypes = []any{
+	(*Empty)(nil),                    // 0: inferno.optimizer.v1.Empty
+	(*NameRequest)(nil),              // 1: inferno.optimizer.v1.NameRequest
+	(*ModelNames)(nil),               // 2: inferno.optimizer.v1.ModelNames
+	(*SystemData)(nil),               // 3: inferno.optimizer.v1.SystemData
+	(*SystemSpec)(nil),               // 4: inferno.optimizer.v1.SystemSpec
+	(*AcceleratorData)(nil),          // 5: inferno.optimizer.v1.AcceleratorData
+	(*AcceleratorSpec)(nil),          // 6: inferno.optimizer.v1.AcceleratorSpec
+	(*PowerSpec)(nil),                // 7: inferno.optimizer.v1.PowerSpec
+	(*CapacityData)(nil),             // 8: inferno.optimizer.v1.CapacityData
+	(*AcceleratorCount)(nil),         // 9: inferno.optimizer.v1.AcceleratorCount
+	(*ModelData)(nil),                // 10: inferno.optimizer.v1.ModelData
+	(*ModelAcceleratorPerfData)(nil), // 11: inferno.optimizer.v1.ModelAcceleratorPerfData
+	(*DecodeParms)(nil),              // 12: inferno.optimizer.v1.DecodeParms
+	(*PrefillParms)(nil),             // 13: inferno.optimizer.v1.PrefillParms
+	(*ServiceClassData)(nil),         // 14: inferno.optimizer.v1.ServiceClassData
+	(*ServiceClassSpec)(nil),         // 15: inferno.optimizer.v1.ServiceClassSpec
+	(*ModelTarget)(nil),              // 16: inferno.optimizer.v1.ModelTarget
+	(*ServerData)(nil),               // 17: inferno.optimizer.v1.ServerData
+	(*ServerSpec)(nil),               // 18: inferno.optimizer.v1.ServerSpec
+	(*AdapterSpec)(nil),              // 19: inferno.optimizer.v1.AdapterSpec
+	(*LowPrioritySpec)(nil),          // 20: inferno.optimizer.v1.LowPrioritySpec
+	(*LoadWindow)(nil),               // 21: inferno.optimizer.v1.LoadWindow
+	(*ServerLoadSpec)(nil),           // 22: inferno.optimizer.v1.ServerLoadSpec
+	(*AllocationData)(nil),           // 23: inferno.optimizer.v1.AllocationData
+	(*StreamLatencyData)(nil),        // 24: inferno.optimizer.v1.StreamLatencyData
+	(*OptimizerData)(nil),            // 25: inferno.optimizer.v1.OptimizerData
+	(*OptimizerSpec)(nil),            // 26: inferno.optimizer.v1.OptimizerSpec
+	(*AllocationSolution)(nil),       // 27: inferno.optimizer.v1.AllocationSolution
+	(*PinnedStatus)(nil),             // 28: inferno.optimizer.v1.PinnedStatus
+	(*SLOCompliance)(nil),            // 29: inferno.optimizer.v1.SLOCompliance
+	(*SolutionMetadata)(nil),         // 30: inferno.optimizer.v1.SolutionMetadata
+	(*GreedyDecision)(nil),           // 31: inferno.optimizer.v1.GreedyDecision
+	(*ServerStatus)(nil),             // 32: inferno.optimizer.v1.ServerStatus
+	(*ApplyRequest)(nil),             // 33: inferno.optimizer.v1.ApplyRequest
+	(*TransitionData)(nil),           // 34: inferno.optimizer.v1.TransitionData
+	(*AllocationDiffData)(nil),       // 35: inferno.optimizer.v1.AllocationDiffData
+	(*ApplyResult)(nil),              // 36: inferno.optimizer.v1.ApplyResult
+	nil,                              // 37: inferno.optimizer.v1.ServiceClassSpec.ReservationsEntry
+	nil,                              // 38: inferno.optimizer.v1.AllocationSolution.AllocationsEntry
+}
+var file_optimizer_proto_depIdxs = []int32{
+	4,  // 0: inferno.optimizer.v1.SystemData.system:type_name -> inferno.optimizer.v1.SystemSpec
+	5,  // 1: inferno.optimizer.v1.SystemSpec.accelerator_data:type_name -> inferno.optimizer.v1.AcceleratorData
+	10, // 2: inferno.optimizer.v1.SystemSpec.model_data:type_name -> inferno.optimizer.v1.ModelData
+	14, // 3: inferno.optimizer.v1.SystemSpec.service_class_data:type_name -> inferno.optimizer.v1.ServiceClassData
+	17, // 4: inferno.optimizer.v1.SystemSpec.server_data:type_name -> inferno.optimizer.v1.ServerData
+	25, // 5: inferno.optimizer.v1.SystemSpec.optimizer_data:type_name -> inferno.optimizer.v1.OptimizerData
+	8,  // 6: inferno.optimizer.v1.SystemSpec.capacity_data:type_name -> inferno.optimizer.v1.CapacityData
+	6,  // 7: inferno.optimizer.v1.AcceleratorData.accelerators:type_name -> inferno.optimizer.v1.AcceleratorSpec
+	7,  // 8: inferno.optimizer.v1.AcceleratorSpec.power:type_name -> inferno.optimizer.v1.PowerSpec
+	9,  // 9: inferno.optimizer.v1.CapacityData.count:type_name -> inferno.optimizer.v1.AcceleratorCount
+	11, // 10: inferno.optimizer.v1.ModelData.models:type_name -> inferno.optimizer.v1.ModelAcceleratorPerfData
+	12, // 11: inferno.optimizer.v1.ModelAcceleratorPerfData.decode_parms:type_name -> inferno.optimizer.v1.DecodeParms
+	13, // 12: inferno.optimizer.v1.ModelAcceleratorPerfData.prefill_parms:type_name -> inferno.optimizer.v1.PrefillParms
+	15, // 13: inferno.optimizer.v1.ServiceClassData.service_classes:type_name -> inferno.optimizer.v1.ServiceClassSpec
+	16, // 14: inferno.optimizer.v1.ServiceClassSpec.model_targets:type_name -> inferno.optimizer.v1.ModelTarget
+	37, // 15: inferno.optimizer.v1.ServiceClassSpec.reservations:type_name -> inferno.optimizer.v1.ServiceClassSpec.ReservationsEntry
+	16, // 16: inferno.optimizer.v1.ServiceClassSpec.default_target:type_name -> inferno.optimizer.v1.ModelTarget
+	18, // 17: inferno.optimizer.v1.ServerData.servers:type_name -> inferno.optimizer.v1.ServerSpec
+	23, // 18: inferno.optimizer.v1.ServerSpec.current_alloc:type_name -> inferno.optimizer.v1.AllocationData
+	23, // 19: inferno.optimizer.v1.ServerSpec.desired_alloc:type_name -> inferno.optimizer.v1.AllocationData
+	21, // 20: inferno.optimizer.v1.ServerSpec.load_profile:type_name -> inferno.optimizer.v1.LoadWindow
+	19, // 21: inferno.optimizer.v1.ServerSpec.adapters:type_name -> inferno.optimizer.v1.AdapterSpec
+	20, // 22: inferno.optimizer.v1.ServerSpec.low_priority:type_name -> inferno.optimizer.v1.LowPrioritySpec
+	23, // 23: inferno.optimizer.v1.ServerSpec.pinned_allocation:type_name -> inferno.optimizer.v1.AllocationData
+	22, // 24: inferno.optimizer.v1.AdapterSpec.load:type_name -> inferno.optimizer.v1.ServerLoadSpec
+	22, // 25: inferno.optimizer.v1.LowPrioritySpec.load:type_name -> inferno.optimizer.v1.ServerLoadSpec
+	22, // 26: inferno.optimizer.v1.LoadWindow.load:type_name -> inferno.optimizer.v1.ServerLoadSpec
+	22, // 27: inferno.optimizer.v1.AllocationData.load:type_name -> inferno.optimizer.v1.ServerLoadSpec
+	23, // 28: inferno.optimizer.v1.AllocationData.secondary:type_name -> inferno.optimizer.v1.AllocationData
+	24, // 29: inferno.optimizer.v1.AllocationData.low_priority:type_name -> inferno.optimizer.v1.StreamLatencyData
+	26, // 30: inferno.optimizer.v1.OptimizerData.optimizer:type_name -> inferno.optimizer.v1.OptimizerSpec
+	38, // 31: inferno.optimizer.v1.AllocationSolution.allocations:type_name -> inferno.optimizer.v1.AllocationSolution.AllocationsEntry
+	32, // 32: inferno.optimizer.v1.AllocationSolution.unallocated:type_name -> inferno.optimizer.v1.ServerStatus
+	30, // 33: inferno.optimizer.v1.AllocationSolution.metadata:type_name -> inferno.optimizer.v1.SolutionMetadata
+	28, // 34: inferno.optimizer.v1.AllocationSolution.pinned:type_name -> inferno.optimizer.v1.PinnedStatus
+	29, // 35: inferno.optimizer.v1.PinnedStatus.targets:type_name -> inferno.optimizer.v1.SLOCompliance
+	31, // 36: inferno.optimizer.v1.SolutionMetadata.trace:type_name -> inferno.optimizer.v1.GreedyDecision
+	32, // 37: inferno.optimizer.v1.SolutionMetadata.shed:type_name -> inferno.optimizer.v1.ServerStatus
+	35, // 38: inferno.optimizer.v1.TransitionData.diff:type_name -> inferno.optimizer.v1.AllocationDiffData
+	34, // 39: inferno.optimizer.v1.ApplyResult.applied:type_name -> inferno.optimizer.v1.TransitionData
+	34, // 40: inferno.optimizer.v1.ApplyResult.pending:type_name -> inferno.optimizer.v1.TransitionData
+	32, // 41: inferno.optimizer.v1.ApplyResult.failed:type_name -> inferno.optimizer.v1.ServerStatus
+	23, // 42: inferno.optimizer.v1.AllocationSolution.AllocationsEntry.value:type_name -> inferno.optimizer.v1.AllocationData
+	5,  // 43: inferno.optimizer.v1.Optimizer.SetAccelerators:input_type -> inferno.optimizer.v1.AcceleratorData
+	0,  // 44: inferno.optimizer.v1.Optimizer.GetAccelerators:input_type -> inferno.optimizer.v1.Empty
+	1,  // 45: inferno.optimizer.v1.Optimizer.GetAccelerator:input_type -> inferno.optimizer.v1.NameRequest
+	6,  // 46: inferno.optimizer.v1.Optimizer.AddAccelerator:input_type -> inferno.optimizer.v1.AcceleratorSpec
+	1,  // 47: inferno.optimizer.v1.Optimizer.RemoveAccelerator:input_type -> inferno.optimizer.v1.NameRequest
+	8,  // 48: inferno.optimizer.v1.Optimizer.SetCapacities:input_type -> inferno.optimizer.v1.CapacityData
+	0,  // 49: inferno.optimizer.v1.Optimizer.GetCapacities:input_type -> inferno.optimizer.v1.Empty
+	1,  // 50: inferno.optimizer.v1.Optimizer.GetCapacity:input_type -> inferno.optimizer.v1.NameRequest
+	9,  // 51: inferno.optimizer.v1.Optimizer.SetCapacity:input_type -> inferno.optimizer.v1.AcceleratorCount
+	1,  // 52: inferno.optimizer.v1.Optimizer.RemoveCapacity:input_type -> inferno.optimizer.v1.NameRequest
+	10, // 53: inferno.optimizer.v1.Optimizer.SetModels:input_type -> inferno.optimizer.v1.ModelData
+	0,  // 54: inferno.optimizer.v1.Optimizer.GetModels:input_type -> inferno.optimizer.v1.Empty
+	1,  // 55: inferno.optimizer.v1.Optimizer.GetModel:input_type -> inferno.optimizer.v1.NameRequest
+	1,  // 56: inferno.optimizer.v1.Optimizer.AddModel:input_type -> inferno.optimizer.v1.NameRequest
+	1,  // 57: inferno.optimizer.v1.Optimizer.RemoveModel:input_type -> inferno.optimizer.v1.NameRequest
+	14, // 58: inferno.optimizer.v1.Optimizer.SetServiceClasses:input_type -> inferno.optimizer.v1.ServiceClassData
+	0,  // 59: inferno.optimizer.v1.Optimizer.GetServiceClasses:input_type -> inferno.optimizer.v1.Empty
+	1,  // 60: inferno.optimizer.v1.Optimizer.GetServiceClass:input_type -> inferno.optimizer.v1.NameRequest
+	15, // 61: inferno.optimizer.v1.Optimizer.AddServiceClass:input_type -> inferno.optimizer.v1.ServiceClassSpec
+	1,  // 62: inferno.optimizer.v1.Optimizer.RemoveServiceClass:input_type -> inferno.optimizer.v1.NameRequest
+	17, // 63: inferno.optimizer.v1.Optimizer.SetServers:input_type -> inferno.optimizer.v1.ServerData
+	0,  // 64: inferno.optimizer.v1.Optimizer.GetServers:input_type -> inferno.optimizer.v1.Empty
+	1,  // 65: inferno.optimizer.v1.Optimizer.GetServer:input_type -> inferno.optimizer.v1.NameRequest
+	18, // 66: inferno.optimizer.v1.Optimizer.AddServer:input_type -> inferno.optimizer.v1.ServerSpec
+	1,  // 67: inferno.optimizer.v1.Optimizer.RemoveServer:input_type -> inferno.optimizer.v1.NameRequest
+	26, // 68: inferno.optimizer.v1.Optimizer.Optimize:input_type -> inferno.optimizer.v1.OptimizerSpec
+	3,  // 69: inferno.optimizer.v1.Optimizer.OptimizeOne:input_type -> inferno.optimizer.v1.SystemData
+	0,  // 70: inferno.optimizer.v1.Optimizer.ApplyAllocation:input_type -> inferno.optimizer.v1.Empty
+	33, // 71: inferno.optimizer.v1.Optimizer.Apply:input_type -> inferno.optimizer.v1.ApplyRequest
+	5,  // 72: inferno.optimizer.v1.Optimizer.SetAccelerators:output_type -> inferno.optimizer.v1.AcceleratorData
+	5,  // 73: inferno.optimizer.v1.Optimizer.GetAccelerators:output_type -> inferno.optimizer.v1.AcceleratorData
+	6,  // 74: inferno.optimizer.v1.Optimizer.GetAccelerator:output_type -> inferno.optimizer.v1.AcceleratorSpec
+	6,  // 75: inferno.optimizer.v1.Optimizer.AddAccelerator:output_type -> inferno.optimizer.v1.AcceleratorSpec
+	6,  // 76: inferno.optimizer.v1.Optimizer.RemoveAccelerator:output_type -> inferno.optimizer.v1.AcceleratorSpec
+	8,  // 77: inferno.optimizer.v1.Optimizer.SetCapacities:output_type -> inferno.optimizer.v1.CapacityData
+	8,  // 78: inferno.optimizer.v1.Optimizer.GetCapacities:output_type -> inferno.optimizer.v1.CapacityData
+	9,  // 79: inferno.optimizer.v1.Optimizer.GetCapacity:output_type -> inferno.optimizer.v1.AcceleratorCount
+	9,  // 80: inferno.optimizer.v1.Optimizer.SetCapacity:output_type -> inferno.optimizer.v1.AcceleratorCount
+	9,  // 81: inferno.optimizer.v1.Optimizer.RemoveCapacity:output_type -> inferno.optimizer.v1.AcceleratorCount
+	10, // 82: inferno.optimizer.v1.Optimizer.SetModels:output_type -> inferno.optimizer.v1.ModelData
+	2,  // 83: inferno.optimizer.v1.Optimizer.GetModels:output_type -> inferno.optimizer.v1.ModelNames
+	10, // 84: inferno.optimizer.v1.Optimizer.GetModel:output_type -> inferno.optimizer.v1.ModelData
+	0,  // 85: inferno.optimizer.v1.Optimizer.AddModel:output_type -> inferno.optimizer.v1.Empty
+	0,  // 86: inferno.optimizer.v1.Optimizer.RemoveModel:output_type -> inferno.optimizer.v1.Empty
+	14, // 87: inferno.optimizer.v1.Optimizer.SetServiceClasses:output_type -> inferno.optimizer.v1.ServiceClassData
+	14, // 88: inferno.optimizer.v1.Optimizer.GetServiceClasses:output_type -> inferno.optimizer.v1.ServiceClassData
+	15, // 89: inferno.optimizer.v1.Optimizer.GetServiceClass:output_type -> inferno.optimizer.v1.ServiceClassSpec
+	15, // 90: inferno.optimizer.v1.Optimizer.AddServiceClass:output_type -> inferno.optimizer.v1.ServiceClassSpec
+	15, // 91: inferno.optimizer.v1.Optimizer.RemoveServiceClass:output_type -> inferno.optimizer.v1.ServiceClassSpec
+	17, // 92: inferno.optimizer.v1.Optimizer.SetServers:output_type -> inferno.optimizer.v1.ServerData
+	17, // 93: inferno.optimizer.v1.Optimizer.GetServers:output_type -> inferno.optimizer.v1.ServerData
+	18, // 94: inferno.optimizer.v1.Optimizer.GetServer:output_type -> inferno.optimizer.v1.ServerSpec
+	18, // 95: inferno.optimizer.v1.Optimizer.AddServer:output_type -> inferno.optimizer.v1.ServerSpec
+	18, // 96: inferno.optimizer.v1.Optimizer.RemoveServer:output_type -> inferno.optimizer.v1.ServerSpec
+	27, // 97: inferno.optimizer.v1.Optimizer.Optimize:output_type -> inferno.optimizer.v1.AllocationSolution
+	27, // 98: inferno.optimizer.v1.Optimizer.OptimizeOne:output_type -> inferno.optimizer.v1.AllocationSolution
+	36, // 99: inferno.optimizer.v1.Optimizer.ApplyAllocation:output_type -> inferno.optimizer.v1.ApplyResult
+	36, // 100: inferno.optimizer.v1.Optimizer.Apply:output_type -> inferno.optimizer.v1.ApplyResult
+	72, // [72:101] is the sub-list for method output_type
+	43, // [43:72] is the sub-list for method input_type
+	43, // [43:43] is the sub-list for extension type_name
+	43, // [43:43] is the sub-list for extension extendee
+	0,  // [0:43] is the sub-list for field type_name
+}
+
+func init() { file_optimizer_proto_init() }
+func file_optimizer_proto_init() {
+	if File_optimizer_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_optimizer_proto_rawDesc), len(file_optimizer_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   39,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_optimizer_proto_goTypes,
+		DependencyIndexes: file_optimizer_proto_depIdxs,
+		MessageInfos:      file_optimizer_proto_msgTypes,
+	}.Build()
+	File_optimizer_proto = out.File
+	file_optimizer_proto_goTypes = nil
+	file_optimizer_proto_depIdxs = nil
+}
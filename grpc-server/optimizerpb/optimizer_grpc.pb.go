@@ -0,0 +1,1201 @@
+// gRPC interface of the optimizer, mirroring the statefull REST server
+//   - messages mirror the data types of package config, with the same field names (in snake case)
+//   - float32 fields are floats, int fields are int32
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: optimizer.proto
+
+package optimizerpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Optimizer_SetAccelerators_FullMethodName    = "/inferno.optimizer.v1.Optimizer/SetAccelerators"
+	Optimizer_GetAccelerators_FullMethodName    = "/inferno.optimizer.v1.Optimizer/GetAccelerators"
+	Optimizer_GetAccelerator_FullMethodName     = "/inferno.optimizer.v1.Optimizer/GetAccelerator"
+	Optimizer_AddAccelerator_FullMethodName     = "/inferno.optimizer.v1.Optimizer/AddAccelerator"
+	Optimizer_RemoveAccelerator_FullMethodName  = "/inferno.optimizer.v1.Optimizer/RemoveAccelerator"
+	Optimizer_SetCapacities_FullMethodName      = "/inferno.optimizer.v1.Optimizer/SetCapacities"
+	Optimizer_GetCapacities_FullMethodName      = "/inferno.optimizer.v1.Optimizer/GetCapacities"
+	Optimizer_GetCapacity_FullMethodName        = "/inferno.optimizer.v1.Optimizer/GetCapacity"
+	Optimizer_SetCapacity_FullMethodName        = "/inferno.optimizer.v1.Optimizer/SetCapacity"
+	Optimizer_RemoveCapacity_FullMethodName     = "/inferno.optimizer.v1.Optimizer/RemoveCapacity"
+	Optimizer_SetModels_FullMethodName          = "/inferno.optimizer.v1.Optimizer/SetModels"
+	Optimizer_GetModels_FullMethodName          = "/inferno.optimizer.v1.Optimizer/GetModels"
+	Optimizer_GetModel_FullMethodName           = "/inferno.optimizer.v1.Optimizer/GetModel"
+	Optimizer_AddModel_FullMethodName           = "/inferno.optimizer.v1.Optimizer/AddModel"
+	Optimizer_RemoveModel_FullMethodName        = "/inferno.optimizer.v1.Optimizer/RemoveModel"
+	Optimizer_SetServiceClasses_FullMethodName  = "/inferno.optimizer.v1.Optimizer/SetServiceClasses"
+	Optimizer_GetServiceClasses_FullMethodName  = "/inferno.optimizer.v1.Optimizer/GetServiceClasses"
+	Optimizer_GetServiceClass_FullMethodName    = "/inferno.optimizer.v1.Optimizer/GetServiceClass"
+	Optimizer_AddServiceClass_FullMethodName    = "/inferno.optimizer.v1.Optimizer/AddServiceClass"
+	Optimizer_RemoveServiceClass_FullMethodName = "/inferno.optimizer.v1.Optimizer/RemoveServiceClass"
+	Optimizer_SetServers_FullMethodName         = "/inferno.optimizer.v1.Optimizer/SetServers"
+	Optimizer_GetServers_FullMethodName         = "/inferno.optimizer.v1.Optimizer/GetServers"
+	Optimizer_GetServer_FullMethodName          = "/inferno.optimizer.v1.Optimizer/GetServer"
+	Optimizer_AddServer_FullMethodName          = "/inferno.optimizer.v1.Optimizer/AddServer"
+	Optimizer_RemoveServer_FullMethodName       = "/inferno.optimizer.v1.Optimizer/RemoveServer"
+	Optimizer_Optimize_FullMethodName           = "/inferno.optimizer.v1.Optimizer/Optimize"
+	Optimizer_OptimizeOne_FullMethodName        = "/inferno.optimizer.v1.Optimizer/OptimizeOne"
+	Optimizer_ApplyAllocation_FullMethodName    = "/inferno.optimizer.v1.Optimizer/ApplyAllocation"
+	Optimizer_Apply_FullMethodName              = "/inferno.optimizer.v1.Optimizer/Apply"
+)
+
+// OptimizerClient is the client API for Optimizer service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type OptimizerClient interface {
+	// accelerators
+	SetAccelerators(ctx context.Context, in *AcceleratorData, opts ...grpc.CallOption) (*AcceleratorData, error)
+	GetAccelerators(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*AcceleratorData, error)
+	GetAccelerator(ctx context.Context, in *NameRequest, opts ...grpc.CallOption) (*AcceleratorSpec, error)
+	AddAccelerator(ctx context.Context, in *AcceleratorSpec, opts ...grpc.CallOption) (*AcceleratorSpec, error)
+	RemoveAccelerator(ctx context.Context, in *NameRequest, opts ...grpc.CallOption) (*AcceleratorSpec, error)
+	// capacities of accelerator types
+	SetCapacities(ctx context.Context, in *CapacityData, opts ...grpc.CallOption) (*CapacityData, error)
+	GetCapacities(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CapacityData, error)
+	GetCapacity(ctx context.Context, in *NameRequest, opts ...grpc.CallOption) (*AcceleratorCount, error)
+	SetCapacity(ctx context.Context, in *AcceleratorCount, opts ...grpc.CallOption) (*AcceleratorCount, error)
+	RemoveCapacity(ctx context.Context, in *NameRequest, opts ...grpc.CallOption) (*AcceleratorCount, error)
+	// models
+	SetModels(ctx context.Context, in *ModelData, opts ...grpc.CallOption) (*ModelData, error)
+	GetModels(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ModelNames, error)
+	GetModel(ctx context.Context, in *NameRequest, opts ...grpc.CallOption) (*ModelData, error)
+	AddModel(ctx context.Context, in *NameRequest, opts ...grpc.CallOption) (*Empty, error)
+	RemoveModel(ctx context.Context, in *NameRequest, opts ...grpc.CallOption) (*Empty, error)
+	// service classes
+	SetServiceClasses(ctx context.Context, in *ServiceClassData, opts ...grpc.CallOption) (*ServiceClassData, error)
+	GetServiceClasses(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ServiceClassData, error)
+	GetServiceClass(ctx context.Context, in *NameRequest, opts ...grpc.CallOption) (*ServiceClassSpec, error)
+	AddServiceClass(ctx context.Context, in *ServiceClassSpec, opts ...grpc.CallOption) (*ServiceClassSpec, error)
+	RemoveServiceClass(ctx context.Context, in *NameRequest, opts ...grpc.CallOption) (*ServiceClassSpec, error)
+	// servers
+	SetServers(ctx context.Context, in *ServerData, opts ...grpc.CallOption) (*ServerData, error)
+	GetServers(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ServerData, error)
+	GetServer(ctx context.Context, in *NameRequest, opts ...grpc.CallOption) (*ServerSpec, error)
+	AddServer(ctx context.Context, in *ServerSpec, opts ...grpc.CallOption) (*ServerSpec, error)
+	RemoveServer(ctx context.Context, in *NameRequest, opts ...grpc.CallOption) (*ServerSpec, error)
+	// optimization
+	Optimize(ctx context.Context, in *OptimizerSpec, opts ...grpc.CallOption) (*AllocationSolution, error)
+	OptimizeOne(ctx context.Context, in *SystemData, opts ...grpc.CallOption) (*AllocationSolution, error)
+	ApplyAllocation(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ApplyResult, error)
+	Apply(ctx context.Context, in *ApplyRequest, opts ...grpc.CallOption) (*ApplyResult, error)
+}
+
+type optimizerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOptimizerClient(cc grpc.ClientConnInterface) OptimizerClient {
+	return &optimizerClient{cc}
+}
+
+func (c *optimizerClient) SetAccelerators(ctx context.Context, in *AcceleratorData, opts ...grpc.CallOption) (*AcceleratorData, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AcceleratorData)
+	err := c.cc.Invoke(ctx, Optimizer_SetAccelerators_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *optimizerClient) GetAccelerators(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*AcceleratorData, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AcceleratorData)
+	err := c.cc.Invoke(ctx, Optimizer_GetAccelerators_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *optimizerClient) GetAccelerator(ctx context.Context, in *NameRequest, opts ...grpc.CallOption) (*AcceleratorSpec, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AcceleratorSpec)
+	err := c.cc.Invoke(ctx, Optimizer_GetAccelerator_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *optimizerClient) AddAccelerator(ctx context.Context, in *AcceleratorSpec, opts ...grpc.CallOption) (*AcceleratorSpec, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AcceleratorSpec)
+	err := c.cc.Invoke(ctx, Optimizer_AddAccelerator_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *optimizerClient) RemoveAccelerator(ctx context.Context, in *NameRequest, opts ...grpc.CallOption) (*AcceleratorSpec, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AcceleratorSpec)
+	err := c.cc.Invoke(ctx, Optimizer_RemoveAccelerator_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *optimizerClient) SetCapacities(ctx context.Context, in *CapacityData, opts ...grpc.CallOption) (*CapacityData, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CapacityData)
+	err := c.cc.Invoke(ctx, Optimizer_SetCapacities_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *optimizerClient) GetCapacities(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CapacityData, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CapacityData)
+	err := c.cc.Invoke(ctx, Optimizer_GetCapacities_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *optimizerClient) GetCapacity(ctx context.Context, in *NameRequest, opts ...grpc.CallOption) (*AcceleratorCount, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AcceleratorCount)
+	err := c.cc.Invoke(ctx, Optimizer_GetCapacity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *optimizerClient) SetCapacity(ctx context.Context, in *AcceleratorCount, opts ...grpc.CallOption) (*AcceleratorCount, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AcceleratorCount)
+	err := c.cc.Invoke(ctx, Optimizer_SetCapacity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *optimizerClient) RemoveCapacity(ctx context.Context, in *NameRequest, opts ...grpc.CallOption) (*AcceleratorCount, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AcceleratorCount)
+	err := c.cc.Invoke(ctx, Optimizer_RemoveCapacity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *optimizerClient) SetModels(ctx context.Context, in *ModelData, opts ...grpc.CallOption) (*ModelData, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ModelData)
+	err := c.cc.Invoke(ctx, Optimizer_SetModels_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *optimizerClient) GetModels(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ModelNames, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ModelNames)
+	err := c.cc.Invoke(ctx, Optimizer_GetModels_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *optimizerClient) GetModel(ctx context.Context, in *NameRequest, opts ...grpc.CallOption) (*ModelData, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ModelData)
+	err := c.cc.Invoke(ctx, Optimizer_GetModel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *optimizerClient) AddModel(ctx context.Context, in *NameRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Optimizer_AddModel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *optimizerClient) RemoveModel(ctx context.Context, in *NameRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Optimizer_RemoveModel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *optimizerClient) SetServiceClasses(ctx context.Context, in *ServiceClassData, opts ...grpc.CallOption) (*ServiceClassData, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ServiceClassData)
+	err := c.cc.Invoke(ctx, Optimizer_SetServiceClasses_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *optimizerClient) GetServiceClasses(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ServiceClassData, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ServiceClassData)
+	err := c.cc.Invoke(ctx, Optimizer_GetServiceClasses_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *optimizerClient) GetServiceClass(ctx context.Context, in *NameRequest, opts ...grpc.CallOption) (*ServiceClassSpec, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ServiceClassSpec)
+	err := c.cc.Invoke(ctx, Optimizer_GetServiceClass_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *optimizerClient) AddServiceClass(ctx context.Context, in *ServiceClassSpec, opts ...grpc.CallOption) (*ServiceClassSpec, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ServiceClassSpec)
+	err := c.cc.Invoke(ctx, Optimizer_AddServiceClass_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *optimizerClient) RemoveServiceClass(ctx context.Context, in *NameRequest, opts ...grpc.CallOption) (*ServiceClassSpec, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ServiceClassSpec)
+	err := c.cc.Invoke(ctx, Optimizer_RemoveServiceClass_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *optimizerClient) SetServers(ctx context.Context, in *ServerData, opts ...grpc.CallOption) (*ServerData, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ServerData)
+	err := c.cc.Invoke(ctx, Optimizer_SetServers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *optimizerClient) GetServers(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ServerData, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ServerData)
+	err := c.cc.Invoke(ctx, Optimizer_GetServers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *optimizerClient) GetServer(ctx context.Context, in *NameRequest, opts ...grpc.CallOption) (*ServerSpec, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ServerSpec)
+	err := c.cc.Invoke(ctx, Optimizer_GetServer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *optimizerClient) AddServer(ctx context.Context, in *ServerSpec, opts ...grpc.CallOption) (*ServerSpec, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ServerSpec)
+	err := c.cc.Invoke(ctx, Optimizer_AddServer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *optimizerClient) RemoveServer(ctx context.Context, in *NameRequest, opts ...grpc.CallOption) (*ServerSpec, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ServerSpec)
+	err := c.cc.Invoke(ctx, Optimizer_RemoveServer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *optimizerClient) Optimize(ctx context.Context, in *OptimizerSpec, opts ...grpc.CallOption) (*AllocationSolution, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AllocationSolution)
+	err := c.cc.Invoke(ctx, Optimizer_Optimize_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *optimizerClient) OptimizeOne(ctx context.Context, in *SystemData, opts ...grpc.CallOption) (*AllocationSolution, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AllocationSolution)
+	err := c.cc.Invoke(ctx, Optimizer_OptimizeOne_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *optimizerClient) ApplyAllocation(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ApplyResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApplyResult)
+	err := c.cc.Invoke(ctx, Optimizer_ApplyAllocation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *optimizerClient) Apply(ctx context.Context, in *ApplyRequest, opts ...grpc.CallOption) (*ApplyResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApplyResult)
+	err := c.cc.Invoke(ctx, Optimizer_Apply_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OptimizerServer is the server API for Optimizer service.
+// All implementations must embed UnimplementedOptimizerServer
+// for forward compatibility.
+type OptimizerServer interface {
+	// accelerators
+	SetAccelerators(context.Context, *AcceleratorData) (*AcceleratorData, error)
+	GetAccelerators(context.Context, *Empty) (*AcceleratorData, error)
+	GetAccelerator(context.Context, *NameRequest) (*AcceleratorSpec, error)
+	AddAccelerator(context.Context, *AcceleratorSpec) (*AcceleratorSpec, error)
+	RemoveAccelerator(context.Context, *NameRequest) (*AcceleratorSpec, error)
+	// capacities of accelerator types
+	SetCapacities(context.Context, *CapacityData) (*CapacityData, error)
+	GetCapacities(context.Context, *Empty) (*CapacityData, error)
+	GetCapacity(context.Context, *NameRequest) (*AcceleratorCount, error)
+	SetCapacity(context.Context, *AcceleratorCount) (*AcceleratorCount, error)
+	RemoveCapacity(context.Context, *NameRequest) (*AcceleratorCount, error)
+	// models
+	SetModels(context.Context, *ModelData) (*ModelData, error)
+	GetModels(context.Context, *Empty) (*ModelNames, error)
+	GetModel(context.Context, *NameRequest) (*ModelData, error)
+	AddModel(context.Context, *NameRequest) (*Empty, error)
+	RemoveModel(context.Context, *NameRequest) (*Empty, error)
+	// service classes
+	SetServiceClasses(context.Context, *ServiceClassData) (*ServiceClassData, error)
+	GetServiceClasses(context.Context, *Empty) (*ServiceClassData, error)
+	GetServiceClass(context.Context, *NameRequest) (*ServiceClassSpec, error)
+	AddServiceClass(context.Context, *ServiceClassSpec) (*ServiceClassSpec, error)
+	RemoveServiceClass(context.Context, *NameRequest) (*ServiceClassSpec, error)
+	// servers
+	SetServers(context.Context, *ServerData) (*ServerData, error)
+	GetServers(context.Context, *Empty) (*ServerData, error)
+	GetServer(context.Context, *NameRequest) (*ServerSpec, error)
+	AddServer(context.Context, *ServerSpec) (*ServerSpec, error)
+	RemoveServer(context.Context, *NameRequest) (*ServerSpec, error)
+	// optimization
+	Optimize(context.Context, *OptimizerSpec) (*AllocationSolution, error)
+	OptimizeOne(context.Context, *SystemData) (*AllocationSolution, error)
+	ApplyAllocation(context.Context, *Empty) (*ApplyResult, error)
+	Apply(context.Context, *ApplyRequest) (*ApplyResult, error)
+	mustEmbedUnimplementedOptimizerServer()
+}
+
+// UnimplementedOptimizerServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedOptimizerServer struct{}
+
+func (UnimplementedOptimizerServer) SetAccelerators(context.Context, *AcceleratorData) (*AcceleratorData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAccelerators not implemented")
+}
+func (UnimplementedOptimizerServer) GetAccelerators(context.Context, *Empty) (*AcceleratorData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccelerators not implemented")
+}
+func (UnimplementedOptimizerServer) GetAccelerator(context.Context, *NameRequest) (*AcceleratorSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccelerator not implemented")
+}
+func (UnimplementedOptimizerServer) AddAccelerator(context.Context, *AcceleratorSpec) (*AcceleratorSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddAccelerator not implemented")
+}
+func (UnimplementedOptimizerServer) RemoveAccelerator(context.Context, *NameRequest) (*AcceleratorSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveAccelerator not implemented")
+}
+func (UnimplementedOptimizerServer) SetCapacities(context.Context, *CapacityData) (*CapacityData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCapacities not implemented")
+}
+func (UnimplementedOptimizerServer) GetCapacities(context.Context, *Empty) (*CapacityData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCapacities not implemented")
+}
+func (UnimplementedOptimizerServer) GetCapacity(context.Context, *NameRequest) (*AcceleratorCount, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCapacity not implemented")
+}
+func (UnimplementedOptimizerServer) SetCapacity(context.Context, *AcceleratorCount) (*AcceleratorCount, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCapacity not implemented")
+}
+func (UnimplementedOptimizerServer) RemoveCapacity(context.Context, *NameRequest) (*AcceleratorCount, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveCapacity not implemented")
+}
+func (UnimplementedOptimizerServer) SetModels(context.Context, *ModelData) (*ModelData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetModels not implemented")
+}
+func (UnimplementedOptimizerServer) GetModels(context.Context, *Empty) (*ModelNames, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetModels not implemented")
+}
+func (UnimplementedOptimizerServer) GetModel(context.Context, *NameRequest) (*ModelData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetModel not implemented")
+}
+func (UnimplementedOptimizerServer) AddModel(context.Context, *NameRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddModel not implemented")
+}
+func (UnimplementedOptimizerServer) RemoveModel(context.Context, *NameRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveModel not implemented")
+}
+func (UnimplementedOptimizerServer) SetServiceClasses(context.Context, *ServiceClassData) (*ServiceClassData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetServiceClasses not implemented")
+}
+func (UnimplementedOptimizerServer) GetServiceClasses(context.Context, *Empty) (*ServiceClassData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetServiceClasses not implemented")
+}
+func (UnimplementedOptimizerServer) GetServiceClass(context.Context, *NameRequest) (*ServiceClassSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetServiceClass not implemented")
+}
+func (UnimplementedOptimizerServer) AddServiceClass(context.Context, *ServiceClassSpec) (*ServiceClassSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddServiceClass not implemented")
+}
+func (UnimplementedOptimizerServer) RemoveServiceClass(context.Context, *NameRequest) (*ServiceClassSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveServiceClass not implemented")
+}
+func (UnimplementedOptimizerServer) SetServers(context.Context, *ServerData) (*ServerData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetServers not implemented")
+}
+func (UnimplementedOptimizerServer) GetServers(context.Context, *Empty) (*ServerData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetServers not implemented")
+}
+func (UnimplementedOptimizerServer) GetServer(context.Context, *NameRequest) (*ServerSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetServer not implemented")
+}
+func (UnimplementedOptimizerServer) AddServer(context.Context, *ServerSpec) (*ServerSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddServer not implemented")
+}
+func (UnimplementedOptimizerServer) RemoveServer(context.Context, *NameRequest) (*ServerSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveServer not implemented")
+}
+func (UnimplementedOptimizerServer) Optimize(context.Context, *OptimizerSpec) (*AllocationSolution, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Optimize not implemented")
+}
+func (UnimplementedOptimizerServer) OptimizeOne(context.Context, *SystemData) (*AllocationSolution, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OptimizeOne not implemented")
+}
+func (UnimplementedOptimizerServer) ApplyAllocation(context.Context, *Empty) (*ApplyResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyAllocation not implemented")
+}
+func (UnimplementedOptimizerServer) Apply(context.Context, *ApplyRequest) (*ApplyResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Apply not implemented")
+}
+func (UnimplementedOptimizerServer) mustEmbedUnimplementedOptimizerServer() {}
+func (UnimplementedOptimizerServer) testEmbeddedByValue()                   {}
+
+// UnsafeOptimizerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OptimizerServer will
+// result in compilation errors.
+type UnsafeOptimizerServer interface {
+	mustEmbedUnimplementedOptimizerServer()
+}
+
+func RegisterOptimizerServer(s grpc.ServiceRegistrar, srv OptimizerServer) {
+	// If the following call pancis, it indicates UnimplementedOptimizerServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Optimizer_ServiceDesc, srv)
+}
+
+func _Optimizer_SetAccelerators_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceleratorData)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OptimizerServer).SetAccelerators(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Optimizer_SetAccelerators_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OptimizerServer).SetAccelerators(ctx, req.(*AcceleratorData))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Optimizer_GetAccelerators_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OptimizerServer).GetAccelerators(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Optimizer_GetAccelerators_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OptimizerServer).GetAccelerators(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Optimizer_GetAccelerator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OptimizerServer).GetAccelerator(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Optimizer_GetAccelerator_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OptimizerServer).GetAccelerator(ctx, req.(*NameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Optimizer_AddAccelerator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceleratorSpec)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OptimizerServer).AddAccelerator(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Optimizer_AddAccelerator_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OptimizerServer).AddAccelerator(ctx, req.(*AcceleratorSpec))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Optimizer_RemoveAccelerator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OptimizerServer).RemoveAccelerator(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Optimizer_RemoveAccelerator_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OptimizerServer).RemoveAccelerator(ctx, req.(*NameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Optimizer_SetCapacities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CapacityData)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OptimizerServer).SetCapacities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Optimizer_SetCapacities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OptimizerServer).SetCapacities(ctx, req.(*CapacityData))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Optimizer_GetCapacities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OptimizerServer).GetCapacities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Optimizer_GetCapacities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OptimizerServer).GetCapacities(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Optimizer_GetCapacity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OptimizerServer).GetCapacity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Optimizer_GetCapacity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OptimizerServer).GetCapacity(ctx, req.(*NameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Optimizer_SetCapacity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceleratorCount)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OptimizerServer).SetCapacity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Optimizer_SetCapacity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OptimizerServer).SetCapacity(ctx, req.(*AcceleratorCount))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Optimizer_RemoveCapacity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OptimizerServer).RemoveCapacity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Optimizer_RemoveCapacity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OptimizerServer).RemoveCapacity(ctx, req.(*NameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Optimizer_SetModels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModelData)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OptimizerServer).SetModels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Optimizer_SetModels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OptimizerServer).SetModels(ctx, req.(*ModelData))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Optimizer_GetModels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OptimizerServer).GetModels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Optimizer_GetModels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OptimizerServer).GetModels(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Optimizer_GetModel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OptimizerServer).GetModel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Optimizer_GetModel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OptimizerServer).GetModel(ctx, req.(*NameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Optimizer_AddModel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OptimizerServer).AddModel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Optimizer_AddModel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OptimizerServer).AddModel(ctx, req.(*NameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Optimizer_RemoveModel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OptimizerServer).RemoveModel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Optimizer_RemoveModel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OptimizerServer).RemoveModel(ctx, req.(*NameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Optimizer_SetServiceClasses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServiceClassData)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OptimizerServer).SetServiceClasses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Optimizer_SetServiceClasses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OptimizerServer).SetServiceClasses(ctx, req.(*ServiceClassData))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Optimizer_GetServiceClasses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OptimizerServer).GetServiceClasses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Optimizer_GetServiceClasses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OptimizerServer).GetServiceClasses(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Optimizer_GetServiceClass_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OptimizerServer).GetServiceClass(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Optimizer_GetServiceClass_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OptimizerServer).GetServiceClass(ctx, req.(*NameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Optimizer_AddServiceClass_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServiceClassSpec)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OptimizerServer).AddServiceClass(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Optimizer_AddServiceClass_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OptimizerServer).AddServiceClass(ctx, req.(*ServiceClassSpec))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Optimizer_RemoveServiceClass_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OptimizerServer).RemoveServiceClass(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Optimizer_RemoveServiceClass_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OptimizerServer).RemoveServiceClass(ctx, req.(*NameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Optimizer_SetServers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServerData)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OptimizerServer).SetServers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Optimizer_SetServers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OptimizerServer).SetServers(ctx, req.(*ServerData))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Optimizer_GetServers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OptimizerServer).GetServers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Optimizer_GetServers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OptimizerServer).GetServers(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Optimizer_GetServer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OptimizerServer).GetServer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Optimizer_GetServer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OptimizerServer).GetServer(ctx, req.(*NameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Optimizer_AddServer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServerSpec)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OptimizerServer).AddServer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Optimizer_AddServer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OptimizerServer).AddServer(ctx, req.(*ServerSpec))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Optimizer_RemoveServer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OptimizerServer).RemoveServer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Optimizer_RemoveServer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OptimizerServer).RemoveServer(ctx, req.(*NameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Optimizer_Optimize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OptimizerSpec)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OptimizerServer).Optimize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Optimizer_Optimize_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OptimizerServer).Optimize(ctx, req.(*OptimizerSpec))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Optimizer_OptimizeOne_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SystemData)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OptimizerServer).OptimizeOne(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Optimizer_OptimizeOne_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OptimizerServer).OptimizeOne(ctx, req.(*SystemData))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Optimizer_ApplyAllocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OptimizerServer).ApplyAllocation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Optimizer_ApplyAllocation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OptimizerServer).ApplyAllocation(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Optimizer_Apply_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OptimizerServer).Apply(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Optimizer_Apply_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OptimizerServer).Apply(ctx, req.(*ApplyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Optimizer_ServiceDesc is the grpc.ServiceDesc for Optimizer service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Optimizer_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "inferno.optimizer.v1.Optimizer",
+	HandlerType: (*OptimizerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SetAccelerators",
+			Handler:    _Optimizer_SetAccelerators_Handler,
+		},
+		{
+			MethodName: "GetAccelerators",
+			Handler:    _Optimizer_GetAccelerators_Handler,
+		},
+		{
+			MethodName: "GetAccelerator",
+			Handler:    _Optimizer_GetAccelerator_Handler,
+		},
+		{
+			MethodName: "AddAccelerator",
+			Handler:    _Optimizer_AddAccelerator_Handler,
+		},
+		{
+			MethodName: "RemoveAccelerator",
+			Handler:    _Optimizer_RemoveAccelerator_Handler,
+		},
+		{
+			MethodName: "SetCapacities",
+			Handler:    _Optimizer_SetCapacities_Handler,
+		},
+		{
+			MethodName: "GetCapacities",
+			Handler:    _Optimizer_GetCapacities_Handler,
+		},
+		{
+			MethodName: "GetCapacity",
+			Handler:    _Optimizer_GetCapacity_Handler,
+		},
+		{
+			MethodName: "SetCapacity",
+			Handler:    _Optimizer_SetCapacity_Handler,
+		},
+		{
+			MethodName: "RemoveCapacity",
+			Handler:    _Optimizer_RemoveCapacity_Handler,
+		},
+		{
+			MethodName: "SetModels",
+			Handler:    _Optimizer_SetModels_Handler,
+		},
+		{
+			MethodName: "GetModels",
+			Handler:    _Optimizer_GetModels_Handler,
+		},
+		{
+			MethodName: "GetModel",
+			Handler:    _Optimizer_GetModel_Handler,
+		},
+		{
+			MethodName: "AddModel",
+			Handler:    _Optimizer_AddModel_Handler,
+		},
+		{
+			MethodName: "RemoveModel",
+			Handler:    _Optimizer_RemoveModel_Handler,
+		},
+		{
+			MethodName: "SetServiceClasses",
+			Handler:    _Optimizer_SetServiceClasses_Handler,
+		},
+		{
+			MethodName: "GetServiceClasses",
+			Handler:    _Optimizer_GetServiceClasses_Handler,
+		},
+		{
+			MethodName: "GetServiceClass",
+			Handler:    _Optimizer_GetServiceClass_Handler,
+		},
+		{
+			MethodName: "AddServiceClass",
+			Handler:    _Optimizer_AddServiceClass_Handler,
+		},
+		{
+			MethodName: "RemoveServiceClass",
+			Handler:    _Optimizer_RemoveServiceClass_Handler,
+		},
+		{
+			MethodName: "SetServers",
+			Handler:    _Optimizer_SetServers_Handler,
+		},
+		{
+			MethodName: "GetServers",
+			Handler:    _Optimizer_GetServers_Handler,
+		},
+		{
+			MethodName: "GetServer",
+			Handler:    _Optimizer_GetServer_Handler,
+		},
+		{
+			MethodName: "AddServer",
+			Handler:    _Optimizer_AddServer_Handler,
+		},
+		{
+			MethodName: "RemoveServer",
+			Handler:    _Optimizer_RemoveServer_Handler,
+		},
+		{
+			MethodName: "Optimize",
+			Handler:    _Optimizer_Optimize_Handler,
+		},
+		{
+			MethodName: "OptimizeOne",
+			Handler:    _Optimizer_OptimizeOne_Handler,
+		},
+		{
+			MethodName: "ApplyAllocation",
+			Handler:    _Optimizer_ApplyAllocation_Handler,
+		},
+		{
+			MethodName: "Apply",
+			Handler:    _Optimizer_Apply_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "optimizer.proto",
+}
@@ -0,0 +1,83 @@
+package rpc
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/llm-inferno/optimizer/grpc-server/optimizerpb"
+	"github.com/llm-inferno/optimizer/pkg/core"
+	"google.golang.org/grpc"
+)
+
+// A statefull gRPC server of the optimizer, keeping a system across calls as the statefull REST server
+type Server struct {
+	optimizerpb.UnimplementedOptimizerServer
+
+	// current system, guarded as it may be replaced (OptimizeOne)
+	system      *core.System
+	systemMutex sync.RWMutex
+
+	// serializes optimizations of the current system
+	optimizeMutex sync.Mutex
+
+	// max time of an optimization, bounding the context of the call
+	maxOptimizationTime time.Duration
+}
+
+// create a gRPC server of the optimizer, with a clean system
+func NewServer() *Server {
+	return &Server{
+		system:              core.NewSystem(),
+		maxOptimizationTime: DefaultMaxOptimizationTime,
+	}
+}
+
+// get the current system
+func (s *Server) getSystem() *core.System {
+	s.systemMutex.RLock()
+	defer s.systemMutex.RUnlock()
+	return s.system
+}
+
+// replace the current system
+func (s *Server) setSystem(system *core.System) {
+	s.systemMutex.Lock()
+	defer s.systemMutex.Unlock()
+	s.system = system
+}
+
+// register the optimizer service on a gRPC server
+func (s *Server) Register(registrar grpc.ServiceRegistrar) {
+	optimizerpb.RegisterOptimizerServer(registrar, s)
+}
+
+// start server, serving calls until it fails
+func (s *Server) Run() error {
+	host := ""
+	port := DefaultGrpcPort
+	if h := os.Getenv(GrpcHostEnvName); h != "" {
+		host = h
+	}
+	if p := os.Getenv(GrpcPortEnvName); p != "" {
+		port = p
+	}
+	if t := os.Getenv(MaxOptimizationTimeEnvName); t != "" {
+		if d, err := time.ParseDuration(t); err == nil && d > 0 {
+			s.maxOptimizationTime = d
+		} else {
+			fmt.Printf("warning: invalid %s=%s, using %v \n", MaxOptimizationTimeEnvName, t, s.maxOptimizationTime)
+		}
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(host, port))
+	if err != nil {
+		return err
+	}
+	grpcServer := grpc.NewServer()
+	s.Register(grpcServer)
+	fmt.Printf("gRPC optimizer server listening on %s \n", listener.Addr())
+	return grpcServer.Serve(listener)
+}
@@ -0,0 +1,123 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/llm-inferno/optimizer/grpc-server/optimizerpb"
+	"github.com/llm-inferno/optimizer/pkg/config"
+	"github.com/llm-inferno/optimizer/pkg/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// data of the test system, with a server of granite_13b in class Premium and capacity of both accelerator types
+func testSystemData() *config.SystemData {
+	spec := testutil.SystemSpec()
+	spec.Servers = config.ServerData{Spec: []config.ServerSpec{testutil.ServerSpec("premium-granite", "Premium", 600)}}
+	spec.Capacity = testutil.Capacity(8)
+	return &config.SystemData{Spec: *spec}
+}
+
+// start a gRPC optimizer server in memory, returning a client to it
+func newTestClient(t *testing.T) optimizerpb.OptimizerClient {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer()
+	NewServer().Register(grpcServer)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("cannot connect to server: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return optimizerpb.NewOptimizerClient(conn)
+}
+
+// Optimizing a system given all its data allocates its servers, and keeps the system for later calls
+func TestOptimizeOne(t *testing.T) {
+	client := newTestClient(t)
+	ctx := context.Background()
+
+	solution, err := client.OptimizeOne(ctx, systemDataToProto(testSystemData()))
+	if err != nil {
+		t.Fatalf("OptimizeOne: %v", err)
+	}
+	alloc := solution.GetAllocations()["premium-granite"]
+	if alloc == nil || alloc.GetAccelerator() != "G2" || alloc.GetNumReplicas() <= 0 {
+		t.Fatalf("allocation of premium-granite=%v, want replicas on G2 (cheapest)", alloc)
+	}
+
+	server, err := client.GetServer(ctx, &optimizerpb.NameRequest{Name: "premium-granite"})
+	if err != nil {
+		t.Fatalf("GetServer: %v", err)
+	}
+	if got := server.GetCurrentAlloc().GetLoad().GetArrivalRate(); got != 600 {
+		t.Errorf("arrival rate of kept server=%v, want 600", got)
+	}
+}
+
+// Data set piecewise is optimized as the same data given at once
+func TestSetDataThenOptimize(t *testing.T) {
+	client := newTestClient(t)
+	ctx := context.Background()
+	data := systemDataToProto(testSystemData()).GetSystem()
+
+	if _, err := client.SetAccelerators(ctx, data.GetAcceleratorData()); err != nil {
+		t.Fatalf("SetAccelerators: %v", err)
+	}
+	if _, err := client.SetModels(ctx, data.GetModelData()); err != nil {
+		t.Fatalf("SetModels: %v", err)
+	}
+	if _, err := client.SetServiceClasses(ctx, data.GetServiceClassData()); err != nil {
+		t.Fatalf("SetServiceClasses: %v", err)
+	}
+	if _, err := client.SetServers(ctx, data.GetServerData()); err != nil {
+		t.Fatalf("SetServers: %v", err)
+	}
+	if _, err := client.SetCapacities(ctx, data.GetCapacityData()); err != nil {
+		t.Fatalf("SetCapacities: %v", err)
+	}
+	solution, err := client.Optimize(ctx, &optimizerpb.OptimizerSpec{})
+	if err != nil {
+		t.Fatalf("Optimize: %v", err)
+	}
+	if alloc := solution.GetAllocations()["premium-granite"]; alloc.GetAccelerator() != "G2" {
+		t.Errorf("allocation of premium-granite=%v, want on G2", alloc)
+	}
+
+	models, err := client.GetModels(ctx, &optimizerpb.Empty{})
+	if err != nil {
+		t.Fatalf("GetModels: %v", err)
+	}
+	if names := models.GetNames(); len(names) != 1 || names[0] != "granite_13b" {
+		t.Errorf("model names=%v, want [granite_13b]", names)
+	}
+}
+
+// Calls fail with the status codes of their errors: not found, or invalid argument
+func TestErrorCodes(t *testing.T) {
+	client := newTestClient(t)
+	ctx := context.Background()
+
+	_, err := client.GetAccelerator(ctx, &optimizerpb.NameRequest{Name: "H100"})
+	if code := status.Code(err); code != codes.NotFound {
+		t.Errorf("GetAccelerator of unknown accelerator: code=%v, want %v", code, codes.NotFound)
+	}
+	_, err = client.SetCapacity(ctx, &optimizerpb.AcceleratorCount{Type: "H100", Count: 4})
+	if code := status.Code(err); code != codes.InvalidArgument {
+		t.Errorf("SetCapacity of unknown accelerator type: code=%v, want %v", code, codes.InvalidArgument)
+	}
+	_, err = client.AddServer(ctx, &optimizerpb.ServerSpec{Name: "s", Class: "Premium", Model: "granite_13b"})
+	if code := status.Code(err); code != codes.InvalidArgument {
+		t.Errorf("AddServer with unknown references: code=%v, want %v", code, codes.InvalidArgument)
+	}
+}
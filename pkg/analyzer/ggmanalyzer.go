@@ -0,0 +1,244 @@
+package analyzer
+
+import (
+	"fmt"
+	"math"
+)
+
+// Analyzer of inference server queue using a G/G/m approximation
+//   - the max batch size is the number of servers (m), the queue is unbounded
+//   - service time of a request depends on the average number of requests in service
+//   - waiting time of M/M/m (Erlang C) is scaled by (ca^2 + cs^2)/2 (Allen-Cunneen)
+type GGmAnalyzer struct {
+	MaxBatchSize int           // maximum batch size (number of servers)
+	ServiceParms *ServiceParms // request processing parameters
+	RequestSize  *RequestSize  // number of input and output tokens per request
+	ArrivalCOV   float32       // coefficient of variation of request inter-arrival time
+	ServiceCOV   float32       // coefficient of variation of request service time
+	RateRange    *RateRange    // range of request rates for model stability
+}
+
+// create a new G/G/m queue analyzer from config (max queue size is not used)
+//   - non-positive coefficients of variation default to one (exponential)
+func NewGGmAnalyzer(qConfig *Configuration, requestSize *RequestSize, arrivalCOV float32, serviceCOV float32) (*GGmAnalyzer, error) {
+	if err := qConfig.check(); err != nil {
+		return nil, err
+	}
+	if err := requestSize.check(); err != nil {
+		return nil, err
+	}
+	if arrivalCOV <= 0 {
+		arrivalCOV = 1
+	}
+	if serviceCOV <= 0 {
+		serviceCOV = 1
+	}
+	qa := &GGmAnalyzer{
+		MaxBatchSize: qConfig.MaxBatchSize,
+		ServiceParms: qConfig.ServiceParms,
+		RequestSize:  requestSize,
+		ArrivalCOV:   arrivalCOV,
+		ServiceCOV:   serviceCOV,
+	}
+	lambdaMin := 1 / qa.serviceTime(1) * Epsilon
+	lambdaMax := float32(qa.MaxBatchSize) / qa.serviceTime(float32(qa.MaxBatchSize)) * (1 - Epsilon)
+	qa.RateRange = &RateRange{Min: lambdaMin * 1000, Max: lambdaMax * 1000}
+	if err := qa.RateRange.check(); err != nil {
+		return nil, fmt.Errorf("batchSize=%d, %s: %w", qConfig.MaxBatchSize, requestSize, err)
+	}
+	return qa, nil
+}
+
+// evaluate performance metrics given request rate
+func (qa *GGmAnalyzer) Analyze(requestRate float32) (metrics *AnalysisMetrics, err error) {
+	if requestRate <= 0 {
+		return nil, fmt.Errorf("invalid request rate %v", requestRate)
+	}
+	if requestRate > qa.RateRange.Max {
+		return nil, fmt.Errorf("rate=%v, max allowed rate=%v", requestRate, qa.RateRange.Max)
+	}
+	lambda := requestRate / 1000
+	n, err := qa.concurrency(lambda)
+	if err != nil {
+		return nil, err
+	}
+	servTime := qa.serviceTime(n)
+	probWait, waitTime := qa.waitTime(n, servTime)
+	return &AnalysisMetrics{
+		Throughput:     requestRate,
+		AvgRespTime:    waitTime + servTime,
+		AvgWaitTime:    waitTime,
+		AvgNumInServ:   n,
+		AvgPrefillTime: qa.ServiceParms.Prefill.PrefillTime(qa.RequestSize.AvgInputTokens, n),
+		AvgTokenTime:   qa.ServiceParms.Decode.DecodeTime(n),
+		MaxRate:        qa.RateRange.Max,
+		Rho:            n / float32(qa.MaxBatchSize),
+		P99WaitTime:    qa.waitTimePercentile(n, servTime, probWait, TailPercentile),
+		P99TokenTime:   qa.ServiceParms.Decode.DecodeTime(n),
+	}, nil
+}
+
+// max request rate (requests/sec)
+func (qa *GGmAnalyzer) MaxRate() float32 {
+	return qa.RateRange.Max
+}
+
+// evaluate diagnostics given request rate: probability of queueing (Erlang C)
+//   - state probabilities are not modeled, and the queue is never full
+func (qa *GGmAnalyzer) Diagnose(requestRate float32) (diagnostics *QueueDiagnostics, err error) {
+	if requestRate <= 0 {
+		return nil, fmt.Errorf("invalid request rate %v", requestRate)
+	}
+	if requestRate > qa.RateRange.Max {
+		return nil, fmt.Errorf("rate=%v, max allowed rate=%v", requestRate, qa.RateRange.Max)
+	}
+	n, err := qa.concurrency(requestRate / 1000)
+	if err != nil {
+		return nil, err
+	}
+	probWait, _ := qa.waitTime(n, qa.serviceTime(n))
+	return &QueueDiagnostics{ProbWait: probWait}, nil
+}
+
+// evaluate max request rates to achieve a given target performance, returns
+//   - max request rates
+//   - performance metrics at min of max request rates
+//   - achieved values of targets
+func (qa *GGmAnalyzer) Size(targetPerf *TargetPerf) (targetRate *TargetRate, metrics *AnalysisMetrics, achieved *TargetPerf, err error) {
+	if err := targetPerf.check(); err != nil {
+		return nil, nil, nil, err
+	}
+	lambdaMax := qa.RateRange.Max / 1000
+
+	// evaluation function of a metric at a given rate (req/msec)
+	evalFunc := func(metric func(*AnalysisMetrics) float32) func(float32) (float32, error) {
+		return func(x float32) (float32, error) {
+			m, err := qa.Analyze(x * 1000)
+			if err != nil {
+				return 0, err
+			}
+			return metric(m), nil
+		}
+	}
+	ttft := func(m *AnalysisMetrics) float32 { return m.AvgWaitTime + m.AvgPrefillTime }
+	itl := func(m *AnalysisMetrics) float32 { return m.AvgTokenTime }
+	ttftP99 := func(m *AnalysisMetrics) float32 { return m.P99WaitTime + m.AvgPrefillTime }
+	itlP99 := func(m *AnalysisMetrics) float32 { return m.P99TokenTime }
+	wait := func(m *AnalysisMetrics) float32 { return WaitFraction(m, qa.RequestSize.AvgOutputTokens) }
+
+	var lambdaStarTTFT, lambdaStarITL, lambdaStarTTFTP99, lambdaStarITLP99, lambdaStarWait float32
+	if lambdaStarTTFT, err = searchRate("TTFT", targetPerf.TargetTTFT, qa.RateRange, ErrUnattainableTTFT, evalFunc(ttft)); err != nil {
+		return nil, nil, nil, err
+	}
+	if lambdaStarITL, err = searchRate("ITL", targetPerf.TargetITL, qa.RateRange, ErrUnattainableITL, evalFunc(itl)); err != nil {
+		return nil, nil, nil, err
+	}
+	if lambdaStarTTFTP99, err = searchRate("TTFTP99", targetPerf.TargetTTFTP99, qa.RateRange, ErrUnattainableTTFTP99, evalFunc(ttftP99)); err != nil {
+		return nil, nil, nil, err
+	}
+	if lambdaStarITLP99, err = searchRate("ITLP99", targetPerf.TargetITLP99, qa.RateRange, ErrUnattainableITLP99, evalFunc(itlP99)); err != nil {
+		return nil, nil, nil, err
+	}
+	if lambdaStarWait, err = searchRate("Wait", targetPerf.TargetWaitFraction, qa.RateRange, ErrUnattainableWait, evalFunc(wait)); err != nil {
+		return nil, nil, nil, err
+	}
+	lambdaStarTPS := lambdaMax
+	if targetPerf.TargetTPS > 0 {
+		lambdaStarTPS = lambdaMax * (1 - StabilitySafetyFraction)
+	}
+
+	// no requests are rejected with an unbounded queue
+	lambdaStarDrop := lambdaMax
+
+	// analyze queue with smaller of rates
+	lambda := min(lambdaStarTTFT, lambdaStarITL, lambdaStarTPS, lambdaStarTTFTP99, lambdaStarITLP99, lambdaStarDrop, lambdaStarWait)
+	if metrics, err = qa.Analyze(lambda * 1000); err != nil {
+		return nil, nil, nil, err
+	}
+
+	targetRate = &TargetRate{
+		RateTargetTTFT: lambdaStarTTFT * 1000,
+		RateTargetITL:  lambdaStarITL * 1000,
+		RateTargetTPS:  lambdaStarTPS * 1000,
+
+		RateTargetTTFTP99: lambdaStarTTFTP99 * 1000,
+		RateTargetITLP99:  lambdaStarITLP99 * 1000,
+
+		RateTargetDrop: lambdaStarDrop * 1000,
+		RateTargetWait: lambdaStarWait * 1000,
+	}
+
+	achieved = &TargetPerf{
+		TargetTTFT: ttft(metrics),
+		TargetITL:  itl(metrics),
+		TargetTPS:  metrics.Throughput * float32(qa.RequestSize.AvgOutputTokens),
+
+		TargetTTFTP99: ttftP99(metrics),
+		TargetITLP99:  itlP99(metrics),
+
+		TargetWaitFraction: wait(metrics),
+	}
+	return targetRate, metrics, achieved, nil
+}
+
+// request service time (msec), given the average number of requests in service
+func (qa *GGmAnalyzer) serviceTime(n float32) float32 {
+	tokens := float32(qa.RequestSize.AvgOutputTokens - 1)
+	return qa.ServiceParms.Prefill.PrefillTime(qa.RequestSize.AvgInputTokens, n) + tokens*qa.ServiceParms.Decode.DecodeTime(n)
+}
+
+// average number of requests in service (n), given arrival rate (req/msec)
+//   - n has to satisfy: n = lambda * serviceTime(n), which is linear in n
+func (qa *GGmAnalyzer) concurrency(lambda float32) (float32, error) {
+	tokens := float32(qa.RequestSize.AvgOutputTokens - 1)
+	prefill := qa.ServiceParms.Prefill
+	decode := qa.ServiceParms.Decode
+	base := prefill.Gamma + decode.Alpha*tokens
+	if qa.RequestSize.AvgInputTokens == 0 {
+		base = decode.Alpha * tokens
+	}
+	slope := prefill.Delta*float32(qa.RequestSize.AvgInputTokens) + decode.Beta*tokens
+	denominator := 1 - lambda*slope
+	if denominator <= 0 {
+		return 0, fmt.Errorf("unstable queue at rate %v", lambda*1000)
+	}
+	n := lambda * base / denominator
+	if n >= float32(qa.MaxBatchSize) {
+		return 0, fmt.Errorf("unstable queue at rate %v", lambda*1000)
+	}
+	return n, nil
+}
+
+// probability of waiting and average waiting time (msec), given offered load (n) and service time
+func (qa *GGmAnalyzer) waitTime(n float32, servTime float32) (probWait float32, waitTime float32) {
+	m := qa.MaxBatchSize
+	a := float64(n)
+
+	// Erlang B recursion, then Erlang C
+	erlangB := float64(1)
+	for k := 1; k <= m; k++ {
+		erlangB = a * erlangB / (float64(k) + a*erlangB)
+	}
+	erlangC := float64(m) * erlangB / (float64(m) - a*(1-erlangB))
+	probWait = float32(min(max(erlangC, 0), 1))
+
+	variability := (qa.ArrivalCOV*qa.ArrivalCOV + qa.ServiceCOV*qa.ServiceCOV) / 2
+	waitTime = probWait * servTime / (float32(m) - n) * variability
+	return probWait, waitTime
+}
+
+// percentile of waiting time (msec), approximating the waiting time of delayed requests as exponential
+func (qa *GGmAnalyzer) waitTimePercentile(n float32, servTime float32, probWait float32, percentile float32) float32 {
+	level := 1 - percentile
+	if probWait <= level {
+		return 0
+	}
+	variability := (qa.ArrivalCOV*qa.ArrivalCOV + qa.ServiceCOV*qa.ServiceCOV) / 2
+	avgDelay := servTime / (float32(qa.MaxBatchSize) - n) * variability
+	return avgDelay * float32(math.Log(float64(probWait/level)))
+}
+
+func (qa *GGmAnalyzer) String() string {
+	return fmt.Sprintf("{maxBatch=%d, servParms:%s, reqSize:%s, cova=%.3f, covs=%.3f, rates:%s}",
+		qa.MaxBatchSize, qa.ServiceParms, qa.RequestSize, qa.ArrivalCOV, qa.ServiceCOV, qa.RateRange)
+}
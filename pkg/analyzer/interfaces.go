@@ -0,0 +1,13 @@
+package analyzer
+
+// Analyzer of an inference server queue
+type Analyzer interface {
+	// evaluate performance metrics given request rate (requests/sec)
+	Analyze(requestRate float32) (*AnalysisMetrics, error)
+	// evaluate max request rates to achieve a given target performance
+	Size(targetPerf *TargetPerf) (*TargetRate, *AnalysisMetrics, *TargetPerf, error)
+	// evaluate diagnostics of the queue given request rate (requests/sec)
+	Diagnose(requestRate float32) (*QueueDiagnostics, error)
+	// max request rate of the queue (requests/sec)
+	MaxRate() float32
+}
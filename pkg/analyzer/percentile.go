@@ -0,0 +1,120 @@
+package analyzer
+
+import (
+	"fmt"
+	"math"
+)
+
+// percentile of tail latency metrics
+const TailPercentile = float32(0.99)
+
+// maximum number of iterations when searching for a percentile value
+const maxPercentileIterations = 100
+
+// evaluate a percentile of the request waiting time, given state probabilities of a solved model
+//   - an admitted request finding n >= N requests in the system waits for (n-N+1) departures,
+//     each at the maximum service rate, hence an Erlang distributed waiting time
+//   - maxServRate is the service rate at full batch (req/msec), resulting time is in msec
+func WaitTimePercentile(p []float64, maxBatchSize int, maxServRate float32, percentile float32) float32 {
+	K := len(p) - 1
+	N := maxBatchSize
+	if K <= N || maxServRate <= 0 || p[K] >= 1 {
+		return 0
+	}
+
+	// tail[j] = probability that an admitted request finds at least (N+j) requests in the system
+	tail := make([]float64, K-N)
+	admitted := 1 - p[K]
+	sum := float64(0)
+	for j := K - N - 1; j >= 0; j-- {
+		sum += p[N+j] / admitted
+		tail[j] = sum
+	}
+	level := 1 - float64(percentile)
+	if tail[0] <= level {
+		return 0
+	}
+
+	// probability of waiting longer than t
+	//   - P[W > t] = sum_j Poisson(j; mu*t) * tail[j]
+	mu := float64(maxServRate)
+	exceeds := func(t float64) float64 {
+		x := mu * t
+		if x <= 0 {
+			return tail[0]
+		}
+		logX := math.Log(x)
+		var prob float64
+		for j := range tail {
+			lg, _ := math.Lgamma(float64(j + 1))
+			prob += math.Exp(-x+float64(j)*logX-lg) * tail[j]
+		}
+		return prob
+	}
+
+	// bracket and bisect the percentile value
+	tLow := float64(0)
+	tHigh := float64(K-N) / mu
+	for i := 0; i < maxPercentileIterations && exceeds(tHigh) > level; i++ {
+		tLow = tHigh
+		tHigh *= 2
+	}
+	for i := 0; i < maxPercentileIterations; i++ {
+		tMid := 0.5 * (tLow + tHigh)
+		if exceeds(tMid) > level {
+			tLow = tMid
+		} else {
+			tHigh = tMid
+		}
+		if tHigh-tLow <= float64(Epsilon)*tHigh {
+			break
+		}
+	}
+	return float32(tHigh)
+}
+
+// evaluate a percentile of the token decode time, given state probabilities of a solved model
+//   - the decode time depends on the number of requests in service, tokens are weighted by that number
+func TokenTimePercentile(p []float64, maxBatchSize int, decodeParms *DecodeParms, percentile float32) float32 {
+	var total float64
+	for i := 1; i < len(p); i++ {
+		total += p[i] * float64(min(i, maxBatchSize))
+	}
+	if total <= 0 {
+		return decodeParms.DecodeTime(1)
+	}
+	var cumulative float64
+	for i := 1; i < len(p); i++ {
+		n := min(i, maxBatchSize)
+		cumulative += p[i] * float64(n)
+		if cumulative/total >= float64(percentile) {
+			return decodeParms.DecodeTime(float32(n))
+		}
+	}
+	return decodeParms.DecodeTime(float32(maxBatchSize))
+}
+
+// Function used in binary search (target percentile TTFT), evaluated on the model of the analyzer
+//   - x is lambda req/msec
+//   - percentile of queueing time plus average prefill time
+func (qa *QueueAnalyzer) EvalTTFTP99(x float32) (float32, error) {
+	model := qa.Model
+	model.Solve(x, 1)
+	if !model.IsValid() {
+		return 0, fmt.Errorf("invalid model %s", model)
+	}
+	waitTime := WaitTimePercentile(model.GetProbabilities(), qa.MaxBatchSize, qa.maxServRate(), TailPercentile)
+	effConc := EffectiveConcurrency(model.GetAvgServTime(), qa.ServiceParms, qa.RequestSize, qa.MaxBatchSize)
+	return waitTime + qa.ServiceParms.Prefill.PrefillTime(qa.RequestSize.AvgInputTokens, effConc), nil
+}
+
+// Function used in binary search (target percentile ITL), evaluated on the model of the analyzer
+//   - x is lambda req/msec
+func (qa *QueueAnalyzer) EvalITLP99(x float32) (float32, error) {
+	model := qa.Model
+	model.Solve(x, 1)
+	if !model.IsValid() {
+		return 0, fmt.Errorf("invalid model %s", model)
+	}
+	return TokenTimePercentile(model.GetProbabilities(), qa.MaxBatchSize, qa.ServiceParms.Decode, TailPercentile), nil
+}
@@ -0,0 +1,142 @@
+package analyzer
+
+import (
+	"fmt"
+)
+
+// Analyzer of an inference server queue shared by two classes of requests under non-preemptive priority scheduling
+//   - queued requests of the high priority class are admitted to the batch first, requests in the batch are not preempted
+//   - both classes share the batch, hence have the same prefill and decode times
+//   - waiting times of the classes are derived from the waiting time W of the queue of all requests (FCFS),
+//     as in the M/G/m non-preemptive priority queue (Cobham) with the same service times for both classes,
+//     conserving the average waiting time: W_high = W (1 - rho) / (1 - rho_high) and W_low = W / (1 - rho_high),
+//     where rho and rho_high are the request rates of all and of high priority requests relative to the max rate
+//   - tail percentiles of waiting times are scaled as averages
+type PriorityAnalyzer struct {
+	Analyzer             // analyzer of the queue of all requests
+	HighFraction float32 // fraction of requests of the high priority class
+	OutputTokens int     // average number of output tokens per request, for relative waiting times
+}
+
+// performance metrics of the classes of requests of a priority queue
+type PriorityMetrics struct {
+	All  *AnalysisMetrics // metrics of all requests
+	High *AnalysisMetrics // metrics of high priority requests
+	Low  *AnalysisMetrics // metrics of low priority requests
+}
+
+// create a new priority queue analyzer of two classes of requests, given an analyzer of the queue of all requests,
+// the fraction of requests of the high priority class, and the average number of output tokens per request
+func NewPriorityAnalyzer(qa Analyzer, highFraction float32, outputTokens int) (*PriorityAnalyzer, error) {
+	if highFraction < 0 || highFraction > 1 {
+		return nil, fmt.Errorf("invalid fraction of high priority requests %v", highFraction)
+	}
+	return &PriorityAnalyzer{
+		Analyzer:     qa,
+		HighFraction: highFraction,
+		OutputTokens: outputTokens,
+	}, nil
+}
+
+// evaluate performance metrics of the classes given request rate of all requests (requests/sec)
+func (pa *PriorityAnalyzer) AnalyzeClasses(requestRate float32) (*PriorityMetrics, error) {
+	metrics, err := pa.Analyze(requestRate)
+	if err != nil {
+		return nil, err
+	}
+	rho := min(requestRate/pa.MaxRate(), 1)
+	highFree := max(1-rho*pa.HighFraction, Epsilon)
+	return &PriorityMetrics{
+		All:  metrics,
+		High: classMetrics(metrics, pa.HighFraction, (1-rho)/highFree),
+		Low:  classMetrics(metrics, 1-pa.HighFraction, 1/highFree),
+	}, nil
+}
+
+// metrics of a class of requests, given metrics of all requests, the fraction of requests of the class,
+// and the factor of waiting times of the class
+func classMetrics(metrics *AnalysisMetrics, fraction float32, waitFactor float32) *AnalysisMetrics {
+	m := *metrics
+	m.Throughput *= fraction
+	m.AvgWaitTime *= waitFactor
+	m.AvgRespTime += m.AvgWaitTime - metrics.AvgWaitTime
+	m.P99WaitTime *= waitFactor
+	return &m
+}
+
+// evaluate the max request rate of all requests (requests/sec) to achieve target performance of both classes,
+// and performance metrics of the classes at that rate
+//   - an error of an unattainable target is wrapped, naming the class
+func (pa *PriorityAnalyzer) SizeClasses(highTarget, lowTarget *TargetPerf) (float32, *PriorityMetrics, error) {
+	rateRange := &RateRange{Min: pa.MaxRate() * Epsilon, Max: pa.MaxRate()}
+	lambda := rateRange.Max / 1000
+	for _, class := range []struct {
+		name    string
+		target  *TargetPerf
+		metrics func(*PriorityMetrics) *AnalysisMetrics
+	}{
+		{"high priority", highTarget, func(pm *PriorityMetrics) *AnalysisMetrics { return pm.High }},
+		{"low priority", lowTarget, func(pm *PriorityMetrics) *AnalysisMetrics { return pm.Low }},
+	} {
+		if err := class.target.check(); err != nil {
+			return 0, nil, fmt.Errorf("%s: %w", class.name, err)
+		}
+		classLambda, err := pa.sizeClass(rateRange, class.target, class.metrics)
+		if err != nil {
+			return 0, nil, fmt.Errorf("%s: %w", class.name, err)
+		}
+		lambda = min(lambda, classLambda)
+	}
+	rate := min(lambda*1000, pa.MaxRate())
+	metrics, err := pa.AnalyzeClasses(rate)
+	if err != nil {
+		return 0, nil, err
+	}
+	return rate, metrics, nil
+}
+
+// max rate (req/msec) to achieve target performance of a class, given its metrics among those of the classes
+func (pa *PriorityAnalyzer) sizeClass(rateRange *RateRange, target *TargetPerf,
+	class func(*PriorityMetrics) *AnalysisMetrics) (float32, error) {
+
+	// evaluation function of a metric of the class at a given rate (req/msec)
+	evalFunc := func(metric func(*AnalysisMetrics) float32) func(float32) (float32, error) {
+		return func(x float32) (float32, error) {
+			// rate rounded to the max rate, as converting to req/msec and back may exceed it
+			pm, err := pa.AnalyzeClasses(min(x*1000, pa.MaxRate()))
+			if err != nil {
+				return 0, err
+			}
+			return metric(class(pm)), nil
+		}
+	}
+
+	lambda := rateRange.Max / 1000
+	for _, search := range []struct {
+		name         string
+		target       float32
+		unattainable error
+		metric       func(*AnalysisMetrics) float32
+	}{
+		{"TTFT", target.TargetTTFT, ErrUnattainableTTFT, func(m *AnalysisMetrics) float32 { return m.AvgWaitTime + m.AvgPrefillTime }},
+		{"ITL", target.TargetITL, ErrUnattainableITL, func(m *AnalysisMetrics) float32 { return m.AvgTokenTime }},
+		{"TTFTP99", target.TargetTTFTP99, ErrUnattainableTTFTP99, func(m *AnalysisMetrics) float32 { return m.P99WaitTime + m.AvgPrefillTime }},
+		{"ITLP99", target.TargetITLP99, ErrUnattainableITLP99, func(m *AnalysisMetrics) float32 { return m.P99TokenTime }},
+		{"Drop", target.TargetDropRate, ErrUnattainableDrop, func(m *AnalysisMetrics) float32 { return m.DropRate }},
+		{"Wait", target.TargetWaitFraction, ErrUnattainableWait, func(m *AnalysisMetrics) float32 { return WaitFraction(m, pa.OutputTokens) }},
+	} {
+		searchLambda, err := searchRate(search.name, search.target, rateRange, search.unattainable, evalFunc(search.metric))
+		if err != nil {
+			return 0, err
+		}
+		lambda = min(lambda, searchLambda)
+	}
+	if target.TargetTPS > 0 {
+		lambda = min(lambda, rateRange.Max/1000*(1-StabilitySafetyFraction))
+	}
+	return lambda, nil
+}
+
+func (pa *PriorityAnalyzer) String() string {
+	return fmt.Sprintf("{analyzer=%v, highFraction=%.3f}", pa.Analyzer, pa.HighFraction)
+}
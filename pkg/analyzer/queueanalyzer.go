@@ -1,13 +1,31 @@
 package analyzer
 
 import (
+	"errors"
 	"fmt"
+	"math"
+	"slices"
 
+	"github.com/llm-inferno/optimizer/pkg/config"
+	"github.com/llm-inferno/optimizer/pkg/metrics"
+	"github.com/llm-inferno/optimizer/pkg/utils"
 	"github.com/llm-inferno/queue-analysis/pkg/queue"
+)
 
-	utils "github.com/llm-inferno/queue-analysis/pkg/utils"
+// errors of unattainable targets (below the bounded region of request rates)
+var (
+	ErrUnattainableTTFT    = errors.New("unattainable TTFT target")
+	ErrUnattainableITL     = errors.New("unattainable ITL target")
+	ErrUnattainableTTFTP99 = errors.New("unattainable tail percentile TTFT target")
+	ErrUnattainableITLP99  = errors.New("unattainable tail percentile ITL target")
+	ErrUnattainableDrop    = errors.New("unattainable drop rate target")
+	ErrUnattainableWait    = errors.New("unattainable relative waiting time target")
 )
 
+// error of a range of request rates which is empty or not finite, e.g. service rates vanishing for very long requests,
+// leaving no rates to search for targets
+var ErrDegenerateRateRange = errors.New("degenerate range of request rates")
+
 // small disturbance around a value
 const Epsilon = float32(0.001)
 
@@ -22,6 +40,7 @@ type QueueAnalyzer struct {
 	RequestSize  *RequestSize                  // number of input and output tokens per request
 	Model        *queue.MM1ModelStateDependent // queueing model
 	RateRange    *RateRange                    // range of request rates for model stability
+	ServRate     []float32                     // state-dependent service rate (requests/msec)
 }
 
 // queue configuration parameters
@@ -71,6 +90,16 @@ type AnalysisMetrics struct {
 	AvgTokenTime   float32 // average token decode time (msec)
 	MaxRate        float32 // maximum throughput (requests/sec)
 	Rho            float32 // utilization
+	P99WaitTime    float32 // tail percentile of request queueing time (msec)
+	P99TokenTime   float32 // tail percentile of token decode time (msec)
+	DropRate       float32 // fraction of arriving requests rejected as the queue is full (blocking probability)
+}
+
+// queue diagnostics data, beyond average metrics
+type QueueDiagnostics struct {
+	Probabilities []float64 // probabilities of the number of requests in the system, from zero (nil if not modeled)
+	ProbWait      float32   // probability of an arriving request queueing (all batch slots busy)
+	ProbFull      float32   // probability of an arriving request finding the queue full (rejected)
 }
 
 // queue performance targets
@@ -78,6 +107,13 @@ type TargetPerf struct {
 	TargetTTFT float32 // target time to first token (queueing + prefill) (msec)
 	TargetITL  float32 // target inter-token latency (msec)
 	TargetTPS  float32 // target token generation throughtput (tokens/sec)
+
+	TargetTTFTP99 float32 // target tail percentile time to first token (queueing + prefill) (msec)
+	TargetITLP99  float32 // target tail percentile inter-token latency (msec)
+
+	TargetDropRate float32 // target max fraction of requests rejected as the queue is full
+
+	TargetWaitFraction float32 // target max ratio of queueing time to request service time (prefill and decode of all output tokens)
 }
 
 // queue max request rates to achieve performance targets
@@ -85,6 +121,13 @@ type TargetRate struct {
 	RateTargetTTFT float32 // max request rate for target TTFT (requests/sec)
 	RateTargetITL  float32 // max request rate for target ITL (requests/sec)
 	RateTargetTPS  float32 // max request rate for target TPS (requests/sec)
+
+	RateTargetTTFTP99 float32 // max request rate for target tail percentile TTFT (requests/sec)
+	RateTargetITLP99  float32 // max request rate for target tail percentile ITL (requests/sec)
+
+	RateTargetDrop float32 // max request rate for target drop rate (requests/sec)
+
+	RateTargetWait float32 // max request rate for target relative waiting time (requests/sec)
 }
 
 // create a new queue analyzer from config
@@ -96,7 +139,11 @@ func NewQueueAnalyzer(qConfig *Configuration, requestSize *RequestSize) (*QueueA
 		return nil, err
 	}
 	// build queueing model
-	return BuildModel(qConfig, requestSize), nil
+	qa := BuildModel(qConfig, requestSize)
+	if err := qa.RateRange.check(); err != nil {
+		return nil, fmt.Errorf("batchSize=%d, %s: %w", qConfig.MaxBatchSize, requestSize, err)
+	}
+	return qa, nil
 }
 
 // build queueing model using service rates, leaving arrival rate as parameter
@@ -126,6 +173,7 @@ func BuildModel(qConfig *Configuration, requestSize *RequestSize) (modelData *Qu
 		RequestSize:  requestSize,
 		Model:        model,
 		RateRange:    rateRange,
+		ServRate:     servRate,
 	}
 }
 
@@ -158,6 +206,10 @@ func (qa *QueueAnalyzer) Analyze(requestRate float32) (metrics *AnalysisMetrics,
 	rho := avgNumInServ / float32(qa.MaxBatchSize)
 	rho = min(max(rho, 0), 1)
 
+	probs := model.GetProbabilities()
+	p99WaitTime := WaitTimePercentile(probs, qa.MaxBatchSize, qa.maxServRate(), TailPercentile)
+	p99TokenTime := TokenTimePercentile(probs, qa.MaxBatchSize, qa.ServiceParms.Decode, TailPercentile)
+
 	// return solution
 	metrics = &AnalysisMetrics{
 		Throughput:     model.GetThroughput() * 1000,
@@ -168,14 +220,39 @@ func (qa *QueueAnalyzer) Analyze(requestRate float32) (metrics *AnalysisMetrics,
 		AvgTokenTime:   tokenTime,
 		MaxRate:        rateRange.Max,
 		Rho:            rho,
+		P99WaitTime:    p99WaitTime,
+		P99TokenTime:   p99TokenTime,
+		DropRate:       blockingProbability(probs),
 	}
 	return metrics, nil
 }
 
-// global variables used by eval functions, to be set before calling eval function
-var evalRequestSize *RequestSize   // number of input and output tokens per request
-var evalServiceParms *ServiceParms // request processing parameters for prefill and decode stages
-var evalMaxBatchSize int           // max batch size
+// evaluate diagnostics given request rate: state probabilities, probability of queueing, and of a full queue
+//   - arriving requests see the state probabilities (Poisson arrivals)
+func (qa *QueueAnalyzer) Diagnose(requestRate float32) (diagnostics *QueueDiagnostics, err error) {
+	if requestRate <= 0 {
+		return nil, fmt.Errorf("invalid request rate %v", requestRate)
+	}
+	if requestRate > qa.RateRange.Max {
+		return nil, fmt.Errorf("rate=%v, max allowed rate=%v", requestRate, qa.RateRange.Max)
+	}
+	model := qa.Model
+	model.Solve(requestRate/1000, 1)
+	if !model.IsValid() {
+		return nil, fmt.Errorf("invalid model %s", model)
+	}
+
+	probs := slices.Clone(model.GetProbabilities())
+	var probWait float64
+	for n := qa.MaxBatchSize; n < len(probs); n++ {
+		probWait += probs[n]
+	}
+	return &QueueDiagnostics{
+		Probabilities: probs,
+		ProbWait:      float32(probWait),
+		ProbFull:      blockingProbability(probs),
+	}, nil
+}
 
 // evaluate max request rates to achieve a given target performance, returns
 //   - max request rates
@@ -189,41 +266,32 @@ func (qa *QueueAnalyzer) Size(targetPerf *TargetPerf) (targetRate *TargetRate, m
 	targetITL := targetPerf.TargetITL
 	targetTPS := targetPerf.TargetTPS
 
-	lambdaMin := qa.RateRange.Min / 1000
 	lambdaMax := qa.RateRange.Max / 1000
 
-	// set global variables for model and parameters used in functional evaluation
-	utils.Model = qa.Model
-	evalRequestSize = qa.RequestSize
-	evalServiceParms = qa.ServiceParms
-	evalMaxBatchSize = qa.MaxBatchSize
-
-	var ind int
-
-	// find max rate to achieve target TTFT time
-	lambdaStarTTFT := lambdaMax
-	if targetTTFT > 0 {
-		lambdaStarTTFT, ind, err = utils.BinarySearch(lambdaMin, lambdaMax, targetTTFT, EvalTTFT)
-		if ind < 0 {
-			err = fmt.Errorf("target is below the bounded region")
-		}
-		if err != nil {
-			return nil, nil, nil, fmt.Errorf("failed to calculate lambdaStarTTFT, targetTTFT=%v, range=%s, ind=%d, err=%v",
-				targetTTFT, qa.RateRange, ind, err)
-		}
-	}
-
-	// find max rate to achieve target ITL time
-	lambdaStarITL := lambdaMax
-	if targetITL > 0 {
-		lambdaStarITL, ind, err = utils.BinarySearch(lambdaMin, lambdaMax, targetITL, EvalITL)
-		if ind < 0 {
-			err = fmt.Errorf("target is below the bounded region")
-		}
-		if err != nil {
-			return nil, nil, nil, fmt.Errorf("failed to calculate lambdaStarITL, targetITL=%v, range=%s, ind=%d, err=%v",
-				targetITL, qa.RateRange, ind, err)
-		}
+	// find max rates to achieve target TTFT and ITL times, average and tail percentile
+	var lambdaStarTTFT, lambdaStarITL, lambdaStarTTFTP99, lambdaStarITLP99, lambdaStarDrop float32
+	if lambdaStarTTFT, err = searchRate("TTFT", targetTTFT, qa.RateRange, ErrUnattainableTTFT, qa.EvalTTFT); err != nil {
+		return nil, nil, nil, err
+	}
+	if lambdaStarITL, err = searchRate("ITL", targetITL, qa.RateRange, ErrUnattainableITL, qa.EvalITL); err != nil {
+		return nil, nil, nil, err
+	}
+	if lambdaStarTTFTP99, err = searchRate("TTFTP99", targetPerf.TargetTTFTP99, qa.RateRange, ErrUnattainableTTFTP99, qa.EvalTTFTP99); err != nil {
+		return nil, nil, nil, err
+	}
+	if lambdaStarITLP99, err = searchRate("ITLP99", targetPerf.TargetITLP99, qa.RateRange, ErrUnattainableITLP99, qa.EvalITLP99); err != nil {
+		return nil, nil, nil, err
+	}
+
+	// find max rate to achieve target drop rate (blocking probability of the finite queue)
+	if lambdaStarDrop, err = searchRate("Drop", targetPerf.TargetDropRate, qa.RateRange, ErrUnattainableDrop, qa.EvalDropRate); err != nil {
+		return nil, nil, nil, err
+	}
+
+	// find max rate to achieve target relative waiting time
+	var lambdaStarWait float32
+	if lambdaStarWait, err = searchRate("Wait", targetPerf.TargetWaitFraction, qa.RateRange, ErrUnattainableWait, qa.EvalWaitFraction); err != nil {
+		return nil, nil, nil, err
 	}
 
 	// find max rate to achieve target TPS
@@ -233,7 +301,7 @@ func (qa *QueueAnalyzer) Size(targetPerf *TargetPerf) (targetRate *TargetRate, m
 	}
 
 	// analyze queue with smaller of rates
-	lambda := min(lambdaStarTTFT, lambdaStarITL, lambdaStarTPS)
+	lambda := min(lambdaStarTTFT, lambdaStarITL, lambdaStarTPS, lambdaStarTTFTP99, lambdaStarITLP99, lambdaStarDrop, lambdaStarWait)
 	requestRate := lambda * 1000 // convert to per-second rate
 	if metrics, err = qa.Analyze(requestRate); err != nil {
 		return nil, nil, nil, err
@@ -243,16 +311,80 @@ func (qa *QueueAnalyzer) Size(targetPerf *TargetPerf) (targetRate *TargetRate, m
 		RateTargetTTFT: lambdaStarTTFT * 1000,
 		RateTargetITL:  lambdaStarITL * 1000,
 		RateTargetTPS:  lambdaStarTPS * 1000,
+
+		RateTargetTTFTP99: lambdaStarTTFTP99 * 1000,
+		RateTargetITLP99:  lambdaStarITLP99 * 1000,
+
+		RateTargetDrop: lambdaStarDrop * 1000,
+		RateTargetWait: lambdaStarWait * 1000,
 	}
 
 	achieved = &TargetPerf{
 		TargetTTFT: metrics.AvgWaitTime + metrics.AvgPrefillTime,
 		TargetITL:  metrics.AvgTokenTime,
 		TargetTPS:  metrics.Throughput * float32(qa.RequestSize.AvgOutputTokens),
+
+		TargetTTFTP99: metrics.P99WaitTime + metrics.AvgPrefillTime,
+		TargetITLP99:  metrics.P99TokenTime,
+
+		TargetDropRate:     metrics.DropRate,
+		TargetWaitFraction: WaitFraction(metrics, qa.RequestSize.AvgOutputTokens),
 	}
 	return targetRate, metrics, achieved, nil
 }
 
+// find max rate (req/msec) in range to achieve a target value of a metric, given its evaluation function
+//   - max of range if target is zero (not considered)
+//   - error wrapping the unattainable error if target is below the bounded region
+//   - a rate with the metric below the target is returned if the search ends with the metric above the target,
+//     within tolerance or not converged, so that the rate does not violate the target
+//   - searches over a metric which is not monotonic in the rate are counted in metrics
+func searchRate(name string, target float32, rateRange *RateRange, unattainable error,
+	eval func(float32) (float32, error)) (float32, error) {
+
+	lambdaMin := rateRange.Min / 1000
+	lambdaMax := rateRange.Max / 1000
+	if target <= 0 {
+		return lambdaMax, nil
+	}
+	if err := rateRange.check(); err != nil {
+		return 0, fmt.Errorf("failed to calculate lambdaStar%s: %w", name, err)
+	}
+	result, err := utils.BinarySearch(lambdaMin, lambdaMax, target, eval, &utils.SearchOptions{
+		AbsTolerance:  config.SearchAbsTolerance,
+		RelTolerance:  config.SearchRelTolerance,
+		MaxIterations: config.SearchMaxIterations,
+		Samples:       config.SearchSamples,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to calculate lambdaStar%s, target%s=%v, range=%s: %w",
+			name, name, target, rateRange, err)
+	}
+	if !result.Monotonic {
+		metrics.NonMonotonicSearches.Inc(name)
+	}
+	if result.Indicator < 0 {
+		return 0, fmt.Errorf("%w: target%s=%v, range=%s", unattainable, name, target, rateRange)
+	}
+	if result.Residual > 0 {
+		return result.XBelow, nil
+	}
+	return result.X, nil
+}
+
+// max request rate (requests/sec)
+func (qa *QueueAnalyzer) MaxRate() float32 {
+	return qa.RateRange.Max
+}
+
+// service rate at max batch size (req/msec)
+func (qa *QueueAnalyzer) maxServRate() float32 {
+	if len(qa.ServRate) == 0 {
+		return 0
+	}
+	return qa.ServRate[len(qa.ServRate)-1]
+}
+
 func (p *PrefillParms) PrefillTime(avgInputTokens int, batchSize float32) float32 {
 	if avgInputTokens == 0 {
 		return 0
@@ -264,28 +396,70 @@ func (p *DecodeParms) DecodeTime(batchSize float32) float32 {
 	return p.Alpha + p.Beta*batchSize
 }
 
-// Function used in binary search (target TTFT)
+// Function used in binary search (target TTFT), evaluated on the model of the analyzer
 //   - x is lambda req/msec
-func EvalTTFT(x float32) (float32, error) {
-	utils.Model.Solve(x, 1)
-	if !utils.Model.IsValid() {
-		return 0, fmt.Errorf("invalid model %s", utils.Model)
-	}
-	avgWaitTime := utils.Model.GetAvgWaitTime()
-	effConc := EffectiveConcurrency(utils.Model.GetAvgServTime(), evalServiceParms, evalRequestSize, evalMaxBatchSize)
-	ttft := avgWaitTime + evalServiceParms.Prefill.PrefillTime(evalRequestSize.AvgInputTokens, effConc)
+func (qa *QueueAnalyzer) EvalTTFT(x float32) (float32, error) {
+	model := qa.Model
+	model.Solve(x, 1)
+	if !model.IsValid() {
+		return 0, fmt.Errorf("invalid model %s", model)
+	}
+	avgWaitTime := model.GetAvgWaitTime()
+	effConc := EffectiveConcurrency(model.GetAvgServTime(), qa.ServiceParms, qa.RequestSize, qa.MaxBatchSize)
+	ttft := avgWaitTime + qa.ServiceParms.Prefill.PrefillTime(qa.RequestSize.AvgInputTokens, effConc)
 	return ttft, nil
 }
 
-// Function used in binary search (target ITL)
+// Function used in binary search (target ITL), evaluated on the model of the analyzer
+//   - x is lambda req/msec
+func (qa *QueueAnalyzer) EvalITL(x float32) (float32, error) {
+	model := qa.Model
+	model.Solve(x, 1)
+	if !model.IsValid() {
+		return 0, fmt.Errorf("invalid model %s", model)
+	}
+	effConc := EffectiveConcurrency(model.GetAvgServTime(), qa.ServiceParms, qa.RequestSize, qa.MaxBatchSize)
+	return qa.ServiceParms.Decode.DecodeTime(effConc), nil
+}
+
+// Function used in binary search (target relative waiting time)
 //   - x is lambda req/msec
-func EvalITL(x float32) (float32, error) {
-	utils.Model.Solve(x, 1)
-	if !utils.Model.IsValid() {
-		return 0, fmt.Errorf("invalid model %s", utils.Model)
+func (qa *QueueAnalyzer) EvalWaitFraction(x float32) (float32, error) {
+	metrics, err := qa.Analyze(x * 1000)
+	if err != nil {
+		return 0, err
 	}
-	effConc := EffectiveConcurrency(utils.Model.GetAvgServTime(), evalServiceParms, evalRequestSize, evalMaxBatchSize)
-	return evalServiceParms.Decode.DecodeTime(effConc), nil
+	return WaitFraction(metrics, qa.RequestSize.AvgOutputTokens), nil
+}
+
+// Ratio of queueing time to the service time of a request, given its number of output tokens
+//   - service time is prefill time and decode time of all output tokens
+func WaitFraction(metrics *AnalysisMetrics, outputTokens int) float32 {
+	servTime := metrics.AvgPrefillTime + float32(outputTokens)*metrics.AvgTokenTime
+	if servTime <= 0 {
+		return 0
+	}
+	return metrics.AvgWaitTime / servTime
+}
+
+// Function used in binary search (target drop rate), evaluated on the model of the analyzer
+//   - x is lambda req/msec
+func (qa *QueueAnalyzer) EvalDropRate(x float32) (float32, error) {
+	model := qa.Model
+	model.Solve(x, 1)
+	if !model.IsValid() {
+		return 0, fmt.Errorf("invalid model %s", model)
+	}
+	return blockingProbability(model.GetProbabilities()), nil
+}
+
+// probability of an arriving request finding the queue full, given state probabilities of a solved model
+//   - arriving requests see the state probabilities (Poisson arrivals)
+func blockingProbability(p []float64) float32 {
+	if len(p) == 0 {
+		return 0
+	}
+	return float32(p[len(p)-1])
 }
 
 // calculate effective average number of requests in service (n), given average request service time
@@ -317,11 +491,24 @@ func (rq *RequestSize) check() error {
 	return nil
 }
 
+// check that a range of request rates is not degenerate: positive and finite rates, with the max above the min
+//   - the max is not above the min if service rates vanish, e.g. for very long requests at batch size 1
+func (rr *RateRange) check() error {
+	if !(rr.Min > 0 && rr.Max > rr.Min) || math.IsInf(float64(rr.Max), 0) {
+		return fmt.Errorf("%w: min=%v, max=%v", ErrDegenerateRateRange, rr.Min, rr.Max)
+	}
+	return nil
+}
+
 // check validity of target values
 func (targetPerf *TargetPerf) check() error {
 	if targetPerf.TargetITL < 0 ||
 		targetPerf.TargetTTFT < 0 ||
-		targetPerf.TargetTPS < 0 {
+		targetPerf.TargetTPS < 0 ||
+		targetPerf.TargetTTFTP99 < 0 ||
+		targetPerf.TargetITLP99 < 0 ||
+		targetPerf.TargetDropRate < 0 || targetPerf.TargetDropRate >= 1 ||
+		targetPerf.TargetWaitFraction < 0 {
 		return fmt.Errorf("invalid target data values %s", targetPerf)
 	}
 	return nil
@@ -363,16 +550,18 @@ func (rr *RateRange) String() string {
 }
 
 func (am *AnalysisMetrics) String() string {
-	return fmt.Sprintf("{tput=%.3f, lat=%.3f, wait=%.3f, conc=%.3f, prefill=%.3f, itl=%.3f, maxRate=%.3f, rho=%0.3f}",
-		am.Throughput, am.AvgRespTime, am.AvgWaitTime, am.AvgNumInServ, am.AvgPrefillTime, am.AvgTokenTime, am.MaxRate, am.Rho)
+	return fmt.Sprintf("{tput=%.3f, lat=%.3f, wait=%.3f, conc=%.3f, prefill=%.3f, itl=%.3f, maxRate=%.3f, rho=%0.3f, waitP99=%.3f, itlP99=%.3f, drop=%.5f}",
+		am.Throughput, am.AvgRespTime, am.AvgWaitTime, am.AvgNumInServ, am.AvgPrefillTime, am.AvgTokenTime, am.MaxRate, am.Rho,
+		am.P99WaitTime, am.P99TokenTime, am.DropRate)
 }
 
 func (tp *TargetPerf) String() string {
-	return fmt.Sprintf("{TTFT=%.3f, ITL=%.3f, TPS=%.3f}",
-		tp.TargetTTFT, tp.TargetITL, tp.TargetTPS)
+	return fmt.Sprintf("{TTFT=%.3f, ITL=%.3f, TPS=%.3f, TTFTP99=%.3f, ITLP99=%.3f, drop=%.5f, wait=%.3f}",
+		tp.TargetTTFT, tp.TargetITL, tp.TargetTPS, tp.TargetTTFTP99, tp.TargetITLP99, tp.TargetDropRate, tp.TargetWaitFraction)
 }
 
 func (tr *TargetRate) String() string {
-	return fmt.Sprintf("{rateTTFT=%.3f, rateITL=%.3f, rateTPS=%.3f}",
-		tr.RateTargetTTFT, tr.RateTargetITL, tr.RateTargetTPS)
+	return fmt.Sprintf("{rateTTFT=%.3f, rateITL=%.3f, rateTPS=%.3f, rateTTFTP99=%.3f, rateITLP99=%.3f, rateDrop=%.3f, rateWait=%.3f}",
+		tr.RateTargetTTFT, tr.RateTargetITL, tr.RateTargetTPS, tr.RateTargetTTFTP99, tr.RateTargetITLP99, tr.RateTargetDrop,
+		tr.RateTargetWait)
 }
@@ -0,0 +1,263 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/llm-inferno/optimizer/pkg/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// Fields required in data, by struct type, named by their json tags
+//   - checked when data is given, as missing fields otherwise silently take zero values
+var requiredFields = map[reflect.Type][]string{
+	reflect.TypeFor[AcceleratorSpec]():          {"name", "type"},
+	reflect.TypeFor[AcceleratorCount]():         {"type"},
+	reflect.TypeFor[ModelAcceleratorPerfData](): {"name", "acc", "maxBatchSize", "atTokens", "decodeParms"},
+	reflect.TypeFor[DecodeParms]():              {"alpha"},
+	reflect.TypeFor[ServiceClassSpec]():         {"name"},
+	reflect.TypeFor[ServerSpec]():               {"name", "model"},
+}
+
+// Unmarshal JSON or YAML data of a file to its corresponding object, checking its fields first (see CheckData)
+//   - errors are prefixed by the file name, e.g. "model-data.json: models[2].decodeParms.alpha is required"
+func FromFileData[T any](fileName string, byteValue []byte, t T) (*T, error) {
+	if err := CheckData(fileName, byteValue, t); err != nil {
+		return nil, err
+	}
+	d, err := utils.FromBytes(byteValue, t)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", fileName, err)
+	}
+	return d, nil
+}
+
+// Check JSON or YAML data of an object, before unmarshalling it, for fields which are mistyped, or required but
+// missing, with the path of each field, e.g. "models[2].decodeParms.alpha is required"
+//   - errors are prefixed by the name of the data (e.g. its file name), if not empty
+//   - fields not known are ignored, as when unmarshalling
+func CheckData(name string, byteValue []byte, t any) error {
+	prefix := dataPrefix(name)
+	data, err := decodeData(byteValue)
+	if err != nil {
+		return fmt.Errorf("%s%w", prefix, err)
+	}
+	c := &dataChecker{prefix: prefix}
+	c.check(data, reflect.TypeOf(t), "")
+	return errors.Join(c.errs...)
+}
+
+// Sections of system data, all required when a system is loaded from a single file
+var systemSections = []string{"acceleratorData", "capacityData", "modelData", "serviceClassData", "serverData", "optimizerData"}
+
+// Unmarshal JSON or YAML data of a file combining all data of a system, checking its fields first (see FromFileData),
+// failing if any section is absent, e.g. "system.json: system.serverData is required"
+//   - unlike other system data, e.g. given to optimize a system, where absent sections are empty
+func SystemFromFileData(fileName string, byteValue []byte) (*SystemData, error) {
+	if err := CheckData(fileName, byteValue, SystemData{}); err != nil {
+		return nil, err
+	}
+	data, err := decodeData(byteValue)
+	if err != nil {
+		return nil, fmt.Errorf("%s%w", dataPrefix(fileName), err)
+	}
+	c := &dataChecker{prefix: dataPrefix(fileName)}
+	root, _ := data.(map[string]any)
+	if spec, ok := root["system"].(map[string]any); ok {
+		for _, section := range systemSections {
+			if spec[section] == nil {
+				c.errorf(fieldPath("system", section), " is required")
+			}
+		}
+	} else {
+		c.errorf("system", " is required")
+	}
+	if err := errors.Join(c.errs...); err != nil {
+		return nil, err
+	}
+	d, err := utils.FromBytes(byteValue, SystemData{})
+	if err != nil {
+		return nil, fmt.Errorf("%s%w", dataPrefix(fileName), err)
+	}
+	return d, nil
+}
+
+// prefix of errors about data with a name (e.g. its file name), empty if no name
+func dataPrefix(name string) string {
+	if name == "" {
+		return ""
+	}
+	return name + ": "
+}
+
+// decode JSON or YAML data to generic values, with JSON numbers kept as such
+func decodeData(byteValue []byte) (any, error) {
+	var data any
+	var err error
+	if utils.IsJSON(byteValue) {
+		decoder := json.NewDecoder(bytes.NewReader(byteValue))
+		decoder.UseNumber()
+		err = decoder.Decode(&data)
+	} else {
+		err = yaml.Unmarshal(byteValue, &data)
+	}
+	return data, err
+}
+
+// Checker of data against a type, collecting errors
+type dataChecker struct {
+	prefix string
+	errs   []error
+}
+
+func (c *dataChecker) errorf(path string, format string, args ...any) {
+	c.errs = append(c.errs, errors.New(c.prefix+path+fmt.Sprintf(format, args...)))
+}
+
+// check a value of data (nil if null) at a path against a type
+func (c *dataChecker) check(data any, t reflect.Type, path string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if data == nil {
+		return
+	}
+	if isQuantityType(t) {
+		if !isNumber(data) {
+			if _, ok := data.(string); !ok {
+				c.errorf(path, ": expected number or string with a unit, got %s", kindOf(data))
+			}
+		}
+		return
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		if _, ok := data.(bool); !ok {
+			c.errorf(path, ": expected boolean, got %s", kindOf(data))
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if !isInteger(data) {
+			c.errorf(path, ": expected integer, got %s", kindOf(data))
+		}
+	case reflect.Float32, reflect.Float64:
+		if !isNumber(data) {
+			c.errorf(path, ": expected number, got %s", kindOf(data))
+		}
+	case reflect.String:
+		if _, ok := data.(string); !ok {
+			c.errorf(path, ": expected string, got %s", kindOf(data))
+		}
+	case reflect.Slice, reflect.Array:
+		items, ok := data.([]any)
+		if !ok {
+			c.errorf(path, ": expected array, got %s", kindOf(data))
+			return
+		}
+		for i, item := range items {
+			c.check(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i))
+		}
+	case reflect.Map:
+		values, ok := data.(map[string]any)
+		if !ok {
+			c.errorf(path, ": expected object, got %s", kindOf(data))
+			return
+		}
+		for _, key := range slices.Sorted(maps.Keys(values)) {
+			c.check(values[key], t.Elem(), fieldPath(path, key))
+		}
+	case reflect.Struct:
+		values, ok := data.(map[string]any)
+		if !ok {
+			c.errorf(path, ": expected object, got %s", kindOf(data))
+			return
+		}
+		fields := make(map[string]reflect.Type)
+		structFields(t, fields)
+		for _, key := range slices.Sorted(maps.Keys(values)) {
+			if fieldType, exists := fields[key]; exists {
+				c.check(values[key], fieldType, fieldPath(path, key))
+			}
+		}
+		for _, key := range requiredFields[t] {
+			if values[key] == nil {
+				c.errorf(fieldPath(path, key), " is required")
+			}
+		}
+	}
+}
+
+// types of the exported fields of a struct type, by json name, inlining fields of embedded structs with no json name
+func structFields(t reflect.Type, fields map[string]reflect.Type) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			structFields(field.Type, fields)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field.Type
+	}
+}
+
+// path of a field of an object at a path
+func fieldPath(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// check if a value of data is a number (JSON or YAML)
+func isNumber(data any) bool {
+	switch data.(type) {
+	case json.Number, int, int64, uint64, float64:
+		return true
+	}
+	return false
+}
+
+// check if a value of data is an integer number (JSON or YAML)
+func isInteger(data any) bool {
+	switch v := data.(type) {
+	case json.Number:
+		_, err := v.Int64()
+		return err == nil
+	case int, int64, uint64:
+		return true
+	}
+	return false
+}
+
+// kind of a value of data, named as in JSON
+func kindOf(data any) string {
+	switch data.(type) {
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	if isNumber(data) {
+		return "number"
+	}
+	return fmt.Sprintf("%T", data)
+}
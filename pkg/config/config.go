@@ -8,6 +8,7 @@ const (
 	PriorityExhaustive                                  // 1 : allocating exhaustively to servers in priority ordering
 	PriorityRoundRobin                                  // 2 : allocating in round-robin fashion within priority groups
 	RoundRobin                                          // 3 : allocating in round-robin fashion across all servers
+	WeightedFair                                        // 4 : allocating in proportion to weights of service classes across all servers
 )
 
 func (p SaturatedAllocationPolicy) String() string {
@@ -20,6 +21,8 @@ func (p SaturatedAllocationPolicy) String() string {
 		return "PriorityRoundRobin"
 	case RoundRobin:
 		return "RoundRobin"
+	case WeightedFair:
+		return "WeightedFair"
 	default:
 		return "Unknown"
 	}
@@ -35,7 +38,341 @@ func SaturatedAllocationPolicyEnum(s string) SaturatedAllocationPolicy {
 		return PriorityRoundRobin
 	case "RoundRobin":
 		return RoundRobin
+	case "WeightedFair":
+		return WeightedFair
 	default:
 		return DefaultSaturatedAllocationPolicy
 	}
 }
+
+// queueing models used to analyze a server
+type QueueModel int
+
+const (
+	MM1StateDependent QueueModel = iota // 0 : M/M/1 with state-dependent service rate and finite queue
+	GGm                                 // 1 : G/G/m approximation with batch slots as servers
+)
+
+func (q QueueModel) String() string {
+	switch q {
+	case MM1StateDependent:
+		return "MM1StateDependent"
+	case GGm:
+		return "GGm"
+	default:
+		return "Unknown"
+	}
+}
+
+func QueueModelEnum(s string) QueueModel {
+	switch s {
+	case "MM1StateDependent":
+		return MM1StateDependent
+	case "GGm":
+		return GGm
+	default:
+		return DefaultQueueModel
+	}
+}
+
+// options for the value of an allocation
+type ValueFunction int
+
+const (
+	CostValue        ValueFunction = iota // 0 : cost of allocation
+	CostPerRPMValue                       // 1 : cost per unit of max request rate of allocation
+	CostLatencyValue                      // 2 : cost of allocation, penalized by utilization
+)
+
+func (v ValueFunction) String() string {
+	switch v {
+	case CostValue:
+		return "Cost"
+	case CostPerRPMValue:
+		return "CostPerRPM"
+	case CostLatencyValue:
+		return "CostLatency"
+	default:
+		return "Unknown"
+	}
+}
+
+func ValueFunctionEnum(s string) ValueFunction {
+	switch s {
+	case "Cost":
+		return CostValue
+	case "CostPerRPM":
+		return CostPerRPMValue
+	case "CostLatency":
+		return CostLatencyValue
+	default:
+		return DefaultValueFunction
+	}
+}
+
+// Algorithm solving the allocation problem
+type Algorithm int
+
+const (
+	Greedy Algorithm = iota // 0 : greedy heuristic
+	MILP                    // 1 : exact mixed integer linear program
+)
+
+func (a Algorithm) String() string {
+	switch a {
+	case Greedy:
+		return "greedy"
+	case MILP:
+		return "milp"
+	default:
+		return "Unknown"
+	}
+}
+
+func AlgorithmEnum(s string) Algorithm {
+	switch s {
+	case "greedy":
+		return Greedy
+	case "milp":
+		return MILP
+	default:
+		return DefaultAlgorithm
+	}
+}
+
+// Metric of an allocation minimized by the optimizer
+type Objective int
+
+const (
+	CostObjective        Objective = iota // 0 : cost of allocation
+	PowerObjective                        // 1 : power consumption of allocation
+	ConsolidateObjective                  // 2 : cost of allocation, preferring fewer accelerator types in use within a cost tolerance
+	MaxHeadroomObjective                  // 3 : cost of allocation, then remaining capacity given to maximize the minimum SLO slack of servers
+)
+
+func (o Objective) String() string {
+	switch o {
+	case CostObjective:
+		return "cost"
+	case PowerObjective:
+		return "power"
+	case ConsolidateObjective:
+		return "consolidate"
+	case MaxHeadroomObjective:
+		return "max-headroom"
+	default:
+		return "Unknown"
+	}
+}
+
+func ObjectiveEnum(s string) Objective {
+	switch s {
+	case "cost":
+		return CostObjective
+	case "power":
+		return PowerObjective
+	case "consolidate":
+		return ConsolidateObjective
+	case "max-headroom":
+		return MaxHeadroomObjective
+	default:
+		return DefaultObjective
+	}
+}
+
+// Placement of servers on interruptible (spot) accelerators
+type SpotPolicy int
+
+const (
+	AllowAllSpot      SpotPolicy = iota // 0 : all servers may be placed on interruptible accelerators
+	AvoidCriticalSpot                   // 1 : critical servers are not placed on interruptible accelerators
+	SpotOnly                            // 2 : all servers are placed on interruptible accelerators only
+)
+
+func (p SpotPolicy) String() string {
+	switch p {
+	case AllowAllSpot:
+		return "allow-all"
+	case AvoidCriticalSpot:
+		return "avoid-critical"
+	case SpotOnly:
+		return "spot-only"
+	default:
+		return "Unknown"
+	}
+}
+
+func SpotPolicyEnum(s string) SpotPolicy {
+	switch s {
+	case "allow-all":
+		return AllowAllSpot
+	case "avoid-critical":
+		return AvoidCriticalSpot
+	case "spot-only":
+		return SpotOnly
+	default:
+		return DefaultSpotPolicy
+	}
+}
+
+// Handling of servers requiring more replicas to satisfy SLOs than their max number of replicas
+type ReplicaCapPolicy int
+
+const (
+	InfeasibleAboveCap ReplicaCapPolicy = iota // 0 : allocation is not feasible
+	DegradeAboveCap                            // 1 : allocation is capped at the max number of replicas and degraded
+)
+
+func (p ReplicaCapPolicy) String() string {
+	switch p {
+	case InfeasibleAboveCap:
+		return "infeasible"
+	case DegradeAboveCap:
+		return "degrade"
+	default:
+		return "Unknown"
+	}
+}
+
+func ReplicaCapPolicyEnum(s string) ReplicaCapPolicy {
+	switch s {
+	case "infeasible":
+		return InfeasibleAboveCap
+	case "degrade":
+		return DegradeAboveCap
+	default:
+		return DefaultReplicaCapPolicy
+	}
+}
+
+// Secondary ordering key of servers with the same priority in the greedy algorithm
+type TieBreak int
+
+const (
+	DeltaTieBreak TieBreak = iota // 0 : larger penalty of moving to the next best allocation first
+	SlackTieBreak                 // 1 : smaller SLO slack (closer to infeasibility) first, then delta
+)
+
+func (t TieBreak) String() string {
+	switch t {
+	case DeltaTieBreak:
+		return "delta"
+	case SlackTieBreak:
+		return "slack"
+	default:
+		return "Unknown"
+	}
+}
+
+func TieBreakEnum(s string) TieBreak {
+	switch s {
+	case "delta":
+		return DeltaTieBreak
+	case "slack":
+		return SlackTieBreak
+	default:
+		return DefaultTieBreak
+	}
+}
+
+// Step of round-robin allocation under saturated condition (RoundRobin and PriorityRoundRobin policies)
+type RoundRobinStep int
+
+const (
+	EqualStep   RoundRobinStep = iota // 0 : one replica to each server per round
+	DeficitStep                       // 1 : one replica at a time to the server with the largest remaining replica deficit (max-min)
+)
+
+func (r RoundRobinStep) String() string {
+	switch r {
+	case EqualStep:
+		return "equal"
+	case DeficitStep:
+		return "deficit"
+	default:
+		return "Unknown"
+	}
+}
+
+func RoundRobinStepEnum(s string) RoundRobinStep {
+	switch s {
+	case "equal":
+		return EqualStep
+	case "deficit":
+		return DeficitStep
+	default:
+		return DefaultRoundRobinStep
+	}
+}
+
+// Period of the cost of an accelerator
+type CostPeriod int
+
+const (
+	HourlyCost  CostPeriod = iota // 0 : cost per hour
+	MonthlyCost                   // 1 : cost per month
+)
+
+func (p CostPeriod) String() string {
+	switch p {
+	case HourlyCost:
+		return "hour"
+	case MonthlyCost:
+		return "month"
+	default:
+		return "Unknown"
+	}
+}
+
+func CostPeriodEnum(s string) CostPeriod {
+	switch s {
+	case "hour":
+		return HourlyCost
+	case "month":
+		return MonthlyCost
+	default:
+		return DefaultCostPeriod
+	}
+}
+
+// Number of hours in a cost period
+func (p CostPeriod) Hours() float32 {
+	if p == MonthlyCost {
+		return HoursPerMonth
+	}
+	return 1
+}
+
+// Direction of a recommendation to scale the number of replicas of a server
+type ScaleAction int
+
+const (
+	NoScale   ScaleAction = iota // 0 : keep the number of replicas
+	ScaleUp                      // 1 : add replicas
+	ScaleDown                    // 2 : remove replicas
+)
+
+func (a ScaleAction) String() string {
+	switch a {
+	case NoScale:
+		return "none"
+	case ScaleUp:
+		return "up"
+	case ScaleDown:
+		return "down"
+	default:
+		return "Unknown"
+	}
+}
+
+// Direction of scaling by an increment of the number of replicas
+func ScaleActionOf(inc int) ScaleAction {
+	switch {
+	case inc > 0:
+		return ScaleUp
+	case inc < 0:
+		return ScaleDown
+	default:
+		return NoScale
+	}
+}
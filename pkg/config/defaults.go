@@ -2,6 +2,7 @@ package config
 
 import (
 	"math"
+	"runtime"
 )
 
 /**
@@ -17,6 +18,27 @@ var SLOMargin = -float32(math.Log(1 - SLOPercentile))
 // maximum number of requests in queueing system as multiples of maximum batch size
 var MaxQueueToBatchRatio = 10
 
+// maximum number of concurrent workers evaluating candidate allocations
+var MaxAllocationWorkers = runtime.NumCPU()
+
+// maximum number of cached allocations (the cache is cleared when full; zero disables caching)
+var MaxAllocationCacheSize = 100000
+
+// maximum number of concurrent workers optimizing independent systems (batch optimization)
+var MaxOptimizationWorkers = runtime.NumCPU()
+
+// absolute tolerance of binary searches of max rates achieving target metrics (not positive if none)
+var SearchAbsTolerance float32 = 0
+
+// tolerance of binary searches of max rates achieving target metrics, relative to the target (not positive if none)
+var SearchRelTolerance float32 = 1e-6
+
+// maximum number of iterations of binary searches of max rates achieving target metrics
+var SearchMaxIterations int = 100
+
+// number of points sampled inside the range of binary searches of max rates, to detect non-monotonic metrics
+var SearchSamples int = 4
+
 // accelerator transition penalty factor
 var AccelPenaltyFactor = float32(0.1)
 
@@ -32,5 +54,77 @@ const DefaultHighPriority int = 1
 // default priority of a service class (lowest)
 const DefaultServiceClassPriority int = DefaultLowPriority
 
+// default weight of a service class
+const DefaultServiceClassWeight float32 = 1
+
 // default option for allocation under saturated condition
 var DefaultSaturatedAllocationPolicy SaturatedAllocationPolicy = None
+
+// default step of round-robin allocation under saturated condition
+var DefaultRoundRobinStep RoundRobinStep = EqualStep
+
+// default algorithm solving the allocation problem
+var DefaultAlgorithm Algorithm = Greedy
+
+// default time budget of the MILP solver (msec)
+var DefaultMILPTimeBudget int = 10000
+
+// default secondary ordering key of servers with the same priority in the greedy algorithm
+var DefaultTieBreak TieBreak = DeltaTieBreak
+
+// default fraction of total cost allowed to increase to reduce the number of accelerator types in use (consolidate objective)
+var DefaultConsolidationTolerance float32 = 0.05
+
+// utilization of a replica above which an allocation is flagged as on the edge of instability
+var MaxRho float32 = 0.9
+
+// add replicas to allocations while the utilization of a replica is above the max, within the max number of replicas
+var AddReplicaAboveMaxRho bool = false
+
+// fraction of the load of a server by which its replicas must remain sufficient when scaling down (hysteresis),
+// so that a load oscillating around a replica boundary does not flap the number of replicas (zero if none)
+var ScaleDownMargin float32 = 0.1
+
+// maximum number of improving moves applied by local search after solving
+var MaxImprovingMoves int = 1000
+
+// default queueing model of a server
+var DefaultQueueModel QueueModel = MM1StateDependent
+
+// default handling of servers requiring more replicas than their max number of replicas
+var DefaultReplicaCapPolicy ReplicaCapPolicy = InfeasibleAboveCap
+
+// coefficient of variation of request inter-arrival times above which servers with the (Poisson arrivals)
+// MM1StateDependent queueing model are sized with the GGm queueing model instead (not positive to disable)
+var BurstyArrivalCOV float32 = 1.5
+
+// default value function of an allocation
+var DefaultValueFunction ValueFunction = CostValue
+
+// default metric of an allocation minimized by the optimizer
+var DefaultObjective Objective = CostObjective
+
+// default placement policy of servers on interruptible (spot) accelerators
+var DefaultSpotPolicy SpotPolicy = AllowAllSpot
+
+// default priority threshold of critical servers (priority values up to the threshold are critical)
+var DefaultCriticalPriority int = 10
+
+// factor of utilization penalizing cost in the latency-aware value function
+var LatencyValueFactor = float32(0.5)
+
+// default period of the cost of accelerators
+var DefaultCostPeriod CostPeriod = HourlyCost
+
+// number of hours in a month, normalizing monthly costs of accelerators to hourly costs
+var HoursPerMonth float32 = 730
+
+// minimum coefficient of determination of decode parameters fitted to measured decode times, below which fits are poor
+var MinPerfFitRSquared float32 = 0.9
+
+// default and max relative perturbations of SLO targets in sensitivity analysis
+var DefaultSensitivityDelta float32 = 0.1
+var MaxSensitivityDelta float32 = 0.5
+
+// number of times a perturbation of an SLO target not feasible is halved in sensitivity analysis, to stay feasible
+var SensitivityHalvings int = 3
@@ -0,0 +1,27 @@
+package config
+
+import (
+	"github.com/llm-inferno/optimizer/pkg/utils"
+)
+
+// Fit decode parameters of the decode time model (alpha + beta * batchSize) to measured decode times at batch sizes,
+// by least squares, with the coefficient of determination of the fit (one if exact)
+//   - an error is returned if there are fewer than two distinct batch sizes
+func FitPerf(points []DecodePoint) (alpha float32, beta float32, rSquared float32, err error) {
+	xs := make([]float32, len(points))
+	ys := make([]float32, len(points))
+	for i, point := range points {
+		xs[i], ys[i] = float32(point.BatchSize), point.DecodeTime
+	}
+	fit, err := utils.LinearFit(xs, ys)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return fit.Intercept, fit.Slope, fit.RSquared, nil
+}
+
+// Decode parameters of the perf data were fitted with a coefficient of determination below the minimum,
+// hence latency estimates based on them are not trustworthy
+func (d *ModelAcceleratorPerfData) PoorFit() bool {
+	return d.FitRSquared > 0 && d.FitRSquared < MinPerfFitRSquared
+}
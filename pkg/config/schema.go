@@ -0,0 +1,119 @@
+package config
+
+import (
+	"reflect"
+)
+
+// URI of the JSON Schema dialect of generated schemas
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// JSON Schema of the top-level data types, by type name, derived from their struct (json) tags
+//   - nested struct types are defined once in the $defs of each schema, and referenced
+func JSONSchema() map[string]any {
+	schemas := make(map[string]any)
+	for _, v := range []any{
+		SystemData{},
+		AcceleratorData{},
+		ModelData{},
+		ServiceClassData{},
+		ServerData{},
+		CapacityData{},
+		OptimizerData{},
+	} {
+		b := NewSchemaBuilder("#/$defs/")
+		t := reflect.TypeOf(v)
+		schema := b.Schema(t)
+		schema["$schema"] = JSONSchemaDialect
+		schema["$defs"] = b.Defs()
+		schemas[t.Name()] = schema
+	}
+	return schemas
+}
+
+// Builder of JSON Schemas of Go types, collecting definitions of named struct types
+type SchemaBuilder struct {
+	refPrefix string         // prefix of references to definitions (e.g. "#/$defs/" or "#/components/schemas/")
+	defs      map[string]any // definitions of named struct types, by type name
+}
+
+func NewSchemaBuilder(refPrefix string) *SchemaBuilder {
+	return &SchemaBuilder{
+		refPrefix: refPrefix,
+		defs:      make(map[string]any),
+	}
+}
+
+// Definitions of named struct types referenced by schemas built so far
+func (b *SchemaBuilder) Defs() map[string]any {
+	return b.defs
+}
+
+// Schema of the type of a value, a reference if a named struct type
+func (b *SchemaBuilder) SchemaOf(v any) map[string]any {
+	return b.schema(reflect.TypeOf(v))
+}
+
+// Schema of a type, with its properties if a struct type, rather than a reference
+func (b *SchemaBuilder) Schema(t reflect.Type) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Struct {
+		return b.structSchema(t)
+	}
+	return b.schema(t)
+}
+
+func (b *SchemaBuilder) schema(t reflect.Type) map[string]any {
+	if t == nil {
+		return map[string]any{}
+	}
+	if isQuantityType(t) {
+		return map[string]any{"type": []string{"number", "string"}}
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return b.schema(t.Elem())
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": b.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": b.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return b.structSchema(t)
+		}
+		if _, exists := b.defs[t.Name()]; !exists {
+			// reserve the definition first, as the type may refer to itself
+			b.defs[t.Name()] = nil
+			b.defs[t.Name()] = b.structSchema(t)
+		}
+		return map[string]any{"$ref": b.refPrefix + t.Name()}
+	default:
+		return map[string]any{}
+	}
+}
+
+// schema of a struct type, with properties named by the json tags of its exported fields
+//   - fields of embedded structs with no json name are inlined
+func (b *SchemaBuilder) structSchema(t reflect.Type) map[string]any {
+	properties := make(map[string]any)
+	b.addProperties(t, properties)
+	return map[string]any{"type": "object", "properties": properties}
+}
+
+func (b *SchemaBuilder) addProperties(t reflect.Type, properties map[string]any) {
+	fields := make(map[string]reflect.Type)
+	structFields(t, fields)
+	for name, fieldType := range fields {
+		properties[name] = b.schema(fieldType)
+	}
+}
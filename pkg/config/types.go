@@ -1,158 +1,634 @@
 package config
 
+import "time"
+
 // TODO: add json validation and default values
 
 // All data related to the system (accelerators, models, service classes, ...)
 type SystemData struct {
-	Spec SystemSpec `json:"system"`
+	Spec SystemSpec `json:"system" yaml:"system"`
 }
 
 // Specifications for system data
 type SystemSpec struct {
 	// static data
-	Accelerators   AcceleratorData  `json:"acceleratorData"`  // accelerator data
-	Models         ModelData        `json:"modelData"`        // model data
-	ServiceClasses ServiceClassData `json:"serviceClassData"` // service class data
-	Servers        ServerData       `json:"serverData"`       // server data
-	Optimizer      OptimizerData    `json:"optimizerData"`    // optimizer data
+	Accelerators   AcceleratorData  `json:"acceleratorData" yaml:"acceleratorData"`   // accelerator data
+	Models         ModelData        `json:"modelData" yaml:"modelData"`               // model data
+	ServiceClasses ServiceClassData `json:"serviceClassData" yaml:"serviceClassData"` // service class data
+	Servers        ServerData       `json:"serverData" yaml:"serverData"`             // server data
+	Optimizer      OptimizerData    `json:"optimizerData" yaml:"optimizerData"`       // optimizer data
 
 	// dynamic data
-	Capacity CapacityData `json:"capacityData"` // data about accelerator type availability
+	Capacity CapacityData `json:"capacityData" yaml:"capacityData"` // data about accelerator type availability
 }
 
 // Data related to an Accelerator
 type AcceleratorData struct {
-	Spec []AcceleratorSpec `json:"accelerators"` // accelerator specs
+	Spec []AcceleratorSpec `json:"accelerators" yaml:"accelerators"` // accelerator specs
 }
 
 // Specifications for accelerator data
 type AcceleratorSpec struct {
-	Name         string    `json:"name"`         // name of accelerator
-	Type         string    `json:"type"`         // name of accelerator type (e.g. A100)
-	Multiplicity int       `json:"multiplicity"` // number of cards of type for this accelerator
-	MemSize      int       `json:"memSize"`      // GB
-	MemBW        int       `json:"memBW"`        // GB/sec
-	Power        PowerSpec `json:"power"`        // power consumption specs
-	Cost         float32   `json:"cost"`         // cents/hr
+	Name         string    `json:"name" yaml:"name"`                 // name of accelerator
+	Type         string    `json:"type" yaml:"type"`                 // name of accelerator type (e.g. A100)
+	Multiplicity int       `json:"multiplicity" yaml:"multiplicity"` // number of cards of type for this accelerator
+	Slices       int       `json:"slices" yaml:"slices"`             // number of partition slices of a device per card (zero if whole device)
+	MemSize      int       `json:"memSize" yaml:"memSize"`           // GB
+	MemBW        int       `json:"memBW" yaml:"memBW"`               // GB/sec
+	Power        PowerSpec `json:"power" yaml:"power"`               // power consumption specs
+	Cost         float32   `json:"cost" yaml:"cost"`                 // cost per period, in currency units (e.g. cents/hr)
+	Currency     string    `json:"currency" yaml:"currency"`         // currency unit of cost (e.g. USD cents, empty if unspecified)
+	CostPeriod   string    `json:"costPeriod" yaml:"costPeriod"`     // period of cost: hour or month (empty for hour)
+
+	Interruptible bool    `json:"interruptible" yaml:"interruptible"` // preemptible (spot) capacity, which may be reclaimed
+	ReclaimRisk   float32 `json:"reclaimRisk" yaml:"reclaimRisk"`     // probability of capacity being reclaimed (interruptible only)
+
+	WarmupSeconds float32 `json:"warmupSeconds" yaml:"warmupSeconds"` // time for a new replica to become ready, loading the model and warming up (sec)
+
+	Draining bool `json:"draining" yaml:"draining"` // deprecated, not a candidate for new allocations while existing allocations remain
+
+	RelativeThroughput float32 `json:"relativeThroughput" yaml:"relativeThroughput"` // throughput relative to other accelerators, to estimate missing perf data (zero if unknown)
 }
 
 // Specifications for Accelerator power consumption data (Watts)
 type PowerSpec struct {
-	Idle     int     `json:"idle"`     // idle power
-	Full     int     `json:"full"`     // full utilization power
-	MidPower int     `json:"midPower"` // power at inflection point
-	MidUtil  float32 `json:"midUtil"`  // utilization at inflection point
+	Idle     int     `json:"idle" yaml:"idle"`         // idle power
+	Full     int     `json:"full" yaml:"full"`         // full utilization power
+	MidPower int     `json:"midPower" yaml:"midPower"` // power at inflection point
+	MidUtil  float32 `json:"midUtil" yaml:"midUtil"`   // utilization at inflection point
 }
 
 // Data about accelerator type availability
 type CapacityData struct {
-	Count []AcceleratorCount `json:"count"` // count of accelerator types
+	Count []AcceleratorCount `json:"count" yaml:"count"` // count of accelerator types
 }
 
 // Count of accelerator types in the system
 type AcceleratorCount struct {
-	Type  string `json:"type"`  // name of accelerator type
-	Count int    `json:"count"` // number of available units
+	Type          string  `json:"type" yaml:"type"`                   // name of accelerator type
+	Count         int     `json:"count" yaml:"count"`                 // number of available units
+	Partitions    int     `json:"partitions" yaml:"partitions"`       // number of partition slices per unit (zero or one if not partitioned)
+	MaxPowerWatts float32 `json:"maxPowerWatts" yaml:"maxPowerWatts"` // power budget of allocations of this type, e.g. of its racks (Watts, zero if none)
+	UnitsPerNode  int     `json:"unitsPerNode" yaml:"unitsPerNode"`   // number of units per node, hosting replicas using several units (zero if units are not grouped in nodes)
 }
 
 // Data related to a Model
 type ModelData struct {
-	PerfData []ModelAcceleratorPerfData `json:"models"` // performance data for model on accelerators
+	PerfData []ModelAcceleratorPerfData `json:"models" yaml:"models"` // performance data for model on accelerators
 }
 
 // Specifications for a combination of a model and accelerator data
 type ModelAcceleratorPerfData struct {
-	Name         string       `json:"name"`         // model name
-	Acc          string       `json:"acc"`          // accelerator name
-	AccCount     int          `json:"accCount"`     // number of accelerator units used by model
-	MaxBatchSize int          `json:"maxBatchSize"` // max batch size based on average number of tokens per request
-	AtTokens     int          `json:"atTokens"`     // average number of tokens per request assumed in max batch size calculation
-	DecodeParms  DecodeParms  `json:"decodeParms"`  // parameters for estimating decode time
-	PrefillParms PrefillParms `json:"prefillParms"` // parameters for estimating prefill time
+	Name          string       `json:"name" yaml:"name"`                   // model name
+	Acc           string       `json:"acc" yaml:"acc"`                     // accelerator name
+	AccCount      int          `json:"accCount" yaml:"accCount"`           // number of accelerator units used by model
+	MaxBatchSize  int          `json:"maxBatchSize" yaml:"maxBatchSize"`   // max batch size based on average number of tokens per request
+	AtTokens      int          `json:"atTokens" yaml:"atTokens"`           // average number of tokens per request assumed in max batch size calculation
+	DecodeParms   DecodeParms  `json:"decodeParms" yaml:"decodeParms"`     // parameters for estimating decode time
+	PrefillParms  PrefillParms `json:"prefillParms" yaml:"prefillParms"`   // parameters for estimating prefill time
+	TPDegree      int          `json:"tpDegree" yaml:"tpDegree"`           // tensor parallelism degree of a replica (zero or one if none)
+	TPScaling     float32      `json:"tpScaling" yaml:"tpScaling"`         // fractional increase in service time per additional degree of tensor parallelism
+	UnitCost      float32      `json:"unitCost" yaml:"unitCost"`           // cost of an accelerator unit for the model, overriding the accelerator cost, in its currency and period (zero if none)
+	WarmupSeconds float32      `json:"warmupSeconds" yaml:"warmupSeconds"` // time for a new replica of the model to become ready, overriding the accelerator warmup time (zero if none)
+	SharedWeights bool         `json:"sharedWeights" yaml:"sharedWeights"` // replicas of a server share loaded model weights, hence replicas after the first cost less
+	SharedCost    float32      `json:"sharedCost" yaml:"sharedCost"`       // cost of each replica after the first, as a fraction of the cost of the first, if sharing weights
+	FitRSquared   float32      `json:"fitRSquared" yaml:"fitRSquared"`     // coefficient of determination of decode parameters fitted to measured decode times (zero if not fitted)
+	BatchWindow   float32      `json:"batchWindow" yaml:"batchWindow"`     // window during which arriving requests wait to join the next batch (msec, zero if none)
+}
+
+// Performance data of a model on an accelerator to import, e.g. from a benchmark
+//   - decode parameters (alpha and beta) not given (both zero) are fitted to measured decode times at batch sizes
+//   - the max batch size, if not given, is the largest measured batch size
+type PerfImportEntry struct {
+	ModelAcceleratorPerfData `yaml:",inline"`
+	Points                   []DecodePoint `json:"points" yaml:"points"` // measured decode times at batch sizes
+}
+
+// Measured decode time of a model on an accelerator at a batch size
+type DecodePoint struct {
+	BatchSize  int     `json:"batchSize" yaml:"batchSize"`   // number of requests in the batch
+	DecodeTime float32 `json:"decodeTime" yaml:"decodeTime"` // decode time of an output token (msec)
+}
+
+// Result of importing performance data of a model on an accelerator
+type PerfImportResult struct {
+	Name     string  `json:"name" yaml:"name"`         // model name
+	Acc      string  `json:"acc" yaml:"acc"`           // accelerator name
+	Fitted   bool    `json:"fitted" yaml:"fitted"`     // decode parameters fitted to measured decode times
+	Points   int     `json:"points" yaml:"points"`     // number of measured decode times
+	RSquared float32 `json:"rSquared" yaml:"rSquared"` // coefficient of determination of the fit (one if exact, zero if not fitted)
+	PoorFit  bool    `json:"poorFit" yaml:"poorFit"`   // fitted with a coefficient of determination below the minimum
+	Error    string  `json:"error" yaml:"error"`       // reason for not importing the data (empty if imported)
 }
 
 // Parameters for estimating decode time = alpha + beta * batchSize (msec); batchSize > 0
 type DecodeParms struct {
-	Alpha float32 `json:"alpha"` // base
-	Beta  float32 `json:"beta"`  // slope
+	Alpha float32 `json:"alpha" yaml:"alpha"` // base
+	Beta  float32 `json:"beta" yaml:"beta"`   // slope
 }
 
 // Parameters for estimating prefill time = gamma + delta * inputTokens * batchSize (msec); inputTokens, batchSize > 0
 type PrefillParms struct {
-	Gamma float32 `json:"gamma"` // base
-	Delta float32 `json:"delta"` // slope
+	Gamma float32 `json:"gamma" yaml:"gamma"` // base
+	Delta float32 `json:"delta" yaml:"delta"` // slope
 }
 
 // Data related to a service class SLOs
 type ServiceClassData struct {
-	Spec []ServiceClassSpec `json:"serviceClasses"`
+	Spec []ServiceClassSpec `json:"serviceClasses" yaml:"serviceClasses"`
 }
 
 // Specification of a service class
 type ServiceClassSpec struct {
-	Name         string        `json:"name"`         // service class name
-	Priority     int           `json:"priority"`     // [1,100] priority (lower value is higher priority)
-	ModelTargets []ModelTarget `json:"modelTargets"` // target SLOs for models
+	Name         string         `json:"name" yaml:"name"`                 // service class name
+	Priority     int            `json:"priority" yaml:"priority"`         // [1,100] priority (lower value is higher priority)
+	Weight       float32        `json:"weight" yaml:"weight"`             // relative share of leftover capacity under saturation (WeightedFair policy)
+	ModelTargets []ModelTarget  `json:"modelTargets" yaml:"modelTargets"` // target SLOs for models
+	Reservations map[string]int `json:"reservations" yaml:"reservations"` // count of accelerator types reserved for servers of the class, by type
+
+	// (optional) target SLOs inherited by models with no target in the class (model name not used)
+	DefaultTarget *ModelTarget `json:"defaultTarget,omitempty" yaml:"defaultTarget,omitempty"`
 }
 
 // Specification of SLO targets for a model
 type ModelTarget struct {
-	Model    string  `json:"model"`    // model name
-	SLO_ITL  float32 `json:"slo-itl"`  // inter-token latency (msec)
-	SLO_TTFT float32 `json:"slo-ttft"` // time to first token, including queueing (msec)
-	SLO_TPS  float32 `json:"slo-tps"`  // throughput (tokens/sec)
+	Model    string       `json:"model" yaml:"model"`       // model name
+	SLO_ITL  Msec         `json:"slo-itl" yaml:"slo-itl"`   // inter-token latency (msec)
+	SLO_TTFT Msec         `json:"slo-ttft" yaml:"slo-ttft"` // time to first token, including queueing (msec)
+	SLO_TPS  TokensPerSec `json:"slo-tps" yaml:"slo-tps"`   // throughput (tokens/sec)
+
+	SLO_ITL_P99  Msec `json:"slo-itl-p99" yaml:"slo-itl-p99"`   // tail percentile inter-token latency (msec)
+	SLO_TTFT_P99 Msec `json:"slo-ttft-p99" yaml:"slo-ttft-p99"` // tail percentile time to first token, including queueing (msec)
+
+	SLO_MaxDropRate float32 `json:"slo-max-drop-rate" yaml:"slo-max-drop-rate"` // max fraction of requests rejected as the queue is full
+
+	SLO_WaitFraction float32 `json:"slo-wait-fraction" yaml:"slo-wait-fraction"` // max queueing time as a fraction of the service time of a request
 }
 
 // Data related to a Server
 type ServerData struct {
-	Spec []ServerSpec `json:"servers"`
+	Spec []ServerSpec `json:"servers" yaml:"servers"`
 }
 
 // Specifications of a server
 type ServerSpec struct {
-	Name            string         `json:"name"`            // server name
-	Class           string         `json:"class"`           // service class name
-	Model           string         `json:"model"`           // model name
-	KeepAccelerator bool           `json:"keepAccelerator"` // option to not change accelerator
-	MinNumReplicas  int            `json:"minNumReplicas"`  // minimum number of replicas
-	MaxBatchSize    int            `json:"maxBatchSize"`    // overriding value for the maximum batch size
-	CurrentAlloc    AllocationData `json:"currentAlloc"`    // current allocation
-	DesiredAlloc    AllocationData `json:"desiredAlloc"`    // desired allocation
+	Name                string         `json:"name" yaml:"name"`                               // server name
+	Class               string         `json:"class" yaml:"class"`                             // service class name
+	Model               string         `json:"model" yaml:"model"`                             // model name
+	KeepAccelerator     bool           `json:"keepAccelerator" yaml:"keepAccelerator"`         // option to not change accelerator
+	AllowedAccelerators []string       `json:"allowedAccelerators" yaml:"allowedAccelerators"` // accelerators the server may be allocated to (all if empty)
+	DeniedAccelerators  []string       `json:"deniedAccelerators" yaml:"deniedAccelerators"`   // accelerators the server may not be allocated to
+	MinNumReplicas      int            `json:"minNumReplicas" yaml:"minNumReplicas"`           // minimum number of replicas
+	WarmReplicas        int            `json:"warmReplicas" yaml:"warmReplicas"`               // minimum number of replicas always provisioned regardless of load (warm pool), not counted as sized for SLOs
+	MaxNumReplicas      int            `json:"maxNumReplicas" yaml:"maxNumReplicas"`           // maximum number of replicas (unbounded if zero), takes precedence over the minimum
+	ReplicaCapPolicy    string         `json:"replicaCapPolicy" yaml:"replicaCapPolicy"`       // handling of SLOs requiring more than the maximum number of replicas
+	ShardFactor         int            `json:"shardFactor" yaml:"shardFactor"`                 // number of replicas must be a multiple of this factor (if > 1)
+	MaxBatchSize        int            `json:"maxBatchSize" yaml:"maxBatchSize"`               // overriding value for the maximum batch size
+	OptimizeBatch       bool           `json:"optimizeBatch" yaml:"optimizeBatch"`             // option to jointly optimize batch size (up to the maximum) and number of replicas
+	QueueModel          string         `json:"queueModel" yaml:"queueModel"`                   // queueing model used to size the server
+	CurrentAlloc        AllocationData `json:"currentAlloc" yaml:"currentAlloc"`               // current allocation
+	DesiredAlloc        AllocationData `json:"desiredAlloc" yaml:"desiredAlloc"`               // desired allocation
+	LoadProfile         []LoadWindow   `json:"loadProfile" yaml:"loadProfile"`                 // (optional) anticipated loads in time windows, e.g. hours of the day
+	Adapters            []AdapterSpec  `json:"adapters" yaml:"adapters"`                       // (optional) adapters of the model (e.g. LoRA) served in the same batch
+
+	// (optional) stream of low priority requests sharing the replicas, queued behind requests of the server (and its adapters)
+	LowPriority *LowPrioritySpec `json:"lowPriority,omitempty" yaml:"lowPriority,omitempty"`
+
+	// (optional) allocation imposed by an operator (accelerator, number of replicas, and max batch size), honored as
+	// given rather than optimized, its accelerator units taken before allocating to other servers
+	PinnedAllocation *AllocationData `json:"pinnedAllocation,omitempty" yaml:"pinnedAllocation,omitempty"`
+}
+
+// A stream of low priority requests to the model of a server, sharing its replicas under non-preemptive priority scheduling
+//   - queued requests of the server are admitted to the batch before queued low priority requests
+//   - the stream has the SLO targets of the model in its service class
+type LowPrioritySpec struct {
+	Class string         `json:"class" yaml:"class"` // service class name of the stream
+	Load  ServerLoadSpec `json:"load" yaml:"load"`   // load statistics of low priority requests
+}
+
+// An adapter of the model of a server (e.g. LoRA), served in the same batch as the model
+//   - the adapter has the same performance data and SLO targets as the model of the server
+type AdapterSpec struct {
+	Name string         `json:"name" yaml:"name"` // adapter name
+	Load ServerLoadSpec `json:"load" yaml:"load"` // load statistics of requests to the adapter
+}
+
+// Anticipated load of a server in a time window
+type LoadWindow struct {
+	Window string         `json:"window" yaml:"window"` // name of time window, common to all servers (e.g. "09:00-12:00")
+	Load   ServerLoadSpec `json:"load" yaml:"load"`     // server load statistics in the window
+}
+
+// Solution of the allocation problem for the loads of servers in a time window
+type WindowPlan struct {
+	Window   string              `json:"window" yaml:"window"`     // name of time window
+	Solution *AllocationSolution `json:"solution" yaml:"solution"` // solution for the loads in the window
+	Error    string              `json:"error" yaml:"error"`       // optimization error (empty if none)
 }
 
 // Data about a server allocation
 type AllocationData struct {
-	Accelerator string         `json:"accelerator"` // accelerator name
-	NumReplicas int            `json:"numReplicas"` // number of replicas
-	MaxBatch    int            `json:"maxBatch"`    // max batch size
-	Cost        float32        `json:"cost"`        // cost of allocation
-	ITLAverage  float32        `json:"itlAverage"`  // average ITL
-	TTFTAverage float32        `json:"ttftAverage"` // average TTFT
-	Load        ServerLoadSpec `json:"load"`        // server load statistics
+	Accelerator string         `json:"accelerator" yaml:"accelerator"` // accelerator name
+	NumReplicas int            `json:"numReplicas" yaml:"numReplicas"` // number of replicas
+	MaxBatch    int            `json:"maxBatch" yaml:"maxBatch"`       // max batch size
+	Cost        float32        `json:"cost" yaml:"cost"`               // cost of allocation (per hour)
+	Power       float32        `json:"power" yaml:"power"`             // power consumption of allocation (Watts)
+	ITLAverage  float32        `json:"itlAverage" yaml:"itlAverage"`   // average ITL
+	TTFTAverage float32        `json:"ttftAverage" yaml:"ttftAverage"` // average TTFT
+	ITLP99      float32        `json:"itlP99" yaml:"itlP99"`           // tail percentile ITL
+	TTFTP99     float32        `json:"ttftP99" yaml:"ttftP99"`         // tail percentile TTFT
+	DropRate    float32        `json:"dropRate" yaml:"dropRate"`       // expected fraction of requests rejected as the queue is full
+	Rho         float32        `json:"rho" yaml:"rho"`                 // utilization of the batch of a replica
+	Load        ServerLoadSpec `json:"load" yaml:"load"`               // server load statistics
+
+	RequestedReplicas int  `json:"requestedReplicas" yaml:"requestedReplicas"` // number of replicas required to satisfy SLOs
+	Degraded          bool `json:"degraded" yaml:"degraded"`                   // fewer replicas than required to satisfy SLOs (under saturation)
+	Idle              bool `json:"idle" yaml:"idle"`                           // zero load, replicas sized by the minimum number of replicas
+	Estimated         bool `json:"estimated" yaml:"estimated"`                 // sized with perf data estimated from other accelerators (speculative)
+	AboveMaxRho       bool `json:"aboveMaxRho" yaml:"aboveMaxRho"`             // utilization of a replica above the max, on the edge of instability
+
+	WarmReplicas int     `json:"warmReplicas" yaml:"warmReplicas"` // replicas provisioned for the warm pool of the server, beyond those sized for SLOs
+	WarmCost     float32 `json:"warmCost" yaml:"warmCost"`         // cost of warm pool replicas, included in the cost of the allocation
+
+	CostPerMillionTokens float32 `json:"costPerMillionTokens" yaml:"costPerMillionTokens"` // cost per million tokens (input and output) at max throughput at SLO
+
+	// latencies of low priority requests, if the server has a low priority stream (latencies above are of its own requests)
+	LowPriority *StreamLatencyData `json:"lowPriority,omitempty" yaml:"lowPriority,omitempty"`
+
+	// replicas on a second accelerator serving part of the load (mixed allocation), cost and power above are totals
+	Secondary *AllocationData `json:"secondary,omitempty" yaml:"secondary,omitempty"`
+}
+
+// Expected latencies of a stream of requests of a server
+type StreamLatencyData struct {
+	TTFTAverage float32 `json:"ttftAverage" yaml:"ttftAverage"` // average TTFT
+	TTFTP99     float32 `json:"ttftP99" yaml:"ttftP99"`         // tail percentile TTFT
 }
 
 // Specifications of server load statistics
 type ServerLoadSpec struct {
-	ArrivalRate  float32 `json:"arrivalRate"`  // req/min
-	AvgInTokens  int     `json:"avgInTokens"`  // average number of input tokens
-	AvgOutTokens int     `json:"avgOutTokens"` // average number of output tokens
+	ArrivalRate  float32 `json:"arrivalRate" yaml:"arrivalRate"`   // req/min
+	AvgInTokens  int     `json:"avgInTokens" yaml:"avgInTokens"`   // average number of input tokens
+	AvgOutTokens int     `json:"avgOutTokens" yaml:"avgOutTokens"` // average number of output tokens
+	ArrivalCOV   float32 `json:"arrivalCOV" yaml:"arrivalCOV"`     // coefficient of variation of request inter-arrival time (G/G/m)
+	ServiceCOV   float32 `json:"serviceCOV" yaml:"serviceCOV"`     // coefficient of variation of request service time (G/G/m)
+}
+
+// Data of a candidate allocation of a server, with its value and maximum request rate per replica
+type CandidateAllocationData struct {
+	AllocationData `yaml:",inline"`
+	Value          float32 `json:"value" yaml:"value"`   // value of allocation (minimized by the optimizer)
+	MaxRPM         float32 `json:"maxRPM" yaml:"maxRPM"` // maximum request rate per replica (req/min)
 }
 
 type AllocationSolution struct {
-	Spec map[string]AllocationData `json:"allocations"` // map of server names to allocation data
+	Spec         map[string]AllocationData `json:"allocations" yaml:"allocations"`   // map of server names to allocation data
+	Unallocated  []ServerStatus            `json:"unallocated" yaml:"unallocated"`   // servers not given an allocation
+	Degraded     []string                  `json:"degraded" yaml:"degraded"`         // servers given fewer replicas than required to satisfy SLOs
+	Idle         []string                  `json:"idle" yaml:"idle"`                 // servers with zero load, given their minimum number of replicas
+	ScaledToZero []string                  `json:"scaledToZero" yaml:"scaledToZero"` // servers with zero load, given no allocation (scale to zero)
+	Estimated    []string                  `json:"estimated" yaml:"estimated"`       // servers given allocations sized with estimated perf data (speculative)
+	Draining     []string                  `json:"draining" yaml:"draining"`         // servers still allocated to draining (deprecated) accelerators
+	Pinned       []PinnedStatus            `json:"pinned" yaml:"pinned"`             // servers given their pinned allocations, with their compliance with SLO targets
+	TotalPower   float32                   `json:"totalPower" yaml:"totalPower"`     // total power consumption of allocations (Watts)
+	TotalCost    float32                   `json:"totalCost" yaml:"totalCost"`       // total cost of allocations, per cost period
+	WarmCost     float32                   `json:"warmCost" yaml:"warmCost"`         // total cost of warm pool replicas of allocations, included in the total cost
+	Currency     string                    `json:"currency" yaml:"currency"`         // currency unit of costs (empty if unspecified)
+	CostPeriod   string                    `json:"costPeriod" yaml:"costPeriod"`     // period of costs of allocations (hour)
+	Metadata     SolutionMetadata          `json:"metadata" yaml:"metadata"`         // data about the solution process
+
+	CostPerMillionTokens float32 `json:"costPerMillionTokens" yaml:"costPerMillionTokens"` // total cost per million tokens (input and output) at max throughput at SLO of allocations
+}
+
+// Snapshot of the specs of a system and its last solution, as of a time, identified by a version
+type SystemSnapshot struct {
+	Version  string              `json:"version" yaml:"version"`   // hash of the specs and solution, the same for the same state
+	AsOf     time.Time           `json:"asOf" yaml:"asOf"`         // time the state was first captured
+	Spec     SystemSpec          `json:"system" yaml:"system"`     // specs of the system, with no optimizer spec
+	Models   []string            `json:"models" yaml:"models"`     // names of models, including models with no perf data
+	Solution *AllocationSolution `json:"solution" yaml:"solution"` // last solution (nil if none)
+}
+
+// Entry of the history of solutions of the optimizer, identified by a sequence number
+type HistoryEntry struct {
+	ID        int64               `json:"id" yaml:"id"`               // sequence number of the entry, increasing over time
+	Time      time.Time           `json:"time" yaml:"time"`           // time the solution was generated
+	InputHash string              `json:"inputHash" yaml:"inputHash"` // hash of the specs of the system and optimizer optimized, the same for the same inputs
+	Optimizer OptimizerSpec       `json:"optimizer" yaml:"optimizer"` // optimizer spec used
+	Summary   SolutionSummary     `json:"summary" yaml:"summary"`     // summary of the solution
+	Solution  *AllocationSolution `json:"solution" yaml:"solution"`   // solution generated
+}
+
+// Summary of a solution
+type SolutionSummary struct {
+	TotalCost   float32 `json:"totalCost" yaml:"totalCost"`     // total cost of allocations, per cost period
+	Allocated   int     `json:"allocated" yaml:"allocated"`     // number of servers given an allocation
+	Unallocated int     `json:"unallocated" yaml:"unallocated"` // number of servers not given an allocation
+}
+
+// Data about the process of finding a solution
+type SolutionMetadata struct {
+	ImprovingMoves  int  `json:"improvingMoves" yaml:"improvingMoves"`   // number of improving moves applied by local search
+	KeptAllocations int  `json:"keptAllocations" yaml:"keptAllocations"` // number of servers kept on their current accelerators when re-optimizing
+	TimedOut        bool `json:"timedOut" yaml:"timedOut"`               // optimization stopped at its time limit, the solution is partial
+
+	AcceleratorTypesBefore int `json:"acceleratorTypesBefore" yaml:"acceleratorTypesBefore"` // number of accelerator types in use before consolidation (consolidate objective)
+	AcceleratorTypesAfter  int `json:"acceleratorTypesAfter" yaml:"acceleratorTypesAfter"`   // number of accelerator types in use after consolidation (consolidate objective)
+
+	PowerHeadroom float32 `json:"powerHeadroom" yaml:"powerHeadroom"` // total power budget not consumed (Watts, zero if no total power budget)
+	MinHeadroom   float32 `json:"minHeadroom" yaml:"minHeadroom"`     // least rate headroom of allocated servers (max-headroom objective)
+	AddedReplicas int     `json:"addedReplicas" yaml:"addedReplicas"` // number of replicas added to increase headroom (max-headroom objective)
+
+	Admitted []string       `json:"admitted,omitempty" yaml:"admitted,omitempty"` // servers admitted, served entirely at SLO (admission control)
+	Shed     []ServerStatus `json:"shed,omitempty" yaml:"shed,omitempty"`         // servers shed, with the binding constraint as reason (admission control)
+
+	Trace []GreedyDecision `json:"trace,omitempty" yaml:"trace,omitempty"` // decisions of the greedy algorithm, in order (debug)
+}
+
+// Decision of the greedy algorithm about a candidate allocation of a server
+type GreedyDecision struct {
+	Step        int    `json:"step" yaml:"step"`               // sequence number of the decision
+	Phase       string `json:"phase" yaml:"phase"`             // phase of the algorithm: allocate, mixed, or best-effort
+	Server      string `json:"server" yaml:"server"`           // server name
+	Action      string `json:"action" yaml:"action"`           // allocated, skipped (moving to the next candidate), or unallocated (no candidate left)
+	Index       int    `json:"index" yaml:"index"`             // index of the candidate allocation in the ordered list of the server (-1 if mixed)
+	Accelerator string `json:"accelerator" yaml:"accelerator"` // accelerator of the candidate allocation
+	Type        string `json:"type" yaml:"type"`               // accelerator type of the candidate allocation
+	NumReplicas int    `json:"numReplicas" yaml:"numReplicas"` // number of replicas of the candidate allocation
+
+	Units          int     `json:"units" yaml:"units"`                       // accelerator units required by the candidate allocation
+	AvailableUnits int     `json:"availableUnits" yaml:"availableUnits"`     // accelerator units of the type available to the server (left after round-robin and weighted best effort)
+	Power          float32 `json:"power" yaml:"power"`                       // power consumption of the candidate allocation (Watts)
+	Value          float32 `json:"value" yaml:"value"`                       // value of the candidate allocation
+	Reason         string  `json:"reason,omitempty" yaml:"reason,omitempty"` // reason a candidate was skipped, e.g. accelerator capacity exhausted
+
+	Delta    float32 `json:"delta" yaml:"delta"`       // delta value of the server after skipping, used to reorder servers
+	Position int     `json:"position" yaml:"position"` // position of the server in the remaining servers after reordering (-1 if unallocated)
+}
+
+// Evaluation of an allocation given for a server (e.g. imposed by an operator) at the current load of the server
+type AllocationEvaluation struct {
+	Server     string          `json:"server" yaml:"server"`         // server name
+	Allocation AllocationData  `json:"allocation" yaml:"allocation"` // evaluated allocation, with its cost, power, and performance at the load
+	Compliant  bool            `json:"compliant" yaml:"compliant"`   // all SLO targets of the server are met
+	Targets    []SLOCompliance `json:"targets" yaml:"targets"`       // compliance with each SLO target of the server
+
+	Saturated bool    `json:"saturated" yaml:"saturated"` // load exceeds the max rate of the replicas, excess requests are not served
+	ServTime  float32 `json:"servTime" yaml:"servTime"`   // average service time of a request: prefill and decode of all output tokens (msec)
+	WaitTime  float32 `json:"waitTime" yaml:"waitTime"`   // average queueing time of a request (msec)
+	Rho       float32 `json:"rho" yaml:"rho"`             // utilization of the batch of a replica
+	MaxRPM    float32 `json:"maxRPM" yaml:"maxRPM"`       // max request rate of all replicas (req/min)
+}
+
+// Allocation of a server sized at a max batch size, a point of a sweep of batch sizes
+type BatchSizePoint struct {
+	BatchSize   int     `json:"batchSize" yaml:"batchSize"`     // max batch size of a replica
+	MaxRPM      float32 `json:"maxRPM" yaml:"maxRPM"`           // max request rate of a replica satisfying SLO targets (req/min)
+	NumReplicas int     `json:"numReplicas" yaml:"numReplicas"` // number of replicas required for the load
+	Cost        float32 `json:"cost" yaml:"cost"`               // cost of the replicas
+	ServTime    float32 `json:"servTime" yaml:"servTime"`       // average service time of a request: prefill and decode of all output tokens (msec)
+	WaitTime    float32 `json:"waitTime" yaml:"waitTime"`       // average queueing time of a request (msec)
+	ITL         float32 `json:"itl" yaml:"itl"`                 // average inter-token latency (msec)
+	TTFT        float32 `json:"ttft" yaml:"ttft"`               // average time to first token (msec)
+	Error       string  `json:"error" yaml:"error"`             // reason the allocation is not feasible at the batch size (empty if feasible)
+}
+
+// Compliance of an allocation with an SLO target
+type SLOCompliance struct {
+	Name     string  `json:"name" yaml:"name"`         // name of the target, as in model targets (e.g. slo-itl)
+	Target   float32 `json:"target" yaml:"target"`     // target value
+	Achieved float32 `json:"achieved" yaml:"achieved"` // value achieved by the allocation
+	Met      bool    `json:"met" yaml:"met"`           // target is met
+}
+
+// Status of a server not given an allocation
+type ServerStatus struct {
+	Name   string `json:"name" yaml:"name"`     // server name
+	Reason string `json:"reason" yaml:"reason"` // reason for not having an allocation
+}
+
+// Inconsistency between data of a system, e.g. capacity of an accelerator type with no accelerator
+type ConsistencyIssue struct {
+	Kind   string `json:"kind" yaml:"kind"`     // kind of the inconsistent data: accelerator, capacity, model, perf data, service class, or server
+	Name   string `json:"name" yaml:"name"`     // name of the data, e.g. accelerator type of capacity, or model of perf data
+	Reason string `json:"reason" yaml:"reason"` // inconsistency
+}
+
+// Compliance with SLO targets of a server given its pinned allocation
+type PinnedStatus struct {
+	Name      string          `json:"name" yaml:"name"`           // server name
+	Compliant bool            `json:"compliant" yaml:"compliant"` // all SLO targets of the server are met
+	Saturated bool            `json:"saturated" yaml:"saturated"` // load exceeds the max rate of the replicas, excess requests are not served
+	Targets   []SLOCompliance `json:"targets" yaml:"targets"`     // compliance with each SLO target of the server
+}
+
+// Difference between the current and desired allocations of a server
+type AllocationDiffData struct {
+	OldAccelerator string  `json:"oldAccelerator" yaml:"oldAccelerator"` // accelerator of current allocation
+	NewAccelerator string  `json:"newAccelerator" yaml:"newAccelerator"` // accelerator of desired allocation
+	OldNumReplicas int     `json:"oldNumReplicas" yaml:"oldNumReplicas"` // number of replicas of current allocation
+	NewNumReplicas int     `json:"newNumReplicas" yaml:"newNumReplicas"` // number of replicas of desired allocation
+	CostDiff       float32 `json:"costDiff" yaml:"costDiff"`             // difference in cost
+}
+
+// Request to apply desired allocations of servers
+type ApplyRequest struct {
+	MaxConcurrentTransitions int `json:"maxConcurrentTransitions" yaml:"maxConcurrentTransitions"` // max number of transitions applied (all if not positive)
+}
+
+// Transition of a server from its current to its desired allocation
+type TransitionData struct {
+	Name    string             `json:"name" yaml:"name"`       // server name
+	Penalty float32            `json:"penalty" yaml:"penalty"` // transition penalty (zero if allocated from or to none)
+	Diff    AllocationDiffData `json:"diff" yaml:"diff"`       // difference between current and desired allocations
+}
+
+// Result of applying desired allocations of servers
+type ApplyResult struct {
+	Applied []TransitionData `json:"applied" yaml:"applied"` // applied transitions, in order
+	Pending []TransitionData `json:"pending" yaml:"pending"` // remaining transitions, in order
+	Failed  []ServerStatus   `json:"failed" yaml:"failed"`   // servers failing to apply their desired allocations, keeping their current allocations
+}
+
+// Recommendation of scaling the allocation of a server to a new load
+type ScaleRecommendation struct {
+	Accelerator string  `json:"accelerator" yaml:"accelerator"` // accelerator of allocation
+	NumReplicas int     `json:"numReplicas" yaml:"numReplicas"` // number of replicas for the new load on the same accelerator (zero if infeasible)
+	Increment   int     `json:"increment" yaml:"increment"`     // change in number of replicas
+	Action      string  `json:"action" yaml:"action"`           // direction of scaling on the same accelerator (up, down, or none)
+	Cost        float32 `json:"cost" yaml:"cost"`               // cost of allocation for the new load on the same accelerator
+	ScaleError  string  `json:"scaleError" yaml:"scaleError"`   // reason scaling on the same accelerator is infeasible (empty if feasible)
+
+	WarmupSeconds float32 `json:"warmupSeconds" yaml:"warmupSeconds"` // expected time to reach the new capacity on the same accelerator (sec, zero if not scaling up)
+
+	BestAccelerator string  `json:"bestAccelerator" yaml:"bestAccelerator"` // accelerator of the best allocation for the new load
+	BestNumReplicas int     `json:"bestNumReplicas" yaml:"bestNumReplicas"` // number of replicas of the best allocation
+	BestCost        float32 `json:"bestCost" yaml:"bestCost"`               // cost of the best allocation
+	Reallocate      bool    `json:"reallocate" yaml:"reallocate"`           // a different accelerator is better for the new load
+
+	BestWarmupSeconds float32 `json:"bestWarmupSeconds" yaml:"bestWarmupSeconds"` // expected time to reach the capacity of the best allocation (sec)
+}
+
+// Result of optimizing a system, one of a batch
+type OptimizationResult struct {
+	Solution *AllocationSolution `json:"solution" yaml:"solution"` // solution (nil if error)
+	Error    string              `json:"error" yaml:"error"`       // optimization error (empty if none)
+	TimeMsec int64               `json:"timeMsec" yaml:"timeMsec"` // time to optimize the system (msec)
+}
+
+// Message of a client streaming optimizations: load updates of servers, and optionally the optimizer spec
+type StreamRequest struct {
+	Optimizer *OptimizerSpec     `json:"optimizer" yaml:"optimizer"` // optimizer spec used from now on (unchanged if nil)
+	Loads     []ServerLoadUpdate `json:"loads" yaml:"loads"`         // updated loads of servers
+}
+
+// Updated load of a server
+type ServerLoadUpdate struct {
+	Name string         `json:"name" yaml:"name"` // server name
+	Load ServerLoadSpec `json:"load" yaml:"load"` // server load statistics
+}
+
+// Changes to the servers of the current system, applied before re-optimizing it
+type SystemDelta struct {
+	Loads         []ServerLoadUpdate `json:"loads" yaml:"loads"`                 // updated loads of servers
+	AddServers    []ServerSpec       `json:"addServers" yaml:"addServers"`       // servers added (not in the system, or removed)
+	RemoveServers []string           `json:"removeServers" yaml:"removeServers"` // names of servers removed
+	Optimizer     *OptimizerSpec     `json:"optimizer" yaml:"optimizer"`         // optimizer spec (default if nil)
+}
+
+// Request to project the cost and capacity needs of the current system, given scaled loads of servers
+type ForecastRequest struct {
+	Factor    float32            `json:"factor" yaml:"factor"`       // multiplier of arrival rates of all servers
+	Factors   map[string]float32 `json:"factors" yaml:"factors"`     // multipliers of arrival rates by server name, overriding the factor
+	Optimizer *OptimizerSpec     `json:"optimizer" yaml:"optimizer"` // optimizer spec (default if nil)
+}
+
+// Projection of the cost and capacity needs of the system under scaled loads
+type ForecastResult struct {
+	TotalCost   float32             `json:"totalCost" yaml:"totalCost"`     // projected total cost of allocations
+	Shortfall   map[string]int      `json:"shortfall" yaml:"shortfall"`     // missing accelerator units by type to satisfy all servers
+	Unallocated []ServerStatus      `json:"unallocated" yaml:"unallocated"` // servers which would not be given an allocation
+	Solution    *AllocationSolution `json:"solution" yaml:"solution"`       // projected solution
+}
+
+// Request to check the feasibility of a server with a load and SLO targets, before optimizing
+type FeasibilityRequest struct {
+	Server ServerSpec      `json:"server" yaml:"server"`                     // server spec
+	Load   *ServerLoadSpec `json:"load,omitempty" yaml:"load,omitempty"`     // load of the server, overriding the load of its current allocation (optional)
+	Target *ModelTarget    `json:"target,omitempty" yaml:"target,omitempty"` // SLO targets of the server, overriding the targets of its service class for its model (optional)
+}
+
+// Feasibility of a server: its best allocation across accelerators, and reasons of infeasible accelerators
+type FeasibilityResult struct {
+	Feasible   bool                     `json:"feasible" yaml:"feasible"`                 // feasible on at least one accelerator
+	Best       *CandidateAllocationData `json:"best,omitempty" yaml:"best,omitempty"`     // allocation with the least value (nil if not feasible)
+	Reason     string                   `json:"reason,omitempty" yaml:"reason,omitempty"` // summary of reasons if not feasible
+	Rejections map[string]string        `json:"rejections" yaml:"rejections"`             // reasons of infeasible allocations, by accelerator name
+	Warnings   []string                 `json:"warnings" yaml:"warnings"`                 // e.g. candidate accelerators with perf data poorly fitted to benchmarks
+}
+
+// Request for the sensitivity of the cost of servers to their SLO targets
+type SensitivityRequest struct {
+	Server string  `json:"server" yaml:"server"` // server name (all servers if empty)
+	Delta  float32 `json:"delta" yaml:"delta"`   // relative perturbation of each SLO target, e.g. 0.1 for 10% (default if zero)
+}
+
+// Sensitivity of the cost of servers to their SLO targets, and of the total cost of the fleet of servers
+type SensitivityResult struct {
+	Delta   float32             `json:"delta" yaml:"delta"`     // relative perturbation of SLO targets requested
+	Servers []ServerSensitivity `json:"servers" yaml:"servers"` // sensitivity of each server, by name
+	Fleet   FleetSensitivity    `json:"fleet" yaml:"fleet"`     // sensitivity of the total cost of the servers
+}
+
+// Sensitivity of the cost of the best allocation of a server to its SLO targets
+type ServerSensitivity struct {
+	Server   string           `json:"server" yaml:"server"`                     // server name
+	Feasible bool             `json:"feasible" yaml:"feasible"`                 // feasible at its current targets (no sensitivity otherwise)
+	Reason   string           `json:"reason,omitempty" yaml:"reason,omitempty"` // summary of reasons if not feasible
+	Cost     float32          `json:"cost" yaml:"cost"`                         // cost of the best allocation at the current targets
+	SLOs     []SLOSensitivity `json:"slos" yaml:"slos"`                         // sensitivity to each SLO target considered (non-zero)
+}
+
+// Sensitivity of the cost of the best allocation of a server to an SLO target
+type SLOSensitivity struct {
+	Name      string          `json:"name" yaml:"name"`           // SLO name, e.g. slo-itl
+	Target    float32         `json:"target" yaml:"target"`       // current target
+	Relaxed   SLOPerturbation `json:"relaxed" yaml:"relaxed"`     // target relaxed (larger latency, smaller throughput)
+	Tightened SLOPerturbation `json:"tightened" yaml:"tightened"` // target tightened (smaller latency, larger throughput)
+	Gradient  float32         `json:"gradient" yaml:"gradient"`   // change of cost per unit increase of the target (msec or tokens/sec), zero if not feasible when perturbed
+}
+
+// Cost of the best allocation of a server with a perturbed SLO target
+type SLOPerturbation struct {
+	Target     float32 `json:"target" yaml:"target"`                     // perturbed target
+	Feasible   bool    `json:"feasible" yaml:"feasible"`                 // feasible at the perturbed target
+	Bounded    bool    `json:"bounded" yaml:"bounded"`                   // perturbation reduced to stay feasible, as the requested one is not
+	Reason     string  `json:"reason,omitempty" yaml:"reason,omitempty"` // reason the requested perturbation is not feasible
+	Cost       float32 `json:"cost" yaml:"cost"`                         // cost of the best allocation (zero if not feasible)
+	CostChange float32 `json:"costChange" yaml:"costChange"`             // cost change from the current targets (zero if not feasible)
+}
+
+// Sensitivity of the total cost of the fleet of servers to their SLO targets
+type FleetSensitivity struct {
+	Cost float32               `json:"cost" yaml:"cost"` // total cost of the best allocations of feasible servers at their current targets
+	SLOs []FleetSLOSensitivity `json:"slos" yaml:"slos"` // sensitivity to each SLO
+}
+
+// Sensitivity of the total cost of the fleet of servers to an SLO, perturbing the targets of all servers
+type FleetSLOSensitivity struct {
+	Name                string   `json:"name" yaml:"name"`                               // SLO name, e.g. slo-itl
+	RelaxedCostChange   float32  `json:"relaxedCostChange" yaml:"relaxedCostChange"`     // total cost change of servers feasible when relaxed
+	TightenedCostChange float32  `json:"tightenedCostChange" yaml:"tightenedCostChange"` // total cost change of servers feasible when tightened
+	Infeasible          []string `json:"infeasible" yaml:"infeasible"`                   // servers not feasible at the requested perturbation
+}
+
+// Utilization of an accelerator type by the allocations of a solution
+type AcceleratorUtilization struct {
+	Type        string  `json:"type" yaml:"type"`               // name of accelerator type
+	Capacity    int     `json:"capacity" yaml:"capacity"`       // number of capacity units (devices or partition slices)
+	Allocated   int     `json:"allocated" yaml:"allocated"`     // number of units allocated to servers
+	Free        int     `json:"free" yaml:"free"`               // number of units not allocated (negative if over capacity)
+	Utilization float32 `json:"utilization" yaml:"utilization"` // allocated units over capacity (percent, zero if no capacity)
+
+	Power         float32 `json:"power" yaml:"power"`                 // power consumption of allocations of this type (Watts)
+	MaxPower      float32 `json:"maxPower" yaml:"maxPower"`           // power budget of this type (Watts, zero if none)
+	PowerHeadroom float32 `json:"powerHeadroom" yaml:"powerHeadroom"` // power budget not consumed (Watts, negative if over budget, zero if no budget)
 }
 
 // Data related to Optimizer
 type OptimizerData struct {
-	Spec OptimizerSpec `json:"optimizer"`
+	Spec OptimizerSpec `json:"optimizer" yaml:"optimizer"`
 }
 
 // Specifications for optimizer data
 type OptimizerSpec struct {
-	Unlimited         bool   `json:"unlimited"`         // unlimited number of accelerator types (for capacity planning and/or cloud)
-	Heterogeneous     bool   `json:"heterogeneous"`     // heterogeneous accelerators assigned to same inference server
-	MILPSolver        bool   `json:"milpSolver"`        // use MILP solver to optimize
-	UseCplex          bool   `json:"useCplex"`          // use CPLEX solver for MILP problem
-	DelayedBestEffort bool   `json:"delayedBestEffort"` // delay best effort allocation after attempting allocation to all priority groups
-	SaturationPolicy  string `json:"saturationPolicy"`  // allocation policy under saturated condition
+	Unlimited              bool    `json:"unlimited" yaml:"unlimited"`                           // unlimited number of accelerator types (for capacity planning and/or cloud)
+	Heterogeneous          bool    `json:"heterogeneous" yaml:"heterogeneous"`                   // heterogeneous accelerators assigned to same inference server
+	Algorithm              string  `json:"algorithm" yaml:"algorithm"`                           // name of algorithm solving allocation problem (greedy or milp)
+	MILPSolver             bool    `json:"milpSolver" yaml:"milpSolver"`                         // use MILP solver to optimize
+	UseCplex               bool    `json:"useCplex" yaml:"useCplex"`                             // use CPLEX solver for MILP problem
+	MILPTimeBudget         int     `json:"milpTimeBudget" yaml:"milpTimeBudget"`                 // time budget of MILP solver before falling back to greedy (msec)
+	PostOptimize           bool    `json:"postOptimize" yaml:"postOptimize"`                     // improve solution by local search after solving
+	ChurnBudget            float32 `json:"churnBudget" yaml:"churnBudget"`                       // fraction of total cost allowed to increase to keep current accelerators when re-optimizing
+	DelayedBestEffort      bool    `json:"delayedBestEffort" yaml:"delayedBestEffort"`           // delay best effort allocation after attempting allocation to all priority groups
+	AllowMixedAccelerators bool    `json:"allowMixedAccelerators" yaml:"allowMixedAccelerators"` // mix replicas on two accelerator types for a server if no single type fits (greedy)
+	SaturationPolicy       string  `json:"saturationPolicy" yaml:"saturationPolicy"`             // allocation policy under saturated condition
+	RoundRobinStep         string  `json:"roundRobinStep" yaml:"roundRobinStep"`                 // step of round-robin allocation policies under saturated condition: equal or deficit
+	OptimizeForPeak        bool    `json:"optimizeForPeak" yaml:"optimizeForPeak"`               // size servers with a load profile for their peak window load
+	ScaleToZero            bool    `json:"scaleToZero" yaml:"scaleToZero"`                       // give no allocation to servers with zero load, rather than their minimum number of replicas
+	AllowEstimatedPerf     bool    `json:"allowEstimatedPerf" yaml:"allowEstimatedPerf"`         // estimate missing perf data of models from other accelerators, given their relative throughput
+	TieBreak               string  `json:"tieBreak" yaml:"tieBreak"`                             // secondary ordering key of servers with the same priority (greedy)
+	MaxThroughput          bool    `json:"maxThroughput" yaml:"maxThroughput"`                   // maximize priority-weighted served throughput, rather than minimize cost
+	MaxTotalCost           float32 `json:"maxTotalCost" yaml:"maxTotalCost"`                     // hard budget on total cost, maximizing served throughput within it (zero if none)
+	AdmissionControl       bool    `json:"admissionControl" yaml:"admissionControl"`             // admit servers whole in priority order within capacity and cost budget, shedding others
+	MaxTotalPowerWatts     float32 `json:"maxTotalPowerWatts" yaml:"maxTotalPowerWatts"`         // power budget of all allocations, in addition to budgets of accelerator types (Watts, zero if none)
+	SpotPolicy             string  `json:"spotPolicy" yaml:"spotPolicy"`                         // placement of servers on interruptible accelerators
+	CriticalPriority       int     `json:"criticalPriority" yaml:"criticalPriority"`             // priority threshold of critical servers for the spot policy (default if zero)
+	ValueFunction          string  `json:"valueFunction" yaml:"valueFunction"`                   // name of function evaluating the value of an allocation
+	Objective              string  `json:"objective" yaml:"objective"`                           // metric of an allocation minimized by the value function (cost, power, consolidate, or max-headroom)
+	ConsolidationTolerance float32 `json:"consolidationTolerance" yaml:"consolidationTolerance"` // fraction of total cost allowed to increase to use fewer accelerator types (default if zero)
+	HeadroomTolerance      float32 `json:"headroomTolerance" yaml:"headroomTolerance"`           // fraction of the least cost of a server within which re-allocating prefers more rate headroom (zero if strict cost)
+	Seed                   int64   `json:"seed" yaml:"seed"`                                     // seed of the random number generator of the solver, the same seed and inputs yield the same solution
+	Debug                  bool    `json:"debug" yaml:"debug"`                                   // record decisions of the greedy algorithm in the solution metadata
 }
@@ -0,0 +1,140 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Units of the spec and of the analysis of queues
+//   - times are in msec, throughput in tokens/sec, and arrival rates of servers in req/min (spec)
+//   - queues are analyzed with request rates in req/sec, and solved with request rates in req/msec
+const (
+	MsecPerSec = 1000
+	SecPerMin  = 60
+	MinPerHour = 60
+)
+
+// Request rate in req/sec given a rate in req/min
+func PerMinToPerSec(rate float32) float32 {
+	return rate / SecPerMin
+}
+
+// Request rate in req/min given a rate in req/sec
+func PerSecToPerMin(rate float32) float32 {
+	return rate * SecPerMin
+}
+
+// Request rate in req/msec given a rate in req/min
+func PerMinToPerMsec(rate float32) float32 {
+	return rate / SecPerMin / MsecPerSec
+}
+
+// Request rate in req/min given a rate in req/msec
+func PerMsecToPerMin(rate float32) float32 {
+	return rate * MsecPerSec * SecPerMin
+}
+
+// Request rate in req/msec given a rate in req/sec
+func PerSecToPerMsec(rate float32) float32 {
+	return rate / MsecPerSec
+}
+
+// Request rate in req/sec given a rate in req/msec
+func PerMsecToPerSec(rate float32) float32 {
+	return rate * MsecPerSec
+}
+
+// Time in msec, given in the spec as a number (msec) or as a string with a unit, e.g. "40ms" or "1.5s"
+type Msec float32
+
+// Factors converting times to msec, by unit
+var msecUnits = map[string]float32{
+	"us":   1.0 / MsecPerSec,
+	"ms":   1,
+	"msec": 1,
+	"s":    MsecPerSec,
+	"sec":  MsecPerSec,
+	"min":  SecPerMin * MsecPerSec,
+}
+
+func (m *Msec) UnmarshalJSON(data []byte) error {
+	v, err := unmarshalQuantityJSON(data, msecUnits)
+	*m = Msec(v)
+	return err
+}
+
+func (m *Msec) UnmarshalYAML(node *yaml.Node) error {
+	v, err := parseQuantity(node.Value, msecUnits)
+	*m = Msec(v)
+	return err
+}
+
+// Throughput in tokens/sec, given in the spec as a number (tokens/sec) or as a string with a unit,
+// e.g. "500 tokens/s" or "30000 tokens/min"
+type TokensPerSec float32
+
+// Factors converting throughput to tokens/sec, by unit
+var tokensPerSecUnits = map[string]float32{
+	"tokens/ms":  MsecPerSec,
+	"tokens/s":   1,
+	"tokens/sec": 1,
+	"tokens/min": 1.0 / SecPerMin,
+}
+
+func (t *TokensPerSec) UnmarshalJSON(data []byte) error {
+	v, err := unmarshalQuantityJSON(data, tokensPerSecUnits)
+	*t = TokensPerSec(v)
+	return err
+}
+
+func (t *TokensPerSec) UnmarshalYAML(node *yaml.Node) error {
+	v, err := parseQuantity(node.Value, tokensPerSecUnits)
+	*t = TokensPerSec(v)
+	return err
+}
+
+// check if a type is a quantity given as a number or as a string with a unit
+func isQuantityType(t reflect.Type) bool {
+	return t == reflect.TypeFor[Msec]() || t == reflect.TypeFor[TokensPerSec]()
+}
+
+// unmarshal a quantity in JSON, a number in the internal unit or a string with a unit, given factors of units
+func unmarshalQuantityJSON(data []byte, units map[string]float32) (float32, error) {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		var v float32
+		err := json.Unmarshal(data, &v)
+		return v, err
+	}
+	return parseQuantity(s, units)
+}
+
+// parse a quantity, a number in the internal unit optionally followed by a unit (space separated or not),
+// given factors converting units to the internal unit
+func parseQuantity(s string, units map[string]float32) (float32, error) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return !strings.ContainsRune("0123456789.+-eE", r)
+	})
+	number, unit := s, ""
+	if i >= 0 {
+		number, unit = s[:i], strings.TrimSpace(s[i:])
+	}
+	v, err := strconv.ParseFloat(number, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid quantity %q: %w", s, err)
+	}
+	if unit == "" {
+		return float32(v), nil
+	}
+	factor, exists := units[unit]
+	if !exists {
+		return 0, fmt.Errorf("invalid quantity %q: unknown unit %s", s, unit)
+	}
+	return float32(v) * factor, nil
+}
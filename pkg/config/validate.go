@@ -0,0 +1,475 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// Factor scaling service times for the communication overhead of tensor parallelism
+//   - one plus the scaling per additional degree beyond the first (one if no tensor parallelism)
+func (d *ModelAcceleratorPerfData) TPFactor() float32 {
+	if d.TPDegree <= 1 {
+		return 1
+	}
+	return 1 + d.TPScaling*float32(d.TPDegree-1)
+}
+
+// Validate performance data of a model on an accelerator, as used by the queueing model
+//   - decode time must be positive and non-decreasing in the batch size
+//   - prefill time must be non-negative and non-decreasing in the number of tokens
+func (d *ModelAcceleratorPerfData) Validate() error {
+	var errs []error
+	if d.DecodeParms.Alpha <= 0 {
+		errs = append(errs, fmt.Errorf("alpha=%v must be positive", d.DecodeParms.Alpha))
+	}
+	if d.DecodeParms.Beta < 0 {
+		errs = append(errs, fmt.Errorf("beta=%v must be non-negative", d.DecodeParms.Beta))
+	}
+	if d.PrefillParms.Gamma < 0 {
+		errs = append(errs, fmt.Errorf("gamma=%v must be non-negative", d.PrefillParms.Gamma))
+	}
+	if d.PrefillParms.Delta < 0 {
+		errs = append(errs, fmt.Errorf("delta=%v must be non-negative", d.PrefillParms.Delta))
+	}
+	if d.TPDegree < 0 {
+		errs = append(errs, fmt.Errorf("tpDegree=%d must be non-negative", d.TPDegree))
+	}
+	if d.TPScaling < 0 {
+		errs = append(errs, fmt.Errorf("tpScaling=%v must be non-negative", d.TPScaling))
+	}
+	if d.UnitCost < 0 {
+		errs = append(errs, fmt.Errorf("unitCost=%v must be non-negative", d.UnitCost))
+	}
+	if d.WarmupSeconds < 0 {
+		errs = append(errs, fmt.Errorf("warmupSeconds=%v must be non-negative", d.WarmupSeconds))
+	}
+	if d.SharedCost < 0 || d.SharedCost > 1 {
+		errs = append(errs, fmt.Errorf("sharedCost=%v must be in [0, 1]", d.SharedCost))
+	}
+	if d.MaxBatchSize <= 0 {
+		errs = append(errs, fmt.Errorf("maxBatchSize=%d must be positive", d.MaxBatchSize))
+	}
+	if d.AtTokens <= 0 {
+		errs = append(errs, fmt.Errorf("atTokens=%d must be positive", d.AtTokens))
+	}
+	if d.BatchWindow < 0 {
+		errs = append(errs, fmt.Errorf("batchWindow=%v must be non-negative", d.BatchWindow))
+	}
+	if d.FitRSquared < 0 || d.FitRSquared > 1 {
+		errs = append(errs, fmt.Errorf("fitRSquared=%v must be in [0, 1]", d.FitRSquared))
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("invalid perf data for model %s on accelerator %s: %w", d.Name, d.Acc, err)
+	}
+	return nil
+}
+
+// Validate an accelerator spec
+func (d *AcceleratorSpec) Validate() error {
+	var errs []error
+	if d.Name == "" {
+		errs = append(errs, errors.New("name must not be empty"))
+	}
+	if d.Type == "" {
+		errs = append(errs, errors.New("type must not be empty"))
+	}
+	if d.Multiplicity < 0 {
+		errs = append(errs, fmt.Errorf("multiplicity=%d must be non-negative", d.Multiplicity))
+	}
+	if d.Slices < 0 {
+		errs = append(errs, fmt.Errorf("slices=%d must be non-negative", d.Slices))
+	}
+	if d.Cost < 0 {
+		errs = append(errs, fmt.Errorf("cost=%v must be non-negative", d.Cost))
+	}
+	if d.CostPeriod != "" && CostPeriodEnum(d.CostPeriod).String() != d.CostPeriod {
+		errs = append(errs, fmt.Errorf("costPeriod=%s must be one of hour, month", d.CostPeriod))
+	}
+	if d.ReclaimRisk < 0 || d.ReclaimRisk > 1 {
+		errs = append(errs, fmt.Errorf("reclaimRisk=%v must be in [0, 1]", d.ReclaimRisk))
+	}
+	if d.WarmupSeconds < 0 {
+		errs = append(errs, fmt.Errorf("warmupSeconds=%v must be non-negative", d.WarmupSeconds))
+	}
+	if d.RelativeThroughput < 0 {
+		errs = append(errs, fmt.Errorf("relativeThroughput=%v must be non-negative", d.RelativeThroughput))
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("invalid accelerator %s: %w", d.Name, err)
+	}
+	return nil
+}
+
+// Validate accelerator data
+//   - accelerators with a currency must all have the same currency, as their costs are normalized to the same period only
+func (d *AcceleratorData) Validate() error {
+	errs := make([]error, len(d.Spec))
+	for i := range d.Spec {
+		errs[i] = d.Spec[i].Validate()
+	}
+	if err := CheckCurrencies(d.Spec...); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// Check that accelerators with a currency all have the same currency
+func CheckCurrencies(specs ...AcceleratorSpec) error {
+	currency, name := "", ""
+	for _, spec := range specs {
+		if spec.Currency == "" {
+			continue
+		}
+		if currency == "" {
+			currency, name = spec.Currency, spec.Name
+		} else if spec.Currency != currency {
+			return fmt.Errorf("currency=%s of accelerator %s differs from currency=%s of accelerator %s",
+				spec.Currency, spec.Name, currency, name)
+		}
+	}
+	return nil
+}
+
+// Validate a count of an accelerator type
+func (d *AcceleratorCount) Validate() error {
+	var errs []error
+	if d.Type == "" {
+		errs = append(errs, errors.New("type must not be empty"))
+	}
+	if d.Count < 0 {
+		errs = append(errs, fmt.Errorf("count=%d must be non-negative", d.Count))
+	}
+	if d.Partitions < 0 {
+		errs = append(errs, fmt.Errorf("partitions=%d must be non-negative", d.Partitions))
+	}
+	if d.MaxPowerWatts < 0 {
+		errs = append(errs, fmt.Errorf("maxPowerWatts=%v must be non-negative", d.MaxPowerWatts))
+	}
+	if d.UnitsPerNode < 0 {
+		errs = append(errs, fmt.Errorf("unitsPerNode=%d must be non-negative", d.UnitsPerNode))
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("invalid capacity of type %s: %w", d.Type, err)
+	}
+	return nil
+}
+
+// Validate capacity data
+func (d *CapacityData) Validate() error {
+	errs := make([]error, len(d.Count))
+	for i := range d.Count {
+		errs[i] = d.Count[i].Validate()
+	}
+	return errors.Join(errs...)
+}
+
+// Validate model data
+func (d *ModelData) Validate() error {
+	errs := make([]error, len(d.PerfData))
+	for i := range d.PerfData {
+		errs[i] = d.PerfData[i].Validate()
+	}
+	return errors.Join(errs...)
+}
+
+// Validate SLO targets of a model
+func (d *ModelTarget) Validate() error {
+	var errs []error
+	if d.Model == "" {
+		errs = append(errs, errors.New("model must not be empty"))
+	}
+	errs = append(errs, d.validateSLOs())
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("invalid target for model %s: %w", d.Model, err)
+	}
+	return nil
+}
+
+// Validate SLO targets, regardless of the model
+func (d *ModelTarget) validateSLOs() error {
+	var errs []error
+	for _, slo := range []struct {
+		name  string
+		value float32
+	}{
+		{"slo-itl", float32(d.SLO_ITL)},
+		{"slo-ttft", float32(d.SLO_TTFT)},
+		{"slo-tps", float32(d.SLO_TPS)},
+		{"slo-itl-p99", float32(d.SLO_ITL_P99)},
+		{"slo-ttft-p99", float32(d.SLO_TTFT_P99)},
+		{"slo-wait-fraction", d.SLO_WaitFraction},
+	} {
+		if slo.value < 0 {
+			errs = append(errs, fmt.Errorf("%s=%v must be non-negative", slo.name, slo.value))
+		}
+	}
+	if d.SLO_MaxDropRate < 0 || d.SLO_MaxDropRate >= 1 {
+		errs = append(errs, fmt.Errorf("slo-max-drop-rate=%v must be in [0, 1)", d.SLO_MaxDropRate))
+	}
+	return errors.Join(errs...)
+}
+
+// Validate a service class spec
+func (d *ServiceClassSpec) Validate() error {
+	var errs []error
+	if d.Name == "" {
+		errs = append(errs, errors.New("name must not be empty"))
+	}
+	if d.Weight < 0 {
+		errs = append(errs, fmt.Errorf("weight=%v must be non-negative", d.Weight))
+	}
+	for i := range d.ModelTargets {
+		errs = append(errs, d.ModelTargets[i].Validate())
+	}
+	if d.DefaultTarget != nil {
+		if err := d.DefaultTarget.validateSLOs(); err != nil {
+			errs = append(errs, fmt.Errorf("invalid default target: %w", err))
+		}
+	}
+	for _, typeName := range slices.Sorted(maps.Keys(d.Reservations)) {
+		if count := d.Reservations[typeName]; count < 0 {
+			errs = append(errs, fmt.Errorf("reservation=%d of type %s must be non-negative", count, typeName))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("invalid service class %s: %w", d.Name, err)
+	}
+	return nil
+}
+
+// Validate service class data
+func (d *ServiceClassData) Validate() error {
+	errs := make([]error, len(d.Spec))
+	for i := range d.Spec {
+		errs[i] = d.Spec[i].Validate()
+	}
+	return errors.Join(errs...)
+}
+
+// Validate server load statistics
+func (d *ServerLoadSpec) Validate() error {
+	var errs []error
+	if d.ArrivalRate < 0 {
+		errs = append(errs, fmt.Errorf("arrivalRate=%v must be non-negative", d.ArrivalRate))
+	}
+	if d.AvgInTokens < 0 {
+		errs = append(errs, fmt.Errorf("avgInTokens=%d must be non-negative", d.AvgInTokens))
+	}
+	if d.AvgOutTokens < 0 {
+		errs = append(errs, fmt.Errorf("avgOutTokens=%d must be non-negative", d.AvgOutTokens))
+	}
+	if d.ArrivalCOV < 0 {
+		errs = append(errs, fmt.Errorf("arrivalCOV=%v must be non-negative", d.ArrivalCOV))
+	}
+	if d.ServiceCOV < 0 {
+		errs = append(errs, fmt.Errorf("serviceCOV=%v must be non-negative", d.ServiceCOV))
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("invalid load: %w", err)
+	}
+	return nil
+}
+
+// Validate a server spec
+func (d *ServerSpec) Validate() error {
+	var errs []error
+	if d.Name == "" {
+		errs = append(errs, errors.New("name must not be empty"))
+	}
+	if d.Model == "" {
+		errs = append(errs, errors.New("model must not be empty"))
+	}
+	if d.MinNumReplicas < 0 {
+		errs = append(errs, fmt.Errorf("minNumReplicas=%d must be non-negative", d.MinNumReplicas))
+	}
+	if d.WarmReplicas < 0 {
+		errs = append(errs, fmt.Errorf("warmReplicas=%d must be non-negative", d.WarmReplicas))
+	}
+	if d.MaxNumReplicas < 0 {
+		errs = append(errs, fmt.Errorf("maxNumReplicas=%d must be non-negative", d.MaxNumReplicas))
+	}
+	if d.ShardFactor < 0 {
+		errs = append(errs, fmt.Errorf("shardFactor=%d must be non-negative", d.ShardFactor))
+	}
+	if d.MaxBatchSize < 0 {
+		errs = append(errs, fmt.Errorf("maxBatchSize=%d must be non-negative", d.MaxBatchSize))
+	}
+	errs = append(errs, d.CurrentAlloc.Load.Validate())
+	for i := range d.LoadProfile {
+		if d.LoadProfile[i].Window == "" {
+			errs = append(errs, errors.New("window of load profile must not be empty"))
+		}
+		errs = append(errs, d.LoadProfile[i].Load.Validate())
+	}
+	adapterNames := make(map[string]bool)
+	for i := range d.Adapters {
+		name := d.Adapters[i].Name
+		if name == "" {
+			errs = append(errs, errors.New("name of adapter must not be empty"))
+		} else if adapterNames[name] {
+			errs = append(errs, fmt.Errorf("duplicate adapter %s", name))
+		}
+		adapterNames[name] = true
+		errs = append(errs, d.Adapters[i].Load.Validate())
+	}
+	if d.LowPriority != nil {
+		if d.LowPriority.Class == "" {
+			errs = append(errs, errors.New("class of low priority stream must not be empty"))
+		}
+		errs = append(errs, d.LowPriority.Load.Validate())
+	}
+	if pinned := d.PinnedAllocation; pinned != nil {
+		if pinned.Accelerator == "" {
+			errs = append(errs, errors.New("accelerator of pinned allocation must not be empty"))
+		}
+		if pinned.NumReplicas <= 0 {
+			errs = append(errs, fmt.Errorf("numReplicas=%d of pinned allocation must be positive", pinned.NumReplicas))
+		}
+		if pinned.MaxBatch < 0 {
+			errs = append(errs, fmt.Errorf("maxBatch=%d of pinned allocation must be non-negative", pinned.MaxBatch))
+		}
+		if pinned.Secondary != nil {
+			errs = append(errs, errors.New("pinned allocation must not be mixed"))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("invalid server %s: %w", d.Name, err)
+	}
+	return nil
+}
+
+// Validate server data
+func (d *ServerData) Validate() error {
+	errs := make([]error, len(d.Spec))
+	for i := range d.Spec {
+		errs[i] = d.Spec[i].Validate()
+	}
+	return errors.Join(errs...)
+}
+
+// Validate an optimizer spec
+func (d *OptimizerSpec) Validate() error {
+	var errs []error
+	if d.MILPTimeBudget < 0 {
+		errs = append(errs, fmt.Errorf("milpTimeBudget=%d must be non-negative", d.MILPTimeBudget))
+	}
+	if d.ChurnBudget < 0 {
+		errs = append(errs, fmt.Errorf("churnBudget=%v must be non-negative", d.ChurnBudget))
+	}
+	if d.ConsolidationTolerance < 0 {
+		errs = append(errs, fmt.Errorf("consolidationTolerance=%v must be non-negative", d.ConsolidationTolerance))
+	}
+	if d.HeadroomTolerance < 0 {
+		errs = append(errs, fmt.Errorf("headroomTolerance=%v must be non-negative", d.HeadroomTolerance))
+	}
+	if d.MaxTotalCost < 0 {
+		errs = append(errs, fmt.Errorf("maxTotalCost=%v must be non-negative", d.MaxTotalCost))
+	}
+	if d.MaxTotalCost > 0 && d.Unlimited {
+		errs = append(errs, errors.New("maxTotalCost is not supported with unlimited"))
+	}
+	if d.MaxTotalCost > 0 && !d.AdmissionControl && (d.MILPSolver || AlgorithmEnum(d.Algorithm) == MILP) {
+		errs = append(errs, errors.New("maxTotalCost is not supported with the MILP solver"))
+	}
+	if d.MaxTotalPowerWatts < 0 {
+		errs = append(errs, fmt.Errorf("maxTotalPowerWatts=%v must be non-negative", d.MaxTotalPowerWatts))
+	}
+	if d.CriticalPriority < 0 {
+		errs = append(errs, fmt.Errorf("criticalPriority=%d must be non-negative", d.CriticalPriority))
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("invalid optimizer spec: %w", err)
+	}
+	return nil
+}
+
+// Validate all data of a system
+func (d *SystemSpec) Validate() error {
+	return errors.Join(
+		d.Accelerators.Validate(),
+		d.Models.Validate(),
+		d.ServiceClasses.Validate(),
+		d.Servers.Validate(),
+		d.Optimizer.Spec.Validate(),
+		d.Capacity.Validate(),
+	)
+}
+
+// Validate all data of a system
+func (d *SystemData) Validate() error {
+	return d.Spec.Validate()
+}
+
+// Validate changes to a system
+func (d *SystemDelta) Validate() error {
+	var errs []error
+	for _, update := range d.Loads {
+		if update.Name == "" {
+			errs = append(errs, errors.New("name of server of load must not be empty"))
+		}
+		errs = append(errs, update.Load.Validate())
+	}
+	for i := range d.AddServers {
+		errs = append(errs, d.AddServers[i].Validate())
+	}
+	for _, name := range d.RemoveServers {
+		if name == "" {
+			errs = append(errs, errors.New("name of removed server must not be empty"))
+		}
+	}
+	if d.Optimizer != nil {
+		errs = append(errs, d.Optimizer.Validate())
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("invalid system delta: %w", err)
+	}
+	return nil
+}
+
+// Validate a feasibility request
+//   - the model of the target defaults to the model of the server
+func (d *FeasibilityRequest) Validate() error {
+	errs := []error{d.Server.Validate()}
+	if d.Load != nil {
+		errs = append(errs, d.Load.Validate())
+	}
+	if d.Target != nil {
+		target := *d.Target
+		target.Model = d.Server.Model
+		errs = append(errs, target.Validate())
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("invalid feasibility request: %w", err)
+	}
+	return nil
+}
+
+// Validate a sensitivity request
+func (d *SensitivityRequest) Validate() error {
+	if d.Delta < 0 || d.Delta > MaxSensitivityDelta {
+		return fmt.Errorf("invalid sensitivity request: delta=%v must be in [0, %v]", d.Delta, MaxSensitivityDelta)
+	}
+	return nil
+}
+
+// Validate a forecast request
+func (d *ForecastRequest) Validate() error {
+	var errs []error
+	if d.Factor < 0 {
+		errs = append(errs, fmt.Errorf("factor=%v must be non-negative", d.Factor))
+	}
+	for _, name := range slices.Sorted(maps.Keys(d.Factors)) {
+		if d.Factors[name] < 0 {
+			errs = append(errs, fmt.Errorf("factor=%v of server %s must be non-negative", d.Factors[name], name))
+		}
+	}
+	if d.Optimizer != nil {
+		errs = append(errs, d.Optimizer.Validate())
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("invalid forecast request: %w", err)
+	}
+	return nil
+}
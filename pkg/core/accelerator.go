@@ -8,6 +8,7 @@ import (
 
 // An accelerator used in an inference server
 //   - full or multiple GPU units (cards)
+//   - partitions (slices) of GPU units, for accelerator types partitioned MIG-style
 type Accelerator struct {
 	name string
 	spec *config.AcceleratorSpec
@@ -27,12 +28,19 @@ func NewAcceleratorFromSpec(spec *config.AcceleratorSpec) *Accelerator {
 
 // Calculate basic parameters
 func (g *Accelerator) Calculate() {
+	if !g.hasMidPoint() {
+		return
+	}
 	g.slopeLow = float32(g.spec.Power.MidPower-g.spec.Power.Idle) / g.spec.Power.MidUtil
 	g.slopeHigh = float32(g.spec.Power.Full-g.spec.Power.MidPower) / (1 - g.spec.Power.MidUtil)
 }
 
 // Evaluate power consumption at a given utilization
 func (g *Accelerator) Power(util float32) float32 {
+	if !g.hasMidPoint() {
+		// linear profile between idle and full power
+		return float32(g.spec.Power.Idle) + float32(g.spec.Power.Full-g.spec.Power.Idle)*util
+	}
 	if util <= g.spec.Power.MidUtil {
 		return float32(g.spec.Power.Idle) + g.slopeLow*util
 	} else {
@@ -40,6 +48,11 @@ func (g *Accelerator) Power(util float32) float32 {
 	}
 }
 
+// check if power profile has a valid inflection point
+func (g *Accelerator) hasMidPoint() bool {
+	return g.spec.Power.MidUtil > 0 && g.spec.Power.MidUtil < 1
+}
+
 func (g *Accelerator) Name() string {
 	return g.name
 }
@@ -52,14 +65,61 @@ func (g *Accelerator) Type() string {
 	return g.spec.Type
 }
 
+// Cost of the accelerator per hour, normalized from the cost period of its spec
 func (g *Accelerator) Cost() float32 {
-	return g.spec.Cost
+	return g.hourlyCost(g.spec.Cost)
+}
+
+// Normalize a cost in the cost period of the accelerator to a cost per hour
+func (g *Accelerator) hourlyCost(cost float32) float32 {
+	return cost / config.CostPeriodEnum(g.spec.CostPeriod).Hours()
+}
+
+// Currency unit of the cost of the accelerator (empty if unspecified)
+func (g *Accelerator) Currency() string {
+	return g.spec.Currency
+}
+
+// Check if the accelerator is preemptible (spot) capacity, which may be reclaimed
+func (g *Accelerator) Interruptible() bool {
+	return g.spec.Interruptible
+}
+
+// Check if the accelerator is draining (deprecated): not a candidate for new allocations
+func (g *Accelerator) Draining() bool {
+	return g.spec.Draining
+}
+
+func (g *Accelerator) ReclaimRisk() float32 {
+	return g.spec.ReclaimRisk
+}
+
+// Time for a new replica on the accelerator to become ready (sec)
+func (g *Accelerator) WarmupSeconds() float32 {
+	return g.spec.WarmupSeconds
+}
+
+// Throughput of the accelerator relative to other accelerators, to estimate missing perf data (zero if unknown)
+func (g *Accelerator) RelativeThroughput() float32 {
+	return g.spec.RelativeThroughput
 }
 
 func (g *Accelerator) Multiplicity() int {
 	return g.spec.Multiplicity
 }
 
+// Number of capacity units of its type used by the accelerator, given the number of slices per device of its type
+//   - a unit is a partition slice of a device for partitioned types, a device otherwise
+//   - whole-device and partition accelerators of a type contend for the same pool of slices,
+//     a whole device using all its slices (placement of partitions on devices is not considered)
+func (g *Accelerator) Units(slicesPerDevice int) int {
+	slices := g.spec.Slices
+	if slices <= 0 {
+		slices = slicesPerDevice
+	}
+	return g.spec.Multiplicity * slices
+}
+
 func (g *Accelerator) MemSize() int {
 	return g.spec.MemSize
 }
@@ -2,8 +2,12 @@ package core
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"maps"
 	"math"
+	"slices"
+	"sync"
 
 	"github.com/llm-inferno/optimizer/pkg/analyzer"
 	"github.com/llm-inferno/optimizer/pkg/config"
@@ -11,158 +15,737 @@ import (
 
 // Allocation details of an accelerator to a server
 type Allocation struct {
-	accelerator string  // name of accelerator
-	numReplicas int     // number of server replicas
-	batchSize   int     // max batch size
-	cost        float32 // cost of this allocation
-	value       float32 // value of this allocation
-	itl         float32 // expected average token decode time (msec)
-	ttft        float32 // expected average request queueing and prefill times (msec)
-	rho         float32 // average concurrently running requests / max batch size
+	accelerator string           // name of accelerator
+	numReplicas int              // number of server replicas
+	batchSize   int              // max batch size
+	cost        float32          // cost of this allocation
+	power       float32          // power consumption of this allocation (Watts)
+	objective   config.Objective // metric of this allocation minimized by its value
+	value       float32          // value of this allocation
+	itl         float32          // expected average token decode time (msec)
+	ttft        float32          // expected average request batch-formation, queueing and prefill times (msec)
+	rho         float32          // average concurrently running requests / max batch size
+	itlP99      float32          // expected tail percentile token decode time (msec)
+	ttftP99     float32          // expected tail percentile request batch-formation, queueing and prefill times (msec)
+	dropRate    float32          // expected fraction of requests rejected as the queue is full
+
+	// expected latencies of low priority requests, queued behind requests of the server (nil if no low priority stream)
+	low *config.StreamLatencyData
 
 	maxArrvRatePerReplica float32 // maximum arrival rate per replica (req/msec)
-}
+	maxRatePerReplica     float32 // maximum arrival rate per replica regardless of SLOs, i.e. stability limit (req/msec)
 
-// Create an allocation of an accelerator to a server; nil if not feasible
-func CreateAllocation(serverName string, gName string) *Allocation {
-	var (
-		acc *Accelerator
+	requestedReplicas int // number of replicas required to satisfy SLOs, more than allocated if degraded (zero if unknown)
 
-		server *Server
-		load   *config.ServerLoadSpec
+	idle      bool // allocation of a server with zero load, sized by its minimum number of replicas
+	warm      int  // number of replicas provisioned for the warm pool of the server, beyond those sized for SLOs
+	estimated bool // allocation sized with performance data estimated from other accelerators
 
-		model *Model
-		perf  *config.ModelAcceleratorPerfData
+	sharedCost float32 // cost of each replica after the first, as a fraction of the cost of the first (one if weights are not shared)
 
-		svc    *ServiceClass
-		target *Target
-	)
+	// replicas on a second accelerator serving part of the load (mixed allocation); nil if not mixed
+	//   - cost, power and value of a mixed allocation are totals over both accelerators
+	secondary *Allocation
+}
+
+// Inputs of an allocation of an accelerator to a server
+type allocationInputs struct {
+	acc    *Accelerator
+	server *Server
+	model  *Model
+	perf   *config.ModelAcceleratorPerfData
+	target *Target
+
+	// target of the low priority stream of the server, given by its service class (nil if none)
+	lowTarget *Target
+}
+
+// get inputs of an allocation of an accelerator to a server of a system; error if missing or not valid
+func getAllocationInputs(system *System, serverName string, gName string) (*allocationInputs, error) {
+	in := &allocationInputs{}
 
 	// get accelerator info
-	if acc = GetAccelerator(gName); acc == nil {
-		return nil
+	if in.acc = system.GetAccelerator(gName); in.acc == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNoAccelerator, gName)
 	}
 
 	// get server info
-	if server = GetServer(serverName); server == nil {
-		return nil
+	if in.server = system.GetServer(serverName); in.server == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNoServer, serverName)
 	}
-	if load = server.Load(); load == nil || load.ArrivalRate < 0 ||
+	if load := in.server.ServedLoad(); load == nil || load.ArrivalRate < 0 ||
 		load.AvgInTokens < 0 || load.AvgOutTokens < 0 {
-		return nil
+		return nil, fmt.Errorf("%w: %v", ErrInvalidLoad, load)
 	}
 
 	// get model info
-	modelName := server.ModelName()
-	if model = GetModel(modelName); model == nil {
-		return nil
+	modelName := in.server.ModelName()
+	if in.model = system.GetModel(modelName); in.model == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNoModel, modelName)
 	}
-	if perf = model.PerfData(gName); perf == nil {
-		return nil
+	if in.perf = in.model.PerfData(gName); in.perf == nil {
+		return nil, fmt.Errorf("%w: model=%s, acc=%s", ErrNoPerfData, modelName, gName)
 	}
 
 	// get service class info
-	if svc = GetServiceClass(server.ServiceClassName()); svc == nil {
-		return nil
+	svcName := in.server.ServiceClassName()
+	svc := system.GetServiceClass(svcName)
+	if svc == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNoServiceClass, svcName)
 	}
-	if target = svc.ModelTarget(modelName); target == nil {
-		return nil
+	if in.target = svc.ModelTarget(modelName); in.target == nil {
+		return nil, fmt.Errorf("%w: model=%s, class=%s", ErrNoTarget, modelName, svcName)
+	}
+	if lowPriority := in.server.LowPriority(); lowPriority != nil {
+		lowSvc := system.GetServiceClass(lowPriority.Class)
+		if lowSvc == nil {
+			return nil, fmt.Errorf("%w: %s", ErrNoServiceClass, lowPriority.Class)
+		}
+		if in.lowTarget = lowSvc.ModelTarget(modelName); in.lowTarget == nil {
+			return nil, fmt.Errorf("%w: model=%s, class=%s", ErrNoTarget, modelName, lowPriority.Class)
+		}
+	}
+	return in, nil
+}
+
+// Create an allocation of an accelerator to a server of a system; error if not feasible
+func CreateAllocation(system *System, serverName string, gName string) (*Allocation, error) {
+	in, err := getAllocationInputs(system, serverName, gName)
+	if err != nil {
+		return nil, err
 	}
+	acc, server, model, perf, target, lowTarget := in.acc, in.server, in.model, in.perf, in.target, in.lowTarget
 
 	// handle zero traffic case
-	if load.ArrivalRate == 0 || load.AvgOutTokens == 0 {
-		return zeroLoadAllocation(server, model, acc, perf)
+	if server.ZeroLoad() {
+		return zeroLoadAllocation(system, server, model, acc, perf), nil
 	}
 
-	// calculate max batch size (N) based on average request length (K)
-	K := load.AvgOutTokens
+	// use the cached allocation for the same inputs, if any
+	if config.MaxAllocationCacheSize <= 0 {
+		return loadAllocation(system, server, model, acc, perf, target, lowTarget)
+	}
+	key := newAllocationKey(server, model, acc, perf, target, lowTarget)
+	if result, exists := cachedAllocation(system, key); exists {
+		return result.alloc, result.err
+	}
+	alloc, err := loadAllocation(system, server, model, acc, perf, target, lowTarget)
+	cacheAllocation(key, alloc, err)
+	return alloc, err
+}
+
+// Create an allocation of an accelerator to a server with (non-zero) load; error if not feasible
+func loadAllocation(system *System, server *Server, model *Model, acc *Accelerator, perf *config.ModelAcceleratorPerfData,
+	target *Target, lowTarget *Target) (*Allocation, error) {
+
+	N := maxBatchSize(server, perf)
+	if !server.optimizeBatchSize {
+		return sizeAllocation(system, server, model, acc, perf, target, lowTarget, N)
+	}
+
+	// jointly search all batch sizes up to the max (as swept by SweepBatchSize) and number of replicas for the
+	// least cost, the larger batch size on ties
+	var bestAlloc *Allocation
+	var errs []error
+	for n := N; n >= 1; n-- {
+		alloc, err := sizeAllocation(system, server, model, acc, perf, target, lowTarget, n)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if bestAlloc == nil || alloc.cost < bestAlloc.cost {
+			bestAlloc = alloc
+		}
+	}
+	if bestAlloc == nil {
+		return nil, errors.Join(errs...)
+	}
+	return bestAlloc, nil
+}
 
-	// use maxBatchSize from configured value or scaled performance data
-	var N int
+// Max batch size (N) of a replica of a server with (non-zero) load, based on average request length (K)
+//   - configured value of the server, or else batch size of performance data scaled to the request length
+func maxBatchSize(server *Server, perf *config.ModelAcceleratorPerfData) int {
 	if server.maxBatchSize > 0 {
-		N = server.maxBatchSize
+		return server.maxBatchSize
+	}
+	K := server.ServedLoad().AvgOutTokens
+	return max(perf.MaxBatchSize*perf.AtTokens/K, 1)
+}
+
+// Size an allocation of an accelerator to a server, given a max batch size; error if not feasible
+//   - with a low priority stream, the max rate per replica satisfies the targets of both streams
+func sizeAllocation(system *System, server *Server, model *Model, acc *Accelerator, perf *config.ModelAcceleratorPerfData,
+	target *Target, lowTarget *Target, N int) (*Allocation, error) {
+
+	gName := acc.Name()
+	queueAnalyzer, err := newQueueAnalyzer(server, perf, N)
+	if err != nil {
+		return nil, err
+	}
+	priorityAnalyzer, err := newPriorityAnalyzer(server, queueAnalyzer, target, lowTarget)
+	if err != nil {
+		return nil, err
+	}
+
+	// determine max rates to satisfy targets, less the batch-formation delay
+	var metrics *analyzer.AnalysisMetrics
+	queueTarget, err := batchTarget(target, perf)
+	if err != nil {
+		return nil, err
+	}
+	if priorityAnalyzer == nil {
+		_, metrics, _, err = queueAnalyzer.Size(newTargetPerf(queueTarget))
 	} else {
-		N = max(perf.MaxBatchSize*perf.AtTokens/K, 1)
+		var lowQueueTarget *Target
+		if lowQueueTarget, err = batchTarget(lowTarget, perf); err != nil {
+			return nil, err
+		}
+		var classMetrics *analyzer.PriorityMetrics
+		_, classMetrics, err = priorityAnalyzer.SizeClasses(newTargetPerf(queueTarget), newTargetPerf(lowQueueTarget))
+		if err == nil {
+			metrics = classMetrics.All
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("batchSize=%d: %w", N, err)
+	}
+	rateStar := metrics.Throughput
+	if rateStar <= 0 {
+		return nil, fmt.Errorf("batchSize=%d: %w: max rate=%v", N, analyzer.ErrDegenerateRateRange, rateStar)
 	}
-	maxQueue := N * config.MaxQueueToBatchRatio
 
-	// create queue analyzer
+	// calculate number of replicas
+	//   - the max number of replicas takes precedence over the min, and bounds the replicas required by SLOs
+	//     according to the replica cap policy of the server: not feasible, or capped and degraded
+	totalRate, _ := servedRequestRate(server, target, lowTarget)
+	numReplicas := int(math.Ceil(float64(totalRate) / float64(rateStar)))
+	sloReplicas := server.ShardReplicas(numReplicas)
+	numReplicas, warmReplicas := server.warmPoolReplicas(numReplicas)
+	if sloReplicas > numReplicas && (numReplicas == 0 || server.replicaCapPolicy != config.DegradeAboveCap) {
+		return nil, fmt.Errorf("%w: required=%d, max=%d", ErrReplicaCap, sloReplicas, server.maxNumReplicas)
+	}
+
+	// analyze queue of one replica
+	//   - a degraded replica is analyzed at no more than its max rate, as excess requests are not served
+	rate := totalRate / float32(numReplicas)
+	if sloReplicas > numReplicas {
+		rate = min(rate, metrics.MaxRate)
+	}
+	metrics, low, err := analyzeReplica(queueAnalyzer, priorityAnalyzer, rate)
+	if err != nil {
+		return nil, fmt.Errorf("batchSize=%d: %w", N, err)
+	}
+
+	// add replicas (a shard at a time) while the utilization of a replica is above the max, if enabled,
+	// within the max number of replicas
+	for config.AddReplicaAboveMaxRho && metrics.Rho > config.MaxRho && sloReplicas <= numReplicas {
+		moreReplicas, moreWarmReplicas := server.warmPoolReplicas(numReplicas + 1)
+		if moreReplicas <= numReplicas {
+			break
+		}
+		if metrics, low, err = analyzeReplica(queueAnalyzer, priorityAnalyzer, totalRate/float32(moreReplicas)); err != nil {
+			return nil, fmt.Errorf("batchSize=%d: %w", N, err)
+		}
+		numReplicas, warmReplicas = moreReplicas, moreWarmReplicas
+	}
+
+	// calculate cost
+	totalNumInstances := model.NumInstances(gName) * numReplicas
+	cost := replicasCost(unitCost(acc, perf)*float32(model.NumInstances(gName)), numReplicas, sharedCost(perf))
+
+	batchWait, batchWaitP99 := batchDelay(perf)
+	rho := metrics.Rho
+	itl := metrics.AvgTokenTime
+	ttft := batchWait + metrics.AvgWaitTime + metrics.AvgPrefillTime
+	itlP99 := metrics.P99TokenTime
+	ttftP99 := batchWaitP99 + metrics.P99WaitTime + metrics.AvgPrefillTime
+	dropRate := metrics.DropRate
+	power := acc.Power(rho) * float32(totalNumInstances)
+	// fmt.Printf("numReplicas=%d; batchSize=%d; rate=%v, itl=%v; ttft=%v; \n", numReplicas, N, rate, itl, ttft)
+
+	alloc := &Allocation{accelerator: gName, numReplicas: numReplicas, batchSize: N,
+		cost: cost, power: power, objective: system.GetObjective(), itl: itl, ttft: ttft, rho: rho, itlP99: itlP99, ttftP99: ttftP99, dropRate: dropRate, maxArrvRatePerReplica: config.PerSecToPerMsec(rateStar),
+		maxRatePerReplica: config.PerSecToPerMsec(queueAnalyzer.MaxRate()),
+		requestedReplicas: max(numReplicas, sloReplicas), warm: warmReplicas, sharedCost: sharedCost(perf), estimated: model.Estimated(gName),
+		low: lowLatencies(low, perf)}
+	alloc.SetValue(system.GetValueFunc()(alloc))
+	return alloc, nil
+}
+
+// Targets bounding the max rate per replica, given the target of a model in a service class
+//   - TTFT is modeled as queueing time plus prefill time, not queueing time alone
+//   - the relative waiting time bounds queueing time to a fraction of the service time of a request,
+//     enforced along with TTFT, the tighter of the two determining the max rate
+//   - a zero target is not considered
+func newTargetPerf(target *Target) *analyzer.TargetPerf {
+	return &analyzer.TargetPerf{
+		TargetTTFT: target.TTFT,
+		TargetITL:  target.ITL,
+		TargetTPS:  target.TPS,
+
+		TargetTTFTP99: target.TTFT_P99,
+		TargetITLP99:  target.ITL_P99,
+
+		TargetDropRate:     target.MaxDropRate,
+		TargetWaitFraction: target.WaitFraction,
+	}
+}
+
+// Batch-formation delay of a request (msec), on average and at the tail percentile, given perf data with a batching window
+//   - with continuous batching, a request arriving within the window waits for the next batch to form, even when
+//     batch slots are free, hence the delay matters most at low load, where queueing time is near zero
+//   - the window is deterministic and Poisson arrivals fall uniformly within it, independently of the queueing of
+//     the analyzer, hence a request waits half the window on average, and the tail percentile of the window at the tail
+//   - the delay is added to queueing and prefill times, whether service times are exponential (M/M/1) or general
+//     (G/G/m), and does not occupy batch slots, hence it does not change the utilization of a replica
+func batchDelay(perf *config.ModelAcceleratorPerfData) (avg float32, p99 float32) {
+	return perf.BatchWindow / 2, perf.BatchWindow * analyzer.TailPercentile
+}
+
+// Target of a stream for queueing and prefill times: its TTFT targets less the batch-formation delay (nil if no target)
+//   - not attainable if a TTFT target is not above the delay
+func batchTarget(target *Target, perf *config.ModelAcceleratorPerfData) (*Target, error) {
+	if target == nil || perf.BatchWindow == 0 {
+		return target, nil
+	}
+	batchWait, batchWaitP99 := batchDelay(perf)
+	t := *target
+	if t.TTFT > 0 {
+		if t.TTFT <= batchWait {
+			return nil, fmt.Errorf("%w: targetTTFT=%v, batch delay=%v", ErrUnattainableTTFT, t.TTFT, batchWait)
+		}
+		t.TTFT -= batchWait
+	}
+	if t.TTFT_P99 > 0 {
+		if t.TTFT_P99 <= batchWaitP99 {
+			return nil, fmt.Errorf("%w: targetTTFTP99=%v, batch delay=%v", ErrUnattainableTTFTP99, t.TTFT_P99, batchWaitP99)
+		}
+		t.TTFT_P99 -= batchWaitP99
+	}
+	return &t, nil
+}
+
+// Create an analyzer of the requests of a server and of its low priority stream sharing a replica, given the analyzer
+// of the queue of a replica and the targets of the streams; nil if the server has no low priority stream
+func newPriorityAnalyzer(server *Server, queueAnalyzer analyzer.Analyzer, target *Target,
+	lowTarget *Target) (*analyzer.PriorityAnalyzer, error) {
+
+	if server.lowPriority == nil || lowTarget == nil {
+		return nil, nil
+	}
+	_, highFraction := servedRequestRate(server, target, lowTarget)
+	return analyzer.NewPriorityAnalyzer(queueAnalyzer, highFraction, server.ServedLoad().AvgOutTokens)
+}
+
+// Analyze the queue of a replica at a request rate (req/sec), returning metrics of the requests of the server
+// and of its low priority stream (nil if none), given the analyzer of the streams (nil if none)
+//   - the concurrency and drop rate of the requests of the server are those of all requests of the replica
+func analyzeReplica(queueAnalyzer analyzer.Analyzer, priorityAnalyzer *analyzer.PriorityAnalyzer,
+	rate float32) (metrics *analyzer.AnalysisMetrics, low *analyzer.AnalysisMetrics, err error) {
+
+	if priorityAnalyzer == nil {
+		metrics, err = queueAnalyzer.Analyze(rate)
+		return metrics, nil, err
+	}
+	classMetrics, err := priorityAnalyzer.AnalyzeClasses(rate)
+	if err != nil {
+		return nil, nil, err
+	}
+	return classMetrics.High, classMetrics.Low, nil
+}
+
+// Latencies of low priority requests given their metrics, including the batch-formation delay; nil if no metrics
+func lowLatencies(low *analyzer.AnalysisMetrics, perf *config.ModelAcceleratorPerfData) *config.StreamLatencyData {
+	if low == nil {
+		return nil
+	}
+	batchWait, batchWaitP99 := batchDelay(perf)
+	return &config.StreamLatencyData{
+		TTFTAverage: batchWait + low.AvgWaitTime + low.AvgPrefillTime,
+		TTFTP99:     batchWaitP99 + low.P99WaitTime + low.AvgPrefillTime,
+	}
+}
+
+// Create a queue analyzer of a replica of a server, given performance data and a max batch size
+//   - service times are scaled for the communication overhead of tensor parallelism
+func newQueueAnalyzer(server *Server, perf *config.ModelAcceleratorPerfData, N int) (analyzer.Analyzer, error) {
+	load := server.ServedLoad()
+	tpFactor := perf.TPFactor()
 	qConfig := &analyzer.Configuration{
 		MaxBatchSize: N,
-		MaxQueueSize: maxQueue,
+		MaxQueueSize: N * config.MaxQueueToBatchRatio,
 		ServiceParms: &analyzer.ServiceParms{
 			Prefill: &analyzer.PrefillParms{
-				Gamma: perf.PrefillParms.Gamma,
-				Delta: perf.PrefillParms.Delta,
+				Gamma: perf.PrefillParms.Gamma * tpFactor,
+				Delta: perf.PrefillParms.Delta * tpFactor,
 			},
 			Decode: &analyzer.DecodeParms{
-				Alpha: perf.DecodeParms.Alpha,
-				Beta:  perf.DecodeParms.Beta,
+				Alpha: perf.DecodeParms.Alpha * tpFactor,
+				Beta:  perf.DecodeParms.Beta * tpFactor,
 			},
 		},
 	}
 
 	requestData := &analyzer.RequestSize{
 		AvgInputTokens:  load.AvgInTokens,
-		AvgOutputTokens: K,
+		AvgOutputTokens: load.AvgOutTokens,
 	}
 
-	queueAnalyzer, err := analyzer.NewQueueAnalyzer(qConfig, requestData)
+	switch server.SizingQueueModel() {
+	case config.GGm:
+		return analyzer.NewGGmAnalyzer(qConfig, requestData, load.ArrivalCOV, load.ServiceCOV)
+	default:
+		return analyzer.NewQueueAnalyzer(qConfig, requestData)
+	}
+}
+
+// Total request rate (req/sec) to be served by all replicas of a server, given its load and target
+func totalRequestRate(load *config.ServerLoadSpec, target *Target) float32 {
+	if target.TPS == 0 {
+		return config.PerMinToPerSec(load.ArrivalRate)
+	}
+	return target.TPS / float32(load.AvgOutTokens)
+}
+
+// Total request rate (req/sec) to be served by all replicas of a server, given the targets of its requests and of its
+// low priority stream, and the fraction of the rate of requests of the server (one if no low priority stream)
+//   - the rate of a stream with no arrivals is zero
+func servedRequestRate(server *Server, target *Target, lowTarget *Target) (totalRate float32, highFraction float32) {
+	if server.lowPriority == nil || lowTarget == nil {
+		return totalRequestRate(server.ServedLoad(), target), 1
+	}
+	streamRate := func(load *config.ServerLoadSpec, target *Target) float32 {
+		if load.ArrivalRate == 0 || load.AvgOutTokens == 0 {
+			return 0
+		}
+		return totalRequestRate(load, target)
+	}
+	highRate := streamRate(server.PriorityLoad(), target)
+	totalRate = highRate + streamRate(&server.lowPriority.Load, lowTarget)
+	if totalRate == 0 {
+		return 0, 1
+	}
+	return totalRate, highRate / totalRate
+}
+
+// Create an allocation of an accelerator to a server, with diagnostics of the queue of a replica; error if not feasible
+//   - the queue is analyzed at the request rate of a replica, as the load is shared equally among replicas
+//   - diagnostics are nil for an allocation with no load
+func CreateAllocationWithDiagnostics(system *System, serverName string, gName string) (*Allocation, *analyzer.QueueDiagnostics, error) {
+	alloc, err := CreateAllocation(system, serverName, gName)
 	if err != nil {
-		fmt.Println(err)
-		return nil
+		return nil, nil, err
+	}
+	in, err := getAllocationInputs(system, serverName, gName)
+	if err != nil {
+		return nil, nil, err
+	}
+	server, perf := in.server, in.perf
+	load := server.ServedLoad()
+	if alloc.numReplicas == 0 || load.ArrivalRate == 0 || load.AvgOutTokens == 0 {
+		return alloc, nil, nil
 	}
 
-	// TODO: do we need this?
-	// waitTimeLimit := target.TTFT / config.SLOMargin // distribution of waiting time assumed exponential
+	queueAnalyzer, err := newQueueAnalyzer(server, perf, alloc.batchSize)
+	if err != nil {
+		return nil, nil, err
+	}
+	totalRate, _ := servedRequestRate(server, in.target, in.lowTarget)
+	rate := totalRate / float32(alloc.numReplicas)
+	diagnostics, err := queueAnalyzer.Diagnose(rate)
+	if err != nil {
+		return nil, nil, fmt.Errorf("batchSize=%d: %w", alloc.batchSize, err)
+	}
+	return alloc, diagnostics, nil
+}
 
-	targetPerf := &analyzer.TargetPerf{
-		TargetTTFT: target.TTFT,
-		TargetITL:  target.ITL,
-		TargetTPS:  target.TPS,
+// Size allocations of an accelerator to a server of a system at each max batch size, from one to the max batch size
+// of the server, exposing the tradeoff between cost and latency, e.g. to decide on the max batch size of the server
+//   - allocations are sized for the current load of the server, which may not be zero
+//   - times are those of a replica at its share of the load, of the requests of the server if it has a low
+//     priority stream
+//   - a batch size with no feasible allocation is reported with the reason
+func SweepBatchSize(system *System, serverName string, gName string) ([]config.BatchSizePoint, error) {
+	in, err := getAllocationInputs(system, serverName, gName)
+	if err != nil {
+		return nil, err
+	}
+	server, perf, target, lowTarget := in.server, in.perf, in.target, in.lowTarget
+	if server.ZeroLoad() {
+		return nil, fmt.Errorf("%w: no load of server %s", ErrInvalidLoad, serverName)
 	}
+	K := server.ServedLoad().AvgOutTokens
+	totalRate, _ := servedRequestRate(server, target, lowTarget)
 
-	// determine max rates to satisfy targets
-	_, metrics, _, err := queueAnalyzer.Size(targetPerf)
+	N := maxBatchSize(server, perf)
+	points := make([]config.BatchSizePoint, 0, N)
+	for n := 1; n <= N; n++ {
+		point := config.BatchSizePoint{BatchSize: n}
+		if err := sweepPoint(system, in, n, totalRate, K, &point); err != nil {
+			point.Error = err.Error()
+		}
+		points = append(points, point)
+	}
+	return points, nil
+}
+
+// size an allocation of a sweep of batch sizes at a max batch size, setting its point; error if not feasible
+func sweepPoint(system *System, in *allocationInputs, n int, totalRate float32, K int, point *config.BatchSizePoint) error {
+	server, perf := in.server, in.perf
+	alloc, err := sizeAllocation(system, server, in.model, in.acc, perf, in.target, in.lowTarget, n)
 	if err != nil {
-		// fmt.Println(err)
-		return nil
+		return err
 	}
-	rateStar := metrics.Throughput
+	queueAnalyzer, err := newQueueAnalyzer(server, perf, n)
+	if err != nil {
+		return err
+	}
+	priorityAnalyzer, err := newPriorityAnalyzer(server, queueAnalyzer, in.target, in.lowTarget)
+	if err != nil {
+		return err
+	}
+	rate := min(totalRate/float32(alloc.numReplicas), queueAnalyzer.MaxRate())
+	metrics, _, err := analyzeReplica(queueAnalyzer, priorityAnalyzer, rate)
+	if err != nil {
+		return fmt.Errorf("batchSize=%d: %w", n, err)
+	}
+	point.MaxRPM = alloc.MaxRPM()
+	point.NumReplicas = alloc.numReplicas
+	point.Cost = alloc.cost
+	point.ServTime = metrics.AvgPrefillTime + float32(K)*metrics.AvgTokenTime
+	point.WaitTime = metrics.AvgWaitTime
+	point.ITL = alloc.itl
+	point.TTFT = alloc.ttft
+	return nil
+}
 
-	// calculate number of replicas
-	var totalRate float32
-	if target.TPS == 0 {
-		totalRate = load.ArrivalRate / 60
-	} else {
-		totalRate = target.TPS / float32(K)
+// Evaluate an allocation given for a server of a system (e.g. imposed by an operator) at the current load of the server,
+// for compliance with its SLO targets
+//   - the allocation is reconstructed from its data: accelerator, number of replicas, and max batch size
+//     (the batch size of sizing allocations of the server, if zero); mixed allocations are not evaluated
+//   - the queue of a replica is analyzed at its share of the load, as when sizing allocations, or at its max rate
+//     if the load exceeds the max rate of the replicas (saturated), excess requests not being served
+//   - with no load, replicas are idle, with the latencies of a single request
+//   - with a low priority stream, its targets are evaluated at the latencies of its requests, with names prefixed by
+//     "low-priority-", and latencies of the allocation are those of the requests of the server
+func EvaluateAllocation(system *System, serverName string, data *config.AllocationData) (*config.AllocationEvaluation, error) {
+	_, eval, err := evaluateAllocation(system, serverName, data)
+	return eval, err
+}
+
+// Evaluate an allocation given for a server of a system, returning the reconstructed allocation and its evaluation
+func evaluateAllocation(system *System, serverName string, data *config.AllocationData) (*Allocation, *config.AllocationEvaluation, error) {
+	if data.Secondary != nil {
+		return nil, nil, errors.New("mixed allocations are not evaluated")
 	}
-	numReplicas := int(math.Ceil(float64(totalRate) / float64(rateStar)))
-	numReplicas = max(numReplicas, server.minNumReplicas)
+	if data.NumReplicas <= 0 {
+		return nil, nil, fmt.Errorf("numReplicas=%d must be positive", data.NumReplicas)
+	}
+	if data.MaxBatch < 0 {
+		return nil, nil, fmt.Errorf("maxBatch=%d must be non-negative", data.MaxBatch)
+	}
+	in, err := getAllocationInputs(system, serverName, data.Accelerator)
+	if err != nil {
+		return nil, nil, err
+	}
+	acc, server, model, perf, target, lowTarget := in.acc, in.server, in.model, in.perf, in.target, in.lowTarget
+	load := server.ServedLoad()
+	K := load.AvgOutTokens
 
-	// calculate cost
+	given := AllocationFromData(data)
+	gName, numReplicas := given.accelerator, given.numReplicas
 	totalNumInstances := model.NumInstances(gName) * numReplicas
-	cost := acc.Cost() * float32(totalNumInstances)
+	alloc := &Allocation{accelerator: gName, numReplicas: numReplicas, batchSize: given.batchSize,
+		cost:      replicasCost(unitCost(acc, perf)*float32(model.NumInstances(gName)), numReplicas, sharedCost(perf)),
+		objective: system.GetObjective(), requestedReplicas: numReplicas, sharedCost: sharedCost(perf), estimated: model.Estimated(gName)}
+	eval := &config.AllocationEvaluation{Server: serverName}
 
-	// analyze queue of one replica
-	rate := totalRate / float32(numReplicas)
-	metrics, err = queueAnalyzer.Analyze(rate)
-	if err != nil {
-		fmt.Println(err)
-		return nil
+	var servedRate float32                   // request rate of requests of the server served by all replicas (req/sec)
+	var lowMetrics *analyzer.AnalysisMetrics // metrics of low priority requests (nil if none or no load)
+	if server.ZeroLoad() {
+		if alloc.batchSize == 0 {
+			alloc.batchSize = perf.MaxBatchSize
+			if server.maxBatchSize > 0 {
+				alloc.batchSize = server.maxBatchSize
+			}
+		}
+		tpFactor := perf.TPFactor()
+		decodeTime := (perf.DecodeParms.Alpha + perf.DecodeParms.Beta) * tpFactor
+		prefillTime := (perf.PrefillParms.Gamma + perf.PrefillParms.Delta) * tpFactor
+		batchWait, batchWaitP99 := batchDelay(perf)
+		alloc.itl, alloc.ttft, alloc.itlP99, alloc.ttftP99 = decodeTime, batchWait+prefillTime, decodeTime, batchWaitP99+prefillTime
+		alloc.power = acc.Power(0) * float32(totalNumInstances)
+		alloc.idle = true
+		eval.ServTime = prefillTime + float32(K)*decodeTime
+	} else {
+		if alloc.batchSize == 0 {
+			alloc.batchSize = maxBatchSize(server, perf)
+		}
+		queueAnalyzer, err := newQueueAnalyzer(server, perf, alloc.batchSize)
+		if err != nil {
+			return nil, nil, err
+		}
+		priorityAnalyzer, err := newPriorityAnalyzer(server, queueAnalyzer, target, lowTarget)
+		if err != nil {
+			return nil, nil, err
+		}
+		maxRate := queueAnalyzer.MaxRate()
+		totalRate, _ := servedRequestRate(server, target, lowTarget)
+		rate := totalRate / float32(numReplicas)
+		if rate > maxRate {
+			rate = maxRate
+			eval.Saturated = true
+		}
+		metrics, low, err := analyzeReplica(queueAnalyzer, priorityAnalyzer, rate)
+		if err != nil {
+			return nil, nil, fmt.Errorf("batchSize=%d: %w", alloc.batchSize, err)
+		}
+		lowMetrics = low
+		batchWait, batchWaitP99 := batchDelay(perf)
+		alloc.low = lowLatencies(low, perf)
+		alloc.itl = metrics.AvgTokenTime
+		alloc.ttft = batchWait + metrics.AvgWaitTime + metrics.AvgPrefillTime
+		alloc.itlP99 = metrics.P99TokenTime
+		alloc.ttftP99 = batchWaitP99 + metrics.P99WaitTime + metrics.AvgPrefillTime
+		alloc.dropRate = metrics.DropRate
+		alloc.rho = metrics.Rho
+		alloc.power = acc.Power(metrics.Rho) * float32(totalNumInstances)
+		alloc.maxArrvRatePerReplica = config.PerSecToPerMsec(maxRate)
+		alloc.maxRatePerReplica = config.PerSecToPerMsec(maxRate)
+		servedRate = metrics.Throughput * float32(numReplicas)
+		eval.ServTime = metrics.AvgPrefillTime + float32(K)*metrics.AvgTokenTime
+		eval.WaitTime = metrics.AvgWaitTime
+		eval.Rho = metrics.Rho
+		eval.MaxRPM = config.PerSecToPerMin(maxRate) * float32(numReplicas)
 	}
-	rho := metrics.Rho
-	itl := metrics.AvgTokenTime
-	ttft := metrics.AvgWaitTime + metrics.AvgPrefillTime
-	// fmt.Printf("numReplicas=%d; batchSize=%d; rate=%v, itl=%v; ttft=%v; \n", numReplicas, N, rate, itl, ttft)
+	alloc.SetValue(system.GetValueFunc()(alloc))
+	eval.Allocation = *alloc.AllocationData()
+	eval.Allocation.Load = *load
 
-	alloc := &Allocation{accelerator: gName, numReplicas: numReplicas, batchSize: N,
-		cost: cost, itl: itl, ttft: ttft, rho: rho, maxArrvRatePerReplica: rateStar / 1000}
-	alloc.SetValue(alloc.cost)
+	// compliance with targets of the requests of the server, and of its low priority stream
+	var waitFraction float32
+	if eval.ServTime > 0 {
+		waitFraction = eval.WaitTime / eval.ServTime
+	}
+	eval.Compliant = !eval.Saturated
+	evaluateTargets(eval, "", target, &analyzer.TargetPerf{
+		TargetTTFT:         alloc.ttft,
+		TargetITL:          alloc.itl,
+		TargetTPS:          servedRate * float32(server.PriorityLoad().AvgOutTokens),
+		TargetTTFTP99:      alloc.ttftP99,
+		TargetITLP99:       alloc.itlP99,
+		TargetDropRate:     alloc.dropRate,
+		TargetWaitFraction: waitFraction,
+	})
+	if lowMetrics != nil {
+		var lowWaitFraction float32
+		if eval.ServTime > 0 {
+			lowWaitFraction = lowMetrics.AvgWaitTime / eval.ServTime
+		}
+		evaluateTargets(eval, "low-priority-", lowTarget, &analyzer.TargetPerf{
+			TargetTTFT:         alloc.low.TTFTAverage,
+			TargetITL:          lowMetrics.AvgTokenTime,
+			TargetTPS:          lowMetrics.Throughput * float32(numReplicas) * float32(server.lowPriority.Load.AvgOutTokens),
+			TargetTTFTP99:      alloc.low.TTFTP99,
+			TargetITLP99:       lowMetrics.P99TokenTime,
+			TargetDropRate:     lowMetrics.DropRate,
+			TargetWaitFraction: lowWaitFraction,
+		})
+	}
+	return alloc, eval, nil
+}
+
+// Evaluate compliance with targets considered (non-zero), given achieved values, with names prefixed by a given prefix
+//   - all latencies and rates at most their targets but throughput
+func evaluateTargets(eval *config.AllocationEvaluation, prefix string, target *Target, achieved *analyzer.TargetPerf) {
+	for _, t := range []struct {
+		name     string
+		target   float32
+		achieved float32
+	}{
+		{"slo-itl", target.ITL, achieved.TargetITL},
+		{"slo-ttft", target.TTFT, achieved.TargetTTFT},
+		{"slo-itl-p99", target.ITL_P99, achieved.TargetITLP99},
+		{"slo-ttft-p99", target.TTFT_P99, achieved.TargetTTFTP99},
+		{"slo-max-drop-rate", target.MaxDropRate, achieved.TargetDropRate},
+		{"slo-wait-fraction", target.WaitFraction, achieved.TargetWaitFraction},
+	} {
+		if t.target > 0 {
+			met := t.achieved <= t.target
+			eval.Targets = append(eval.Targets, config.SLOCompliance{Name: prefix + t.name, Target: t.target, Achieved: t.achieved, Met: met})
+			eval.Compliant = eval.Compliant && met
+		}
+	}
+	if target.TPS > 0 {
+		met := achieved.TargetTPS >= target.TPS
+		eval.Targets = append(eval.Targets, config.SLOCompliance{Name: prefix + "slo-tps", Target: target.TPS, Achieved: achieved.TargetTPS, Met: met})
+		eval.Compliant = eval.Compliant && met
+	}
+}
+
+// Create a mixed allocation of a server, with replicas of two allocations of the server on different accelerators
+//   - the load is shared in proportion to the max rates of replicas, hence replicas satisfy SLOs if their
+//     numbers serve at least the fractions of the load, i.e. n1/N1 + n2/N2 >= 1, with N1 and N2 the numbers
+//     of replicas of the allocations
+//   - the first allocation gives the accelerator, batch size, and performance of the mixed allocation
+//   - costs, powers, and values (including transition penalties) are scaled by the numbers of replicas
+func CreateMixedAllocation(first *Allocation, numFirst int, second *Allocation, numSecond int) *Allocation {
+	alloc := first.withReplicas(numFirst)
+	alloc.secondary = second.withReplicas(numSecond)
+	alloc.cost += alloc.secondary.cost
+	alloc.power += alloc.secondary.power
+	alloc.value += alloc.secondary.value
 	return alloc
 }
 
-func (a *Allocation) Scale(serverName string) (alloc *Allocation, inc int) {
+// copy of a (single) allocation with a given number of replicas, scaling cost, power, and value
+func (a *Allocation) withReplicas(numReplicas int) *Allocation {
+	b := a.Clone()
+	b.secondary = nil
+	b.Rescale(numReplicas)
+	b.requestedReplicas = numReplicas
+	return b
+}
+
+// Cost of a (single) allocation rescaled to a number of replicas, e.g. to charge the marginal cost of added replicas
+//   - cost is proportional to the number of replicas, unless replicas share model weights
+func (a *Allocation) CostOfReplicas(numReplicas int) float32 {
+	if a.numReplicas <= 0 {
+		return 0
+	}
+	replicaCost := a.cost / (1 + a.sharedCost*float32(a.numReplicas-1))
+	return replicasCost(replicaCost, numReplicas, a.sharedCost)
+}
+
+// Change the number of replicas of a (single) allocation, scaling its cost, power, and value
+//   - power is proportional to the number of replicas, as is cost unless replicas share model weights
+//   - value is scaled as the cost in terms of the objective of the allocation
+func (a *Allocation) Rescale(numReplicas int) {
+	if numReplicas < a.numReplicas {
+		a.warm = max(a.warm-(a.numReplicas-numReplicas), 0)
+	}
+	if a.numReplicas > 0 {
+		oldCost := a.ObjectiveCost()
+		a.cost = a.CostOfReplicas(numReplicas)
+		a.power *= float32(numReplicas) / float32(a.numReplicas)
+		if oldCost > 0 {
+			a.value *= a.ObjectiveCost() / oldCost
+		} else {
+			a.value *= float32(numReplicas) / float32(a.numReplicas)
+		}
+	}
+	a.numReplicas = numReplicas
+}
+
+// Scale an allocation to the current load of a server of a system, keeping the same accelerator, given the current
+// number of replicas of the server, with the increment of the number of replicas and the direction of scaling
+//   - scaling up is recommended as soon as the load requires more replicas than the current number
+//   - scaling down is recommended only to the number of replicas sufficient for the load increased by a margin
+//     (config.ScaleDownMargin), hence not at all if the current number is needed at that load (hysteresis);
+//     latency metrics of the allocation remain those of the number of replicas required by the load
+func (a *Allocation) Scale(system *System, serverName string,
+	curReplicas int) (alloc *Allocation, inc int, action config.ScaleAction, err error) {
 	var (
 		acc    *Accelerator
 		server *Server
@@ -170,40 +753,105 @@ func (a *Allocation) Scale(serverName string) (alloc *Allocation, inc int) {
 	)
 
 	// get server info
-	if server = GetServer(serverName); server == nil {
-		return nil, 0
+	if server = system.GetServer(serverName); server == nil {
+		return nil, 0, config.NoScale, fmt.Errorf("%w: %s", ErrNoServer, serverName)
 	}
-	if load = server.Load(); load == nil {
-		return nil, 0
+	if load = server.ServedLoad(); load == nil {
+		return nil, 0, config.NoScale, fmt.Errorf("%w: %v", ErrInvalidLoad, load)
 	}
 
 	// get accelerator info
 	gName := a.accelerator
-	if acc = GetAccelerator(gName); acc == nil {
-		return nil, 0
+	if acc = system.GetAccelerator(gName); acc == nil {
+		return nil, 0, config.NoScale, fmt.Errorf("%w: %s", ErrNoAccelerator, gName)
 	}
 
 	// create new allocation
-	alloc = CreateAllocation(serverName, gName)
-	inc = alloc.numReplicas - a.numReplicas
-	return alloc, inc
+	if alloc, err = CreateAllocation(system, serverName, gName); err != nil {
+		return nil, 0, config.NoScale, err
+	}
+
+	// scale down no further than the number of replicas sufficient for the load with a margin
+	if alloc.numReplicas < curReplicas && config.ScaleDownMargin > 0 {
+		numReplicas := curReplicas
+		server.withScaledLoad(1+config.ScaleDownMargin, func() {
+			if marginAlloc, marginErr := CreateAllocation(system, serverName, gName); marginErr == nil {
+				numReplicas = min(max(marginAlloc.numReplicas, alloc.numReplicas), curReplicas)
+			}
+		})
+		alloc.Rescale(numReplicas)
+	}
+	inc = alloc.numReplicas - curReplicas
+	return alloc, inc, config.ScaleActionOf(inc), nil
 }
 
-func (a *Allocation) ReAllocate(serverName string) (*Allocation, string) {
-	minVal := float32(0)
-	var minAlloc *Allocation
-	for gName := range GetAccelerators() {
-		if alloc := CreateAllocation(serverName, gName); alloc != nil {
-			if minVal == 0 || alloc.value < minVal {
-				minVal = alloc.value
-				minAlloc = alloc
+// Find the allocation with minimum value to a server across all accelerators allowed for the server
+//   - candidate accelerators are evaluated concurrently by a bounded pool of workers
+//   - ties in value are broken by accelerator name
+//   - with a positive headroom tolerance of the system, the allocation with the most rate headroom for the load
+//     of the server is preferred among allocations with values within the tolerance of the minimum
+//   - error joins the reasons of all accelerators if none is feasible
+func (a *Allocation) ReAllocate(system *System, serverName string) (*Allocation, string, error) {
+	gNames := slices.Sorted(maps.Keys(system.GetAccelerators()))
+	if server := system.GetServer(serverName); server != nil {
+		gNames = slices.DeleteFunc(gNames, func(gName string) bool {
+			return !server.AcceleratorAllowed(gName) || (system.GetAccelerator(gName).Draining() && !server.CurrentlyOn(gName))
+		})
+	}
+	allocs := make([]*Allocation, len(gNames))
+	errs := make([]error, len(gNames))
+
+	// evaluate candidate allocations
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	numWorkers := min(max(config.MaxAllocationWorkers, 1), len(gNames))
+	for w := 0; w < numWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if allocs[i], errs[i] = CreateAllocation(system, serverName, gNames[i]); errs[i] != nil {
+					errs[i] = fmt.Errorf("%s: %w", gNames[i], errs[i])
+				}
 			}
+		}()
+	}
+	for i := range gNames {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	// select allocation with minimum value, in order of accelerator names, moving away from draining accelerators on ties
+	var minAlloc *Allocation
+	for _, alloc := range allocs {
+		if alloc != nil && (minAlloc == nil || alloc.value < minAlloc.value ||
+			(alloc.value == minAlloc.value && system.GetAccelerator(minAlloc.accelerator).Draining() &&
+				!system.GetAccelerator(alloc.accelerator).Draining())) {
+			minAlloc = alloc
 		}
 	}
 	if minAlloc == nil {
-		return nil, ""
+		if len(gNames) == 0 {
+			return nil, "", ErrNoCandidate
+		}
+		return nil, "", errors.Join(errs...)
 	}
-	return minAlloc, minAlloc.accelerator
+
+	// prefer headroom among allocations with values within the tolerance, in order of accelerator names
+	server := system.GetServer(serverName)
+	if tolerance := system.GetHeadroomTolerance(); tolerance > 0 && server != nil {
+		rate := server.ServedLoad().ArrivalRate
+		maxValue := minAlloc.value * (1 + tolerance)
+		bestAlloc := minAlloc
+		for _, alloc := range allocs {
+			if alloc != nil && alloc.value <= maxValue && alloc.Headroom(rate) > bestAlloc.Headroom(rate) {
+				bestAlloc = alloc
+			}
+		}
+		minAlloc = bestAlloc
+	}
+	return minAlloc, minAlloc.accelerator, nil
 }
 
 func (a *Allocation) Accelerator() string {
@@ -218,6 +866,86 @@ func (a *Allocation) SetNumReplicas(n int) {
 	a.numReplicas = n
 }
 
+// Number of replicas required to satisfy SLOs (zero if unknown)
+func (a *Allocation) RequestedReplicas() int {
+	return a.requestedReplicas
+}
+
+// Check if the allocation has fewer replicas than required to satisfy SLOs, e.g. scaled down under saturation
+func (a *Allocation) Degraded() bool {
+	return a.numReplicas < a.requestedReplicas
+}
+
+// Expected fraction of requests rejected as the queue of a replica is full
+func (a *Allocation) DropRate() float32 {
+	return a.dropRate
+}
+
+// Utilization of the batch of a replica: average number of running requests over the max batch size
+func (a *Allocation) Rho() float32 {
+	return a.rho
+}
+
+// Check if the utilization of a replica is above the max (config.MaxRho), i.e. the allocation is on the edge
+// of instability, with latencies growing steeply under a slight increase of the load
+func (a *Allocation) AboveMaxRho() bool {
+	return a.rho > config.MaxRho
+}
+
+// Allocation of a server with zero load, sized by its minimum number of replicas rather than by its SLOs
+func (a *Allocation) Idle() bool {
+	return a.idle
+}
+
+// Number of replicas provisioned for the warm pool of the server, beyond those sized for SLOs
+func (a *Allocation) WarmReplicas() int {
+	return a.warm
+}
+
+// Cost of the replicas provisioned for the warm pool of the server, including those of a secondary allocation
+func (a *Allocation) WarmCost() float32 {
+	cost := a.cost
+	warmCost := float32(0)
+	if a.secondary != nil {
+		cost -= a.secondary.cost
+		warmCost = a.secondary.WarmCost()
+	}
+	if a.warm > 0 && a.numReplicas > 0 {
+		replicaCost := cost / (1 + a.sharedCost*float32(a.numReplicas-1))
+		warmCost += cost - replicasCost(replicaCost, a.numReplicas-a.warm, a.sharedCost)
+	}
+	return warmCost
+}
+
+// Allocation sized with performance data estimated from other accelerators, hence speculative
+func (a *Allocation) Estimated() bool {
+	return a.estimated || a.secondary != nil && a.secondary.estimated
+}
+
+// Replicas on a second accelerator serving part of the load; nil if not a mixed allocation
+func (a *Allocation) Secondary() *Allocation {
+	return a.secondary
+}
+
+// Check if the allocation mixes replicas on two accelerators
+func (a *Allocation) Mixed() bool {
+	return a.secondary != nil
+}
+
+// Single allocations on one accelerator making up the allocation, each with its own cost, power, and value
+//   - the allocation itself if not mixed
+func (a *Allocation) Legs() []*Allocation {
+	if a.secondary == nil {
+		return []*Allocation{a}
+	}
+	primary := a.Clone()
+	primary.secondary = nil
+	primary.cost -= a.secondary.cost
+	primary.power -= a.secondary.power
+	primary.value -= a.secondary.value
+	return []*Allocation{primary, a.secondary}
+}
+
 func (a *Allocation) MaxBatchSize() int {
 	return a.batchSize
 }
@@ -231,7 +959,50 @@ func (a *Allocation) MaxArrvRatePerReplica() float32 {
 }
 
 func (a *Allocation) MaxRPM() float32 {
-	return a.maxArrvRatePerReplica * 1000 * 60
+	return config.PerMsecToPerMin(a.maxArrvRatePerReplica)
+}
+
+// SLO slack of the allocation: max arrival rate of a replica at SLO (lambdaStar) as a fraction of its max arrival
+// rate regardless of SLOs; smaller if closer to infeasibility, one if not known
+func (a *Allocation) SLOSlack() float32 {
+	if a.maxRatePerReplica <= 0 {
+		return 1
+	}
+	return min(a.maxArrvRatePerReplica/a.maxRatePerReplica, 1)
+}
+
+// Rate headroom of the allocation given its arrival rate (req/min): fraction of the max arrival rate of its replicas
+// at SLO not used by the load; one if no load, negative if the load exceeds the max rate of its replicas
+func (a *Allocation) Headroom(rate float32) float32 {
+	maxRate := float32(a.numReplicas) * a.MaxRPM()
+	if rate <= 0 || maxRate <= 0 {
+		return 1
+	}
+	return 1 - rate/maxRate
+}
+
+// Max throughput of the allocation at SLO (tokens/hour), over the replicas on all its accelerators, given the load
+// of its server, counting input (prefill) and output (decode) tokens of requests
+func (a *Allocation) MaxTokensPerHour(load *config.ServerLoadSpec) float32 {
+	maxRate := float32(a.numReplicas) * a.MaxRPM()
+	if a.secondary != nil {
+		maxRate += float32(a.secondary.numReplicas) * a.secondary.MaxRPM()
+	}
+	return maxRate * config.MinPerHour * float32(load.AvgInTokens+load.AvgOutTokens)
+}
+
+// Cost of the allocation (per hour) per million tokens of its max throughput at SLO, given the load of its server;
+// zero if no throughput
+func (a *Allocation) CostPerMillionTokens(load *config.ServerLoadSpec) float32 {
+	return costPerMillionTokens(a.cost, a.MaxTokensPerHour(load))
+}
+
+// cost (per hour) per million tokens of a throughput (tokens/hour); zero if no throughput
+func costPerMillionTokens(cost float32, tokensPerHour float32) float32 {
+	if tokensPerHour <= 0 {
+		return 0
+	}
+	return cost * 1e6 / tokensPerHour
 }
 
 func (a *Allocation) Cost() float32 {
@@ -242,6 +1013,27 @@ func (a *Allocation) SetCost(cost float32) {
 	a.cost = cost
 }
 
+func (a *Allocation) Power() float32 {
+	return a.power
+}
+
+func (a *Allocation) SetPower(power float32) {
+	a.power = power
+}
+
+// Cost of the allocation in terms of its objective (dollar cost or power)
+func (a *Allocation) ObjectiveCost() float32 {
+	return a.costOf(a.objective)
+}
+
+// cost of the allocation in terms of an objective (dollar cost unless power)
+func (a *Allocation) costOf(objective config.Objective) float32 {
+	if objective == config.PowerObjective {
+		return a.power
+	}
+	return a.cost
+}
+
 func (a *Allocation) Value() float32 {
 	return a.value
 }
@@ -252,18 +1044,56 @@ func (a *Allocation) SetValue(value float32) {
 }
 
 func (a *Allocation) Saturated(totalRate float32) bool {
-	return totalRate > float32(a.numReplicas)*a.MaxRPM()
+	maxRate := float32(a.numReplicas) * a.MaxRPM()
+	if a.secondary != nil {
+		maxRate += float32(a.secondary.numReplicas) * a.secondary.MaxRPM()
+	}
+	return totalRate > maxRate
+}
+
+// Cost of an accelerator unit for a model per hour: the override in its perf data if given, the accelerator cost otherwise
+func unitCost(acc *Accelerator, perf *config.ModelAcceleratorPerfData) float32 {
+	if perf.UnitCost > 0 {
+		return acc.hourlyCost(perf.UnitCost)
+	}
+	return acc.Cost()
+}
+
+// Time for a new replica of a model on an accelerator to become ready (sec):
+// the override in its perf data if given, the accelerator warmup time otherwise
+func warmupSeconds(acc *Accelerator, perf *config.ModelAcceleratorPerfData) float32 {
+	if perf.WarmupSeconds > 0 {
+		return perf.WarmupSeconds
+	}
+	return acc.WarmupSeconds()
+}
+
+// Cost of each replica after the first of a model on an accelerator, as a fraction of the cost of the first:
+// the shared cost in its perf data if sharing weights, one otherwise
+func sharedCost(perf *config.ModelAcceleratorPerfData) float32 {
+	if perf.SharedWeights {
+		return perf.SharedCost
+	}
+	return 1
+}
+
+// Cost of a number of replicas, given the cost of a replica and the fraction of it paid by replicas after the first
+func replicasCost(replicaCost float32, numReplicas int, shared float32) float32 {
+	if numReplicas <= 0 {
+		return 0
+	}
+	return replicaCost * (1 + shared*float32(numReplicas-1))
 }
 
 // Allocation in case of zero load
-func zeroLoadAllocation(server *Server, model *Model, acc *Accelerator, perf *config.ModelAcceleratorPerfData) *Allocation {
+func zeroLoadAllocation(system *System, server *Server, model *Model, acc *Accelerator, perf *config.ModelAcceleratorPerfData) *Allocation {
 
-	numReplicas := server.minNumReplicas
+	numReplicas, warmReplicas := server.warmPoolReplicas(0)
 	gName := acc.Name()
 	if numReplicas == 0 {
 		alloc := &Allocation{accelerator: "", numReplicas: 0, batchSize: 0,
-			cost: 0, itl: 0, ttft: 0, rho: 0, maxArrvRatePerReplica: 0}
-		alloc.SetValue(0)
+			cost: 0, objective: system.GetObjective(), itl: 0, ttft: 0, rho: 0, maxArrvRatePerReplica: 0, idle: true, sharedCost: 1}
+		alloc.SetValue(system.GetValueFunc()(alloc))
 		return alloc
 	}
 
@@ -272,73 +1102,142 @@ func zeroLoadAllocation(server *Server, model *Model, acc *Accelerator, perf *co
 		maxBatchSize = server.maxBatchSize
 	}
 	totalNumInstances := model.NumInstances(gName) * numReplicas
-	cost := acc.Cost() * float32(totalNumInstances)
+	cost := replicasCost(unitCost(acc, perf)*float32(model.NumInstances(gName)), numReplicas, sharedCost(perf))
 
 	//TODO: maxArrvRatePerReplica seems to be meaningless
-	decodeTime := perf.DecodeParms.Alpha + perf.DecodeParms.Beta
-	maxDecodeTime := perf.DecodeParms.Alpha + perf.DecodeParms.Beta*float32(maxBatchSize)
-	prefillTime := perf.PrefillParms.Gamma + perf.PrefillParms.Delta
+	tpFactor := perf.TPFactor()
+	decodeTime := (perf.DecodeParms.Alpha + perf.DecodeParms.Beta) * tpFactor
+	maxDecodeTime := (perf.DecodeParms.Alpha + perf.DecodeParms.Beta*float32(maxBatchSize)) * tpFactor
+	prefillTime := (perf.PrefillParms.Gamma + perf.PrefillParms.Delta) * tpFactor
 	maxServTime := prefillTime + maxDecodeTime
 	maxArrvRatePerReplica := float32(maxBatchSize) / maxServTime
+	batchWait, batchWaitP99 := batchDelay(perf)
 
 	alloc := &Allocation{accelerator: gName, numReplicas: numReplicas, batchSize: maxBatchSize,
-		cost: cost, itl: decodeTime, ttft: prefillTime, rho: 0, maxArrvRatePerReplica: maxArrvRatePerReplica}
-	alloc.SetValue(alloc.cost)
+		cost: cost, power: acc.Power(0) * float32(totalNumInstances), objective: system.GetObjective(), itl: decodeTime, ttft: batchWait + prefillTime, rho: 0, itlP99: decodeTime, ttftP99: batchWaitP99 + prefillTime,
+		maxArrvRatePerReplica: maxArrvRatePerReplica, requestedReplicas: numReplicas, idle: true, warm: warmReplicas, sharedCost: sharedCost(perf),
+		estimated: model.Estimated(gName)}
+	alloc.SetValue(system.GetValueFunc()(alloc))
 	return alloc
 }
 
 // Calculate penalty for transitioning from this allocation (a) to another allocation (b)
 func (a *Allocation) TransitionPenalty(b *Allocation) float32 {
+	aCost, bCost := a.costOf(b.objective), b.ObjectiveCost()
 	if a.accelerator == b.accelerator {
 		if a.numReplicas == b.numReplicas {
 			return 0
 		} else {
-			return b.cost - a.cost
+			return bCost - aCost
 		}
 	}
-	return config.AccelPenaltyFactor*(a.cost+b.cost) + (b.cost - a.cost)
+	return config.AccelPenaltyFactor*(aCost+bCost) + (bCost - aCost)
 }
 
 func (a *Allocation) Clone() *Allocation {
-	return &Allocation{
+	b := &Allocation{
 		accelerator: a.accelerator,
 		numReplicas: a.numReplicas,
 		batchSize:   a.batchSize,
 		cost:        a.cost,
+		power:       a.power,
+		objective:   a.objective,
 		value:       a.value,
 		itl:         a.itl,
 		ttft:        a.ttft,
 		rho:         a.rho,
+		itlP99:      a.itlP99,
+		ttftP99:     a.ttftP99,
+		dropRate:    a.dropRate,
+		low:         a.low,
 
 		maxArrvRatePerReplica: a.maxArrvRatePerReplica,
+		maxRatePerReplica:     a.maxRatePerReplica,
+		requestedReplicas:     a.requestedReplicas,
+		idle:                  a.idle,
+		warm:                  a.warm,
+		estimated:             a.estimated,
+		sharedCost:            a.sharedCost,
 	}
+	if a.secondary != nil {
+		b.secondary = a.secondary.Clone()
+	}
+	return b
 }
 
 func (a *Allocation) AllocationData() *config.AllocationData {
-	return &config.AllocationData{
+	data := &config.AllocationData{
 		Accelerator: a.accelerator,
 		NumReplicas: a.numReplicas,
 		MaxBatch:    a.batchSize,
 		Cost:        a.cost,
+		Power:       a.power,
 		ITLAverage:  a.itl,
 		TTFTAverage: a.ttft,
+		ITLP99:      a.itlP99,
+		TTFTP99:     a.ttftP99,
+		DropRate:    a.dropRate,
+		Rho:         a.rho,
+
+		RequestedReplicas: a.requestedReplicas,
+		Degraded:          a.Degraded(),
+		Idle:              a.idle,
+		Estimated:         a.estimated,
+		AboveMaxRho:       a.AboveMaxRho(),
+
+		WarmReplicas: a.warm,
+		WarmCost:     a.WarmCost(),
+	}
+	if a.low != nil {
+		low := *a.low
+		data.LowPriority = &low
 	}
+	if a.secondary != nil {
+		data.Secondary = a.secondary.AllocationData()
+	}
+	return data
 }
 
+// Allocation from its data, e.g. the current allocation of a server
+//   - replicas do not share the cost of model weights, as data does not give the shared cost; servers set it from
+//     the perf data of their models (see setSharedCost)
 func AllocationFromData(data *config.AllocationData) *Allocation {
-	return &Allocation{
+	alloc := &Allocation{
 		accelerator: data.Accelerator,
 		numReplicas: data.NumReplicas,
 		batchSize:   data.MaxBatch,
 		cost:        data.Cost,
+		power:       data.Power,
 		itl:         data.ITLAverage,
 		ttft:        data.TTFTAverage,
+		itlP99:      data.ITLP99,
+		ttftP99:     data.TTFTP99,
+		dropRate:    data.DropRate,
+		rho:         data.Rho,
+
+		requestedReplicas: data.RequestedReplicas,
+		idle:              data.Idle,
+		warm:              data.WarmReplicas,
+		estimated:         data.Estimated,
+		sharedCost:        1,
 	}
+	if data.LowPriority != nil {
+		low := *data.LowPriority
+		alloc.low = &low
+	}
+	if data.Secondary != nil {
+		alloc.secondary = AllocationFromData(data.Secondary)
+	}
+	return alloc
 }
 
 func (a *Allocation) String() string {
-	return fmt.Sprintf("{acc=%s; numRep=%d; maxBatch=%d; cost=%v, val=%v, itl=%v, ttft=%v, rho=%v, maxRPM=%v}",
-		a.accelerator, a.numReplicas, a.batchSize, a.cost, a.value, a.itl, a.ttft, a.rho, a.MaxRPM())
+	s := fmt.Sprintf("{acc=%s; numRep=%d; reqRep=%d; maxBatch=%d; cost=%v, power=%v, val=%v, itl=%v, ttft=%v, itlP99=%v, ttftP99=%v, drop=%v, rho=%v, maxRPM=%v}",
+		a.accelerator, a.numReplicas, a.requestedReplicas, a.batchSize, a.cost, a.power, a.value, a.itl, a.ttft, a.itlP99, a.ttftP99, a.dropRate, a.rho, a.MaxRPM())
+	if a.secondary != nil {
+		s += "+" + a.secondary.String()
+	}
+	return s
 }
 
 // Orchestration difference between two allocations
@@ -379,6 +1278,21 @@ func CreateAllocationDiff(a *Allocation, b *Allocation) *AllocationDiff {
 	}
 }
 
+// Check if the accelerator or number of replicas change
+func (d *AllocationDiff) Changed() bool {
+	return d.oldAccelerator != d.newAccelerator || d.oldNumReplicas != d.newNumReplicas
+}
+
+func (d *AllocationDiff) AllocationDiffData() *config.AllocationDiffData {
+	return &config.AllocationDiffData{
+		OldAccelerator: d.oldAccelerator,
+		NewAccelerator: d.newAccelerator,
+		OldNumReplicas: d.oldNumReplicas,
+		NewNumReplicas: d.newNumReplicas,
+		CostDiff:       d.costDiff,
+	}
+}
+
 func (d *AllocationDiff) String() string {
 	var b bytes.Buffer
 	fmt.Fprintf(&b, "{ %s -> %s, %d -> %d, %v }",
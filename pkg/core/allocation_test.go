@@ -0,0 +1,249 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/llm-inferno/optimizer/pkg/config"
+	"github.com/llm-inferno/optimizer/pkg/testutil"
+)
+
+// A shard factor rounds the number of replicas required by SLOs up to a multiple of the factor, charging the cost
+// of the added replicas
+func TestShardFactorRoundsReplicasUp(t *testing.T) {
+	spec := testutil.SystemSpec()
+	sharded := testutil.ServerSpec("sharded", "Premium", 600)
+	sharded.ShardFactor = 4
+	spec.Servers.Spec = append(spec.Servers.Spec, testutil.ServerSpec("unsharded", "Premium", 600), sharded)
+	system := testutil.SetFromSpec(t, NewSystem(), spec)
+
+	unshardedAlloc, err := CreateAllocation(system, "unsharded", "G2")
+	if err != nil {
+		t.Fatalf("unsharded allocation: %v", err)
+	}
+	if unshardedAlloc.NumReplicas() != 5 {
+		t.Fatalf("unsharded replicas=%d, want 5", unshardedAlloc.NumReplicas())
+	}
+	shardedAlloc, err := CreateAllocation(system, "sharded", "G2")
+	if err != nil {
+		t.Fatalf("sharded allocation: %v", err)
+	}
+	if shardedAlloc.NumReplicas() != 8 {
+		t.Errorf("sharded replicas=%d, want 8 (5 rounded up to a multiple of 4)", shardedAlloc.NumReplicas())
+	}
+	if want := float32(8 * 25); shardedAlloc.Cost() != want {
+		t.Errorf("sharded cost=%v, want %v (8 replicas of G2 at cost 25)", shardedAlloc.Cost(), want)
+	}
+}
+
+// The max number of replicas bounds the replicas required by SLOs (7 on G2 at 800 req/min): an allocation above the
+// cap is not feasible, or capped and degraded, according to the replica cap policy; the max takes precedence over
+// the min number of replicas
+func TestMaxNumReplicasCapsSLOReplicas(t *testing.T) {
+	tests := []struct {
+		name         string
+		minReplicas  int
+		maxReplicas  int
+		policy       string
+		wantErr      error
+		wantReplicas int
+		wantDegraded bool
+	}{
+		{name: "above SLO replicas", maxReplicas: 10, wantReplicas: 7},
+		{name: "infeasible by default", maxReplicas: 5, wantErr: ErrReplicaCap},
+		{name: "infeasible", maxReplicas: 5, policy: "infeasible", wantErr: ErrReplicaCap},
+		{name: "degrade", maxReplicas: 5, policy: "degrade", wantReplicas: 5, wantDegraded: true},
+		{name: "max over min", minReplicas: 9, maxReplicas: 8, wantReplicas: 8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := testutil.SystemSpec()
+			server := testutil.ServerSpec("capped", "Premium", 800)
+			server.MinNumReplicas, server.MaxNumReplicas, server.ReplicaCapPolicy = tt.minReplicas, tt.maxReplicas, tt.policy
+			spec.Servers.Spec = append(spec.Servers.Spec, server)
+			system := testutil.SetFromSpec(t, NewSystem(), spec)
+
+			alloc, err := CreateAllocation(system, "capped", "G2")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err=%v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("allocation: %v", err)
+			}
+			if alloc.NumReplicas() != tt.wantReplicas || alloc.Degraded() != tt.wantDegraded {
+				t.Errorf("replicas=%d, degraded=%v, want replicas=%d, degraded=%v",
+					alloc.NumReplicas(), alloc.Degraded(), tt.wantReplicas, tt.wantDegraded)
+			}
+			if tt.wantDegraded && alloc.RequestedReplicas() != 7 {
+				t.Errorf("requested replicas=%d, want 7 required by SLOs", alloc.RequestedReplicas())
+			}
+		})
+	}
+}
+
+// An allocation with a replica utilization above the max (0.74 at 600 req/min on 5 G2 replicas, above 0.7) is
+// flagged, with its utilization in its data; if enabled, replicas are added until the utilization is at most the
+// max, within the max number of replicas
+func TestAllocationAboveMaxRho(t *testing.T) {
+	tests := []struct {
+		name         string
+		addReplicas  bool
+		maxReplicas  int
+		wantReplicas int
+		wantAbove    bool
+	}{
+		{name: "flagged", wantReplicas: 5, wantAbove: true},
+		{name: "replicas added", addReplicas: true, wantReplicas: 6},
+		{name: "replicas added within max", addReplicas: true, maxReplicas: 5, wantReplicas: 5, wantAbove: true},
+	}
+	defer func(maxRho float32, add bool) {
+		config.MaxRho, config.AddReplicaAboveMaxRho = maxRho, add
+	}(config.MaxRho, config.AddReplicaAboveMaxRho)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.MaxRho, config.AddReplicaAboveMaxRho = 0.7, tt.addReplicas
+			spec := testutil.SystemSpec()
+			server := testutil.ServerSpec("busy", "Premium", 600)
+			server.MaxNumReplicas = tt.maxReplicas
+			spec.Servers.Spec = append(spec.Servers.Spec, server)
+			system := testutil.SetFromSpec(t, NewSystem(), spec)
+
+			alloc, err := CreateAllocation(system, "busy", "G2")
+			if err != nil {
+				t.Fatalf("allocation: %v", err)
+			}
+			if alloc.NumReplicas() != tt.wantReplicas || alloc.AboveMaxRho() != tt.wantAbove {
+				t.Errorf("replicas=%d, rho=%v, above max=%v, want replicas=%d, above max=%v",
+					alloc.NumReplicas(), alloc.Rho(), alloc.AboveMaxRho(), tt.wantReplicas, tt.wantAbove)
+			}
+			if data := alloc.AllocationData(); data.Rho != alloc.Rho() || data.AboveMaxRho != alloc.AboveMaxRho() {
+				t.Errorf("data rho=%v, above max=%v, want rho=%v, above max=%v",
+					data.Rho, data.AboveMaxRho, alloc.Rho(), alloc.AboveMaxRho())
+			}
+		})
+	}
+}
+
+// Cached allocations are keyed by the settings of the search of the max rate of a replica, so that changed settings
+// do not reuse allocations sized with other settings
+func TestAllocationKeyHasSearchSettings(t *testing.T) {
+	defer func(abs, rel float32, iterations, samples int) {
+		config.SearchAbsTolerance, config.SearchRelTolerance = abs, rel
+		config.SearchMaxIterations, config.SearchSamples = iterations, samples
+	}(config.SearchAbsTolerance, config.SearchRelTolerance, config.SearchMaxIterations, config.SearchSamples)
+
+	spec := testutil.SystemSpec()
+	spec.Servers.Spec = append(spec.Servers.Spec, testutil.ServerSpec("busy", "Premium", 600))
+	system := testutil.SetFromSpec(t, NewSystem(), spec)
+	in, err := getAllocationInputs(system, "busy", "G2")
+	if err != nil {
+		t.Fatalf("allocation inputs: %v", err)
+	}
+	newKey := func() allocationKey {
+		return newAllocationKey(in.server, in.model, in.acc, in.perf, in.target, in.lowTarget)
+	}
+
+	tests := []struct {
+		name   string
+		change func()
+	}{
+		{name: "absolute tolerance", change: func() { config.SearchAbsTolerance = 0.5 }},
+		{name: "relative tolerance", change: func() { config.SearchRelTolerance = 1e-3 }},
+		{name: "max iterations", change: func() { config.SearchMaxIterations = 10 }},
+		{name: "samples", change: func() { config.SearchSamples = 16 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := newKey()
+			tt.change()
+			if newKey() == key {
+				t.Errorf("same key after changing the %s", tt.name)
+			}
+		})
+	}
+}
+
+// Joint optimization of batch size and replicas is cheaper than sizing replicas at the max batch size, and finds the
+// cheapest batch size of a sweep of all batch sizes
+func TestOptimizeBatchCostsLessThanSequential(t *testing.T) {
+	spec := testutil.SystemSpec()
+	spec.ServiceClasses.Spec[0].ModelTargets[0].SLO_ITL = 30
+	spec.ServiceClasses.Spec[0].ModelTargets[0].SLO_TTFT = 2000
+	joint := testutil.ServerSpec("joint", "Premium", 1200)
+	joint.OptimizeBatch = true
+	spec.Servers.Spec = append(spec.Servers.Spec, testutil.ServerSpec("sequential", "Premium", 1200), joint)
+	system := testutil.SetFromSpec(t, NewSystem(), spec)
+
+	seqAlloc, err := CreateAllocation(system, "sequential", "A100")
+	if err != nil {
+		t.Fatalf("sequential allocation: %v", err)
+	}
+	jointAlloc, err := CreateAllocation(system, "joint", "A100")
+	if err != nil {
+		t.Fatalf("joint allocation: %v", err)
+	}
+	if jointAlloc.Cost() >= seqAlloc.Cost() {
+		t.Errorf("joint cost=%v (replicas=%d, batch=%d), want less than sequential cost=%v (replicas=%d, batch=%d)",
+			jointAlloc.Cost(), jointAlloc.NumReplicas(), jointAlloc.MaxBatchSize(),
+			seqAlloc.Cost(), seqAlloc.NumReplicas(), seqAlloc.MaxBatchSize())
+	}
+
+	points, err := SweepBatchSize(system, "joint", "A100")
+	if err != nil {
+		t.Fatalf("sweep: %v", err)
+	}
+	minCost := seqAlloc.Cost()
+	for _, point := range points {
+		if point.Error == "" {
+			minCost = min(minCost, point.Cost)
+		}
+	}
+	if jointAlloc.Cost() != minCost {
+		t.Errorf("joint cost=%v, want least cost of sweep=%v", jointAlloc.Cost(), minCost)
+	}
+}
+
+// Reallocation over a large catalog of accelerators, evaluating candidates serially (one worker) and concurrently
+// (two to eight workers); the speedup is bounded by the number of CPUs available
+func BenchmarkReAllocateLargeCatalog(b *testing.B) {
+	// a catalog of 64 accelerator types, variants of A100 and G2 with scaled costs and performance
+	spec := testutil.SystemSpec()
+	baseAccs, basePerfs := spec.Accelerators.Spec, spec.Models.PerfData
+	spec.Accelerators.Spec, spec.Models.PerfData = nil, nil
+	for i := range 32 {
+		scale := 1 + float32(i)/32
+		for j, acc := range baseAccs {
+			acc.Name = fmt.Sprintf("%s-%d", acc.Name, i)
+			acc.Type = acc.Name
+			acc.Cost *= scale
+			perf := basePerfs[j]
+			perf.Acc = acc.Name
+			perf.DecodeParms.Alpha /= scale
+			perf.PrefillParms.Gamma /= scale
+			spec.Accelerators.Spec = append(spec.Accelerators.Spec, acc)
+			spec.Models.PerfData = append(spec.Models.PerfData, perf)
+		}
+	}
+	spec.Servers.Spec = append(spec.Servers.Spec, testutil.ServerSpec("large", "Premium", 3000))
+	system := testutil.SetFromSpec(b, NewSystem(), spec)
+	alloc, err := CreateAllocation(system, "large", "A100-0")
+	if err != nil {
+		b.Fatalf("allocation: %v", err)
+	}
+
+	defer func(workers int) { config.MaxAllocationWorkers = workers }(config.MaxAllocationWorkers)
+	for _, workers := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			config.MaxAllocationWorkers = workers
+			for range b.N {
+				if _, _, err := alloc.ReAllocate(system, "large"); err != nil {
+					b.Fatalf("reallocate: %v", err)
+				}
+			}
+		})
+	}
+}
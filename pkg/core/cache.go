@@ -0,0 +1,128 @@
+package core
+
+import (
+	"sync"
+
+	"github.com/llm-inferno/optimizer/pkg/config"
+	"github.com/llm-inferno/optimizer/pkg/metrics"
+)
+
+// Inputs determining the allocation of an accelerator to a server, compared by value
+//   - a change in any spec yields a different key, hence entries never become stale
+//   - the objective and value function are not inputs, as the value is evaluated when an entry is used
+type allocationKey struct {
+	acc          config.AcceleratorSpec
+	numInstances int
+	perf         config.ModelAcceleratorPerfData
+	target       Target
+	load         config.ServerLoadSpec
+
+	// low priority stream sharing the replicas: its load and target (zero if none)
+	lowPriority bool
+	lowLoad     config.ServerLoadSpec
+	lowTarget   Target
+
+	minNumReplicas    int
+	warmReplicas      int
+	maxNumReplicas    int
+	replicaCapPolicy  config.ReplicaCapPolicy
+	shardFactor       int
+	maxBatchSize      int
+	optimizeBatchSize bool
+	queueModel        config.QueueModel
+	maxQueueRatio     int
+	maxRho            float32 // max utilization of a replica, if replicas are added above it (zero otherwise)
+
+	// settings of the search of the max rate of a replica at its targets
+	searchAbsTolerance  float32
+	searchRelTolerance  float32
+	searchMaxIterations int
+	searchSamples       int
+}
+
+// Result of creating an allocation: the allocation, or the reason it is not feasible
+type allocationResult struct {
+	alloc *Allocation
+	err   error
+}
+
+// Memoization of allocations, shared by all systems as entries are determined by their keys
+//   - cleared when full, bounding memory of long running what-if sweeps
+var allocationCache = struct {
+	sync.Mutex
+	entries map[allocationKey]allocationResult
+}{entries: make(map[allocationKey]allocationResult)}
+
+func newAllocationKey(server *Server, model *Model, acc *Accelerator, perf *config.ModelAcceleratorPerfData,
+	target *Target, lowTarget *Target) allocationKey {
+	key := allocationKey{
+		acc:          *acc.spec,
+		numInstances: model.NumInstances(acc.Name()),
+		perf:         *perf,
+		target:       *target,
+		load:         *server.ServedLoad(),
+
+		minNumReplicas:    server.minNumReplicas,
+		warmReplicas:      server.warmReplicas,
+		maxNumReplicas:    server.maxNumReplicas,
+		replicaCapPolicy:  server.replicaCapPolicy,
+		shardFactor:       server.shardFactor,
+		maxBatchSize:      server.maxBatchSize,
+		optimizeBatchSize: server.optimizeBatchSize,
+		queueModel:        server.SizingQueueModel(),
+		maxQueueRatio:     config.MaxQueueToBatchRatio,
+
+		searchAbsTolerance:  config.SearchAbsTolerance,
+		searchRelTolerance:  config.SearchRelTolerance,
+		searchMaxIterations: config.SearchMaxIterations,
+		searchSamples:       config.SearchSamples,
+	}
+	if config.AddReplicaAboveMaxRho {
+		key.maxRho = config.MaxRho
+	}
+	if server.lowPriority != nil && lowTarget != nil {
+		key.lowPriority = true
+		key.lowLoad = server.lowPriority.Load
+		key.lowTarget = *lowTarget
+	}
+	return key
+}
+
+// Get a cached allocation result; false if not cached
+//   - the allocation is a copy, with the objective and value of the system
+func cachedAllocation(system *System, key allocationKey) (allocationResult, bool) {
+	allocationCache.Lock()
+	result, exists := allocationCache.entries[key]
+	allocationCache.Unlock()
+	if !exists {
+		metrics.AllocationCacheLookups.Inc("miss")
+		return result, false
+	}
+	metrics.AllocationCacheLookups.Inc("hit")
+	if result.alloc != nil {
+		result.alloc = result.alloc.Clone()
+		result.alloc.objective = system.GetObjective()
+		result.alloc.SetValue(system.GetValueFunc()(result.alloc))
+	}
+	return result, true
+}
+
+// Cache an allocation result (a copy of the allocation)
+func cacheAllocation(key allocationKey, alloc *Allocation, err error) {
+	if alloc != nil {
+		alloc = alloc.Clone()
+	}
+	allocationCache.Lock()
+	defer allocationCache.Unlock()
+	if len(allocationCache.entries) >= config.MaxAllocationCacheSize {
+		clear(allocationCache.entries)
+	}
+	allocationCache.entries[key] = allocationResult{alloc: alloc, err: err}
+}
+
+// Remove all cached allocations
+func ClearAllocationCache() {
+	allocationCache.Lock()
+	defer allocationCache.Unlock()
+	clear(allocationCache.entries)
+}
@@ -0,0 +1,165 @@
+package core
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// Kinds of data of a system with consistency errors
+const (
+	AcceleratorKind  = "accelerator"
+	CapacityKind     = "capacity"
+	ModelKind        = "model"
+	PerfDataKind     = "perf data"
+	ServiceClassKind = "service class"
+	ServerKind       = "server"
+)
+
+// Inconsistency between data of a system, e.g. capacity of an accelerator type with no accelerator
+type ConsistencyError struct {
+	Kind string // kind of the inconsistent data, e.g. CapacityKind
+	Name string // name of the data, e.g. accelerator type of capacity, or model of perf data
+	Err  error  // inconsistency, wrapping a sentinel error where one applies, e.g. ErrNoAcceleratorType
+}
+
+func (e *ConsistencyError) Error() string {
+	return fmt.Sprintf("%s %s: %v", e.Kind, e.Name, e.Err)
+}
+
+func (e *ConsistencyError) Unwrap() error {
+	return e.Err
+}
+
+// Check that accelerator types have accelerators in the system, e.g. types of capacity being set
+func (s *System) CheckAcceleratorTypes(typeNames ...string) error {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	errs := make([]error, 0)
+	for _, typeName := range typeNames {
+		if !s.hasAcceleratorType(typeName) {
+			errs = append(errs, fmt.Errorf("%w: %s", ErrNoAcceleratorType, typeName))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Check if an accelerator of the system has a type
+func (s *System) hasAcceleratorType(typeName string) bool {
+	for _, acc := range s.accelerators {
+		if acc.Type() == typeName {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate the consistency of data of the system, returning consistency errors (*ConsistencyError), ordered by kind
+// of data then name; empty if consistent
+//   - accelerators: currencies differing from that of the first accelerator (by name) with a currency
+//   - capacity: counts of accelerator types with no accelerator
+//   - models: models with no perf data, and perf data on accelerators not in the system
+//   - service classes: targets of models not in the system, and reservations of accelerator types with no accelerator
+//   - servers: references to models, service classes, and accelerators not in the system (allowed, current,
+//     and pinned; denied accelerators need not be in the system), and models with no target in the service class
+//     of the server
+func (s *System) Validate() []error {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	errs := make([]error, 0)
+	add := func(kind string, name string, err error) {
+		errs = append(errs, &ConsistencyError{Kind: kind, Name: name, Err: err})
+	}
+
+	// accelerators
+	currency, currencyAcc := "", ""
+	for _, accName := range slices.Sorted(maps.Keys(s.accelerators)) {
+		acc := s.accelerators[accName]
+		switch {
+		case acc.Currency() == "":
+		case currency == "":
+			currency, currencyAcc = acc.Currency(), accName
+		case acc.Currency() != currency:
+			add(AcceleratorKind, accName, fmt.Errorf("currency=%s differs from currency=%s of accelerator %s",
+				acc.Currency(), currency, currencyAcc))
+		}
+	}
+
+	// capacity
+	for _, typeName := range slices.Sorted(maps.Keys(s.capacity)) {
+		if !s.hasAcceleratorType(typeName) {
+			add(CapacityKind, typeName, ErrNoAcceleratorType)
+		}
+	}
+
+	// models and their perf data
+	for _, modelName := range slices.Sorted(maps.Keys(s.models)) {
+		model := s.models[modelName]
+		measured := slices.Sorted(maps.Keys(model.perfData))
+		measured = slices.DeleteFunc(measured, model.Estimated)
+		if len(measured) == 0 {
+			add(ModelKind, modelName, ErrNoPerfData)
+		}
+		for _, accName := range measured {
+			if s.accelerators[accName] == nil {
+				add(PerfDataKind, modelName, fmt.Errorf("%w: %s", ErrNoAccelerator, accName))
+			}
+		}
+	}
+
+	// service classes
+	for _, className := range slices.Sorted(maps.Keys(s.serviceClasses)) {
+		svc := s.serviceClasses[className]
+		for _, modelName := range slices.Sorted(maps.Keys(svc.targets)) {
+			if s.models[modelName] == nil {
+				add(ServiceClassKind, className, fmt.Errorf("target: %w: %s", ErrNoModel, modelName))
+			}
+		}
+		for _, typeName := range slices.Sorted(maps.Keys(svc.reservations)) {
+			if !s.hasAcceleratorType(typeName) {
+				add(ServiceClassKind, className, fmt.Errorf("reservation: %w: %s", ErrNoAcceleratorType, typeName))
+			}
+		}
+	}
+
+	// servers
+	for _, serverName := range slices.Sorted(maps.Keys(s.servers)) {
+		server := s.servers[serverName]
+		if err := s.checkServerReferences(server.spec); err != nil {
+			add(ServerKind, serverName, err)
+		}
+		accNames := slices.Clone(server.allowedAccelerators)
+		if server.curAllocation != nil {
+			for _, leg := range server.curAllocation.Legs() {
+				accNames = append(accNames, leg.accelerator)
+			}
+		}
+		if server.pinned != nil {
+			accNames = append(accNames, server.pinned.Accelerator)
+		}
+		slices.Sort(accNames)
+		for _, accName := range slices.Compact(accNames) {
+			if accName != "" && s.accelerators[accName] == nil {
+				add(ServerKind, serverName, fmt.Errorf("%w: %s", ErrNoAccelerator, accName))
+			}
+		}
+		if svc := s.serviceClasses[server.serviceClassName]; svc != nil && svc.ModelTarget(server.modelName) == nil {
+			add(ServerKind, serverName, fmt.Errorf("%w: %s in %s", ErrNoTarget, server.modelName, server.serviceClassName))
+		}
+	}
+	slices.SortStableFunc(errs, func(a, b error) int {
+		return cmp.Compare(kindOrder(a), kindOrder(b))
+	})
+	return errs
+}
+
+// order of the kind of data of a consistency error
+func kindOrder(err error) int {
+	var e *ConsistencyError
+	if errors.As(err, &e) {
+		return slices.Index([]string{AcceleratorKind, CapacityKind, ModelKind, PerfDataKind, ServiceClassKind, ServerKind}, e.Kind)
+	}
+	return -1
+}
@@ -0,0 +1,88 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/llm-inferno/optimizer/pkg/analyzer"
+)
+
+// Errors of creating an allocation of an accelerator to a server
+var (
+	ErrNoAccelerator     = errors.New("accelerator not found")
+	ErrNoAcceleratorType = errors.New("no accelerator of type")
+	ErrNoServer          = errors.New("server not found")
+	ErrServerExists      = errors.New("server already exists")
+	ErrInvalidLoad       = errors.New("invalid server load")
+	ErrNoModel           = errors.New("model not found")
+	ErrNoPerfData        = errors.New("no performance data of model on accelerator")
+	ErrNoServiceClass    = errors.New("service class not found")
+	ErrNoTarget          = errors.New("no target for model in service class")
+	ErrNoCandidate       = errors.New("no candidate accelerators")
+	ErrCapacityExhausted = errors.New("accelerator capacity exhausted")
+	ErrBudgetExhausted   = errors.New("cost budget exhausted")
+	ErrPowerExhausted    = errors.New("power budget exhausted")
+	ErrSpotPolicy        = errors.New("no candidate accelerators allowed by spot policy")
+	ErrNoAllocation      = errors.New("no allocation of server")
+	ErrTimeout           = errors.New("optimization time limit exceeded")
+	ErrRename            = errors.New("name cannot be changed by an update")
+	ErrReplicaCap        = errors.New("replicas required exceed max number of replicas")
+	ErrScaledToZero      = errors.New("scaled to zero under zero load")
+
+	ErrUnattainableTTFT    = analyzer.ErrUnattainableTTFT
+	ErrUnattainableITL     = analyzer.ErrUnattainableITL
+	ErrUnattainableTTFTP99 = analyzer.ErrUnattainableTTFTP99
+	ErrUnattainableITLP99  = analyzer.ErrUnattainableITLP99
+	ErrUnattainableDrop    = analyzer.ErrUnattainableDrop
+	ErrUnattainableWait    = analyzer.ErrUnattainableWait
+)
+
+// reasons of infeasible allocations, in order of precedence when classifying an error
+var allocationReasons = []error{
+	ErrNoAccelerator, ErrNoServer, ErrInvalidLoad, ErrNoModel, ErrNoPerfData, ErrNoServiceClass, ErrNoTarget,
+	ErrUnattainableTTFT, ErrUnattainableITL, ErrUnattainableTTFTP99, ErrUnattainableITLP99,
+	ErrUnattainableDrop, ErrUnattainableWait, ErrReplicaCap, ErrTimeout,
+}
+
+// Classify an allocation error by its reason; the error itself if not a known reason
+func allocationReason(err error) error {
+	for _, reason := range allocationReasons {
+		if errors.Is(err, reason) {
+			return reason
+		}
+	}
+	return err
+}
+
+// Reason of an infeasible allocation, for reporting; other if not a known reason
+func InfeasibleReason(err error) string {
+	if reason := allocationReason(err); reason != err {
+		return reason.Error()
+	}
+	return "other"
+}
+
+// Summarize allocation errors by accelerator into a reason string, grouping accelerators with the same reason
+func summarizeAllocationErrors(errs map[string]error) string {
+	if len(errs) == 0 {
+		return ErrNoCandidate.Error()
+	}
+	gNamesByReason := make(map[string][]string)
+	for _, gName := range slices.Sorted(maps.Keys(errs)) {
+		reason := allocationReason(errs[gName]).Error()
+		gNamesByReason[reason] = append(gNamesByReason[reason], gName)
+	}
+	if len(gNamesByReason) == 1 {
+		for reason := range gNamesByReason {
+			return reason + " on all accelerators"
+		}
+	}
+	parts := make([]string, 0, len(gNamesByReason))
+	for _, reason := range slices.Sorted(maps.Keys(gNamesByReason)) {
+		parts = append(parts, fmt.Sprintf("%s on %s", reason, strings.Join(gNamesByReason[reason], ", ")))
+	}
+	return strings.Join(parts, "; ")
+}
@@ -2,6 +2,9 @@ package core
 
 import (
 	"fmt"
+	"maps"
+	"math"
+	"slices"
 
 	"github.com/llm-inferno/optimizer/pkg/config"
 )
@@ -15,6 +18,9 @@ type Model struct {
 
 	// number of accelerator instances needed to fit a model on a given accelerator
 	numInstances map[string]int
+
+	// accelerators with performance data estimated from other accelerators
+	estimated map[string]bool
 }
 
 func NewModel(name string) *Model {
@@ -22,12 +28,70 @@ func NewModel(name string) *Model {
 		name:         name,
 		perfData:     make(map[string]*config.ModelAcceleratorPerfData),
 		numInstances: make(map[string]int),
+		estimated:    make(map[string]bool),
 	}
 }
 
 // Calculate basic parameters
+//   - performance data estimated by an earlier calculation is removed
 func (m *Model) Calculate(accelerators map[string]*Accelerator) {
-	// add any operations here
+	for accName := range m.estimated {
+		delete(m.perfData, accName)
+		delete(m.numInstances, accName)
+	}
+	clear(m.estimated)
+}
+
+// Estimate performance data of the model on accelerators with no performance data, given their relative throughput
+//   - the reference is the accelerator with measured performance data and the closest relative throughput
+//     (then the first by name); decode and prefill times are scaled by the ratio of relative throughputs
+//   - other parameters (e.g. max batch size, number of instances) are those of the reference
+//   - accelerators with no relative throughput, or with no reference, are not estimated
+func (m *Model) EstimatePerfData(accelerators map[string]*Accelerator) {
+	accNames := slices.Sorted(maps.Keys(accelerators))
+	for _, accName := range accNames {
+		throughput := accelerators[accName].RelativeThroughput()
+		if m.perfData[accName] != nil || throughput <= 0 {
+			continue
+		}
+		var ref *config.ModelAcceleratorPerfData
+		var refThroughput float32
+		for _, refName := range accNames {
+			perf := m.perfData[refName]
+			t := accelerators[refName].RelativeThroughput()
+			if perf == nil || m.estimated[refName] || t <= 0 {
+				continue
+			}
+			if ref == nil || throughputDistance(t, throughput) < throughputDistance(refThroughput, throughput) {
+				ref, refThroughput = perf, t
+			}
+		}
+		if ref == nil {
+			continue
+		}
+		factor := refThroughput / throughput
+		perf := *ref
+		perf.Acc = accName
+		perf.DecodeParms.Alpha *= factor
+		perf.DecodeParms.Beta *= factor
+		perf.PrefillParms.Gamma *= factor
+		perf.PrefillParms.Delta *= factor
+		perf.UnitCost = 0
+		perf.WarmupSeconds = 0
+		m.perfData[accName] = &perf
+		m.numInstances[accName] = m.numInstances[ref.Acc]
+		m.estimated[accName] = true
+	}
+}
+
+// distance between two relative throughputs, symmetric in their ratio
+func throughputDistance(a, b float32) float64 {
+	return math.Abs(math.Log(float64(a / b)))
+}
+
+// Performance data of the model on the accelerator is estimated from other accelerators
+func (m *Model) Estimated(acceleratorName string) bool {
+	return m.estimated[acceleratorName]
 }
 
 func (m *Model) Name() string {
@@ -42,7 +106,11 @@ func (m *Model) PerfData(acceleratorName string) *config.ModelAcceleratorPerfDat
 	return m.perfData[acceleratorName]
 }
 
-func (m *Model) AddPerfDataFromSpec(spec *config.ModelAcceleratorPerfData) {
+// Add performance data of the model on an accelerator; error if data is not valid
+func (m *Model) AddPerfDataFromSpec(spec *config.ModelAcceleratorPerfData) error {
+	if err := spec.Validate(); err != nil {
+		return err
+	}
 	if spec.Name == m.name {
 		m.perfData[spec.Acc] = spec
 		var count int
@@ -50,25 +118,59 @@ func (m *Model) AddPerfDataFromSpec(spec *config.ModelAcceleratorPerfData) {
 			count = 1
 		}
 		m.numInstances[spec.Acc] = count
+		delete(m.estimated, spec.Acc)
 	}
+	return nil
 }
 
 func (m *Model) RemovePerfData(accName string) {
 	delete(m.perfData, accName)
+	delete(m.estimated, accName)
 }
 
 func (m *Model) Spec() *config.ModelData {
 	md := &config.ModelData{
-		PerfData: make([]config.ModelAcceleratorPerfData, len(m.perfData)),
+		PerfData: make([]config.ModelAcceleratorPerfData, 0, len(m.perfData)),
 	}
-	i := 0
-	for _, pd := range m.perfData {
-		md.PerfData[i] = *pd
-		i++
+	for _, accName := range slices.Sorted(maps.Keys(m.perfData)) {
+		if !m.estimated[accName] {
+			md.PerfData = append(md.PerfData, *m.perfData[accName])
+		}
 	}
 	return md
 }
 
+// Performance data of a model on an accelerator given an entry to import, with the result of the import
+//   - decode parameters are fitted to the measured decode times of the entry if not given (both zero),
+//     with the coefficient of determination of the fit; the fit is poor if below the minimum
+//   - the max batch size, if not given, is the largest measured batch size
+//   - the error of the result is set if the measured decode times cannot be fitted or the perf data is not valid
+func PerfDataFromImport(entry *config.PerfImportEntry) (*config.ModelAcceleratorPerfData, *config.PerfImportResult) {
+	perf := entry.ModelAcceleratorPerfData
+	result := &config.PerfImportResult{Name: perf.Name, Acc: perf.Acc, Points: len(entry.Points)}
+	if perf.MaxBatchSize == 0 {
+		for _, point := range entry.Points {
+			perf.MaxBatchSize = max(perf.MaxBatchSize, point.BatchSize)
+		}
+	}
+	if perf.DecodeParms.Alpha == 0 && perf.DecodeParms.Beta == 0 {
+		alpha, beta, rSquared, err := config.FitPerf(entry.Points)
+		if err != nil {
+			result.Error = fmt.Sprintf("failed to fit decode parameters of model %s on accelerator %s: %v", perf.Name, perf.Acc, err)
+			return &perf, result
+		}
+		perf.DecodeParms = config.DecodeParms{Alpha: alpha, Beta: beta}
+		perf.FitRSquared = rSquared
+		result.Fitted = true
+		result.RSquared = rSquared
+		result.PoorFit = rSquared < config.MinPerfFitRSquared
+	}
+	if err := perf.Validate(); err != nil {
+		result.Error = err.Error()
+	}
+	return &perf, result
+}
+
 func (m *Model) String() string {
 	return fmt.Sprintf("Model: name=%s; numInstances=%v",
 		m.name, m.numInstances)
@@ -0,0 +1,173 @@
+package core
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/llm-inferno/optimizer/pkg/config"
+)
+
+// SLO targets perturbed in sensitivity analysis, in order
+var sensitivitySLOs = []struct {
+	name    string
+	relaxUp bool // relaxed by increasing the target (latency), or else by decreasing it (throughput)
+	get     func(t *config.ModelTarget) float32
+	set     func(t *config.ModelTarget, v float32)
+}{
+	{"slo-itl", true,
+		func(t *config.ModelTarget) float32 { return float32(t.SLO_ITL) },
+		func(t *config.ModelTarget, v float32) { t.SLO_ITL = config.Msec(v) }},
+	{"slo-ttft", true,
+		func(t *config.ModelTarget) float32 { return float32(t.SLO_TTFT) },
+		func(t *config.ModelTarget, v float32) { t.SLO_TTFT = config.Msec(v) }},
+	{"slo-tps", false,
+		func(t *config.ModelTarget) float32 { return float32(t.SLO_TPS) },
+		func(t *config.ModelTarget, v float32) { t.SLO_TPS = config.TokensPerSec(v) }},
+}
+
+// Sensitivity of the cost of servers to their SLO targets (ITL, TTFT, and TPS), without changing state
+//   - each target considered (non-zero) of a server is relaxed and tightened by a relative delta, one at a time,
+//     and the best allocation of the server recalculated as when checking its feasibility, regardless of capacity
+//   - a perturbation not feasible is halved (up to config.SensitivityHalvings times) to stay feasible, with the
+//     reason the requested one is not feasible
+//   - the gradient of cost is a central difference if both perturbations are feasible, one-sided otherwise
+//   - the sensitivity of the fleet sums cost changes of servers feasible at their current targets
+func (s *System) Sensitivity(request *config.SensitivityRequest) (*config.SensitivityResult, error) {
+	delta := request.Delta
+	if delta == 0 {
+		delta = config.DefaultSensitivityDelta
+	}
+	servers := s.Spec().Servers.Spec
+	if request.Server != "" {
+		i := slices.IndexFunc(servers, func(spec config.ServerSpec) bool { return spec.Name == request.Server })
+		if i < 0 {
+			return nil, fmt.Errorf("%w: %s", ErrNoServer, request.Server)
+		}
+		servers = servers[i : i+1]
+	}
+
+	result := &config.SensitivityResult{
+		Delta:   delta,
+		Servers: make([]config.ServerSensitivity, 0, len(servers)),
+		Fleet:   config.FleetSensitivity{SLOs: make([]config.FleetSLOSensitivity, 0)},
+	}
+	fleet := make(map[string]*config.FleetSLOSensitivity)
+	for i := range servers {
+		sensitivity, err := s.serverSensitivity(&servers[i], delta)
+		if err != nil {
+			return nil, err
+		}
+		result.Servers = append(result.Servers, *sensitivity)
+		if !sensitivity.Feasible {
+			continue
+		}
+		result.Fleet.Cost += sensitivity.Cost
+		for _, slo := range sensitivity.SLOs {
+			f := fleet[slo.Name]
+			if f == nil {
+				f = &config.FleetSLOSensitivity{Name: slo.Name, Infeasible: make([]string, 0)}
+				fleet[slo.Name] = f
+			}
+			f.RelaxedCostChange += slo.Relaxed.CostChange
+			f.TightenedCostChange += slo.Tightened.CostChange
+			if slo.Relaxed.Reason != "" || slo.Tightened.Reason != "" {
+				f.Infeasible = append(f.Infeasible, sensitivity.Server)
+			}
+		}
+	}
+	for _, slo := range sensitivitySLOs {
+		if f := fleet[slo.name]; f != nil {
+			result.Fleet.SLOs = append(result.Fleet.SLOs, *f)
+		}
+	}
+	return result, nil
+}
+
+// Sensitivity of the cost of the best allocation of a server to its SLO targets, given a relative delta
+func (s *System) serverSensitivity(serverSpec *config.ServerSpec, delta float32) (*config.ServerSensitivity, error) {
+	sensitivity := &config.ServerSensitivity{Server: serverSpec.Name, SLOs: make([]config.SLOSensitivity, 0)}
+	target := s.serverModelTarget(serverSpec)
+	base, err := s.CheckFeasibility(&config.FeasibilityRequest{Server: *serverSpec, Target: target})
+	if err != nil {
+		return nil, err
+	}
+	if !base.Feasible || target == nil {
+		sensitivity.Reason = base.Reason
+		return sensitivity, nil
+	}
+	sensitivity.Feasible, sensitivity.Cost = true, base.Best.Cost
+
+	for _, slo := range sensitivitySLOs {
+		value := slo.get(target)
+		if value <= 0 {
+			continue
+		}
+		sign := float32(1)
+		if !slo.relaxUp {
+			sign = -1
+		}
+		sloSensitivity := config.SLOSensitivity{Name: slo.name, Target: value}
+		for _, p := range []struct {
+			perturbation *config.SLOPerturbation
+			sign         float32
+		}{{&sloSensitivity.Relaxed, sign}, {&sloSensitivity.Tightened, -sign}} {
+			if *p.perturbation, err = s.perturbSLO(serverSpec, target, slo.set, value, p.sign*delta, base.Best.Cost); err != nil {
+				return nil, err
+			}
+		}
+		relaxed, tightened := &sloSensitivity.Relaxed, &sloSensitivity.Tightened
+		switch {
+		case relaxed.Feasible && tightened.Feasible:
+			sloSensitivity.Gradient = (relaxed.Cost - tightened.Cost) / (relaxed.Target - tightened.Target)
+		case relaxed.Feasible:
+			sloSensitivity.Gradient = relaxed.CostChange / (relaxed.Target - value)
+		case tightened.Feasible:
+			sloSensitivity.Gradient = tightened.CostChange / (tightened.Target - value)
+		}
+		sensitivity.SLOs = append(sensitivity.SLOs, sloSensitivity)
+	}
+	return sensitivity, nil
+}
+
+// Cost of the best allocation of a server with an SLO target perturbed by a relative delta (signed), given a setter
+// of the SLO, its current value, and the current cost
+//   - the delta is halved while not feasible, up to config.SensitivityHalvings times
+func (s *System) perturbSLO(serverSpec *config.ServerSpec, target *config.ModelTarget, set func(*config.ModelTarget, float32),
+	value float32, delta float32, baseCost float32) (config.SLOPerturbation, error) {
+
+	p := config.SLOPerturbation{Target: value * (1 + delta)}
+	for i := 0; i <= config.SensitivityHalvings; i, delta = i+1, delta/2 {
+		perturbed := *target
+		set(&perturbed, value*(1+delta))
+		result, err := s.CheckFeasibility(&config.FeasibilityRequest{Server: *serverSpec, Target: &perturbed})
+		if err != nil {
+			return p, err
+		}
+		if result.Feasible {
+			p.Target, p.Feasible, p.Bounded = value*(1+delta), true, i > 0
+			p.Cost, p.CostChange = result.Best.Cost, result.Best.Cost-baseCost
+			return p, nil
+		}
+		if i == 0 {
+			p.Reason = result.Reason
+		}
+	}
+	return p, nil
+}
+
+// Target of the model of a server in its service class (the default class if none), as a spec; nil if none
+func (s *System) serverModelTarget(serverSpec *config.ServerSpec) *config.ModelTarget {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	svc := s.serviceClasses[cmp.Or(serverSpec.Class, config.DefaultServiceClassName)]
+	if svc == nil {
+		return nil
+	}
+	target := svc.ModelTarget(serverSpec.Model)
+	if target == nil {
+		return nil
+	}
+	spec := target.ModelTarget(serverSpec.Model)
+	return &spec
+}
@@ -1,7 +1,12 @@
 package core
 
 import (
+	"cmp"
+	"errors"
 	"fmt"
+	"maps"
+	"math"
+	"slices"
 
 	"github.com/llm-inferno/optimizer/pkg/config"
 )
@@ -13,21 +18,56 @@ type Server struct {
 	modelName        string
 	keepAccelerator  bool
 	minNumReplicas   int
+	warmReplicas     int
+	maxNumReplicas   int
+	shardFactor      int
 	maxBatchSize     int
 
-	// server load statistics
+	// accelerators the server may be allocated to (all if empty), and may not be allocated to
+	allowedAccelerators []string
+	deniedAccelerators  []string
+
+	// jointly optimize batch size and number of replicas
+	optimizeBatchSize bool
+
+	// queueing model used to size the server
+	queueModel config.QueueModel
+
+	// handling of SLOs requiring more than the max number of replicas
+	replicaCapPolicy config.ReplicaCapPolicy
+
+	// server load statistics (of the model, not including adapters)
 	load *config.ServerLoadSpec
 
+	// adapters of the model served in the same batch, with their loads
+	adapters []config.AdapterSpec
+
+	// stream of low priority requests sharing the replicas, with its load (nil if none)
+	lowPriority *config.LowPrioritySpec
+
+	// allocation imposed by an operator, honored as given (nil if none), and its evaluation at the load of the server
+	pinned     *config.AllocationData
+	pinnedEval *config.AllocationEvaluation
+
 	// for all accelerators
 	allAllocations map[string]*Allocation
 
+	// reasons of infeasible allocations, by accelerator
+	allocationErrors map[string]error
+
 	// allocated solution
 	allocation *Allocation
 
+	// reason set by the solver for not allocating feasible allocations (capacity exhausted if nil)
+	unallocatedError error
+
 	// current allocation
 	curAllocation *Allocation
 
 	spec *config.ServerSpec
+
+	// system the server belongs to
+	system *System
 }
 
 func NewServerFromSpec(spec *config.ServerSpec) *Server {
@@ -36,49 +76,144 @@ func NewServerFromSpec(spec *config.ServerSpec) *Server {
 	if svcName == "" {
 		svcName = config.DefaultServiceClassName
 	}
+	var lowPriority *config.LowPrioritySpec
+	if spec.LowPriority != nil {
+		lp := *spec.LowPriority
+		lowPriority = &lp
+	}
+	var pinned *config.AllocationData
+	if spec.PinnedAllocation != nil {
+		p := *spec.PinnedAllocation
+		pinned = &p
+	}
 	return &Server{
 		name:             spec.Name,
 		serviceClassName: svcName,
 		modelName:        spec.Model,
 		load:             &ld,
+		adapters:         slices.Clone(spec.Adapters),
+		lowPriority:      lowPriority,
+		pinned:           pinned,
 		keepAccelerator:  spec.KeepAccelerator,
-		minNumReplicas:   spec.MinNumReplicas,
-		maxBatchSize:     spec.MaxBatchSize,
 
-		allAllocations: map[string]*Allocation{},
-		curAllocation:  AllocationFromData(&spec.CurrentAlloc),
-		spec:           spec,
+		allowedAccelerators: slices.Clone(spec.AllowedAccelerators),
+		deniedAccelerators:  slices.Clone(spec.DeniedAccelerators),
+
+		minNumReplicas: spec.MinNumReplicas,
+		warmReplicas:   spec.WarmReplicas,
+		maxNumReplicas: spec.MaxNumReplicas,
+		shardFactor:    spec.ShardFactor,
+		maxBatchSize:   spec.MaxBatchSize,
+
+		optimizeBatchSize: spec.OptimizeBatch,
+		queueModel:        config.QueueModelEnum(spec.QueueModel),
+		replicaCapPolicy:  config.ReplicaCapPolicyEnum(spec.ReplicaCapPolicy),
+
+		allAllocations:   map[string]*Allocation{},
+		allocationErrors: map[string]error{},
+		curAllocation:    AllocationFromData(&spec.CurrentAlloc),
+		spec:             spec,
 	}
 }
 
 // Calculate allocations for a set of accelerators
+//   - the pinned allocation only, if the server is pinned
 func (s *Server) Calculate(accelerators map[string]*Accelerator) {
+	s.setSharedCost(s.curAllocation)
+	if s.pinned != nil {
+		s.calculatePinned()
+		return
+	}
 	candidateAccelerators := s.GetCandidateAccelerators(accelerators)
 	s.allAllocations = make(map[string]*Allocation)
+	s.allocationErrors = make(map[string]error)
 	for _, g := range candidateAccelerators {
-		if alloc := CreateAllocation(s.name, g.Name()); alloc != nil {
-			if s.curAllocation != nil {
-				penalty := s.curAllocation.TransitionPenalty(alloc)
-				alloc.SetValue(penalty)
+		alloc, err := CreateAllocation(s.system, s.name, g.Name())
+		if err != nil {
+			s.allocationErrors[g.Name()] = err
+			continue
+		}
+		if s.curAllocation != nil {
+			// transition penalty (in units of cost), converted to units of the value function by the ratio of
+			// value to cost of the allocation
+			penalty := s.curAllocation.TransitionPenalty(alloc)
+			if curAcc := accelerators[s.curAllocation.accelerator]; curAcc != nil && curAcc.Draining() &&
+				alloc.accelerator != s.curAllocation.accelerator {
+				// no penalty for moving away from a draining accelerator, other than the cost difference
+				penalty = alloc.ObjectiveCost() - s.curAllocation.costOf(alloc.objective)
 			}
-			s.allAllocations[g.Name()] = alloc
+			scale := float32(1)
+			if cost := alloc.ObjectiveCost(); cost > 0 {
+				scale = alloc.Value() / cost
+			}
+			alloc.SetValue(penalty * scale)
 		}
+		s.allAllocations[g.Name()] = alloc
+	}
+}
+
+// Calculate the pinned allocation of the server, evaluated at its load for compliance with its SLO targets
+//   - the pinned accelerator is taken as given, even if not allowed for the server or draining
+func (s *Server) calculatePinned() {
+	s.allAllocations = make(map[string]*Allocation)
+	s.allocationErrors = make(map[string]error)
+	alloc, eval, err := evaluateAllocation(s.system, s.name, s.pinned)
+	s.pinnedEval = eval
+	if err != nil {
+		s.allocationErrors[s.pinned.Accelerator] = err
+		return
+	}
+	s.allAllocations[s.pinned.Accelerator] = alloc
+}
+
+// Set the same error for all candidate accelerators, as allocations could not be calculated
+func (s *Server) failCalculate(accelerators map[string]*Accelerator, err error) {
+	s.allAllocations = make(map[string]*Allocation)
+	s.allocationErrors = make(map[string]error)
+	for gName := range s.GetCandidateAccelerators(accelerators) {
+		s.allocationErrors[gName] = err
 	}
 }
 
 // Create a subset of candidate accelerators for a server from a given set
+//   - the current accelerator only, if the server keeps its accelerator
+//   - accelerators allowed for the server only, if any, excluding accelerators denied for the server
+//   - excluding draining accelerators, unless the server is currently allocated to them
 func (s *Server) GetCandidateAccelerators(accelerators map[string]*Accelerator) map[string]*Accelerator {
 	if s.keepAccelerator {
 		if s.curAllocation != nil && s.curAllocation.accelerator != "" {
 			accMap := make(map[string]*Accelerator)
 			curAccName := s.curAllocation.accelerator
-			if curAcc := accelerators[curAccName]; curAcc != nil {
+			if curAcc := accelerators[curAccName]; curAcc != nil && s.AcceleratorAllowed(curAccName) {
 				accMap[curAccName] = curAcc
 			}
 			return accMap
 		}
 	}
-	return accelerators
+	accMap := make(map[string]*Accelerator)
+	for gName, acc := range accelerators {
+		if s.AcceleratorAllowed(gName) && (!acc.Draining() || s.CurrentlyOn(gName)) {
+			accMap[gName] = acc
+		}
+	}
+	return accMap
+}
+
+// Check if the current allocation of the server uses an accelerator
+func (s *Server) CurrentlyOn(gName string) bool {
+	if s.curAllocation == nil {
+		return false
+	}
+	return s.curAllocation.accelerator == gName ||
+		(s.curAllocation.secondary != nil && s.curAllocation.secondary.accelerator == gName)
+}
+
+// Check if the server may be allocated to an accelerator, given its allowed and denied accelerators
+func (s *Server) AcceleratorAllowed(gName string) bool {
+	if len(s.allowedAccelerators) > 0 && !slices.Contains(s.allowedAccelerators, gName) {
+		return false
+	}
+	return !slices.Contains(s.deniedAccelerators, gName)
 }
 
 func (s *Server) Name() string {
@@ -90,12 +225,26 @@ func (s *Server) ServiceClassName() string {
 }
 
 func (s *Server) Priority() int {
-	if svc := GetServiceClass(s.serviceClassName); svc != nil {
+	if s.system == nil {
+		return config.DefaultServiceClassPriority
+	}
+	if svc := s.system.GetServiceClass(s.serviceClassName); svc != nil {
 		return svc.Priority()
 	}
 	return config.DefaultServiceClassPriority
 }
 
+// Weight of the service class of the server, its relative share of leftover capacity under saturation
+func (s *Server) Weight() float32 {
+	if s.system == nil {
+		return config.DefaultServiceClassWeight
+	}
+	if svc := s.system.GetServiceClass(s.serviceClassName); svc != nil {
+		return svc.Weight()
+	}
+	return config.DefaultServiceClassWeight
+}
+
 func (s *Server) ModelName() string {
 	return s.modelName
 }
@@ -104,14 +253,216 @@ func (s *Server) KeepAccelerator() bool {
 	return s.keepAccelerator
 }
 
+// Check if the server is pinned to an allocation imposed by an operator, honored as given by solvers
+func (s *Server) Pinned() bool {
+	return s.pinned != nil
+}
+
+// Evaluation of the pinned allocation of the server at its load (nil if not pinned or not feasible)
+func (s *Server) PinnedEvaluation() *config.AllocationEvaluation {
+	return s.pinnedEval
+}
+
+func (s *Server) ShardFactor() int {
+	return s.shardFactor
+}
+
+// Round up a number of replicas to a multiple of the shard factor of the server
+func (s *Server) ShardReplicas(numReplicas int) int {
+	if s.shardFactor <= 1 || numReplicas%s.shardFactor == 0 {
+		return numReplicas
+	}
+	return (numReplicas/s.shardFactor + 1) * s.shardFactor
+}
+
+// Round down a number of replicas to a multiple of the shard factor of the server
+func (s *Server) ShardReplicasFloor(numReplicas int) int {
+	if s.shardFactor <= 1 {
+		return numReplicas
+	}
+	return numReplicas - numReplicas%s.shardFactor
+}
+
+// Max number of replicas of the server (zero if unbounded)
+func (s *Server) MaxNumReplicas() int {
+	return s.maxNumReplicas
+}
+
+// Number of replicas of the server given a number of replicas required by SLOs, within the min and max number of replicas,
+// and the number of these replicas provisioned for the warm pool of the server, beyond those sized for SLOs
+func (s *Server) warmPoolReplicas(sloReplicas int) (numReplicas int, warmReplicas int) {
+	sizedReplicas := s.CapReplicas(s.ShardReplicas(max(sloReplicas, s.minNumReplicas)))
+	numReplicas = s.CapReplicas(s.ShardReplicas(max(sloReplicas, s.minNumReplicas, s.warmReplicas)))
+	return numReplicas, numReplicas - sizedReplicas
+}
+
+// Cap a number of replicas to the max number of replicas of the server, if any, keeping a multiple of the shard factor
+func (s *Server) CapReplicas(numReplicas int) int {
+	if s.maxNumReplicas <= 0 {
+		return numReplicas
+	}
+	return min(numReplicas, s.ShardReplicasFloor(s.maxNumReplicas))
+}
+
+func (s *Server) QueueModel() config.QueueModel {
+	return s.queueModel
+}
+
+// Queueing model used to size the server under its current load
+//   - the MM1StateDependent model assumes Poisson arrivals, which is optimistic for bursty traffic,
+//     hence the GGm model is used instead if the arrival COV exceeds the bursty threshold
+func (s *Server) SizingQueueModel() config.QueueModel {
+	load := s.ServedLoad()
+	if s.queueModel == config.MM1StateDependent && load != nil &&
+		config.BurstyArrivalCOV > 0 && load.ArrivalCOV > config.BurstyArrivalCOV {
+		return config.GGm
+	}
+	return s.queueModel
+}
+
+// Load of the model of the server, not including adapters
 func (s *Server) Load() *config.ServerLoadSpec {
 	return s.load
 }
 
+// Adapters of the model served in the same batch, with their loads
+func (s *Server) Adapters() []config.AdapterSpec {
+	return s.adapters
+}
+
+// Stream of low priority requests sharing the replicas of the server (nil if none)
+func (s *Server) LowPriority() *config.LowPrioritySpec {
+	return s.lowPriority
+}
+
+// Load served by the server: the load of the model aggregated with loads of its adapters and of its low priority stream,
+// sharing a batch
+//   - arrival rates are summed, and other statistics are averaged weighted by arrival rates
+//   - the load of the model if no adapters and no low priority stream
+func (s *Server) ServedLoad() *config.ServerLoadSpec {
+	if s.load == nil || s.lowPriority == nil {
+		return s.PriorityLoad()
+	}
+	return aggregateLoad([]*config.ServerLoadSpec{s.PriorityLoad(), &s.lowPriority.Load})
+}
+
+// Load of the high priority requests of the server: the load of the model aggregated with loads of its adapters
+//   - the load served by the server if no low priority stream
+func (s *Server) PriorityLoad() *config.ServerLoadSpec {
+	if s.load == nil || len(s.adapters) == 0 {
+		return s.load
+	}
+	loads := make([]*config.ServerLoadSpec, 0, len(s.adapters)+1)
+	loads = append(loads, s.load)
+	for i := range s.adapters {
+		loads = append(loads, &s.adapters[i].Load)
+	}
+	return aggregateLoad(loads)
+}
+
+// aggregate of loads of request streams served together
+//   - the arrival COV of the superposition is approximated by the weighted average, and the service COV
+//     does not include the variance between streams (exact if streams have the same statistics)
+//   - the first load if no arrivals
+func aggregateLoad(loads []*config.ServerLoadSpec) *config.ServerLoadSpec {
+	var rate, inTokens, outTokens, arrivalCOV, serviceCOV float32
+	for _, load := range loads {
+		rate += load.ArrivalRate
+		inTokens += load.ArrivalRate * float32(load.AvgInTokens)
+		outTokens += load.ArrivalRate * float32(load.AvgOutTokens)
+		arrivalCOV += load.ArrivalRate * load.ArrivalCOV
+		serviceCOV += load.ArrivalRate * load.ServiceCOV
+	}
+	if rate == 0 {
+		load := *loads[0]
+		return &load
+	}
+	return &config.ServerLoadSpec{
+		ArrivalRate:  rate,
+		AvgInTokens:  int(math.Round(float64(inTokens / rate))),
+		AvgOutTokens: int(math.Round(float64(outTokens / rate))),
+		ArrivalCOV:   arrivalCOV / rate,
+		ServiceCOV:   serviceCOV / rate,
+	}
+}
+
+// Anticipated loads of the server in time windows (empty if none)
+func (s *Server) LoadProfile() []config.LoadWindow {
+	return s.spec.LoadProfile
+}
+
+// Load of the server in a time window of its load profile; nil if none
+func (s *Server) WindowLoad(window string) *config.ServerLoadSpec {
+	for i := range s.spec.LoadProfile {
+		if s.spec.LoadProfile[i].Window == window {
+			load := s.spec.LoadProfile[i].Load
+			return &load
+		}
+	}
+	return nil
+}
+
+// Peak load of the server over the time windows of its load profile; nil if none
+//   - the load with the largest arrival rate, then the largest number of output and input tokens
+func (s *Server) PeakLoad() *config.ServerLoadSpec {
+	var peak *config.ServerLoadSpec
+	for i := range s.spec.LoadProfile {
+		load := &s.spec.LoadProfile[i].Load
+		if peak == nil || cmp.Or(cmp.Compare(load.ArrivalRate, peak.ArrivalRate),
+			cmp.Compare(load.AvgOutTokens, peak.AvgOutTokens), cmp.Compare(load.AvgInTokens, peak.AvgInTokens)) > 0 {
+			peak = load
+		}
+	}
+	if peak == nil {
+		return nil
+	}
+	load := *peak
+	return &load
+}
+
+// Server has no load: no arrivals or no output tokens, including adapters
+func (s *Server) ZeroLoad() bool {
+	load := s.ServedLoad()
+	return load != nil && (load.ArrivalRate == 0 || load.AvgOutTokens == 0)
+}
+
+// Server may be given no allocation (scaled to zero): it has no load and no warm pool
+func (s *Server) ScalableToZero() bool {
+	return s.ZeroLoad() && s.warmReplicas <= 0
+}
+
 func (s *Server) SetLoad(load *config.ServerLoadSpec) {
 	s.load = load
 }
 
+// Scale the arrival rates of the load of the server, its adapters, and its low priority stream by a factor
+//   - loads are copied, as they may be shared with clones of the system
+func (s *Server) scaleLoad(factor float32) {
+	if s.load == nil {
+		return
+	}
+	load := *s.load
+	load.ArrivalRate *= factor
+	s.load = &load
+	s.adapters = slices.Clone(s.adapters)
+	for i := range s.adapters {
+		s.adapters[i].Load.ArrivalRate *= factor
+	}
+	if s.lowPriority != nil {
+		lowPriority := *s.lowPriority
+		lowPriority.Load.ArrivalRate *= factor
+		s.lowPriority = &lowPriority
+	}
+}
+
+// Call a function with the loads of the server scaled by a factor, restoring the loads afterwards
+func (s *Server) withScaledLoad(factor float32, f func()) {
+	load, adapters, lowPriority := s.load, s.adapters, s.lowPriority
+	defer func() { s.load, s.adapters, s.lowPriority = load, adapters, lowPriority }()
+	s.scaleLoad(factor)
+	f()
+}
+
 func (s *Server) Allocation() *Allocation {
 	return s.allocation
 }
@@ -123,6 +474,24 @@ func (s *Server) SetAllocation(alloc *Allocation) {
 
 func (s *Server) RemoveAllocation() {
 	s.allocation = nil
+	s.unallocatedError = nil
+}
+
+// Set the reason for not allocating feasible allocations to the server, e.g. budget exhausted
+func (s *Server) SetUnallocatedError(err error) {
+	s.unallocatedError = err
+}
+
+// Give no allocation to the server, as it has zero load (scale to zero)
+func (s *Server) ScaleToZero() {
+	s.allocation = nil
+	s.unallocatedError = ErrScaledToZero
+	s.UpdateDesiredAlloc()
+}
+
+// Server was given no allocation as it has zero load
+func (s *Server) ScaledToZero() bool {
+	return s.allocation == nil && s.unallocatedError == ErrScaledToZero
 }
 
 func (s *Server) CurAllocation() *Allocation {
@@ -137,12 +506,51 @@ func (s *Server) AllAllocations() map[string]*Allocation {
 	return s.allAllocations
 }
 
+func (s *Server) AllocationErrors() map[string]error {
+	return s.allocationErrors
+}
+
+// Reason for the server not having an allocation; nil if allocated
+//   - reasons of all candidate accelerators if none is feasible
+//   - capacity exhausted if feasible allocations were not given accelerators (unless another reason is set by the solver)
+func (s *Server) AllocationError() error {
+	if s.allocation != nil {
+		return nil
+	}
+	if len(s.allAllocations) > 0 {
+		if s.unallocatedError != nil {
+			return s.unallocatedError
+		}
+		return ErrCapacityExhausted
+	}
+	if len(s.allocationErrors) == 0 {
+		return ErrNoCandidate
+	}
+	errs := make([]error, 0, len(s.allocationErrors))
+	for _, gName := range slices.Sorted(maps.Keys(s.allocationErrors)) {
+		errs = append(errs, fmt.Errorf("%s: %w", gName, s.allocationErrors[gName]))
+	}
+	return errors.Join(errs...)
+}
+
+// Summary of the reason for the server not having an allocation; empty if allocated
+func (s *Server) UnallocatedReason() string {
+	if s.allocation != nil {
+		return ""
+	}
+	if len(s.allAllocations) > 0 {
+		return s.AllocationError().Error()
+	}
+	return summarizeAllocationErrors(s.allocationErrors)
+}
+
 func (s *Server) Spec() *config.ServerSpec {
 	return s.spec
 }
 
 func (s *Server) Saturated() bool {
-	return s.allocation != nil && s.load != nil && s.allocation.Saturated(s.load.ArrivalRate)
+	load := s.ServedLoad()
+	return s.allocation != nil && load != nil && s.allocation.Saturated(load.ArrivalRate)
 }
 
 func (s *Server) UpdateDesiredAlloc() {
@@ -154,10 +562,49 @@ func (s *Server) UpdateDesiredAlloc() {
 	}
 }
 
-func (s *Server) ApplyDesiredAlloc() {
+// Apply the desired allocation of the server as its current allocation
+//   - the current allocation is not changed if the desired allocation is on an accelerator not in the system,
+//     e.g. removed since optimizing
+func (s *Server) ApplyDesiredAlloc() error {
+	if err := s.checkAccelerators(&s.spec.DesiredAlloc); err != nil {
+		return err
+	}
 	s.spec.CurrentAlloc = s.spec.DesiredAlloc
 	s.curAllocation = AllocationFromData(&s.spec.CurrentAlloc)
+	s.setSharedCost(s.curAllocation)
 	s.load = &s.spec.CurrentAlloc.Load
+	return nil
+}
+
+// Set the shared cost of replicas of an allocation of the server made from data (and of its secondary allocation),
+// given the perf data of the model of the server on the accelerator of the allocation, if any
+func (s *Server) setSharedCost(alloc *Allocation) {
+	if s.system == nil {
+		return
+	}
+	model := s.system.GetModel(s.modelName)
+	if model == nil {
+		return
+	}
+	for ; alloc != nil; alloc = alloc.secondary {
+		if perf := model.PerfData(alloc.accelerator); perf != nil {
+			alloc.sharedCost = sharedCost(perf)
+		}
+	}
+}
+
+// check that the accelerators of allocation data (if any) are in the system of the server
+func (s *Server) checkAccelerators(data *config.AllocationData) error {
+	if s.system == nil || data.Accelerator == "" {
+		return nil
+	}
+	if s.system.GetAccelerator(data.Accelerator) == nil {
+		return fmt.Errorf("%w: %s", ErrNoAccelerator, data.Accelerator)
+	}
+	if data.Secondary != nil {
+		return s.checkAccelerators(data.Secondary)
+	}
+	return nil
 }
 
 func (s *Server) String() string {
@@ -2,6 +2,8 @@ package core
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 
 	"github.com/llm-inferno/optimizer/pkg/config"
 )
@@ -10,7 +12,12 @@ import (
 type ServiceClass struct {
 	name     string             // unique name
 	priority int                // non-negative priority (smaller values for higher priority)
+	weight   float32            // positive relative share of leftover capacity under saturation
 	targets  map[string]*Target // target SLOs for each model
+
+	defaultTarget *Target // target SLOs inherited by models with no target (nil if none)
+
+	reservations map[string]int // count of accelerator types reserved for servers of the class, by type
 }
 
 // target SLOs for service class
@@ -18,11 +25,36 @@ type Target struct {
 	ITL  float32
 	TTFT float32
 	TPS  float32
+
+	// optional tail percentile targets (zero if not considered)
+	ITL_P99  float32
+	TTFT_P99 float32
+
+	// optional max fraction of requests rejected as the queue is full (zero if not considered)
+	MaxDropRate float32
+
+	// optional max queueing time relative to the service time of a request (zero if not considered)
+	WaitFraction float32
 }
 
 func (t *Target) String() string {
-	return fmt.Sprintf("[ITL=%v, TTFT=%v, TPS=%v]",
-		t.ITL, t.TTFT, t.TPS)
+	return fmt.Sprintf("[ITL=%v, TTFT=%v, TPS=%v, ITL_P99=%v, TTFT_P99=%v, MaxDropRate=%v, WaitFraction=%v]",
+		t.ITL, t.TTFT, t.TPS, t.ITL_P99, t.TTFT_P99, t.MaxDropRate, t.WaitFraction)
+}
+
+// Model target specification corresponding to this target
+func (t *Target) ModelTarget(modelName string) config.ModelTarget {
+	return config.ModelTarget{
+		Model:        modelName,
+		SLO_ITL:      config.Msec(t.ITL),
+		SLO_TTFT:     config.Msec(t.TTFT),
+		SLO_TPS:      config.TokensPerSec(t.TPS),
+		SLO_ITL_P99:  config.Msec(t.ITL_P99),
+		SLO_TTFT_P99: config.Msec(t.TTFT_P99),
+
+		SLO_MaxDropRate:  t.MaxDropRate,
+		SLO_WaitFraction: t.WaitFraction,
+	}
 }
 
 func NewServiceClass(name string, priority int) *ServiceClass {
@@ -32,15 +64,23 @@ func NewServiceClass(name string, priority int) *ServiceClass {
 	return &ServiceClass{
 		name:     name,
 		priority: priority,
+		weight:   config.DefaultServiceClassWeight,
 		targets:  map[string]*Target{},
+
+		reservations: map[string]int{},
 	}
 }
 
 func NewServiceClassFromSpec(spec *config.ServiceClassSpec) *ServiceClass {
 	svc := NewServiceClass(spec.Name, spec.Priority)
+	svc.SetWeight(spec.Weight)
+	svc.SetReservations(spec.Reservations)
 	for _, modelTarget := range spec.ModelTargets {
 		svc.AddModelTarget(&modelTarget)
 	}
+	if spec.DefaultTarget != nil {
+		svc.SetDefaultTarget(spec.DefaultTarget)
+	}
 	return svc
 }
 
@@ -52,11 +92,59 @@ func (c *ServiceClass) Priority() int {
 	return c.priority
 }
 
+func (c *ServiceClass) Weight() float32 {
+	return c.weight
+}
+
+// Set the weight of the service class (default if not positive)
+func (c *ServiceClass) SetWeight(weight float32) {
+	if weight <= 0 {
+		weight = config.DefaultServiceClassWeight
+	}
+	c.weight = weight
+}
+
+// Count of accelerator types reserved for servers of the class, by type
+func (c *ServiceClass) Reservations() map[string]int {
+	return c.reservations
+}
+
+// Set the count of accelerator types reserved for servers of the class (positive counts only)
+func (c *ServiceClass) SetReservations(reservations map[string]int) {
+	c.reservations = make(map[string]int)
+	for typeName, count := range reservations {
+		if count > 0 {
+			c.reservations[typeName] = count
+		}
+	}
+}
+
+// Target of a model in the service class: its own target, or else the default target of the class (nil if neither)
 func (c *ServiceClass) ModelTarget(modelName string) *Target {
+	if target, exists := c.targets[modelName]; exists {
+		return target
+	}
+	return c.defaultTarget
+}
+
+// Own target of a model in the service class, not inherited from the default target of the class (nil if none)
+func (c *ServiceClass) OwnModelTarget(modelName string) *Target {
 	return c.targets[modelName]
 }
 
-// update model targets from  service class specification (replace if already exists)
+// Default target of the service class, inherited by models with no target (nil if none)
+func (c *ServiceClass) DefaultTarget() *Target {
+	return c.defaultTarget
+}
+
+// set the default target of the service class from a model target specification (model name not used)
+func (c *ServiceClass) SetDefaultTarget(spec *config.ModelTarget) *Target {
+	c.defaultTarget = newTarget(spec)
+	return c.defaultTarget
+}
+
+// update model targets from  service class specification (replace if already exists),
+// and the default target if given
 func (c *ServiceClass) UpdateModelTargets(spec *config.ServiceClassSpec) bool {
 	if spec.Name != c.name || spec.Priority != c.priority {
 		return false
@@ -64,45 +152,58 @@ func (c *ServiceClass) UpdateModelTargets(spec *config.ServiceClassSpec) bool {
 	for _, modelTarget := range spec.ModelTargets {
 		c.AddModelTarget(&modelTarget)
 	}
+	if spec.DefaultTarget != nil {
+		c.SetDefaultTarget(spec.DefaultTarget)
+	}
 	return true
 }
 
 // add a model target to the service class (replace if already exists)
 func (c *ServiceClass) AddModelTarget(spec *config.ModelTarget) *Target {
-	modelName := spec.Model
-	target := &Target{
-		ITL:  spec.SLO_ITL,
-		TTFT: spec.SLO_TTFT,
-		TPS:  spec.SLO_TPS,
-	}
-	c.targets[modelName] = target
+	target := newTarget(spec)
+	c.targets[spec.Model] = target
 	return target
 }
 
+// target given by a model target specification
+func newTarget(spec *config.ModelTarget) *Target {
+	return &Target{
+		ITL:  float32(spec.SLO_ITL),
+		TTFT: float32(spec.SLO_TTFT),
+		TPS:  float32(spec.SLO_TPS),
+
+		ITL_P99:  float32(spec.SLO_ITL_P99),
+		TTFT_P99: float32(spec.SLO_TTFT_P99),
+
+		MaxDropRate:  spec.SLO_MaxDropRate,
+		WaitFraction: spec.SLO_WaitFraction,
+	}
+}
+
 func (c *ServiceClass) RemoveModelTarget(modelName string) {
 	delete(c.targets, modelName)
 }
 
 func (c *ServiceClass) Spec() config.ServiceClassSpec {
-	modelTargets := make([]config.ModelTarget, len(c.targets))
-	i := 0
-	for modelName, target := range c.targets {
-		modelTargets[i] = config.ModelTarget{
-			Model:    modelName,
-			SLO_ITL:  target.ITL,
-			SLO_TTFT: target.TTFT,
-			SLO_TPS:  target.TPS,
-		}
-		i++
+	modelTargets := make([]config.ModelTarget, 0, len(c.targets))
+	for _, modelName := range slices.Sorted(maps.Keys(c.targets)) {
+		modelTargets = append(modelTargets, c.targets[modelName].ModelTarget(modelName))
 	}
-	return config.ServiceClassSpec{
+	spec := config.ServiceClassSpec{
 		Name:         c.name,
 		Priority:     c.priority,
+		Weight:       c.weight,
 		ModelTargets: modelTargets,
+		Reservations: maps.Clone(c.reservations),
+	}
+	if c.defaultTarget != nil {
+		defaultTarget := c.defaultTarget.ModelTarget("")
+		spec.DefaultTarget = &defaultTarget
 	}
+	return spec
 }
 
 func (c *ServiceClass) String() string {
-	return fmt.Sprintf("ServiceClass: name=%s; priority=%d; targets=%v",
-		c.name, c.priority, c.targets)
+	return fmt.Sprintf("ServiceClass: name=%s; priority=%d; weight=%v; targets=%v; default=%v; reservations=%v",
+		c.name, c.priority, c.weight, c.targets, c.defaultTarget, c.reservations)
 }
@@ -0,0 +1,99 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/llm-inferno/optimizer/pkg/config"
+)
+
+// Snapshot of the specs of the system and its last solution: an immutable, consistent copy, read without contending
+// with an optimization in progress
+//   - refreshed when read if the system was locked for writing since the last snapshot, unless it is still locked
+//     (e.g. by an optimization), in which case the last snapshot is returned; an optimization publishes a new
+//     snapshot when generating its solution
+//   - the version of a snapshot is a hash of its specs and solution, hence the same for the same state
+//   - snapshots are shared by readers and must not be modified
+func (s *System) Snapshot() *config.SystemSnapshot {
+	if s.mutex.TryRLock() {
+		s.refreshSnapshot()
+		s.mutex.RUnlock()
+	} else if s.snapshot.Load() == nil {
+		// no snapshot yet, wait for the first one
+		s.mutex.RLock()
+		s.refreshSnapshot()
+		s.mutex.RUnlock()
+	}
+	return s.snapshot.Load().SystemSnapshot
+}
+
+// Read-write lock of a system, counting acquisitions of the write lock, so that snapshots are only published after
+// the system may have changed
+type systemMutex struct {
+	sync.RWMutex
+	generation uint64 // number of acquisitions of the write lock
+}
+
+func (m *systemMutex) Lock() {
+	m.RWMutex.Lock()
+	m.generation++
+}
+
+// Snapshot with the generation of the lock of the system when published
+type publishedSnapshot struct {
+	*config.SystemSnapshot
+	generation uint64
+}
+
+// Publish a snapshot if the system was locked for writing since the last snapshot, holding the lock
+func (s *System) refreshSnapshot() {
+	if last := s.snapshot.Load(); last == nil || last.generation != s.mutex.generation {
+		s.publishSnapshot()
+	}
+}
+
+// Publish a snapshot of the current specs and last solution, holding the lock, keeping the last snapshot if the
+// state is unchanged
+func (s *System) publishSnapshot() {
+	state := struct {
+		Spec     *config.SystemSpec         `json:"system"`
+		Models   []string                   `json:"models"`
+		Solution *config.AllocationSolution `json:"solution"`
+	}{s.spec(), slices.Sorted(maps.Keys(s.models)), s.allocationSolution}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return
+	}
+	hash := sha256.Sum256(data)
+	version := hex.EncodeToString(hash[:8])
+	if last := s.snapshot.Load(); last != nil && last.Version == version {
+		s.snapshot.Store(&publishedSnapshot{SystemSnapshot: last.SystemSnapshot, generation: s.mutex.generation})
+		return
+	}
+
+	// copy of the solution, shared with callers of GenerateSolution
+	var solution *config.AllocationSolution
+	if state.Solution != nil {
+		solution = &config.AllocationSolution{}
+		if err := json.Unmarshal(data, &struct {
+			Solution *config.AllocationSolution `json:"solution"`
+		}{solution}); err != nil {
+			return
+		}
+	}
+	s.snapshot.Store(&publishedSnapshot{
+		SystemSnapshot: &config.SystemSnapshot{
+			Version:  version,
+			AsOf:     time.Now(),
+			Spec:     *state.Spec,
+			Models:   state.Models,
+			Solution: solution,
+		},
+		generation: s.mutex.generation,
+	})
+}
@@ -2,66 +2,178 @@ package core
 
 import (
 	"bytes"
+	"cmp"
+	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"sync/atomic"
 
 	"github.com/llm-inferno/optimizer/pkg/config"
 )
 
-var (
-	// a static reference to the singleton system object
-	TheSystem *System
-)
+// System comprising all accelerators, models, service classes, and servers
+//   - methods are safe for concurrent use: setters take the write lock, getters take the read lock
+//   - getters of collections return copies of the maps
+//   - solvers access the system through the Get methods below, holding the lock (see Lock())
+type System struct {
+	mutex systemMutex
 
-func GetAccelerator(name string) *Accelerator {
-	return TheSystem.Accelerator(name)
+	accelerators   map[string]*Accelerator
+	models         map[string]*Model
+	serviceClasses map[string]*ServiceClass
+	servers        map[string]*Server
+
+	capacity           map[string]int               // available count of accelerator types
+	partitions         map[string]int               // number of partition slices per device of accelerator types
+	powerBudgets       map[string]float32           // power budgets of allocations of accelerator types (Watts)
+	unitsPerNode       map[string]int               // number of devices per node of accelerator types grouped in nodes
+	allocationByType   map[string]*AllocationByType // number of allocated accelerator types
+	allocationSolution *config.AllocationSolution
+	solutionMetadata   config.SolutionMetadata // data about the process of finding the solution
+
+	valueFunc ValueFunc        // value function of allocations
+	objective config.Objective // metric of allocations minimized by the value function
+
+	allowEstimatedPerf bool    // estimate missing perf data of models from other accelerators
+	headroomTolerance  float32 // fraction of the minimum value within which allocations with more headroom are preferred
+
+	snapshot atomic.Pointer[publishedSnapshot] // last snapshot of specs and solution, read without locking
 }
 
-func GetModel(name string) *Model {
-	return TheSystem.Model(name)
+// Methods accessing the system without locking, used while calculating and solving (holding the system lock)
+
+func (s *System) GetAccelerator(name string) *Accelerator {
+	return s.accelerators[name]
 }
 
-func GetServiceClass(name string) *ServiceClass {
-	return TheSystem.ServiceClass(name)
+func (s *System) GetModel(name string) *Model {
+	return s.models[name]
 }
 
-func GetServer(name string) *Server {
-	return TheSystem.Server(name)
+func (s *System) GetServiceClass(name string) *ServiceClass {
+	return s.serviceClasses[name]
 }
 
-func GetAccelerators() map[string]*Accelerator {
-	return TheSystem.accelerators
+func (s *System) GetServer(name string) *Server {
+	return s.servers[name]
 }
 
-func GetModels() map[string]*Model {
-	return TheSystem.models
+func (s *System) GetAccelerators() map[string]*Accelerator {
+	return s.accelerators
 }
 
-func GetServers() map[string]*Server {
-	return TheSystem.servers
+func (s *System) GetModels() map[string]*Model {
+	return s.models
 }
 
-func GetCapacities() map[string]int {
-	return TheSystem.capacity
+func (s *System) GetServers() map[string]*Server {
+	return s.servers
 }
 
-// System comprising all accelerators, models, service classes, and servers
-type System struct {
-	accelerators   map[string]*Accelerator
-	models         map[string]*Model
-	serviceClasses map[string]*ServiceClass
-	servers        map[string]*Server
+func (s *System) GetCapacities() map[string]int {
+	return s.capacity
+}
 
-	capacity           map[string]int               // available count of accelerator types
-	allocationByType   map[string]*AllocationByType // number of allocated accelerator types
-	allocationSolution *config.AllocationSolution
+// Get number of partition slices per device of an accelerator type (one if not partitioned)
+func (s *System) GetSlicesPerDevice(typeName string) int {
+	return max(s.partitions[typeName], 1)
+}
+
+// Get available capacity units of accelerator types
+//   - a unit is a partition slice of a device for partitioned types, a device otherwise
+func (s *System) GetCapacityUnits() map[string]int {
+	units := make(map[string]int, len(s.capacity))
+	for typeName, count := range s.capacity {
+		units[typeName] = count * s.GetSlicesPerDevice(typeName)
+	}
+	return units
+}
+
+// Get power budgets of accelerator types with a budget (Watts)
+func (s *System) GetPowerBudgets() map[string]float32 {
+	return maps.Clone(s.powerBudgets)
+}
+
+// Get capacity units of nodes of accelerator types grouped in nodes, by type
+//   - the count of devices of a type fills nodes of its number of devices per node, the last node holding the rest
+func (s *System) GetNodeUnits() map[string][]int {
+	nodes := make(map[string][]int, len(s.unitsPerNode))
+	for typeName, unitsPerNode := range s.unitsPerNode {
+		count := s.capacity[typeName]
+		slicesPerDevice := s.GetSlicesPerDevice(typeName)
+		typeNodes := make([]int, 0, (count+unitsPerNode-1)/unitsPerNode)
+		for ; count > 0; count -= unitsPerNode {
+			typeNodes = append(typeNodes, min(count, unitsPerNode)*slicesPerDevice)
+		}
+		nodes[typeName] = typeNodes
+	}
+	return nodes
+}
+
+// Get the currency unit of costs of accelerators, the first by name with a currency (empty if none)
+func (s *System) GetCurrency() string {
+	for _, accName := range slices.Sorted(maps.Keys(s.accelerators)) {
+		if currency := s.accelerators[accName].Currency(); currency != "" {
+			return currency
+		}
+	}
+	return ""
+}
+
+// Get capacity units of accelerator types reserved for service classes, by service class name and type
+//   - reservations are granted in order of priority of service classes (then name), up to the capacity of types
+func (s *System) GetReservedUnits() map[string]map[string]int {
+	classNames := slices.SortedFunc(maps.Keys(s.serviceClasses), func(a, b string) int {
+		return cmp.Or(cmp.Compare(s.serviceClasses[a].priority, s.serviceClasses[b].priority), cmp.Compare(a, b))
+	})
+	remaining := s.GetCapacityUnits()
+	reserved := make(map[string]map[string]int)
+	for _, className := range classNames {
+		for typeName, count := range s.serviceClasses[className].reservations {
+			units := min(count*s.GetSlicesPerDevice(typeName), remaining[typeName])
+			if units <= 0 {
+				continue
+			}
+			if reserved[className] == nil {
+				reserved[className] = make(map[string]int)
+			}
+			reserved[className][typeName] = units
+			remaining[typeName] -= units
+		}
+	}
+	return reserved
+}
+
+// Get number of capacity units of its type used by an accelerator
+func (s *System) GetUnits(acc *Accelerator) int {
+	return acc.Units(s.GetSlicesPerDevice(acc.Type()))
+}
+
+func (s *System) GetObjective() config.Objective {
+	return s.objective
+}
+
+func (s *System) GetHeadroomTolerance() float32 {
+	return s.headroomTolerance
+}
+
+func (s *System) GetValueFunc() ValueFunc {
+	if s.valueFunc == nil {
+		return CostValue
+	}
+	return s.valueFunc
 }
 
 // Allocation data about an accelerator type
 type AllocationByType struct {
 	name  string  // name of accelerator type
-	count int     // total number of this type
-	limit int     // maximum number of this type
+	count int     // total number of capacity units (devices or partition slices) of this type
+	limit int     // maximum number of capacity units of this type
 	cost  float32 // total cost of this type
+	power float32 // total power consumption of this type (Watts)
 }
 
 // Create a new system
@@ -73,19 +185,160 @@ func NewSystem() *System {
 		servers:        make(map[string]*Server),
 
 		capacity:           make(map[string]int),
+		partitions:         make(map[string]int),
+		powerBudgets:       make(map[string]float32),
+		unitsPerNode:       make(map[string]int),
 		allocationByType:   make(map[string]*AllocationByType),
 		allocationSolution: nil,
+
+		valueFunc: CostValue,
+		objective: config.DefaultObjective,
 	}
 }
 
-// Set system from spec
-func (s *System) SetFromSpec(d *config.SystemSpec) *config.OptimizerSpec {
+// Set system from spec; error if data is not valid
+func (s *System) SetFromSpec(d *config.SystemSpec) (*config.OptimizerSpec, error) {
 	s.SetAcceleratorsFromSpec(&d.Accelerators)
-	s.SetModelsFromSpec(&d.Models)
+	if err := s.SetModelsFromSpec(&d.Models); err != nil {
+		return nil, err
+	}
 	s.SetServiceClassesFromSpec(&d.ServiceClasses)
 	s.SetServersFromSpec(&d.Servers)
 	s.SetCapacityFromSpec(&d.Capacity)
-	return &d.Optimizer.Spec
+	return &d.Optimizer.Spec, nil
+}
+
+// Create a system from a (JSON or YAML) file combining all its data (SystemData), returning the optimizer spec of the data
+//   - fails if the file cannot be read, if any section (accelerators, capacity, models, service classes, servers,
+//     and optimizer) is absent, or if data is not valid
+func NewSystemFromFile(fileName string) (*System, *config.OptimizerSpec, error) {
+	byteValue, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, nil, err
+	}
+	d, err := config.SystemFromFileData(fileName, byteValue)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := d.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", fileName, err)
+	}
+	s := NewSystem()
+	optimizerSpec, err := s.SetFromSpec(&d.Spec)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", fileName, err)
+	}
+	return s, optimizerSpec, nil
+}
+
+// Spec of the system, reflecting current loads of servers, with no optimizer spec
+func (s *System) Spec() *config.SystemSpec {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.spec()
+}
+
+func (s *System) spec() *config.SystemSpec {
+	spec := &config.SystemSpec{}
+	spec.Accelerators.Spec = make([]config.AcceleratorSpec, 0, len(s.accelerators))
+	spec.Models.PerfData = make([]config.ModelAcceleratorPerfData, 0)
+	spec.ServiceClasses.Spec = make([]config.ServiceClassSpec, 0, len(s.serviceClasses))
+	spec.Servers.Spec = make([]config.ServerSpec, 0, len(s.servers))
+	spec.Capacity.Count = make([]config.AcceleratorCount, 0, len(s.capacity))
+	for _, name := range slices.Sorted(maps.Keys(s.accelerators)) {
+		spec.Accelerators.Spec = append(spec.Accelerators.Spec, *s.accelerators[name].spec)
+	}
+	for _, name := range slices.Sorted(maps.Keys(s.models)) {
+		spec.Models.PerfData = append(spec.Models.PerfData, s.models[name].Spec().PerfData...)
+	}
+	for _, name := range slices.Sorted(maps.Keys(s.serviceClasses)) {
+		spec.ServiceClasses.Spec = append(spec.ServiceClasses.Spec, s.serviceClasses[name].Spec())
+	}
+	for _, name := range slices.Sorted(maps.Keys(s.servers)) {
+		server := s.servers[name]
+		serverSpec := *server.spec
+		if server.load != nil {
+			serverSpec.CurrentAlloc.Load = *server.load
+		}
+		serverSpec.Adapters = slices.Clone(server.adapters)
+		if server.lowPriority != nil {
+			lowPriority := *server.lowPriority
+			serverSpec.LowPriority = &lowPriority
+		}
+		spec.Servers.Spec = append(spec.Servers.Spec, serverSpec)
+	}
+	for _, typeName := range slices.Sorted(maps.Keys(s.capacity)) {
+		spec.Capacity.Count = append(spec.Capacity.Count, config.AcceleratorCount{
+			Type:          typeName,
+			Count:         s.capacity[typeName],
+			Partitions:    s.partitions[typeName],
+			MaxPowerWatts: s.powerBudgets[typeName],
+			UnitsPerNode:  s.unitsPerNode[typeName],
+		})
+	}
+	return spec
+}
+
+// Create an independent copy of the system from its spec, e.g. to evaluate what-if scenarios
+func (s *System) Clone() (*System, error) {
+	return s.cloneWithSpec(s.Spec())
+}
+
+// Create an independent system from a spec, with the settings of the system
+func (s *System) cloneWithSpec(spec *config.SystemSpec) (*System, error) {
+	clone := NewSystem()
+	if _, err := clone.SetFromSpec(spec); err != nil {
+		return nil, err
+	}
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	clone.valueFunc = s.valueFunc
+	clone.objective = s.objective
+	clone.allowEstimatedPerf = s.allowEstimatedPerf
+	clone.headroomTolerance = s.headroomTolerance
+	return clone, nil
+}
+
+// Set loads of servers with a load profile to their peak loads over the time windows
+func (s *System) SetPeakLoads() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, server := range s.servers {
+		if peak := server.PeakLoad(); peak != nil {
+			server.SetLoad(peak)
+		}
+	}
+}
+
+// Names of time windows of load profiles of servers, in order of first appearance (servers by name)
+func (s *System) LoadWindows() []string {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	windows := make([]string, 0)
+	for _, name := range slices.Sorted(maps.Keys(s.servers)) {
+		for _, w := range s.servers[name].LoadProfile() {
+			if !slices.Contains(windows, w.Window) {
+				windows = append(windows, w.Window)
+			}
+		}
+	}
+	return windows
+}
+
+// Scale arrival rates of servers by factors, given by server name, or a default factor for other servers
+func (s *System) ScaleLoads(factor float32, factors map[string]float32) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for name, server := range s.servers {
+		if server.load == nil {
+			continue
+		}
+		f := factor
+		if serverFactor, exists := factors[name]; exists {
+			f = serverFactor
+		}
+		server.scaleLoad(f)
+	}
 }
 
 // Set accelerators from spec
@@ -97,11 +350,29 @@ func (s *System) SetAcceleratorsFromSpec(d *config.AcceleratorData) {
 
 // Add an accelerator (replace if already exists)
 func (s *System) AddAcceleratorFromSpec(spec config.AcceleratorSpec) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 	s.accelerators[spec.Name] = NewAcceleratorFromSpec(&spec)
 }
 
+// Check that the currencies of accelerator specs are the same as those of accelerators of the system,
+// other than accelerators replaced by the specs
+func (s *System) CheckAcceleratorCurrencies(specs ...config.AcceleratorSpec) error {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	all := slices.Clone(specs)
+	for _, accName := range slices.Sorted(maps.Keys(s.accelerators)) {
+		if !slices.ContainsFunc(specs, func(spec config.AcceleratorSpec) bool { return spec.Name == accName }) {
+			all = append(all, *s.accelerators[accName].Spec())
+		}
+	}
+	return config.CheckCurrencies(all...)
+}
+
 // Remove an accelerator
 func (s *System) RemoveAccelerator(name string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 	if s.accelerators[name] == nil {
 		return fmt.Errorf("accelerator %s not found", name)
 	}
@@ -109,6 +380,17 @@ func (s *System) RemoveAccelerator(name string) error {
 	return nil
 }
 
+// Acquire exclusive access to the system, e.g. while solving through the Get methods
+//   - other methods of the system may not be called by the holder of the lock
+func (s *System) Lock() {
+	s.mutex.Lock()
+}
+
+// Release exclusive access to the system
+func (s *System) Unlock() {
+	s.mutex.Unlock()
+}
+
 // Set capacity count from spec
 func (s *System) SetCapacityFromSpec(d *config.CapacityData) {
 	for _, v := range d.Count {
@@ -118,23 +400,78 @@ func (s *System) SetCapacityFromSpec(d *config.CapacityData) {
 
 // Set capacity count for an accelerator type
 func (s *System) SetCountFromSpec(spec config.AcceleratorCount) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 	s.capacity[spec.Type] = spec.Count
+	s.partitions[spec.Type] = spec.Partitions
+	if spec.MaxPowerWatts > 0 {
+		s.powerBudgets[spec.Type] = spec.MaxPowerWatts
+	} else {
+		delete(s.powerBudgets, spec.Type)
+	}
+	if spec.UnitsPerNode > 0 {
+		s.unitsPerNode[spec.Type] = spec.UnitsPerNode
+	} else {
+		delete(s.unitsPerNode, spec.Type)
+	}
 }
 
-// Set models from spec
-func (s *System) SetModelsFromSpec(d *config.ModelData) {
+// Set models from spec; error, with no models set, if any perf data is not valid
+func (s *System) SetModelsFromSpec(d *config.ModelData) error {
+	var errs []error
+	for _, pd := range d.PerfData {
+		errs = append(errs, pd.Validate())
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 	for _, pd := range d.PerfData {
 		modelName := pd.Name
 		var model *Model
 		if model = s.models[modelName]; model == nil {
-			model = s.AddModel(modelName)
+			model = NewModel(modelName)
+			s.models[modelName] = model
 		}
 		model.AddPerfDataFromSpec(&pd)
 	}
+	return nil
+}
+
+// Import performance data of models on accelerators, e.g. from benchmarks, returning the result of each entry, in order
+//   - decode parameters not given are fitted to the measured decode times of an entry (see PerfDataFromImport)
+//   - entries which are not valid, or of accelerators not in the system, are not imported, others are imported
+//     (replacing existing perf data), adding models not in the system
+func (s *System) ImportPerfData(entries []config.PerfImportEntry) []config.PerfImportResult {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	results := make([]config.PerfImportResult, len(entries))
+	for i := range entries {
+		perf, result := PerfDataFromImport(&entries[i])
+		if result.Error == "" && s.accelerators[perf.Acc] == nil {
+			result.Error = fmt.Errorf("%w: %s", ErrNoAccelerator, perf.Acc).Error()
+		}
+		if result.Error == "" {
+			model := s.models[perf.Name]
+			if model == nil {
+				model = NewModel(perf.Name)
+				s.models[perf.Name] = model
+			}
+			if err := model.AddPerfDataFromSpec(perf); err != nil {
+				result.Error = err.Error()
+			}
+		}
+		results[i] = *result
+	}
+	return results
 }
 
 // Add a model (replace if already exists)
 func (s *System) AddModel(name string) *Model {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 	model := NewModel(name)
 	s.models[name] = model
 	return model
@@ -142,6 +479,8 @@ func (s *System) AddModel(name string) *Model {
 
 // Remove a model
 func (s *System) RemoveModel(name string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 	if s.models[name] == nil {
 		return fmt.Errorf("model %s not found", name)
 	}
@@ -149,20 +488,210 @@ func (s *System) RemoveModel(name string) error {
 	return nil
 }
 
+// Remove performance data of a model on an accelerator, returning a copy of the removed data
+func (s *System) RemoveModelPerfData(name string, accName string) (*config.ModelAcceleratorPerfData, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	model := s.models[name]
+	if model == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNoModel, name)
+	}
+	perfData := model.PerfData(accName)
+	if perfData == nil {
+		return nil, fmt.Errorf("%w: model=%s, acc=%s", ErrNoPerfData, name, accName)
+	}
+	spec := *perfData
+	model.RemovePerfData(accName)
+	return &spec, nil
+}
+
 // Set servers from spec
 func (s *System) SetServersFromSpec(d *config.ServerData) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 	for _, v := range d.Spec {
-		s.servers[v.Name] = NewServerFromSpec(&v)
+		server := NewServerFromSpec(&v)
+		server.system = s
+		s.servers[v.Name] = server
 	}
 }
 
 // Add a server (replace if already exists)
 func (s *System) AddServerFromSpec(spec config.ServerSpec) {
-	s.servers[spec.Name] = NewServerFromSpec(&spec)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	server := NewServerFromSpec(&spec)
+	server.system = s
+	s.servers[spec.Name] = server
+}
+
+// Check that the model and service class (if any) referenced by a server spec exist
+func (s *System) CheckServerReferences(spec *config.ServerSpec) error {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.checkServerReferences(spec)
+}
+
+func (s *System) checkServerReferences(spec *config.ServerSpec) error {
+	if s.models[spec.Model] == nil {
+		return fmt.Errorf("%w: %s", ErrNoModel, spec.Model)
+	}
+	if spec.Class != "" && s.serviceClasses[spec.Class] == nil {
+		return fmt.Errorf("%w: %s", ErrNoServiceClass, spec.Class)
+	}
+	if spec.LowPriority != nil && s.serviceClasses[spec.LowPriority.Class] == nil {
+		return fmt.Errorf("%w: %s", ErrNoServiceClass, spec.LowPriority.Class)
+	}
+	return nil
+}
+
+// Update a server by patching a copy of its spec, returning the updated spec
+//   - the server is replaced by one created from the patched spec, hence it needs to be optimized again
+//   - the spec is not changed if the patch fails
+//   - the server keeps its current load (e.g. as set by SetServerLoad), unless the patch changes the load
+func (s *System) PatchServer(name string, patch func(*config.ServerSpec) error) (*config.ServerSpec, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	server := s.servers[name]
+	if server == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNoServer, name)
+	}
+	spec := cloneServerSpec(server.spec)
+	if err := patch(&spec); err != nil {
+		return nil, err
+	}
+	if spec.Name != name {
+		return nil, fmt.Errorf("%w: server %s", ErrRename, name)
+	}
+	if spec.CurrentAlloc.Load == server.spec.CurrentAlloc.Load && server.load != nil {
+		spec.CurrentAlloc.Load = *server.load
+	}
+	patched := NewServerFromSpec(&spec)
+	patched.system = s
+	s.servers[name] = patched
+	return &spec, nil
+}
+
+// Deep copy of a server spec, sharing no slices or pointers with the spec, e.g. to be patched
+func cloneServerSpec(spec *config.ServerSpec) config.ServerSpec {
+	c := *spec
+	c.AllowedAccelerators = slices.Clone(spec.AllowedAccelerators)
+	c.DeniedAccelerators = slices.Clone(spec.DeniedAccelerators)
+	c.LoadProfile = slices.Clone(spec.LoadProfile)
+	c.Adapters = slices.Clone(spec.Adapters)
+	if spec.LowPriority != nil {
+		lp := *spec.LowPriority
+		c.LowPriority = &lp
+	}
+	c.CurrentAlloc = cloneAllocationData(spec.CurrentAlloc)
+	c.DesiredAlloc = cloneAllocationData(spec.DesiredAlloc)
+	if spec.PinnedAllocation != nil {
+		pinned := cloneAllocationData(*spec.PinnedAllocation)
+		c.PinnedAllocation = &pinned
+	}
+	return c
+}
+
+// Deep copy of allocation data, including latencies of its low priority stream and its secondary allocation
+func cloneAllocationData(data config.AllocationData) config.AllocationData {
+	if data.LowPriority != nil {
+		lp := *data.LowPriority
+		data.LowPriority = &lp
+	}
+	if data.Secondary != nil {
+		secondary := cloneAllocationData(*data.Secondary)
+		data.Secondary = &secondary
+	}
+	return data
+}
+
+// Get a copy of the current load of a server
+func (s *System) ServerLoad(name string) (*config.ServerLoadSpec, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	server := s.servers[name]
+	if server == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNoServer, name)
+	}
+	load := *server.load
+	return &load, nil
+}
+
+// Set the current load of a server, e.g. as observed by a controller
+//   - the server keeps its current and desired allocations, it needs to be optimized again for the load
+func (s *System) SetServerLoad(name string, load config.ServerLoadSpec) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	server := s.servers[name]
+	if server == nil {
+		return fmt.Errorf("%w: %s", ErrNoServer, name)
+	}
+	server.SetLoad(&load)
+	return nil
+}
+
+// Apply changes to the servers of the system: remove servers, add servers, then update loads of servers
+//   - the changes are checked first, the system is not changed if any fails, e.g. a removed server not in the
+//     system, or an added server already in the system (and not removed) or added more than once
+//   - other servers keep their current and desired allocations
+func (s *System) ApplyDelta(delta *config.SystemDelta) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	names := make(map[string]bool, len(s.servers))
+	for name := range s.servers {
+		names[name] = true
+	}
+	var errs []error
+	for _, name := range delta.RemoveServers {
+		if !names[name] {
+			errs = append(errs, fmt.Errorf("%w: %s", ErrNoServer, name))
+		}
+		delete(names, name)
+	}
+	added := make(map[string]bool, len(delta.AddServers))
+	for i := range delta.AddServers {
+		name := delta.AddServers[i].Name
+		switch {
+		case added[name]:
+			errs = append(errs, fmt.Errorf("server %s: added more than once", name))
+		case names[name]:
+			errs = append(errs, fmt.Errorf("%w: %s", ErrServerExists, name))
+		}
+		if err := s.checkServerReferences(&delta.AddServers[i]); err != nil {
+			errs = append(errs, fmt.Errorf("server %s: %w", name, err))
+		}
+		added[name] = true
+		names[name] = true
+	}
+	for _, update := range delta.Loads {
+		if !names[update.Name] {
+			errs = append(errs, fmt.Errorf("%w: %s", ErrNoServer, update.Name))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	for _, name := range delta.RemoveServers {
+		delete(s.servers, name)
+	}
+	for i := range delta.AddServers {
+		spec := delta.AddServers[i]
+		server := NewServerFromSpec(&spec)
+		server.system = s
+		s.servers[spec.Name] = server
+	}
+	for _, update := range delta.Loads {
+		load := update.Load
+		s.servers[update.Name].SetLoad(&load)
+	}
+	return nil
 }
 
 // Remove a server
 func (s *System) RemoveServer(name string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 	if s.servers[name] == nil {
 		return fmt.Errorf("server %s not found", name)
 	}
@@ -172,72 +701,178 @@ func (s *System) RemoveServer(name string) error {
 
 // Set service classes from spec
 func (s *System) SetServiceClassesFromSpec(d *config.ServiceClassData) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 	for _, scSpec := range d.Spec {
 		s.serviceClasses[scSpec.Name] = NewServiceClassFromSpec(&scSpec)
 	}
 }
 
-// Add a service class (replace if already exists)
-func (s *System) AddServiceClass(name string, priority int) {
-	s.serviceClasses[name] = NewServiceClass(name, priority)
+// Update the target of a model in a service class by patching a copy of its spec, returning the updated spec
+//   - the target is not changed if the patch fails
+func (s *System) PatchServiceClassModelTarget(name string, modelName string,
+	patch func(*config.ModelTarget) error) (*config.ModelTarget, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	svc := s.serviceClasses[name]
+	if svc == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNoServiceClass, name)
+	}
+	target := svc.ModelTarget(modelName)
+	if target == nil {
+		return nil, fmt.Errorf("%w: model=%s, class=%s", ErrNoTarget, modelName, name)
+	}
+	spec := target.ModelTarget(modelName)
+	if err := patch(&spec); err != nil {
+		return nil, err
+	}
+	if spec.Model != modelName {
+		return nil, fmt.Errorf("%w: model %s", ErrRename, modelName)
+	}
+	svc.AddModelTarget(&spec)
+	return &spec, nil
+}
+
+// Remove the own target of a model in a service class, returning a copy of the removed target
+func (s *System) RemoveServiceClassModelTarget(name string, modelName string) (*config.ModelTarget, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	svc := s.serviceClasses[name]
+	if svc == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNoServiceClass, name)
+	}
+	target := svc.OwnModelTarget(modelName)
+	if target == nil {
+		return nil, fmt.Errorf("%w: model=%s, class=%s", ErrNoTarget, modelName, name)
+	}
+	spec := target.ModelTarget(modelName)
+	svc.RemoveModelTarget(modelName)
+	return &spec, nil
+}
+
+// Add a service class (replace if already exists), returning a copy of its spec
+func (s *System) AddServiceClass(name string, priority int) config.ServiceClassSpec {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	svc := NewServiceClass(name, priority)
+	s.serviceClasses[name] = svc
+	return svc.Spec()
 }
 
-// Remove a service class
-func (s *System) RemoveServiceClass(name string) error {
-	if s.serviceClasses[name] == nil {
-		return fmt.Errorf("service class %s not found", name)
+// Remove a service class, returning a copy of the spec of the removed class
+func (s *System) RemoveServiceClass(name string) (*config.ServiceClassSpec, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	svc := s.serviceClasses[name]
+	if svc == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNoServiceClass, name)
 	}
 	delete(s.serviceClasses, name)
-	return nil
+	spec := svc.Spec()
+	return &spec, nil
+}
+
+// Set the value function of allocations
+func (s *System) SetValueFunc(valueFunc ValueFunc) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.valueFunc = valueFunc
+}
+
+func (s *System) SetObjective(objective config.Objective) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.objective = objective
+}
+
+// Set whether missing perf data of models is estimated from other accelerators, given their relative throughput
+func (s *System) SetAllowEstimatedPerf(allow bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.allowEstimatedPerf = allow
+}
+
+// Set the fraction of the minimum value of allocations of a server within which the allocation with the most
+// rate headroom is preferred when re-allocating (zero for the minimum value only)
+func (s *System) SetHeadroomTolerance(tolerance float32) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.headroomTolerance = tolerance
+}
+
+func (s *System) SetSolutionMetadata(metadata *config.SolutionMetadata) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.solutionMetadata = *metadata
 }
 
 // Get all accelerators
 func (s *System) Accelerators() map[string]*Accelerator {
-	return s.accelerators
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return maps.Clone(s.accelerators)
 }
 
 // Get all models
 func (s *System) Models() map[string]*Model {
-	return s.models
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return maps.Clone(s.models)
 }
 
 // Get all service classes
 func (s *System) ServiceClasses() map[string]*ServiceClass {
-	return s.serviceClasses
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return maps.Clone(s.serviceClasses)
 }
 
 // Get all servers
 func (s *System) Servers() map[string]*Server {
-	return s.servers
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return maps.Clone(s.servers)
 }
 
 // Get accelerator object for a given accelerator name; nil if doesn't exist
 func (s *System) Accelerator(name string) *Accelerator {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
 	return s.accelerators[name]
 }
 
 // Get model object for a given model name; nil if doesn't exist
 func (s *System) Model(name string) *Model {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
 	return s.models[name]
 }
 
 // Get service class object for a given service class name; nil if doesn't exist
 func (s *System) ServiceClass(name string) *ServiceClass {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
 	return s.serviceClasses[name]
 }
 
 // Get server object for a given server name; nil if doesn't exist
 func (s *System) Server(name string) *Server {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
 	return s.servers[name]
 }
 
 // Get capacities of accelerator types
 func (s *System) Capacities() map[string]int {
-	return s.capacity
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return maps.Clone(s.capacity)
 }
 
 // Get capacity of an accelerator type
 func (s *System) Capacity(name string) (int, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
 	if cap, exists := s.capacity[name]; !exists {
 		return 0, false
 	} else {
@@ -245,76 +880,553 @@ func (s *System) Capacity(name string) (int, bool) {
 	}
 }
 
+// Get number of partition slices per device of an accelerator type (zero if not partitioned)
+func (s *System) Partitions(name string) int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.partitions[name]
+}
+
+// Get power budget of allocations of an accelerator type (Watts, zero if none)
+func (s *System) PowerBudget(name string) float32 {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.powerBudgets[name]
+}
+
+// Get number of devices per node of an accelerator type (zero if not grouped in nodes)
+func (s *System) UnitsPerNode(name string) int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.unitsPerNode[name]
+}
+
 // Remove capacity of an accelerator type
 func (s *System) RemoveCapacity(name string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 	if _, exists := s.capacity[name]; !exists {
 		return false
 	}
 	delete(s.capacity, name)
+	delete(s.partitions, name)
+	delete(s.powerBudgets, name)
+	delete(s.unitsPerNode, name)
 	return true
 }
 
+// Evaluate an allocation given for a server at its current load, for compliance with its SLO targets
+//   - no state is changed
+func (s *System) EvaluateAllocation(serverName string, data *config.AllocationData) (*config.AllocationEvaluation, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return EvaluateAllocation(s, serverName, data)
+}
+
+// Size allocations of an accelerator to a server at each max batch size, up to the max batch size of the server
+//   - no state is changed
+func (s *System) SweepBatchSize(serverName string, gName string) ([]config.BatchSizePoint, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return SweepBatchSize(s, serverName, gName)
+}
+
+// Differences between the current (applied) and desired allocations of servers, for servers with changes
+//   - no state is changed
+func (s *System) PlanDiff() map[string]*AllocationDiff {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	diffs := make(map[string]*AllocationDiff)
+	for serverName, server := range s.servers {
+		curAlloc := server.CurAllocation()
+		if curAlloc != nil && curAlloc.accelerator == "" {
+			curAlloc = nil
+		}
+		if diff := CreateAllocationDiff(curAlloc, server.Allocation()); diff != nil && diff.Changed() {
+			diffs[serverName] = diff
+		}
+	}
+	return diffs
+}
+
+// Apply desired allocations of servers as their current allocations, in batches of transitions
+//   - transitions (servers with changes between current and desired allocations) are applied in ascending order
+//     of their transition penalty (then server name), up to a max number of transitions (all if not positive)
+//   - desired allocations of servers with no changes are applied
+//   - servers failing to apply their desired allocations keep their current allocations, and failed transitions
+//     count towards the max number of transitions
+//   - returns the applied and the remaining pending transitions, in order, and the failed servers, by name
+func (s *System) ApplyDesiredAllocs(maxTransitions int) *config.ApplyResult {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	result := &config.ApplyResult{
+		Applied: make([]config.TransitionData, 0),
+		Failed:  make([]config.ServerStatus, 0),
+	}
+	fail := func(serverName string, err error) {
+		result.Failed = append(result.Failed, config.ServerStatus{Name: serverName, Reason: err.Error()})
+	}
+	transitions := make([]config.TransitionData, 0)
+	for _, serverName := range slices.Sorted(maps.Keys(s.servers)) {
+		server := s.servers[serverName]
+		curAlloc := server.CurAllocation()
+		if curAlloc != nil && curAlloc.accelerator == "" {
+			curAlloc = nil
+		}
+		desiredAlloc := server.Allocation()
+		diff := CreateAllocationDiff(curAlloc, desiredAlloc)
+		if diff == nil || !diff.Changed() {
+			if err := server.ApplyDesiredAlloc(); err != nil {
+				fail(serverName, err)
+			}
+			continue
+		}
+		var penalty float32
+		if curAlloc != nil && desiredAlloc != nil {
+			penalty = curAlloc.TransitionPenalty(desiredAlloc)
+		}
+		transitions = append(transitions, config.TransitionData{
+			Name:    serverName,
+			Penalty: penalty,
+			Diff:    *diff.AllocationDiffData(),
+		})
+	}
+	slices.SortFunc(transitions, func(a, b config.TransitionData) int {
+		return cmp.Or(cmp.Compare(a.Penalty, b.Penalty), cmp.Compare(a.Name, b.Name))
+	})
+
+	numAttempted := len(transitions)
+	if maxTransitions > 0 {
+		numAttempted = min(numAttempted, maxTransitions)
+	}
+	for _, t := range transitions[:numAttempted] {
+		if err := s.servers[t.Name].ApplyDesiredAlloc(); err != nil {
+			fail(t.Name, err)
+			continue
+		}
+		result.Applied = append(result.Applied, t)
+	}
+	result.Pending = transitions[numAttempted:]
+	slices.SortFunc(result.Failed, func(a, b config.ServerStatus) int { return cmp.Compare(a.Name, b.Name) })
+	return result
+}
+
+// Copies of all feasible allocations of a server, ordered by value (then accelerator name); false if server doesn't exist
+//   - no state is changed
+func (s *System) CandidateAllocations(serverName string) ([]*Allocation, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	server := s.servers[serverName]
+	if server == nil {
+		return nil, false
+	}
+	allocs := make([]*Allocation, 0, len(server.allAllocations))
+	for _, alloc := range server.allAllocations {
+		allocs = append(allocs, alloc.Clone())
+	}
+	slices.SortFunc(allocs, func(a, b *Allocation) int {
+		return cmp.Or(cmp.Compare(a.value, b.value), cmp.Compare(a.accelerator, b.accelerator))
+	})
+	return allocs, true
+}
+
+// Check the feasibility of a server, given its load and SLO targets, without changing state
+//   - allocations are calculated on a copy of the system with this server only (replacing a server with the same name)
+//   - the target replaces the target of the service class of the server (the default class if none) for its model
+//   - the best allocation is the one with the least value, regardless of capacity
+func (s *System) CheckFeasibility(request *config.FeasibilityRequest) (*config.FeasibilityResult, error) {
+	if err := s.CheckServerReferences(&request.Server); err != nil {
+		return nil, err
+	}
+	spec := s.Spec()
+	serverSpec := request.Server
+	if request.Load != nil {
+		serverSpec.CurrentAlloc.Load = *request.Load
+	}
+	spec.Servers.Spec = []config.ServerSpec{serverSpec}
+	if request.Target != nil {
+		target := *request.Target
+		target.Model = serverSpec.Model
+		className := cmp.Or(serverSpec.Class, config.DefaultServiceClassName)
+		i := slices.IndexFunc(spec.ServiceClasses.Spec, func(c config.ServiceClassSpec) bool { return c.Name == className })
+		if i < 0 {
+			spec.ServiceClasses.Spec = append(spec.ServiceClasses.Spec,
+				config.ServiceClassSpec{Name: className, Priority: config.DefaultServiceClassPriority})
+			i = len(spec.ServiceClasses.Spec) - 1
+		}
+		spec.ServiceClasses.Spec[i].ModelTargets = append(spec.ServiceClasses.Spec[i].ModelTargets, target)
+	}
+	clone, err := s.cloneWithSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+	clone.Calculate()
+
+	server := clone.servers[serverSpec.Name]
+	result := &config.FeasibilityResult{
+		Rejections: make(map[string]string, len(server.allocationErrors)),
+		Warnings:   make([]string, 0),
+	}
+	for gName, err := range server.allocationErrors {
+		result.Rejections[gName] = err.Error()
+	}
+	if model := clone.models[serverSpec.Model]; model != nil {
+		candidates := server.GetCandidateAccelerators(clone.accelerators)
+		for _, gName := range slices.Sorted(maps.Keys(candidates)) {
+			if perf := model.PerfData(gName); perf != nil && perf.PoorFit() {
+				result.Warnings = append(result.Warnings, fmt.Sprintf(
+					"decode parameters of model %s on accelerator %s fitted with R²=%.3f, below %v: latency estimates are not trustworthy",
+					model.Name(), gName, perf.FitRSquared, config.MinPerfFitRSquared))
+			}
+		}
+	}
+	if allocs, _ := clone.CandidateAllocations(serverSpec.Name); len(allocs) > 0 {
+		best := allocs[0]
+		result.Feasible = true
+		result.Best = &config.CandidateAllocationData{
+			AllocationData: *best.AllocationData(),
+			Value:          best.Value(),
+			MaxRPM:         best.MaxRPM(),
+		}
+		result.Best.Load = *server.Load()
+	} else {
+		result.Reason = summarizeAllocationErrors(server.allocationErrors)
+	}
+	return result, nil
+}
+
+// Recommend scaling the allocation of a server to a new load, without changing state
+//   - the allocation is the current (applied) allocation of the server if any, its desired allocation otherwise
+//   - the allocation is scaled on the same accelerator, and compared with the best allocation across all accelerators
+func (s *System) RecommendScale(serverName string, load *config.ServerLoadSpec) (*config.ScaleRecommendation, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	server := s.servers[serverName]
+	if server == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNoServer, serverName)
+	}
+	alloc := server.CurAllocation()
+	if alloc == nil || alloc.accelerator == "" {
+		alloc = server.Allocation()
+	}
+	if alloc == nil || alloc.accelerator == "" {
+		return nil, fmt.Errorf("%w: %s", ErrNoAllocation, serverName)
+	}
+
+	// evaluate allocations for the new load, restoring the load of the server afterwards
+	curLoad := server.Load()
+	server.SetLoad(load)
+	defer server.SetLoad(curLoad)
+	scaledAlloc, inc, action, scaleErr := alloc.Scale(s, serverName, alloc.numReplicas)
+	bestAlloc, bestName, bestErr := alloc.ReAllocate(s, serverName)
+	if scaleErr != nil && bestErr != nil {
+		return nil, errors.Join(scaleErr, bestErr)
+	}
+
+	recommendation := &config.ScaleRecommendation{
+		Accelerator: alloc.accelerator,
+	}
+	if scaleErr != nil {
+		recommendation.ScaleError = scaleErr.Error()
+	} else {
+		recommendation.NumReplicas = scaledAlloc.numReplicas
+		recommendation.Increment = inc
+		recommendation.Action = action.String()
+		recommendation.Cost = scaledAlloc.cost
+		if inc > 0 {
+			recommendation.WarmupSeconds = s.replicaWarmup(server, alloc.accelerator)
+		}
+	}
+	if bestErr == nil {
+		recommendation.BestAccelerator = bestName
+		recommendation.BestNumReplicas = bestAlloc.numReplicas
+		recommendation.BestCost = bestAlloc.cost
+		recommendation.Reallocate = bestName != alloc.accelerator &&
+			(scaleErr != nil || bestAlloc.value < scaledAlloc.value)
+		if bestName != alloc.accelerator || bestAlloc.numReplicas > alloc.numReplicas {
+			recommendation.BestWarmupSeconds = s.replicaWarmup(server, bestName)
+		}
+	}
+	return recommendation, nil
+}
+
+// time for a new replica of a server on an accelerator to become ready (sec), zero if unknown
+func (s *System) replicaWarmup(server *Server, gName string) float32 {
+	acc := s.accelerators[gName]
+	model := s.models[server.modelName]
+	if acc == nil || model == nil {
+		return 0
+	}
+	if perf := model.PerfData(gName); perf != nil {
+		return warmupSeconds(acc, perf)
+	}
+	return acc.WarmupSeconds()
+}
+
 // Calculate basic parameters
 func (s *System) Calculate() {
+	s.CalculateContext(context.Background())
+}
+
+// Calculate, stopping when the context is done
+//   - servers not calculated by then have no feasible allocations, with ErrTimeout as the reason
+func (s *System) CalculateContext(ctx context.Context) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 	for _, g := range s.accelerators {
 		g.Calculate()
 	}
 	for _, m := range s.models {
 		m.Calculate(s.accelerators)
+		if s.allowEstimatedPerf {
+			m.EstimatePerfData(s.accelerators)
+		}
 	}
 	for _, v := range s.servers {
+		if ctx.Err() != nil {
+			v.failCalculate(s.accelerators, ErrTimeout)
+			continue
+		}
 		v.Calculate(s.accelerators)
 	}
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("%w: %w", ErrTimeout, err)
+	}
+	return nil
 }
 
 // Accumulate allocation data by accelerator type
 func (s *System) AllocateByType() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 	s.allocationByType = map[string]*AllocationByType{}
-	for _, server := range s.Servers() {
+	for _, server := range s.servers {
 		modelName := server.ModelName()
-		serverAlloc := server.Allocation()
-		if serverAlloc == nil {
+		if server.Allocation() == nil {
 			continue
 		}
-		accName := serverAlloc.accelerator
-		acc := s.accelerators[accName]
-		model := s.Model(modelName)
-		if acc == nil || model == nil {
+		for _, serverAlloc := range server.Allocation().Legs() {
+			accName := serverAlloc.accelerator
+			acc := s.accelerators[accName]
+			model := s.models[modelName]
+			if acc == nil || model == nil {
+				continue
+			}
+			nameType := acc.Type()
+			var alloc *AllocationByType
+			var exists bool
+			if alloc, exists = s.allocationByType[nameType]; !exists {
+				alloc = &AllocationByType{
+					name:  nameType,
+					count: 0,
+					limit: s.capacity[nameType] * s.GetSlicesPerDevice(nameType),
+					cost:  0,
+				}
+			}
+			alloc.count += serverAlloc.numReplicas * model.numInstances[accName] * s.GetUnits(acc)
+			alloc.cost += serverAlloc.cost
+			alloc.power += serverAlloc.power
+			s.allocationByType[nameType] = alloc
+		}
+	}
+}
+
+// Get utilization of accelerator types (allocated units over capacity), for types with capacity
+func (s *System) Utilization() map[string]float32 {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	utilization := make(map[string]float32)
+	for typeName, a := range s.allocationByType {
+		if a.limit > 0 {
+			utilization[typeName] = float32(a.count) / float32(a.limit)
+		}
+	}
+	return utilization
+}
+
+// Get total power consumption of allocations of all accelerator types (Watts)
+func (s *System) TotalPower() float32 {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	power := float32(0)
+	for _, a := range s.allocationByType {
+		power += a.power
+	}
+	return power
+}
+
+// Get utilization data of accelerator types with capacity or allocated units, ordered by type name
+func (s *System) UtilizationData() []config.AcceleratorUtilization {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	units := s.GetCapacityUnits()
+	for typeName, a := range s.allocationByType {
+		units[typeName] = a.limit
+	}
+	data := make([]config.AcceleratorUtilization, 0, len(units))
+	for _, typeName := range slices.Sorted(maps.Keys(units)) {
+		u := config.AcceleratorUtilization{
+			Type:     typeName,
+			Capacity: units[typeName],
+		}
+		if a := s.allocationByType[typeName]; a != nil {
+			u.Allocated = a.count
+			u.Power = a.power
+		}
+		u.Free = u.Capacity - u.Allocated
+		if u.Capacity > 0 {
+			u.Utilization = 100 * float32(u.Allocated) / float32(u.Capacity)
+		}
+		if budget := s.powerBudgets[typeName]; budget > 0 {
+			u.MaxPower = budget
+			u.PowerHeadroom = budget - u.Power
+		}
+		data = append(data, u)
+	}
+	return data
+}
+
+// Capacity of accelerator types used by the allocations of the current solution, ordered by type name
+//   - the number of devices of a partitioned type is rounded up to hold all its allocated slices
+func (s *System) UsedCapacity() *config.CapacityData {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	units := make(map[string]int)
+	for _, server := range s.servers {
+		alloc := server.Allocation()
+		if alloc == nil {
+			continue
+		}
+		for _, leg := range alloc.Legs() {
+			acc := s.accelerators[leg.accelerator]
+			model := s.models[server.modelName]
+			if acc != nil && model != nil {
+				units[acc.Type()] += leg.numReplicas * model.numInstances[leg.accelerator] * s.GetUnits(acc)
+			}
+		}
+	}
+	data := &config.CapacityData{Count: make([]config.AcceleratorCount, 0, len(units))}
+	for _, typeName := range slices.Sorted(maps.Keys(units)) {
+		slicesPerDevice := s.GetSlicesPerDevice(typeName)
+		data.Count = append(data.Count, config.AcceleratorCount{
+			Type:       typeName,
+			Count:      (units[typeName] + slicesPerDevice - 1) / slicesPerDevice,
+			Partitions: s.partitions[typeName],
+		})
+	}
+	return data
+}
+
+// Shortfall of accelerator units by type to satisfy SLOs of all servers, given the current solution
+//   - demand includes units of allocations at their requested number of replicas, if degraded,
+//     and units of the best (least value) feasible allocation of unallocated servers
+//   - types with no shortfall are not included
+func (s *System) CapacityShortfall() map[string]int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	demand := make(map[string]int)
+	addUnits := func(server *Server, alloc *Allocation, numReplicas int) {
+		acc := s.accelerators[alloc.accelerator]
+		model := s.models[server.modelName]
+		if acc != nil && model != nil {
+			demand[acc.Type()] += numReplicas * model.numInstances[alloc.accelerator] * s.GetUnits(acc)
+		}
+	}
+	for _, server := range s.servers {
+		if alloc := server.Allocation(); alloc != nil {
+			for _, leg := range alloc.Legs() {
+				addUnits(server, leg, max(leg.numReplicas, leg.requestedReplicas))
+			}
 			continue
 		}
-		nameType := acc.Type()
-		var alloc *AllocationByType
-		var exists bool
-		if alloc, exists = s.allocationByType[nameType]; !exists {
-			alloc = &AllocationByType{
-				name:  nameType,
-				count: 0,
-				limit: s.capacity[nameType],
-				cost:  0,
+		var best *Allocation
+		for _, gName := range slices.Sorted(maps.Keys(server.allAllocations)) {
+			if alloc := server.allAllocations[gName]; best == nil || alloc.value < best.value {
+				best = alloc
 			}
 		}
-		alloc.count += serverAlloc.numReplicas * model.numInstances[accName] * acc.Multiplicity()
-		alloc.cost += serverAlloc.cost
-		s.allocationByType[nameType] = alloc
+		if best != nil {
+			addUnits(server, best, best.numReplicas)
+		}
+	}
+	capacityUnits := s.GetCapacityUnits()
+	shortfall := make(map[string]int)
+	for typeName, units := range demand {
+		if missing := units - capacityUnits[typeName]; missing > 0 {
+			shortfall[typeName] = missing
+		}
 	}
+	return shortfall
 }
 
 // generate json allocation solution for all servers in the system
 func (s *System) GenerateSolution() *config.AllocationSolution {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 	allocationSolution := config.AllocationSolution{
-		Spec: make(map[string]config.AllocationData),
+		Spec:         make(map[string]config.AllocationData),
+		Unallocated:  make([]config.ServerStatus, 0),
+		Degraded:     make([]string, 0),
+		Idle:         make([]string, 0),
+		ScaledToZero: make([]string, 0),
+		Estimated:    make([]string, 0),
+		Draining:     make([]string, 0),
+		Pinned:       make([]config.PinnedStatus, 0),
+		Currency:     s.GetCurrency(),
+		CostPeriod:   config.HourlyCost.String(),
+		Metadata:     s.solutionMetadata,
 	}
-	for serverName, server := range s.servers {
+	var totalTokensPerHour float32
+	for _, serverName := range slices.Sorted(maps.Keys(s.servers)) {
+		server := s.servers[serverName]
 		serverAlloc := server.Allocation()
+		if server.ScaledToZero() {
+			allocationSolution.ScaledToZero = append(allocationSolution.ScaledToZero, serverName)
+			continue
+		}
 		if serverAlloc == nil {
+			allocationSolution.Unallocated = append(allocationSolution.Unallocated, config.ServerStatus{
+				Name:   serverName,
+				Reason: server.UnallocatedReason(),
+			})
 			continue
 		}
 		load := server.Load()
 		allocData := serverAlloc.AllocationData()
 		allocData.Load = *load
+		allocData.CostPerMillionTokens = serverAlloc.CostPerMillionTokens(load)
+		totalTokensPerHour += serverAlloc.MaxTokensPerHour(load)
 		allocationSolution.Spec[serverName] = *allocData
+		if serverAlloc.Degraded() {
+			allocationSolution.Degraded = append(allocationSolution.Degraded, serverName)
+		}
+		if serverAlloc.Idle() {
+			allocationSolution.Idle = append(allocationSolution.Idle, serverName)
+		}
+		if serverAlloc.Estimated() {
+			allocationSolution.Estimated = append(allocationSolution.Estimated, serverName)
+		}
+		if slices.ContainsFunc(serverAlloc.Legs(), func(leg *Allocation) bool {
+			acc := s.accelerators[leg.accelerator]
+			return acc != nil && acc.Draining()
+		}) {
+			allocationSolution.Draining = append(allocationSolution.Draining, serverName)
+		}
+		if eval := server.PinnedEvaluation(); server.Pinned() && eval != nil {
+			allocationSolution.Pinned = append(allocationSolution.Pinned, config.PinnedStatus{
+				Name:      serverName,
+				Compliant: eval.Compliant,
+				Saturated: eval.Saturated,
+				Targets:   eval.Targets,
+			})
+		}
+		allocationSolution.TotalPower += serverAlloc.power
+		allocationSolution.TotalCost += allocData.Cost
+		allocationSolution.WarmCost += allocData.WarmCost
 	}
+	allocationSolution.CostPerMillionTokens = costPerMillionTokens(allocationSolution.TotalCost, totalTokensPerHour)
 	s.allocationSolution = &allocationSolution
+	s.publishSnapshot()
 	return &allocationSolution
 }
 
@@ -325,6 +1437,8 @@ func (a *AllocationByType) String() string {
 }
 
 func (s *System) String() string {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
 	var b bytes.Buffer
 	// b.WriteString("Accelerators: \n")
 	// for _, g := range s.accelerators {
@@ -346,10 +1460,10 @@ func (s *System) String() string {
 
 	b.WriteString("Solution: \n")
 	totalCost := float32(0)
-	for serverName, server := range s.Servers() {
+	for serverName, server := range s.servers {
 		srvClassName := server.ServiceClassName()
 		modelName := server.ModelName()
-		load := server.Load()
+		load := server.ServedLoad()
 		svc := s.serviceClasses[srvClassName]
 		if load == nil || svc == nil {
 			continue
@@ -360,7 +1474,8 @@ func (s *System) String() string {
 		}
 		alloc := server.Allocation()
 		if alloc == nil {
-			fmt.Fprintf(&b, "s=%s; c=%s; m=%s; no feasible allocation! \n", serverName, srvClassName, modelName)
+			fmt.Fprintf(&b, "s=%s; c=%s; m=%s; no feasible allocation! reason=%s \n",
+				serverName, srvClassName, modelName, server.UnallocatedReason())
 			continue
 		}
 		totalCost += alloc.cost
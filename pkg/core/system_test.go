@@ -0,0 +1,112 @@
+package core
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/llm-inferno/optimizer/pkg/config"
+	"github.com/llm-inferno/optimizer/pkg/testutil"
+	"github.com/llm-inferno/optimizer/pkg/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// System data converted from JSON to YAML, keeping the JSON field names, yields the same system as the JSON data
+func TestYAMLRoundTripSameSystem(t *testing.T) {
+	spec := testutil.SystemSpec()
+	server := testutil.ServerSpec("premium-granite", "Premium", 600)
+	server.QueueModel = "GGm"
+	server.CurrentAlloc.Accelerator, server.CurrentAlloc.NumReplicas = "G2", 5
+	spec.Servers.Spec = append(spec.Servers.Spec, server)
+	spec.Capacity = testutil.Capacity(8)
+	spec.Optimizer.Spec = config.OptimizerSpec{Algorithm: "greedy", SaturationPolicy: "RoundRobin"}
+
+	jsonBytes, err := json.Marshal(config.SystemData{Spec: *spec})
+	if err != nil {
+		t.Fatalf("marshal JSON: %v", err)
+	}
+	var doc any
+	if err := json.Unmarshal(jsonBytes, &doc); err != nil {
+		t.Fatalf("unmarshal JSON: %v", err)
+	}
+	yamlBytes, err := yaml.Marshal(doc)
+	if err != nil {
+		t.Fatalf("marshal YAML: %v", err)
+	}
+	if utils.IsJSON(yamlBytes) {
+		t.Fatalf("YAML data detected as JSON:\n%s", yamlBytes)
+	}
+
+	systemOf := func(data []byte) (*System, *config.OptimizerSpec) {
+		d, err := utils.FromBytes(data, config.SystemData{})
+		if err != nil {
+			t.Fatalf("cannot parse data: %v", err)
+		}
+		system := testutil.SetFromSpec(t, NewSystem(), &d.Spec)
+		return system, &d.Spec.Optimizer.Spec
+	}
+	jsonSystem, jsonOptimizer := systemOf(jsonBytes)
+	yamlSystem, yamlOptimizer := systemOf(yamlBytes)
+	for name, acc := range jsonSystem.Accelerators() {
+		if got := yamlSystem.Accelerator(name); got == nil || !reflect.DeepEqual(got.Spec(), acc.Spec()) {
+			t.Errorf("accelerator %s from YAML differs from JSON", name)
+		}
+	}
+	for name, model := range jsonSystem.Models() {
+		got := yamlSystem.Model(name)
+		if got == nil {
+			t.Errorf("model %s missing from YAML", name)
+			continue
+		}
+		for accName := range jsonSystem.Accelerators() {
+			if !reflect.DeepEqual(got.PerfData(accName), model.PerfData(accName)) {
+				t.Errorf("perf data of model %s on %s from YAML differs from JSON", name, accName)
+			}
+		}
+	}
+	for name, svc := range jsonSystem.ServiceClasses() {
+		if got := yamlSystem.ServiceClass(name); got == nil || !reflect.DeepEqual(got.Spec(), svc.Spec()) {
+			t.Errorf("service class %s from YAML differs from JSON", name)
+		}
+	}
+	for name, server := range jsonSystem.Servers() {
+		if got := yamlSystem.Server(name); got == nil || !reflect.DeepEqual(got.Spec(), server.Spec()) {
+			t.Errorf("server %s from YAML differs from JSON", name)
+		}
+	}
+	if got, want := yamlSystem.Capacities(), jsonSystem.Capacities(); !reflect.DeepEqual(got, want) {
+		t.Errorf("capacity from YAML=%v, want %v", got, want)
+	}
+	if len(yamlSystem.Servers()) != 1 || len(yamlSystem.Capacities()) != 2 {
+		t.Errorf("system from YAML has %d servers and %d capacities, want 1 and 2",
+			len(yamlSystem.Servers()), len(yamlSystem.Capacities()))
+	}
+	if !reflect.DeepEqual(yamlOptimizer, jsonOptimizer) {
+		t.Errorf("optimizer spec from YAML=%+v, want %+v", *yamlOptimizer, *jsonOptimizer)
+	}
+}
+
+// A snapshot is published when read only after the system was locked for writing, and is the same snapshot if the
+// system is unchanged
+func TestSnapshotPublishedOnChange(t *testing.T) {
+	system := testutil.SetFromSpec(t, NewSystem(), testutil.SystemSpec())
+	snapshot := system.Snapshot()
+	published := system.snapshot.Load()
+	if system.Snapshot(); system.snapshot.Load() != published {
+		t.Errorf("snapshot published again without a change of the system")
+	}
+
+	system.Lock()
+	system.Unlock()
+	if got := system.Snapshot(); got != snapshot {
+		t.Errorf("new snapshot %s of an unchanged system, want %s", got.Version, snapshot.Version)
+	}
+
+	system.AddModel("new-model")
+	got := system.Snapshot()
+	if got.Version == snapshot.Version || !slices.Contains(got.Models, "new-model") {
+		t.Errorf("snapshot %s with models %v after adding a model, want a new version with the model",
+			got.Version, got.Models)
+	}
+}
@@ -0,0 +1,39 @@
+package core
+
+import (
+	"github.com/llm-inferno/optimizer/pkg/config"
+)
+
+// Function evaluating the value of an allocation (smaller values are preferred)
+//   - cost in value functions is the metric of the system objective (dollar cost or power)
+type ValueFunc func(a *Allocation) float32
+
+// Value of an allocation is its cost
+func CostValue(a *Allocation) float32 {
+	return a.ObjectiveCost()
+}
+
+// Value of an allocation is its cost per unit of max request rate (req/min)
+func CostPerRPMValue(a *Allocation) float32 {
+	if rpm := float32(a.numReplicas) * a.MaxRPM(); rpm > 0 {
+		return a.ObjectiveCost() / rpm
+	}
+	return a.ObjectiveCost()
+}
+
+// Value of an allocation is its cost, penalized by utilization (less latency headroom)
+func CostLatencyValue(a *Allocation) float32 {
+	return a.ObjectiveCost() * (1 + config.LatencyValueFactor*a.rho)
+}
+
+// Get value function by name; cost value if name is unknown
+func ValueFuncByName(name string) ValueFunc {
+	switch config.ValueFunctionEnum(name) {
+	case config.CostPerRPMValue:
+		return CostPerRPMValue
+	case config.CostLatencyValue:
+		return CostLatencyValue
+	default:
+		return CostValue
+	}
+}
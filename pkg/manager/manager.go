@@ -1,6 +1,13 @@
 package manager
 
 import (
+	"context"
+	"errors"
+	"maps"
+	"math/rand"
+	"slices"
+
+	"github.com/llm-inferno/optimizer/pkg/config"
 	"github.com/llm-inferno/optimizer/pkg/core"
 	"github.com/llm-inferno/optimizer/pkg/solver"
 )
@@ -11,17 +18,209 @@ type Manager struct {
 }
 
 func NewManager(system *core.System, optimizer *solver.Optimizer) *Manager {
-	core.TheSystem = system
+	if spec := optimizer.Spec(); spec != nil {
+		system.SetValueFunc(core.ValueFuncByName(spec.ValueFunction))
+		system.SetObjective(config.ObjectiveEnum(spec.Objective))
+		system.SetAllowEstimatedPerf(spec.AllowEstimatedPerf)
+		system.SetHeadroomTolerance(spec.HeadroomTolerance)
+	}
 	return &Manager{
 		system:    system,
 		optimizer: optimizer,
 	}
 }
 
-func (m *Manager) Optimize() error {
-	if err := m.optimizer.Optimize(); err != nil {
+// Optimize, within the time allowed by the context
+//   - ErrTimeout if the context is done before completion, with a partial but valid solution
+func (m *Manager) Optimize(ctx context.Context) error {
+	return m.run(ctx, m.optimizer.Optimize)
+}
+
+// Re-optimize, using the current allocations of servers as a warm start
+//   - servers are kept on their current accelerators, rather than moved to cheaper ones,
+//     as long as the total cost increase is within the churn budget of the optimizer spec
+//   - transition penalties from current allocations are already part of the values of allocations
+func (m *Manager) Reoptimize(ctx context.Context) error {
+	return m.run(ctx, m.optimizer.Reoptimize)
+}
+
+// Plan of changes from the current (applied) to the desired allocations of servers, without applying them
+func (m *Manager) PlanDiff() map[string]*core.AllocationDiff {
+	return m.system.PlanDiff()
+}
+
+// run an optimization and summarize its solution, also if partial as the optimization timed out
+func (m *Manager) run(ctx context.Context, optimize func(context.Context, *core.System) error) error {
+	err := m.solve(ctx, optimize)
+	if err != nil && !errors.Is(err, core.ErrTimeout) {
 		return err
 	}
 	m.system.AllocateByType()
-	return nil
+	typesBefore, typesAfter := m.optimizer.NumAcceleratorTypes()
+	minHeadroom, addedReplicas := m.optimizer.Headroom()
+	admitted, shed := m.optimizer.Admission()
+	metadata := &config.SolutionMetadata{
+		ImprovingMoves:  m.optimizer.NumImprovingMoves(),
+		KeptAllocations: m.optimizer.NumKept(),
+		TimedOut:        err != nil,
+
+		AcceleratorTypesBefore: typesBefore,
+		AcceleratorTypesAfter:  typesAfter,
+		MinHeadroom:            minHeadroom,
+		AddedReplicas:          addedReplicas,
+		Admitted:               admitted,
+		Shed:                   shed,
+		Trace:                  m.optimizer.Trace(),
+	}
+	if spec := m.optimizer.Spec(); spec != nil && spec.MaxTotalPowerWatts > 0 {
+		metadata.PowerHeadroom = spec.MaxTotalPowerWatts - m.system.TotalPower()
+	}
+	m.system.SetSolutionMetadata(metadata)
+	return err
+}
+
+// run an optimization holding exclusive access to the system
+func (m *Manager) solve(ctx context.Context, optimize func(context.Context, *core.System) error) error {
+	m.system.Lock()
+	defer m.system.Unlock()
+	return optimize(ctx, m.system)
+}
+
+// Plan allocations of servers for each time window of their load profiles, for scheduled scaling
+//   - for each window, servers with a load in the window are given that load (others keep their current load),
+//     and the system is optimized; windows are disjoint in time, hence each window may use all the capacity
+//   - a timed out window has a partial solution; windows after the context is done are not planned
+//   - loads and allocations of servers are restored afterwards
+func (m *Manager) PlanWindows(ctx context.Context) []config.WindowPlan {
+	windows := m.system.LoadWindows()
+	plans := make([]config.WindowPlan, 0, len(windows))
+	if len(windows) == 0 {
+		return plans
+	}
+	servers := m.system.Servers()
+	serverNames := slices.Sorted(maps.Keys(servers))
+
+	// keep current loads and allocations
+	loads := make(map[string]*config.ServerLoadSpec)
+	allocs := make(map[string]*core.Allocation)
+	for _, name := range serverNames {
+		server := servers[name]
+		loads[name] = server.Load()
+		allocs[name] = server.Allocation()
+	}
+
+	for _, window := range windows {
+		if ctx.Err() != nil {
+			break
+		}
+		for _, name := range serverNames {
+			server := servers[name]
+			if load := server.WindowLoad(window); load != nil {
+				server.SetLoad(load)
+			} else {
+				server.SetLoad(loads[name])
+			}
+		}
+		m.system.CalculateContext(ctx)
+		plan := config.WindowPlan{Window: window}
+		if err := m.run(ctx, m.optimizer.Optimize); err != nil && !errors.Is(err, core.ErrTimeout) {
+			plan.Error = err.Error()
+		} else {
+			plan.Solution = m.system.GenerateSolution()
+		}
+		plans = append(plans, plan)
+	}
+
+	// restore loads and allocations
+	for _, name := range serverNames {
+		server := servers[name]
+		server.SetLoad(loads[name])
+		if alloc := allocs[name]; alloc != nil {
+			server.SetAllocation(alloc)
+		} else {
+			server.RemoveAllocation()
+			server.UpdateDesiredAlloc()
+		}
+	}
+	m.system.Calculate()
+	m.system.AllocateByType()
+	m.system.GenerateSolution()
+	return plans
+}
+
+// Evaluate stability of the current solution under random load perturbations
+//   - loads of all servers are perturbed by a random factor in [1-perturbation, 1+perturbation] and the system is re-solved;
+//     random factors are drawn from a generator seeded by the optimizer spec, hence the score is reproducible
+//   - returns one minus the fraction of servers whose allocation changed, averaged over trials (1 is fully stable)
+//   - loads and allocations of servers are restored afterwards
+func (m *Manager) StabilityScore(perturbation float32, trials int) float32 {
+	if trials <= 0 {
+		return 1
+	}
+	serverNames := slices.Sorted(maps.Keys(m.system.Servers()))
+	if len(serverNames) == 0 {
+		return 1
+	}
+
+	// keep current loads and allocations
+	loads := make(map[string]*config.ServerLoadSpec)
+	allocs := make(map[string]*core.Allocation)
+	for _, name := range serverNames {
+		server := m.system.Server(name)
+		loads[name] = server.Load()
+		allocs[name] = server.Allocation()
+	}
+
+	var seed int64
+	if spec := m.optimizer.Spec(); spec != nil {
+		seed = spec.Seed
+	}
+	rng := rand.New(rand.NewSource(seed))
+	var totalChurn float32
+	for t := 0; t < trials; t++ {
+		for _, name := range serverNames {
+			load := loads[name]
+			if load == nil {
+				continue
+			}
+			perturbed := *load
+			perturbed.ArrivalRate *= 1 + perturbation*(2*rng.Float32()-1)
+			m.system.Server(name).SetLoad(&perturbed)
+		}
+		m.system.Calculate()
+		if err := m.solve(context.Background(), m.optimizer.Optimize); err != nil {
+			continue
+		}
+		changed := 0
+		for _, name := range serverNames {
+			if !sameAllocation(allocs[name], m.system.Server(name).Allocation()) {
+				changed++
+			}
+		}
+		totalChurn += float32(changed) / float32(len(serverNames))
+	}
+
+	// restore loads and allocations
+	for _, name := range serverNames {
+		server := m.system.Server(name)
+		server.SetLoad(loads[name])
+		if alloc := allocs[name]; alloc != nil {
+			server.SetAllocation(alloc)
+		} else {
+			server.RemoveAllocation()
+			server.UpdateDesiredAlloc()
+		}
+	}
+	m.system.Calculate()
+	m.system.AllocateByType()
+
+	return 1 - totalChurn/float32(trials)
+}
+
+// check if two allocations have the same accelerator and number of replicas
+func sameAllocation(a, b *core.Allocation) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Accelerator() == b.Accelerator() && a.NumReplicas() == b.NumReplicas()
 }
@@ -0,0 +1,39 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/llm-inferno/optimizer/pkg/config"
+	"github.com/llm-inferno/optimizer/pkg/core"
+	"github.com/llm-inferno/optimizer/pkg/solver"
+	"github.com/llm-inferno/optimizer/pkg/testutil"
+)
+
+// A high transition penalty weight keeps servers on their current accelerators under load perturbations, yielding
+// a higher stability score than a low weight, with which servers move to the cheapest accelerator for each load
+func TestStabilityScoreHigherWithHighTransitionPenalty(t *testing.T) {
+	defer func(factor float32) { config.AccelPenaltyFactor = factor }(config.AccelPenaltyFactor)
+	score := func(penaltyFactor float32) float32 {
+		config.AccelPenaltyFactor = penaltyFactor
+		spec := testutil.SystemSpec()
+		// for loads near 550, 6 replicas on A100 (114) are cheaper than 5 on G2 (125) below about 525 req/min
+		spec.Accelerators.Spec[0].Cost = 19
+		server := testutil.ServerSpec("premium-granite", "Premium", 550)
+		server.CurrentAlloc.Accelerator, server.CurrentAlloc.NumReplicas, server.CurrentAlloc.Cost = "G2", 5, 125
+		spec.Servers.Spec = append(spec.Servers.Spec, server)
+		spec.Capacity = testutil.Capacity(8)
+		system := testutil.SetFromSpec(t, core.NewSystem(), spec)
+		system.Calculate()
+		m := NewManager(system, solver.NewOptimizerFromSpec(&config.OptimizerSpec{}))
+		if err := m.Optimize(context.Background()); err != nil {
+			t.Fatalf("optimize: %v", err)
+		}
+		return m.StabilityScore(0.08, 20)
+	}
+
+	low, high := score(0), score(1)
+	if high <= low {
+		t.Errorf("stability score: high penalty=%v, low penalty=%v, want high penalty higher", high, low)
+	}
+}
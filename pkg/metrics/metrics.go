@@ -0,0 +1,205 @@
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Metrics of the optimizer, exposed in the Prometheus text format
+var (
+	OptimizationDuration = NewHistogram("inferno_optimization_duration_seconds",
+		"Duration of optimization requests.",
+		[]float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30})
+	AllocatedServers = NewGauge("inferno_allocated_servers",
+		"Number of servers given an allocation in the last solution.", "")
+	UnallocatedServers = NewGauge("inferno_unallocated_servers",
+		"Number of servers not given an allocation in the last solution.", "")
+	SolutionCost = NewGauge("inferno_solution_cost",
+		"Total cost of allocations in the last solution.", "")
+	AcceleratorUtilization = NewGauge("inferno_accelerator_utilization",
+		"Allocated units of an accelerator type over its capacity in the last solution.", "type")
+	InfeasibleAllocations = NewCounter("inferno_infeasible_allocations_total",
+		"Number of infeasible allocations by reason.", "reason")
+	AllocationCacheLookups = NewCounter("inferno_allocation_cache_lookups_total",
+		"Number of lookups of cached allocations by result (hit or miss).", "result")
+	NonMonotonicSearches = NewCounter("inferno_non_monotonic_searches_total",
+		"Number of searches of max rates where a metric is not monotonic in the rate, by metric.", "metric")
+)
+
+// a metric which writes itself in the Prometheus text format
+type collector interface {
+	write(w io.Writer)
+}
+
+var (
+	registryMutex sync.Mutex
+	registry      []collector
+)
+
+func register(c collector) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	registry = append(registry, c)
+}
+
+// Write all metrics in the Prometheus text format
+func Write(w io.Writer) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	for _, c := range registry {
+		c.write(w)
+	}
+}
+
+// A set of values of a metric, by value of an (optional) label
+type labeledValues struct {
+	mutex     sync.Mutex
+	name      string
+	help      string
+	kind      string
+	labelName string // empty if not labeled
+	values    map[string]float64
+}
+
+func (v *labeledValues) write(w io.Writer) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", v.name, v.help, v.name, v.kind)
+	for _, labelValue := range slices.Sorted(maps.Keys(v.values)) {
+		value := formatFloat(v.values[labelValue])
+		if v.labelName == "" {
+			fmt.Fprintf(&b, "%s %s\n", v.name, value)
+		} else {
+			fmt.Fprintf(&b, "%s{%s=%q} %s\n", v.name, v.labelName, escapeLabel(labelValue), value)
+		}
+	}
+	w.Write(b.Bytes())
+}
+
+// Value for a label value (ignored if not labeled), zero if not set
+func (v *labeledValues) Value(labelValue string) float64 {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	if v.labelName == "" {
+		labelValue = ""
+	}
+	return v.values[labelValue]
+}
+
+// A monotonically increasing count
+type Counter struct {
+	labeledValues
+}
+
+func NewCounter(name, help, labelName string) *Counter {
+	c := &Counter{labeledValues{name: name, help: help, kind: "counter", labelName: labelName,
+		values: make(map[string]float64)}}
+	register(c)
+	return c
+}
+
+// Increment the count for a label value (ignored if not labeled)
+func (c *Counter) Inc(labelValue string) {
+	c.Add(labelValue, 1)
+}
+
+// Add a non-negative amount to the count for a label value (ignored if not labeled)
+func (c *Counter) Add(labelValue string, amount float64) {
+	if amount < 0 {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.labelName == "" {
+		labelValue = ""
+	}
+	c.values[labelValue] += amount
+}
+
+// A value which may go up and down
+type Gauge struct {
+	labeledValues
+}
+
+func NewGauge(name, help, labelName string) *Gauge {
+	g := &Gauge{labeledValues{name: name, help: help, kind: "gauge", labelName: labelName,
+		values: make(map[string]float64)}}
+	register(g)
+	return g
+}
+
+// Set the value for a label value (ignored if not labeled)
+func (g *Gauge) Set(labelValue string, value float64) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if g.labelName == "" {
+		labelValue = ""
+	}
+	g.values[labelValue] = value
+}
+
+// Remove values of all label values
+func (g *Gauge) Reset() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.values = make(map[string]float64)
+}
+
+// A distribution of observed values in cumulative buckets
+type Histogram struct {
+	mutex   sync.Mutex
+	name    string
+	help    string
+	bounds  []float64 // upper bounds of buckets, increasing
+	counts  []uint64  // count of observations per bucket (not cumulative)
+	sum     float64
+	numObsv uint64
+}
+
+func NewHistogram(name, help string, bounds []float64) *Histogram {
+	h := &Histogram{name: name, help: help, bounds: bounds, counts: make([]uint64, len(bounds))}
+	register(h)
+	return h
+}
+
+// Observe a value
+func (h *Histogram) Observe(value float64) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if i, _ := slices.BinarySearch(h.bounds, value); i < len(h.bounds) {
+		h.counts[i]++
+	}
+	h.sum += value
+	h.numObsv++
+}
+
+func (h *Histogram) write(w io.Writer) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s histogram\n", h.name, h.help, h.name)
+	var cumulative uint64
+	for i, bound := range h.bounds {
+		cumulative += h.counts[i]
+		fmt.Fprintf(&b, "%s_bucket{le=%q} %d\n", h.name, formatFloat(bound), cumulative)
+	}
+	fmt.Fprintf(&b, "%s_bucket{le=\"+Inf\"} %d\n", h.name, h.numObsv)
+	fmt.Fprintf(&b, "%s_sum %s\n%s_count %d\n", h.name, formatFloat(h.sum), h.name, h.numObsv)
+	w.Write(b.Bytes())
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// escape a label value (%q escapes quotes and backslashes; newlines are replaced)
+func escapeLabel(s string) string {
+	return strings.ReplaceAll(s, "\n", " ")
+}
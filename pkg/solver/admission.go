@@ -0,0 +1,119 @@
+package solver
+
+import (
+	"cmp"
+	"maps"
+	"math"
+	"slices"
+
+	"github.com/llm-inferno/optimizer/pkg/config"
+	"github.com/llm-inferno/optimizer/pkg/core"
+)
+
+// Entry for a server, used during admission control
+type admissionEntry struct {
+	server     *core.Server
+	priority   int
+	density    float32            // weighted load per cost of the least value candidate allocation
+	candidates []*core.Allocation // candidate allocations, ordered by value
+}
+
+// Admit servers whole, each given an allocation serving its entire load at SLO, maximizing the total
+// priority-weighted served load within accelerator capacity and the cost budget, if any (admission control)
+//   - servers are considered in priority order, and within a priority by decreasing weighted load per cost
+//     of their least value candidate allocation (knapsack-style), admitting servers which still fit afterwards
+//   - an admitted server takes its least value candidate allocation fitting the remaining capacity and budget
+//   - other servers are shed, with the binding constraint: the constraint failed by the candidate allocation
+//     checked furthest, capacity then power then cost budget
+//   - pinned servers are neither admitted nor shed, their allocations taking capacity and budget first
+func (s *Solver) SolveAdmission() {
+	budget := s.optimizerSpec.MaxTotalCost
+	if budget <= 0 {
+		budget = math.MaxFloat32
+	}
+	available := newCapacityPool(s.system, s.optimizerSpec.MaxTotalPowerWatts)
+	available.takePinned(s.system)
+	_, pinnedCost := pinnedUnits(s.system)
+	budget -= pinnedCost
+	s.admitted = make([]string, 0)
+	s.shed = make([]config.ServerStatus, 0)
+
+	// create entries for all servers with candidate allocations
+	entries := make([]*admissionEntry, 0)
+	for _, serverName := range slices.Sorted(maps.Keys(s.system.GetServers())) {
+		server := s.system.GetServer(serverName)
+		if server.Pinned() {
+			continue
+		}
+		server.RemoveAllocation()
+		allAllocs := s.candidateAllocations(server)
+		if len(allAllocs) == 0 {
+			if len(server.AllAllocations()) > 0 {
+				server.SetUnallocatedError(core.ErrSpotPolicy)
+			}
+			continue
+		}
+		e := &admissionEntry{
+			server:     server,
+			priority:   server.Priority(),
+			candidates: make([]*core.Allocation, 0, len(allAllocs)),
+		}
+		for _, gName := range slices.Sorted(maps.Keys(allAllocs)) {
+			e.candidates = append(e.candidates, allAllocs[gName])
+		}
+		slices.SortStableFunc(e.candidates, s.compareAllocations)
+		if load := server.ServedLoad(); load != nil {
+			weightedLoad := priorityWeight(e.priority) * load.ArrivalRate
+			e.density = weightedLoad / max(e.candidates[0].Cost(), math.SmallestNonzeroFloat32)
+		}
+		entries = append(entries, e)
+	}
+	slices.SortStableFunc(entries, func(a, b *admissionEntry) int {
+		if a.priority != b.priority {
+			return cmp.Compare(a.priority, b.priority)
+		}
+		return cmp.Compare(b.density, a.density)
+	})
+
+	// admit servers in order, if any candidate allocation fits
+	for _, e := range entries {
+		alloc, reason := s.admit(e, available, budget)
+		if alloc == nil {
+			e.server.SetUnallocatedError(reason)
+			s.shed = append(s.shed, config.ServerStatus{Name: e.server.Name(), Reason: reason.Error()})
+			continue
+		}
+		acc := s.system.GetAccelerator(alloc.Accelerator())
+		unitsPerReplica := s.system.GetModel(e.server.ModelName()).NumInstances(acc.Name()) * s.system.GetUnits(acc)
+		available.take(e.server, acc.Type(), alloc.NumReplicas(), unitsPerReplica, alloc.Power())
+		budget -= alloc.Cost()
+		e.server.SetAllocation(alloc)
+		s.admitted = append(s.admitted, e.server.Name())
+	}
+}
+
+// Least value candidate allocation of an entry fitting the available capacity and the remaining cost budget,
+// or the binding constraint if none fits
+func (s *Solver) admit(e *admissionEntry, available *capacityPool, budget float32) (*core.Allocation, error) {
+	constraints := []error{core.ErrCapacityExhausted, core.ErrPowerExhausted, core.ErrBudgetExhausted}
+	binding := 0
+	model := s.system.GetModel(e.server.ModelName())
+	for _, alloc := range e.candidates {
+		acc := s.system.GetAccelerator(alloc.Accelerator())
+		if acc == nil || model == nil {
+			continue
+		}
+		unitsPerReplica := model.NumInstances(acc.Name()) * s.system.GetUnits(acc)
+		switch {
+		case !available.fits(e.server, acc.Type(), alloc.NumReplicas(), unitsPerReplica):
+			// capacity binds, unless another candidate gets further
+		case !available.powerFits(map[string]float32{acc.Type(): alloc.Power()}):
+			binding = max(binding, 1)
+		case alloc.Cost() > budget:
+			binding = max(binding, 2)
+		default:
+			return alloc, nil
+		}
+	}
+	return nil, constraints[binding]
+}
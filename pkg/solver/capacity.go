@@ -0,0 +1,189 @@
+package solver
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/llm-inferno/optimizer/pkg/core"
+)
+
+// Accelerator units available to servers during greedy allocation
+//   - units reserved for a service class are only drawn by servers of the class, before shared units
+//   - other units are shared by all servers
+//   - allocations are also limited by the power budgets of accelerator types and the total power budget, if any
+//   - for types grouped in nodes, the units of a replica are on a single node (reserved units are not tied to nodes)
+type capacityPool struct {
+	shared   map[string]int            // units by type shared by all servers
+	reserved map[string]map[string]int // units by service class name and type, reserved for servers of the class
+
+	power        map[string]float32 // remaining power budget by type, for types with a budget (Watts)
+	totalPower   float32            // remaining total power budget (Watts)
+	powerLimited bool               // total power budget is set
+
+	nodes map[string][]int // free units of nodes by type, for types grouped in nodes
+}
+
+// create a pool of the capacity units of the system, less units reserved for service classes,
+// given a total power budget (zero if none)
+func newCapacityPool(system *core.System, maxTotalPower float32) *capacityPool {
+	p := &capacityPool{
+		shared:       make(map[string]int),
+		reserved:     system.GetReservedUnits(),
+		power:        system.GetPowerBudgets(),
+		totalPower:   maxTotalPower,
+		powerLimited: maxTotalPower > 0,
+		nodes:        system.GetNodeUnits(),
+	}
+	maps.Copy(p.shared, system.GetCapacityUnits())
+	for _, units := range p.reserved {
+		for typeName, count := range units {
+			p.shared[typeName] -= count
+		}
+	}
+	return p
+}
+
+// take units and power of the pinned allocations of servers, before allocating to other servers
+func (p *capacityPool) takePinned(system *core.System) {
+	servers := system.GetServers()
+	for _, serverName := range slices.Sorted(maps.Keys(servers)) {
+		server := servers[serverName]
+		if alloc := server.Allocation(); server.Pinned() && alloc != nil {
+			accType, unitsPerReplica := replicaUnits(system, server, alloc)
+			p.take(server, accType, alloc.NumReplicas(), unitsPerReplica, alloc.Power())
+		}
+	}
+}
+
+// accelerator units used by the pinned allocations of servers, by type, and their total cost
+func pinnedUnits(system *core.System) (map[string]int, float32) {
+	units := make(map[string]int)
+	var cost float32
+	for _, server := range system.GetServers() {
+		if alloc := server.Allocation(); server.Pinned() && alloc != nil {
+			accType, count := allocationUnits(system, server, alloc)
+			units[accType] += count
+			cost += alloc.Cost()
+		}
+	}
+	return units, cost
+}
+
+// units of an accelerator type available to a server
+func (p *capacityPool) units(server *core.Server, typeName string) int {
+	return p.shared[typeName] + p.reserved[server.ServiceClassName()][typeName]
+}
+
+// whether a number of replicas of an accelerator type, given the units of a replica, fit in the units available
+// to a server, each replica on a single node for types grouped in nodes
+func (p *capacityPool) fits(server *core.Server, typeName string, numReplicas int, unitsPerReplica int) bool {
+	if p.units(server, typeName) < numReplicas*unitsPerReplica {
+		return false
+	}
+	nodes, exists := p.nodes[typeName]
+	return !exists || numReplicas <= 0 || nodeReplicas(nodes, unitsPerReplica) >= numReplicas
+}
+
+// whether power consumed on accelerator types, by type, is within the remaining power budgets
+func (p *capacityPool) powerFits(power map[string]float32) bool {
+	total := float32(0)
+	for typeName, typePower := range power {
+		if budget, exists := p.power[typeName]; exists && typePower > budget {
+			return false
+		}
+		total += typePower
+	}
+	return !p.powerLimited || total <= p.totalPower
+}
+
+// number of replicas of an accelerator type available to a server, given the units and power of a replica
+func (p *capacityPool) replicas(server *core.Server, typeName string, unitsPerReplica int, powerPerReplica float32) int {
+	if unitsPerReplica <= 0 {
+		return 0
+	}
+	n := p.units(server, typeName) / unitsPerReplica
+	if nodes, exists := p.nodes[typeName]; exists {
+		n = min(n, nodeReplicas(nodes, unitsPerReplica))
+	}
+	if powerPerReplica > 0 {
+		if budget, exists := p.power[typeName]; exists {
+			n = min(n, int(max(budget, 0)/powerPerReplica))
+		}
+		if p.powerLimited {
+			n = min(n, int(max(p.totalPower, 0)/powerPerReplica))
+		}
+	}
+	return n
+}
+
+// take units of replicas of an accelerator type for a server, given the units of a replica, from units reserved
+// for its service class first, and the power they consume
+//   - for types grouped in nodes, each replica is placed on the node with the fewest free units that fit it (best fit)
+func (p *capacityPool) take(server *core.Server, typeName string, numReplicas int, unitsPerReplica int, power float32) {
+	count := numReplicas * unitsPerReplica
+	if nodes, exists := p.nodes[typeName]; exists {
+		for range numReplicas {
+			placeReplica(nodes, unitsPerReplica)
+		}
+	}
+	if reserved := p.reserved[server.ServiceClassName()]; reserved != nil {
+		fromReserved := min(count, reserved[typeName])
+		reserved[typeName] -= fromReserved
+		count -= fromReserved
+	}
+	p.shared[typeName] -= count
+	if _, exists := p.power[typeName]; exists {
+		p.power[typeName] -= power
+	}
+	p.totalPower -= power
+}
+
+// number of replicas, given the units of a replica, fitting in the free units of nodes, each on a single node
+func nodeReplicas(nodes []int, unitsPerReplica int) int {
+	if unitsPerReplica <= 0 {
+		return 0
+	}
+	n := 0
+	for _, free := range nodes {
+		n += max(free, 0) / unitsPerReplica
+	}
+	return n
+}
+
+// place a replica using a number of units on the node with the fewest free units that fit it (the first if tied);
+// if none fits, e.g. for allocations not made by the greedy algorithm, the units are taken from nodes with the most
+// free units
+func placeReplica(nodes []int, units int) {
+	best := -1
+	for i, free := range nodes {
+		if free >= units && (best < 0 || free < nodes[best]) {
+			best = i
+		}
+	}
+	if best >= 0 {
+		nodes[best] -= units
+		return
+	}
+	for units > 0 && len(nodes) > 0 {
+		most := 0
+		for i, free := range nodes {
+			if free > nodes[most] {
+				most = i
+			}
+		}
+		if nodes[most] <= 0 {
+			return
+		}
+		taken := min(units, nodes[most])
+		nodes[most] -= taken
+		units -= taken
+	}
+}
+
+// power consumed by a replica of an allocation (Watts)
+func replicaPower(alloc *core.Allocation) float32 {
+	if alloc.NumReplicas() <= 0 {
+		return 0
+	}
+	return alloc.Power() / float32(alloc.NumReplicas())
+}
@@ -0,0 +1,67 @@
+package solver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/llm-inferno/optimizer/pkg/config"
+	"github.com/llm-inferno/optimizer/pkg/testutil"
+)
+
+// A burst of load of a low priority class does not draw units of an accelerator type reserved for another class,
+// when allocating, improving the solution by local search, or keeping servers on their current accelerators
+func TestReservedPoolNotStarvedByLowPriorityBurst(t *testing.T) {
+	tests := []struct {
+		name          string
+		a100Cost      float32 // cost of A100 (G2 costs 25)
+		burstRate     float32 // arrival rate of the low priority server (req/min)
+		burstCurrent  string  // current accelerator of the low priority server (none if empty)
+		optimizer     config.OptimizerSpec
+		reduceChurn   bool
+		wantSaturated bool
+	}{
+		{name: "greedy under saturation", a100Cost: 10, burstRate: 3000,
+			optimizer: config.OptimizerSpec{SaturationPolicy: "RoundRobin"}, wantSaturated: true},
+		{name: "improve", a100Cost: 10, burstRate: 300,
+			optimizer: config.OptimizerSpec{PostOptimize: true}},
+		{name: "reduce churn", a100Cost: 40, burstRate: 300, burstCurrent: "A100",
+			optimizer: config.OptimizerSpec{ChurnBudget: 10}, reduceChurn: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := testutil.SystemSpec()
+			spec.Accelerators.Spec[0].Cost = tt.a100Cost
+			gold, bronze := testutil.ServiceClassSpec("Gold", 1), testutil.ServiceClassSpec("Bronze", 10)
+			gold.Reservations = map[string]int{"A100": 4}
+			spec.ServiceClasses.Spec = append(spec.ServiceClasses.Spec, gold, bronze)
+			burst := testutil.ServerSpec("burst", "Bronze", tt.burstRate)
+			if tt.burstCurrent != "" {
+				burst.CurrentAlloc.Accelerator = tt.burstCurrent
+				burst.CurrentAlloc.NumReplicas = 2
+			}
+			spec.Servers.Spec = append(spec.Servers.Spec, testutil.ServerSpec("reserved", "Gold", 60), burst)
+			spec.Capacity.Count = []config.AcceleratorCount{{Type: "A100", Count: 4}, {Type: "G2", Count: 20}}
+			system := newTestSystem(t, spec)
+
+			solver := NewSolver(system, &tt.optimizer)
+			if err := solver.Solve(context.Background()); err != nil {
+				t.Fatalf("solve: %v", err)
+			}
+			if tt.reduceChurn {
+				solver.ReduceChurn(tt.optimizer.ChurnBudget)
+			}
+
+			server := system.GetServer("burst")
+			alloc := server.Allocation()
+			if alloc == nil {
+				t.Fatal("burst server not allocated")
+			}
+			if alloc.Accelerator() == "A100" {
+				t.Errorf("burst server allocated %d replicas of A100, reserved for Gold", alloc.NumReplicas())
+			}
+			if server.Saturated() != tt.wantSaturated {
+				t.Errorf("burst server saturated=%v, want %v", server.Saturated(), tt.wantSaturated)
+			}
+		})
+	}
+}
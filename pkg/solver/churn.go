@@ -0,0 +1,74 @@
+package solver
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+
+	"github.com/llm-inferno/optimizer/pkg/core"
+)
+
+// Reduce churn of a solution by keeping servers on their current accelerators, returning the number of servers kept
+//   - a server is kept on its current accelerator if it has a feasible allocation there and capacity allows,
+//     honoring units reserved for service classes and power budgets
+//   - servers are kept in increasing order of the cost increase, as long as the total increase is within
+//     a budget, given as a fraction of the total cost of the solution (in terms of the system objective)
+func (s *Solver) ReduceChurn(budget float32) int {
+	type keepCandidate struct {
+		server   *core.Server
+		alloc    *core.Allocation
+		increase float32
+	}
+
+	// candidates to keep
+	var totalCost float32
+	candidates := make([]*keepCandidate, 0)
+	for _, serverName := range slices.Sorted(maps.Keys(s.system.GetServers())) {
+		server := s.system.GetServer(serverName)
+		alloc := server.Allocation()
+		if alloc == nil {
+			continue
+		}
+		totalCost += alloc.ObjectiveCost()
+
+		curAlloc := server.CurAllocation()
+		if curAlloc == nil || curAlloc.Accelerator() == "" || curAlloc.Accelerator() == alloc.Accelerator() || alloc.Mixed() ||
+			server.Pinned() {
+			continue
+		}
+		if acc := s.system.GetAccelerator(curAlloc.Accelerator()); acc != nil && acc.Draining() {
+			// moving away from a draining accelerator is not churn to reduce
+			continue
+		}
+		if keepAlloc := s.candidateAllocations(server)[curAlloc.Accelerator()]; keepAlloc != nil {
+			candidates = append(candidates, &keepCandidate{
+				server:   server,
+				alloc:    keepAlloc,
+				increase: keepAlloc.ObjectiveCost() - alloc.ObjectiveCost(),
+			})
+		}
+	}
+	slices.SortStableFunc(candidates, func(a, b *keepCandidate) int {
+		return cmp.Compare(a.increase, b.increase)
+	})
+
+	// keep servers on their current accelerators within budget
+	remaining := budget * totalCost
+	numKept := 0
+	for _, c := range candidates {
+		if c.increase > remaining {
+			break
+		}
+		move := &allocationMove{
+			servers: []*core.Server{c.server},
+			allocs:  []*core.Allocation{c.alloc},
+		}
+		if !s.feasible(move, nil) {
+			continue
+		}
+		move.apply()
+		remaining -= max(c.increase, 0)
+		numKept++
+	}
+	return numKept
+}
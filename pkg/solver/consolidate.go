@@ -0,0 +1,115 @@
+package solver
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+
+	"github.com/llm-inferno/optimizer/pkg/core"
+)
+
+// Reduce the number of accelerator types in use by a solution, returning the number of types in use before and after
+//   - the least used type (fewest servers, then fewest units) is retired first, by moving all its servers
+//     to their cheapest candidate allocations on other types in use, if capacity allows (honoring units reserved
+//     for service classes and power budgets)
+//   - a type is retired only if the total cost increase stays within a tolerance, given as a fraction
+//     of the total cost of the solution (in terms of the system objective)
+//   - types used by servers with a saturated (best effort), mixed, or pinned allocation are not retired
+func (s *Solver) Consolidate(tolerance float32) (before int, after int) {
+	// total cost of the current solution, and servers by type in use
+	var totalCost float32
+	servers := s.system.GetServers()
+	for _, serverName := range slices.Sorted(maps.Keys(servers)) {
+		if alloc := servers[serverName].Allocation(); alloc != nil {
+			totalCost += alloc.ObjectiveCost()
+		}
+	}
+	usage := s.serversByType()
+	before = len(usage)
+
+	// retire types in use, least used first, until none can be retired
+	remaining := tolerance * totalCost
+	for retired := true; retired && s.ctx.Err() == nil; {
+		retired = false
+		for _, accType := range usage.leastUsed() {
+			if moves, increase := s.retireType(accType, usage); moves != nil && increase <= remaining {
+				for _, move := range moves {
+					move.apply()
+				}
+				remaining -= max(increase, 0)
+				usage = s.serversByType()
+				retired = true
+				break
+			}
+		}
+	}
+	return before, len(usage)
+}
+
+// Servers of a solution by accelerator type in use
+type typeUsage map[string][]*core.Server
+
+// servers of the current solution by accelerator type in use, ordered by name
+func (s *Solver) serversByType() typeUsage {
+	usage := make(typeUsage)
+	servers := s.system.GetServers()
+	for _, serverName := range slices.Sorted(maps.Keys(servers)) {
+		server := servers[serverName]
+		alloc := server.Allocation()
+		if alloc == nil {
+			continue
+		}
+		for _, leg := range alloc.Legs() {
+			if accType, units := allocationUnits(s.system, server, leg); units > 0 &&
+				!slices.Contains(usage[accType], server) {
+				usage[accType] = append(usage[accType], server)
+			}
+		}
+	}
+	return usage
+}
+
+// types in use, ordered by increasing number of servers, then name
+func (u typeUsage) leastUsed() []string {
+	return slices.SortedFunc(maps.Keys(u), func(a, b string) int {
+		return cmp.Or(cmp.Compare(len(u[a]), len(u[b])), cmp.Compare(a, b))
+	})
+}
+
+// moves of all servers off an accelerator type, to their cheapest candidate allocations on other types in use,
+// and the total cost increase; nil if some server cannot be moved
+func (s *Solver) retireType(accType string, usage typeUsage) ([]*allocationMove, float32) {
+	trial := make(map[*core.Server]*core.Allocation)
+	moves := make([]*allocationMove, 0, len(usage[accType]))
+	var increase float32
+	for _, server := range usage[accType] {
+		alloc := server.Allocation()
+		if server.Saturated() || alloc.Mixed() || server.Pinned() {
+			return nil, 0
+		}
+		allAllocs := s.candidateAllocations(server)
+		var best *allocationMove
+		for _, gName := range slices.Sorted(maps.Keys(allAllocs)) {
+			candidate := allAllocs[gName]
+			newType, _ := allocationUnits(s.system, server, candidate)
+			if newType == accType || usage[newType] == nil {
+				continue
+			}
+			move := &allocationMove{
+				servers: []*core.Server{server},
+				allocs:  []*core.Allocation{candidate},
+				gain:    alloc.ObjectiveCost() - candidate.ObjectiveCost(),
+			}
+			if (best == nil || move.gain > best.gain) && s.feasible(move, trial) {
+				best = move
+			}
+		}
+		if best == nil {
+			return nil, 0
+		}
+		trial[server] = best.allocs[0]
+		moves = append(moves, best)
+		increase -= best.gain
+	}
+	return moves, increase
+}
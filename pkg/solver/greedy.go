@@ -10,6 +10,7 @@ import (
 
 	"github.com/llm-inferno/optimizer/pkg/config"
 	"github.com/llm-inferno/optimizer/pkg/core"
+	"github.com/llm-inferno/optimizer/pkg/metrics"
 )
 
 // Entry for a server, used during greedy allocation
@@ -19,6 +20,7 @@ type serverEntry struct {
 	curIndex    int                // current index in allocation list
 	allocations []*core.Allocation // ordered list of allocations
 	delta       float32            // delta penalty if current allocation not allowed and next allocation is allowed
+	overPower   bool               // an allocation had enough accelerator units but exceeded a power budget
 }
 
 func (e *serverEntry) String() string {
@@ -34,16 +36,28 @@ type ServerEntriesOrder func(a, b *serverEntry) int
 // Find optimal allocations using greedy algorithm, assuming limited accelerator capacity
 func (s *Solver) SolveGreedy() {
 
-	// make a copy of count of available accelerator types
-	available := make(map[string]int)
-	maps.Copy(available, core.GetCapacities())
+	// make a pool of available accelerator units, shared or reserved for service classes, and power budgets,
+	// less those of pinned allocations
+	available := newCapacityPool(s.system, s.optimizerSpec.MaxTotalPowerWatts)
+	available.takePinned(s.system)
 
 	// create entries for all servers, sorting candidate allocations per server
 	var entries []*serverEntry = make([]*serverEntry, 0)
-	for serverName, server := range core.GetServers() {
+	servers := s.system.GetServers()
+	for _, serverName := range slices.Sorted(maps.Keys(servers)) {
+		server := servers[serverName]
+		if server.Pinned() {
+			continue
+		}
 		server.RemoveAllocation()
-		allAllocs := server.AllAllocations()
+		for _, err := range server.AllocationErrors() {
+			metrics.InfeasibleAllocations.Inc(core.InfeasibleReason(err))
+		}
+		allAllocs := s.candidateAllocations(server)
 		if len(allAllocs) == 0 {
+			if len(server.AllAllocations()) > 0 {
+				server.SetUnallocatedError(core.ErrSpotPolicy)
+			}
 			continue
 		}
 		e := &serverEntry{
@@ -53,14 +67,10 @@ func (s *Solver) SolveGreedy() {
 			allocations: make([]*core.Allocation, len(allAllocs)),
 			delta:       0,
 		}
-		i := 0
-		for _, alloc := range allAllocs {
-			e.allocations[i] = alloc
-			i++
+		for i, gName := range slices.Sorted(maps.Keys(allAllocs)) {
+			e.allocations[i] = allAllocs[gName]
 		}
-		slices.SortFunc(e.allocations, func(a, b *core.Allocation) int {
-			return cmp.Compare(a.Value(), b.Value())
-		})
+		slices.SortStableFunc(e.allocations, s.compareAllocations)
 		if len(e.allocations) > 1 {
 			// value is difference between this and next allocation
 			e.delta = e.allocations[1].Value() - e.allocations[0].Value()
@@ -72,11 +82,23 @@ func (s *Solver) SolveGreedy() {
 	}
 
 	// sorting function for server entries
-	// - straight priorities, then delta values
+	// - straight priorities, then (optionally) SLO slack of current allocations, then delta values, then allocation
+	//   values, then server names
+	// - servers closer to infeasibility go first when maximizing headroom, as with the slack tie-break
+	bySlack := config.TieBreakEnum(s.optimizerSpec.TieBreak) == config.SlackTieBreak ||
+		config.ObjectiveEnum(s.optimizerSpec.Objective) == config.MaxHeadroomObjective
 	orderFunc := func(a, b *serverEntry) int {
 		if a.priority == b.priority {
+			if bySlack {
+				if c := cmp.Compare(a.allocations[a.curIndex].SLOSlack(), b.allocations[b.curIndex].SLOSlack()); c != 0 {
+					return c
+				}
+			}
 			if a.delta == b.delta {
-				return cmp.Compare(b.allocations[b.curIndex].Value(), a.allocations[a.curIndex].Value())
+				if c := cmp.Compare(b.allocations[b.curIndex].Value(), a.allocations[a.curIndex].Value()); c != 0 {
+					return c
+				}
+				return cmp.Compare(a.serverName, b.serverName)
 			}
 			return cmp.Compare(b.delta, a.delta)
 		} else {
@@ -89,23 +111,32 @@ func (s *Solver) SolveGreedy() {
 	// allocate
 	if s.optimizerSpec.DelayedBestEffort {
 		// allocate to all servers
-		unallocated := allocate(entries, available, orderFunc)
+		unallocated := s.allocate(entries, available, orderFunc)
+		unallocated = s.mixAccelerators(unallocated, available)
 		// best effort allocation to all remaining servers
-		bestEffort(unallocated, available, s.optimizerSpec.SaturationPolicy)
+		s.bestEffort(unallocated, available, s.optimizerSpec.SaturationPolicy)
 	} else {
 		groupEntries := makePriorityGroups(entries)
 		for _, group := range groupEntries {
 			// allocate to servers in priority group
-			unallocated := allocate(group, available, orderFunc)
+			unallocated := s.allocate(group, available, orderFunc)
+			unallocated = s.mixAccelerators(unallocated, available)
 			// best effort allocation to servers in priority group
-			bestEffort(unallocated, available, s.optimizerSpec.SaturationPolicy)
+			s.bestEffort(unallocated, available, s.optimizerSpec.SaturationPolicy)
+		}
+	}
+
+	// servers left unallocated as power budgets were exhausted
+	for _, e := range entries {
+		if server := s.system.GetServer(e.serverName); server != nil && server.Allocation() == nil && e.overPower {
+			server.SetUnallocatedError(core.ErrPowerExhausted)
 		}
 	}
 }
 
 // allocate, satisfying SLO requirements, returning servers that did not receive any allocation
-func allocate(entries []*serverEntry,
-	available map[string]int,
+func (s *Solver) allocate(entries []*serverEntry,
+	available *capacityPool,
 	orderFunc ServerEntriesOrder) (unallocatedEntries []*serverEntry) {
 
 	unallocatedEntries = make([]*serverEntry, 0)
@@ -121,30 +152,41 @@ func allocate(entries []*serverEntry,
 
 		// check if current allocation in entry can be satisfied
 		serverName := top.serverName
-		server := core.GetServer(serverName)
+		server := s.system.GetServer(serverName)
 		if server == nil {
 			continue
 		}
-		model := core.GetModel(server.ModelName())
+		model := s.system.GetModel(server.ModelName())
 		if model == nil {
 			continue
 		}
 		alloc := top.allocations[top.curIndex]
 		gName := alloc.Accelerator()
-		acc := core.GetAccelerator(gName)
+		acc := s.system.GetAccelerator(gName)
 		if acc == nil {
 			continue
 		}
 		tName := acc.Type()
-		unitsPerReplica := model.NumInstances(gName) * acc.Spec().Multiplicity
+		unitsPerReplica := model.NumInstances(gName) * s.system.GetUnits(acc)
 		count := alloc.NumReplicas() * unitsPerReplica
 
-		// check if accelerator type of current allocation is available, allocate
-		if available[tName] >= count {
-			available[tName] -= count
+		// check if accelerator type of current allocation is available within power budgets, allocate
+		var reason error
+		if !available.fits(server, tName, alloc.NumReplicas(), unitsPerReplica) {
+			reason = core.ErrCapacityExhausted
+		} else if !available.powerFits(map[string]float32{tName: alloc.Power()}) {
+			reason = core.ErrPowerExhausted
+			top.overPower = true
+		}
+		d := s.decision(greedyAllocate, server, top.curIndex, alloc, count, available)
+		if reason == nil {
+			available.take(server, tName, alloc.NumReplicas(), unitsPerReplica, alloc.Power())
 			server.SetAllocation(alloc)
+			d.Action = decisionAllocated
+			s.record(d)
 		} else {
 			// otherwise, move to next candidate allocation
+			d.Reason = reason.Error()
 			top.curIndex++
 			if top.curIndex+1 < len(top.allocations) {
 				// not last allocation, calculate delta
@@ -152,6 +194,13 @@ func allocate(entries []*serverEntry,
 			} else if top.curIndex == len(top.allocations) {
 				// no more allocations, could not satisfy any, add server to unallocated list
 				unallocatedEntries = append(unallocatedEntries, top)
+				if top.overPower {
+					metrics.InfeasibleAllocations.Inc(core.ErrPowerExhausted.Error())
+				} else {
+					metrics.InfeasibleAllocations.Inc(core.ErrCapacityExhausted.Error())
+				}
+				d.Action, d.Position = decisionUnallocated, -1
+				s.record(d)
 				continue
 			} else {
 				// last allocation, set large delta value
@@ -160,29 +209,35 @@ func allocate(entries []*serverEntry,
 			// reorder server entries
 			i, _ := slices.BinarySearchFunc(entries, top, orderFunc)
 			entries = slices.Insert(entries, i, top)
+			d.Action, d.Delta, d.Position = decisionSkipped, top.delta, i
+			s.record(d)
 		}
 	}
 	return unallocatedEntries
 }
 
 // give best effort allocation to unallocated servers according to saturation policy
-func bestEffort(unallocatedServers []*serverEntry, available map[string]int, policy string) {
+func (s *Solver) bestEffort(unallocatedServers []*serverEntry, available *capacityPool, policy string) {
 	switch config.SaturatedAllocationPolicyEnum(policy) {
 
 	// allocate exhaustively to servers in priority ordering
 	case config.PriorityExhaustive:
-		allocateMaximally(unallocatedServers, available)
+		s.allocateMaximally(unallocatedServers, available)
 
 	// allocate in round-robin fashion within priority groups
 	case config.PriorityRoundRobin:
 		priorityGroups := makePriorityGroups(unallocatedServers)
 		for _, group := range priorityGroups {
-			allocateEqually(group, available)
+			s.allocateRoundRobin(group, available)
 		}
 
 	// allocate in round-robin fashion across all servers
 	case config.RoundRobin:
-		allocateEqually(unallocatedServers, available)
+		s.allocateRoundRobin(unallocatedServers, available)
+
+	// allocate in proportion to weights of service classes across all servers
+	case config.WeightedFair:
+		s.allocateWeighted(unallocatedServers, available)
 
 	// do not allocate beyond satisfying SLOs
 	case config.None:
@@ -191,29 +246,26 @@ func bestEffort(unallocatedServers []*serverEntry, available map[string]int, pol
 
 // Allocate remaining accelerators among unallocated servers
 //   - priority ordering: one server at a time exhaustively, until no resources to satisfy requirements
-func allocateMaximally(serverEntries []*serverEntry, available map[string]int) {
-	// fmt.Println("Unallocated server entries: ", serverEntries)
+//   - the number of replicas of a server is a multiple of its shard factor
+func (s *Solver) allocateMaximally(serverEntries []*serverEntry, available *capacityPool) {
 	for _, entry := range serverEntries {
 		for _, alloc := range entry.allocations {
 			accName := alloc.Accelerator()
 			serverName := entry.serverName
-			server := core.GetServer(serverName)
-			model := core.GetModel(server.ModelName())
-			if acc := core.GetAccelerator(accName); acc != nil && model != nil && server != nil {
-				if unitsPerReplica := model.NumInstances(accName) * acc.Spec().Multiplicity; unitsPerReplica > 0 {
-					maxReplicas := available[acc.Type()] / unitsPerReplica
-					if maxReplicas = min(maxReplicas, alloc.NumReplicas()); maxReplicas > 0 {
-						curNumReplicas := alloc.NumReplicas()
-						// adjust cost and value
-						factor := float32(maxReplicas) / float32(curNumReplicas)
-						alloc.SetCost(alloc.Cost() * factor)
-						alloc.SetValue(alloc.Value() * factor)
-						alloc.SetNumReplicas(maxReplicas)
+			server := s.system.GetServer(serverName)
+			model := s.system.GetModel(server.ModelName())
+			if acc := s.system.GetAccelerator(accName); acc != nil && model != nil && server != nil {
+				if unitsPerReplica := model.NumInstances(accName) * s.system.GetUnits(acc); unitsPerReplica > 0 {
+					maxReplicas := available.replicas(server, acc.Type(), unitsPerReplica, replicaPower(alloc))
+					if maxReplicas = server.ShardReplicasFloor(min(maxReplicas, alloc.NumReplicas())); maxReplicas > 0 {
+						// adjust cost, power, and value
+						alloc.Rescale(maxReplicas)
 						server.SetAllocation(alloc)
 						count := maxReplicas * unitsPerReplica
-						available[acc.Type()] -= count
-						// fmt.Printf("updated allocation: server=%s, acc=%s, maxReplicas=%d, type=%s, count=%d \n",
-						// 	serverName, accName, maxReplicas, acc.Type(), count)
+						d := s.decision(greedyBestEffort, server, slices.Index(entry.allocations, alloc), alloc, count, available)
+						d.Action = decisionAllocated
+						s.record(d)
+						available.take(server, acc.Type(), maxReplicas, unitsPerReplica, alloc.Power())
 						break
 					}
 				}
@@ -230,21 +282,33 @@ type serverAllocationTicket struct {
 
 	accType         string // type of accelerator allocated to server
 	unitsPerReplica int
+	powerPerReplica float32
+	step            int // number of replicas allocated at a time: the shard factor of the server (at least one)
 	numReplicas     int
 	finalAlloc      *core.Allocation
 }
 
+// Allocate remaining accelerators among a group of unallocated servers in round-robin fashion, with the step
+// of the optimizer spec: equal (one replica to each member per round) or deficit
+func (s *Solver) allocateRoundRobin(serverEntries []*serverEntry, available *capacityPool) {
+	if config.RoundRobinStepEnum(s.optimizerSpec.RoundRobinStep) == config.DeficitStep {
+		s.allocateByDeficit(serverEntries, available)
+		return
+	}
+	s.allocateEqually(serverEntries, available)
+}
+
 // Allocate remaining accelerators among a group of unallocated servers
 //   - round-robin allocation to members in group until no resources to satisfy requirements
-func allocateEqually(serverEntries []*serverEntry, available map[string]int) {
-	// fmt.Println("Unallocated server entries: ", serverEntries)
+//   - a member is given its step of replicas (its shard factor) per round
+func (s *Solver) allocateEqually(serverEntries []*serverEntry, available *capacityPool) {
 
 	// create allocation tickets for all valid members in group
 	tickets := make(map[string]*serverAllocationTicket)
 	for _, serverEntry := range serverEntries {
 		serverName := serverEntry.serverName
-		server := core.GetServer(serverName)
-		model := core.GetModel(server.ModelName())
+		server := s.system.GetServer(serverName)
+		model := s.system.GetModel(server.ModelName())
 		if model == nil || server == nil {
 			continue
 		}
@@ -266,31 +330,15 @@ func allocateEqually(serverEntries []*serverEntry, available map[string]int) {
 				continue
 			}
 			// determine candidate allocation for not yet processed members
-			if !ticket.active {
-				for _, alloc := range serverEntry.allocations {
-					accName := alloc.Accelerator()
-					if acc := core.GetAccelerator(accName); acc != nil {
-						unitsPerReplica := ticket.model.NumInstances(accName) * acc.Spec().Multiplicity
-						if unitsPerReplica > 0 && available[acc.Type()] >= unitsPerReplica {
-							ticket.active = true
-							ticket.accType = acc.Type()
-							ticket.unitsPerReplica = unitsPerReplica
-							ticket.finalAlloc = alloc
-							break
-						}
-					}
-				}
-				// check if no candidate allocation was found
-				if !ticket.active {
-					delete(tickets, serverName)
-					continue
-				}
+			if !ticket.active && !s.activate(ticket, available) {
+				delete(tickets, serverName)
+				continue
 			}
-			// make one allocation (replica) to member
-			replicasAvailable := available[ticket.accType] / ticket.unitsPerReplica
-			if replicasAllocatable := min(replicasAvailable, ticket.finalAlloc.NumReplicas()); replicasAllocatable > 0 {
-				ticket.numReplicas++
-				available[ticket.accType] -= ticket.unitsPerReplica
+			// make one allocation (step of replicas) to member
+			replicasAvailable := available.replicas(ticket.server, ticket.accType, ticket.unitsPerReplica, ticket.powerPerReplica)
+			if replicasAllocatable := min(replicasAvailable, ticket.finalAlloc.NumReplicas()); replicasAllocatable >= ticket.step {
+				ticket.numReplicas += ticket.step
+				available.take(ticket.server, ticket.accType, ticket.step, ticket.unitsPerReplica, float32(ticket.step)*ticket.powerPerReplica)
 				allocatedTickets[serverName] = ticket
 			} else {
 				// remove ticket if can no longer allocate
@@ -298,20 +346,130 @@ func allocateEqually(serverEntries []*serverEntry, available map[string]int) {
 			}
 		}
 	}
-	// update allocated members
-	for _, ticket := range allocatedTickets {
+	s.setTicketAllocations(allocatedTickets, available)
+}
+
+// Allocate remaining accelerators among unallocated servers in proportion to weights of their service classes
+//   - the weight of a class is divided equally among its unallocated servers, so that the share of a class does not
+//     grow with its number of servers
+//   - one replica at a time to the server with the smallest number of replicas relative to its share of weight
+//     (weighted max-min fair), until no resources to satisfy requirements
+func (s *Solver) allocateWeighted(serverEntries []*serverEntry, available *capacityPool) {
+	numServers := make(map[string]int)
+	for _, entry := range serverEntries {
+		if server := s.system.GetServer(entry.serverName); server != nil {
+			numServers[server.ServiceClassName()]++
+		}
+	}
+	s.allocateMaxMin(serverEntries, available, func(ticket *serverAllocationTicket) float32 {
+		share := ticket.server.Weight() / float32(numServers[ticket.server.ServiceClassName()])
+		return float32(ticket.numReplicas) / share
+	})
+}
+
+// Allocate remaining accelerators among a group of unallocated servers by their remaining replica deficit
+//   - one replica at a time to the server furthest from its target, i.e. with the largest number of replicas
+//     required by its candidate allocation not yet given (max-min), until servers reach their targets or
+//     no resources to satisfy requirements
+func (s *Solver) allocateByDeficit(serverEntries []*serverEntry, available *capacityPool) {
+	s.allocateMaxMin(serverEntries, available, func(ticket *serverAllocationTicket) float32 {
+		return float32(ticket.numReplicas - ticket.finalAlloc.NumReplicas())
+	})
+}
+
+// Allocate remaining accelerators among unallocated servers, one step of replicas (its shard factor) at a time to the
+// server with the smallest key (in order of entries if tied), given a function of the key of an active ticket, until
+// servers reach the replicas of their candidate allocations or no resources to satisfy requirements
+func (s *Solver) allocateMaxMin(serverEntries []*serverEntry, available *capacityPool,
+	key func(ticket *serverAllocationTicket) float32) {
+
+	tickets := make([]*serverAllocationTicket, 0, len(serverEntries))
+	for _, serverEntry := range serverEntries {
+		server := s.system.GetServer(serverEntry.serverName)
+		if server == nil {
+			continue
+		}
+		model := s.system.GetModel(server.ModelName())
+		if model == nil {
+			continue
+		}
+		tickets = append(tickets, &serverAllocationTicket{
+			entry:  serverEntry,
+			server: server,
+			model:  model,
+		})
+	}
+
+	allocatedTickets := make(map[string]*serverAllocationTicket)
+	for {
+		// pick member with smallest key, in order of entries if tied
+		next := -1
+		var nextKey float32
+		for i, ticket := range tickets {
+			if ticket == nil {
+				continue
+			}
+			if !ticket.active && !s.activate(ticket, available) {
+				tickets[i] = nil
+				continue
+			}
+			if ticket.numReplicas >= ticket.finalAlloc.NumReplicas() ||
+				available.replicas(ticket.server, ticket.accType, ticket.unitsPerReplica, ticket.powerPerReplica) < ticket.step {
+				tickets[i] = nil
+				continue
+			}
+			if k := key(ticket); next < 0 || k < nextKey {
+				next, nextKey = i, k
+			}
+		}
+		if next < 0 {
+			break
+		}
+		// make one allocation (step of replicas) to member
+		ticket := tickets[next]
+		ticket.numReplicas += ticket.step
+		available.take(ticket.server, ticket.accType, ticket.step, ticket.unitsPerReplica, float32(ticket.step)*ticket.powerPerReplica)
+		allocatedTickets[ticket.entry.serverName] = ticket
+	}
+	s.setTicketAllocations(allocatedTickets, available)
+}
+
+// determine the first candidate allocation of a ticket with enough available accelerator units and power for a step
+// of replicas, false if none
+func (s *Solver) activate(ticket *serverAllocationTicket, available *capacityPool) bool {
+	step := max(ticket.server.ShardFactor(), 1)
+	for _, alloc := range ticket.entry.allocations {
+		accName := alloc.Accelerator()
+		if acc := s.system.GetAccelerator(accName); acc != nil {
+			unitsPerReplica := ticket.model.NumInstances(accName) * s.system.GetUnits(acc)
+			powerPerReplica := replicaPower(alloc)
+			if available.replicas(ticket.server, acc.Type(), unitsPerReplica, powerPerReplica) >= step {
+				ticket.active = true
+				ticket.step = step
+				ticket.accType = acc.Type()
+				ticket.unitsPerReplica = unitsPerReplica
+				ticket.powerPerReplica = powerPerReplica
+				ticket.finalAlloc = alloc
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// set scaled allocations of servers given their tickets, in order of server names
+//   - units of the allocations are already taken from the available units
+func (s *Solver) setTicketAllocations(allocatedTickets map[string]*serverAllocationTicket, available *capacityPool) {
+	for _, serverName := range slices.Sorted(maps.Keys(allocatedTickets)) {
+		ticket := allocatedTickets[serverName]
 		alloc := ticket.finalAlloc
-		numReplicas := ticket.numReplicas
-		curNumReplicas := alloc.NumReplicas()
 		// adjust cost and value
-		factor := float32(numReplicas) / float32(curNumReplicas)
-		alloc.SetCost(alloc.Cost() * factor)
-		alloc.SetValue(alloc.Value() * factor)
-		alloc.SetNumReplicas(numReplicas)
+		alloc.Rescale(ticket.numReplicas)
 		ticket.server.SetAllocation(alloc)
-		// count := ticket.numReplicas * ticket.unitsPerReplica
-		// fmt.Printf("updated allocation: server=%s, acc=%s, accCount=%d, type=%s, count=%d \n",
-		// 	ticket.server.Name(), alloc.Accelerator(), ticket.numReplicas, ticket.accType, count)
+		d := s.decision(greedyBestEffort, ticket.server, slices.Index(ticket.entry.allocations, alloc), alloc,
+			ticket.numReplicas*ticket.unitsPerReplica, available)
+		d.Action = decisionAllocated
+		s.record(d)
 	}
 }
 
@@ -0,0 +1,90 @@
+package solver
+
+import (
+	"testing"
+
+	"github.com/llm-inferno/optimizer/pkg/config"
+	"github.com/llm-inferno/optimizer/pkg/testutil"
+)
+
+// Under the WeightedFair saturation policy, scarce capacity is divided among service classes in the ratio of their
+// weights, regardless of their number of servers
+func TestWeightedFairSharesByClassWeight(t *testing.T) {
+	tests := []struct {
+		name          string
+		silverServers []string
+	}{
+		{name: "one server per class", silverServers: []string{"silver"}},
+		{name: "more servers in lighter class", silverServers: []string{"silver-1", "silver-2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := testutil.SystemSpec()
+			gold, silver := testutil.ServiceClassSpec("Gold", 1), testutil.ServiceClassSpec("Silver", 1)
+			gold.Weight, silver.Weight = 3, 1
+			spec.ServiceClasses.Spec = append(spec.ServiceClasses.Spec, gold, silver)
+			spec.Servers.Spec = append(spec.Servers.Spec, testutil.ServerSpec("gold", "Gold", 3000))
+			for _, name := range tt.silverServers {
+				spec.Servers.Spec = append(spec.Servers.Spec, testutil.ServerSpec(name, "Silver", 3000))
+			}
+			spec.Capacity.Count = []config.AcceleratorCount{{Type: "G2", Count: 8}}
+			system := newTestSystem(t, spec)
+			solveTestSystem(t, system, &config.OptimizerSpec{SaturationPolicy: "WeightedFair"})
+
+			goldReplicas := numReplicas(system, "gold")
+			silverReplicas := 0
+			for _, name := range tt.silverServers {
+				silverReplicas += numReplicas(system, name)
+			}
+			if goldReplicas != 6 || silverReplicas != 2 {
+				t.Errorf("replicas of gold=%d, silver=%d, want 6 and 2 (3:1)", goldReplicas, silverReplicas)
+			}
+		})
+	}
+}
+
+// With the slack tie-break, the server of the same priority closer to infeasibility (tighter SLO relative to the max
+// rate of a replica) gets its preferred accelerator first, although the delta ordering favors the other server
+func TestSlackTieBreakFavorsTightestServer(t *testing.T) {
+	tests := []struct {
+		tieBreak string
+		wantAcc  map[string]string
+	}{
+		{tieBreak: "delta", wantAcc: map[string]string{"tight": "A100", "loose": "G2"}},
+		{tieBreak: "slack", wantAcc: map[string]string{"tight": "G2", "loose": "A100"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tieBreak, func(t *testing.T) {
+			spec := testutil.SystemSpec()
+			tight, loose := testutil.ServiceClassSpec("Tight", 1), testutil.ServiceClassSpec("Loose", 1)
+			tight.ModelTargets[0].SLO_ITL, tight.ModelTargets[0].SLO_TTFT = 26, 2000
+			loose.ModelTargets[0].SLO_ITL, loose.ModelTargets[0].SLO_TTFT = 200, 2000
+			spec.ServiceClasses.Spec = append(spec.ServiceClasses.Spec, tight, loose)
+			spec.Servers.Spec = append(spec.Servers.Spec, testutil.ServerSpec("tight", "Tight", 600),
+				testutil.ServerSpec("loose", "Loose", 2400))
+			spec.Capacity.Count = []config.AcceleratorCount{{Type: "A100", Count: 40}, {Type: "G2", Count: 20}}
+			system := newTestSystem(t, spec)
+
+			slackOnG2 := func(name string) float32 {
+				var slack float32
+				for _, alloc := range system.GetServer(name).AllAllocations() {
+					if alloc.Accelerator() == "G2" {
+						slack = alloc.SLOSlack()
+					}
+				}
+				return slack
+			}
+			if slackOnG2("tight") >= slackOnG2("loose") {
+				t.Fatalf("slack on G2 of tight=%v, loose=%v, want tight smaller", slackOnG2("tight"), slackOnG2("loose"))
+			}
+
+			solveTestSystem(t, system, &config.OptimizerSpec{TieBreak: tt.tieBreak})
+			for name, want := range tt.wantAcc {
+				alloc := system.GetServer(name).Allocation()
+				if alloc == nil || alloc.Accelerator() != want {
+					t.Errorf("allocation of %s=%v, want on %s", name, alloc, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package solver
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/llm-inferno/optimizer/pkg/core"
+)
+
+// Entry for an allocated server, used when giving remaining capacity to increase headroom
+type headroomEntry struct {
+	server          *core.Server
+	alloc           *core.Allocation // allocation of the server, with added replicas
+	accType         string           // type of accelerator of the allocation
+	unitsPerReplica int              // number of accelerator units per replica
+	powerPerReplica float32          // power consumed per replica (Watts)
+	step            int              // number of replicas added at a time (shard factor)
+	rate            float32          // arrival rate served by the server (req/min)
+}
+
+// rate headroom of an entry: fraction of the max rate of its replicas not used by its load
+func (e *headroomEntry) headroom() float32 {
+	return e.alloc.Headroom(e.rate)
+}
+
+// Give accelerator capacity left by a solution to allocated servers, maximizing the least rate headroom of servers,
+// returning the least headroom and the number of added replicas
+//   - the headroom of a server is the fraction of the max arrival rate of its replicas at SLO not used by its load
+//   - replicas are added one at a time (a shard at a time for sharded servers) to the server with the least headroom,
+//     then the next least if it cannot grow, within the available units, power budgets, and max number of replicas
+//   - servers with mixed allocations, no load, or pinned allocations are not given replicas
+//   - latency metrics of allocations are those at the number of replicas sized for SLOs, hence upper bounds
+func (s *Solver) MaximizeHeadroom() (minHeadroom float32, added int) {
+	// accelerator units and power available after the current solution
+	available := newCapacityPool(s.system, s.optimizerSpec.MaxTotalPowerWatts)
+	entries := make([]*headroomEntry, 0)
+	servers := s.system.GetServers()
+	for _, serverName := range slices.Sorted(maps.Keys(servers)) {
+		server := servers[serverName]
+		alloc := server.Allocation()
+		if alloc == nil {
+			continue
+		}
+		for _, leg := range alloc.Legs() {
+			accType, unitsPerReplica := replicaUnits(s.system, server, leg)
+			available.take(server, accType, leg.NumReplicas(), unitsPerReplica, leg.Power())
+		}
+		load := server.ServedLoad()
+		if load == nil || alloc.Mixed() || alloc.Idle() || server.Pinned() {
+			continue
+		}
+		accType, unitsPerReplica := replicaUnits(s.system, server, alloc)
+		if load.ArrivalRate <= 0 || unitsPerReplica <= 0 || alloc.MaxRPM() <= 0 {
+			continue
+		}
+		// copy of the selected allocation, shared with the candidate allocations of the server
+		alloc = alloc.Clone()
+		server.SetAllocation(alloc)
+		entries = append(entries, &headroomEntry{
+			server:          server,
+			alloc:           alloc,
+			accType:         accType,
+			unitsPerReplica: unitsPerReplica,
+			powerPerReplica: replicaPower(alloc),
+			step:            max(server.ShardFactor(), 1),
+			rate:            load.ArrivalRate,
+		})
+	}
+
+	// add replicas to the server with the least headroom, the first by name if tied,
+	// dropping servers which cannot grow
+	for len(entries) > 0 && s.ctx.Err() == nil {
+		next := 0
+		for i, e := range entries {
+			if e.headroom() < entries[next].headroom() {
+				next = i
+			}
+		}
+		e := entries[next]
+		numReplicas := e.alloc.NumReplicas() + e.step
+		if (e.server.MaxNumReplicas() > 0 && numReplicas > e.server.MaxNumReplicas()) ||
+			available.replicas(e.server, e.accType, e.unitsPerReplica, e.powerPerReplica) < e.step {
+			entries = slices.Delete(entries, next, next+1)
+			continue
+		}
+		e.alloc.Rescale(numReplicas)
+		e.server.UpdateDesiredAlloc()
+		available.take(e.server, e.accType, e.step, e.unitsPerReplica, float32(e.step)*e.powerPerReplica)
+		added += e.step
+	}
+
+	// least headroom of servers, after adding replicas
+	minHeadroom = 1
+	for _, server := range servers {
+		if alloc := server.Allocation(); alloc != nil && !alloc.Mixed() {
+			if load := server.ServedLoad(); load != nil {
+				minHeadroom = min(minHeadroom, alloc.Headroom(load.ArrivalRate))
+			}
+		}
+	}
+	return minHeadroom, added
+}
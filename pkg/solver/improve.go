@@ -0,0 +1,160 @@
+package solver
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/llm-inferno/optimizer/pkg/config"
+	"github.com/llm-inferno/optimizer/pkg/core"
+)
+
+// Improve a solution by local search, returning the number of improving moves applied
+//   - moves are single-server reallocations and pairwise swaps of accelerators between two servers
+//   - a move is applied if it reduces the total value (cost including transition penalty) within available capacity,
+//     honoring units reserved for service classes and power budgets
+//   - the move with the largest reduction is applied at each iteration, until a local optimum or an iteration cap,
+//     or until the context of the solve is done
+//   - servers with no allocation, a saturated (best effort) allocation, a mixed allocation, or a pinned allocation
+//     are not moved
+func (s *Solver) Improve() int {
+	serverNames := slices.Sorted(maps.Keys(s.system.GetServers()))
+	movable := make([]*core.Server, 0, len(serverNames))
+	for _, serverName := range serverNames {
+		server := s.system.GetServer(serverName)
+		alloc := server.Allocation()
+		if alloc != nil && !server.Saturated() && !alloc.Mixed() && !server.Pinned() {
+			movable = append(movable, server)
+		}
+	}
+
+	candidates := make(map[*core.Server]map[string]*core.Allocation, len(movable))
+	for _, server := range movable {
+		candidates[server] = s.candidateAllocations(server)
+	}
+
+	numMoves := 0
+	for numMoves < config.MaxImprovingMoves && s.ctx.Err() == nil {
+		move := s.bestMove(movable, candidates)
+		if move == nil {
+			break
+		}
+		move.apply()
+		numMoves++
+	}
+	return numMoves
+}
+
+// A change of allocations of servers
+type allocationMove struct {
+	servers []*core.Server
+	allocs  []*core.Allocation
+	gain    float32 // reduction in total value
+}
+
+// find the move with the largest reduction in total value, given candidate allocations of servers; nil if none
+func (s *Solver) bestMove(servers []*core.Server, candidates map[*core.Server]map[string]*core.Allocation) *allocationMove {
+	var best *allocationMove
+	consider := func(move *allocationMove) {
+		if move.gain > 0 && (best == nil || move.gain > best.gain) && s.feasible(move, nil) {
+			best = move
+		}
+	}
+
+	for i, s1 := range servers {
+		a1 := s1.Allocation()
+		allAllocs := candidates[s1]
+
+		// single-server reallocation
+		for _, gName := range slices.Sorted(maps.Keys(allAllocs)) {
+			b1 := allAllocs[gName]
+			consider(&allocationMove{
+				servers: []*core.Server{s1},
+				allocs:  []*core.Allocation{b1},
+				gain:    a1.Value() - b1.Value(),
+			})
+		}
+
+		// pairwise swap of accelerators
+		for _, s2 := range servers[i+1:] {
+			a2 := s2.Allocation()
+			if a1.Accelerator() == a2.Accelerator() {
+				continue
+			}
+			b1 := allAllocs[a2.Accelerator()]
+			b2 := candidates[s2][a1.Accelerator()]
+			if b1 == nil || b2 == nil {
+				continue
+			}
+			consider(&allocationMove{
+				servers: []*core.Server{s1, s2},
+				allocs:  []*core.Allocation{b1, b2},
+				gain:    a1.Value() + a2.Value() - b1.Value() - b2.Value(),
+			})
+		}
+	}
+	return best
+}
+
+// check if the capacity available to servers after releasing their allocations suffices for the new allocations of
+// a move, given tentative allocations of other servers (nil if none)
+//   - units reserved for service classes and power budgets are honored, as when allocating
+func (s *Solver) feasible(m *allocationMove, tentative map[*core.Server]*core.Allocation) bool {
+	allocs := maps.Clone(tentative)
+	if allocs == nil {
+		allocs = make(map[*core.Server]*core.Allocation, len(m.servers))
+	}
+	for _, server := range m.servers {
+		allocs[server] = nil
+	}
+	available := s.capacityAfter(allocs)
+	for i, server := range m.servers {
+		for _, leg := range m.allocs[i].Legs() {
+			accType, unitsPerReplica := replicaUnits(s.system, server, leg)
+			if available.replicas(server, accType, unitsPerReplica, replicaPower(leg)) < leg.NumReplicas() {
+				return false
+			}
+			available.take(server, accType, leg.NumReplicas(), unitsPerReplica, leg.Power())
+		}
+	}
+	return true
+}
+
+// capacity available after the allocations of servers, in order of server names: the current allocation of a
+// server, unless given (nil if none)
+func (s *Solver) capacityAfter(allocs map[*core.Server]*core.Allocation) *capacityPool {
+	available := newCapacityPool(s.system, s.optimizerSpec.MaxTotalPowerWatts)
+	servers := s.system.GetServers()
+	for _, serverName := range slices.Sorted(maps.Keys(servers)) {
+		server := servers[serverName]
+		alloc, given := allocs[server]
+		if !given {
+			alloc = server.Allocation()
+		}
+		if alloc == nil {
+			continue
+		}
+		for _, leg := range alloc.Legs() {
+			accType, unitsPerReplica := replicaUnits(s.system, server, leg)
+			available.take(server, accType, leg.NumReplicas(), unitsPerReplica, leg.Power())
+		}
+	}
+	return available
+}
+
+// apply the move
+func (m *allocationMove) apply() {
+	for i, server := range m.servers {
+		server.SetAllocation(m.allocs[i])
+	}
+}
+
+// type and number of accelerator units used by an allocation of a server
+func allocationUnits(system *core.System, server *core.Server, alloc *core.Allocation) (string, int) {
+	gName := alloc.Accelerator()
+	acc := system.GetAccelerator(gName)
+	model := system.GetModel(server.ModelName())
+	if acc == nil || model == nil {
+		return "", 0
+	}
+	return acc.Type(), alloc.NumReplicas() * model.NumInstances(gName) * system.GetUnits(acc)
+}
@@ -1,7 +1,12 @@
 package solver
 
 import (
+	"context"
 	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"time"
 
 	lpsolveConfig "github.com/llm-inferno/lpsolve/pkg/config"
 	lpsolve "github.com/llm-inferno/lpsolve/pkg/core"
@@ -11,6 +16,7 @@ import (
 )
 
 type MILPSolver struct {
+	system        *core.System
 	optimizerSpec *config.OptimizerSpec
 
 	numServers             int         // number of servers (a pair of service class and model)
@@ -36,8 +42,9 @@ type MILPSolver struct {
 	accTypeLookup []string       // index -> acceleratorTypeName
 }
 
-func NewMILPSolver(optimizerSpec *config.OptimizerSpec) *MILPSolver {
+func NewMILPSolver(system *core.System, optimizerSpec *config.OptimizerSpec) *MILPSolver {
 	return &MILPSolver{
+		system:        system,
 		optimizerSpec: optimizerSpec,
 	}
 }
@@ -58,11 +65,55 @@ func (v *MILPSolver) Solve() error {
 	return nil
 }
 
+// Solve within a time budget; false if the budget is exceeded, or the context is done, before the problem is solved
+//   - the run of the solver is cancelled if not started by then, and bounded by the budget (in whole seconds)
+//     otherwise, as lp_solve cannot be interrupted
+//   - a late result is discarded: the run solves a problem of its own, and neither the solver nor servers are
+//     updated but by a run completing within the budget
+func (v *MILPSolver) SolveWithin(ctx context.Context, budget time.Duration) (bool, error) {
+	v.preProcess()
+
+	isLimited := !v.optimizerSpec.Unlimited
+	isMulti := v.optimizerSpec.Heterogeneous
+	useCplex := v.optimizerSpec.UseCplex
+	runCtx, cancel := context.WithTimeout(ctx, budget)
+	defer cancel()
+
+	type result struct {
+		p   lpsolve.Problem
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		if err := runCtx.Err(); err != nil {
+			done <- result{err: err}
+			return
+		}
+		p, err := v.solveProblem(isLimited, isMulti, useCplex, int(math.Ceil(budget.Seconds())))
+		done <- result{p: p, err: err}
+	}()
+
+	select {
+	case r := <-done:
+		if runCtx.Err() != nil {
+			return false, nil
+		}
+		if r.err != nil {
+			return true, r.err
+		}
+		v.printResults(problemType(isMulti), r.p)
+		v.postProcess()
+		return true, nil
+	case <-runCtx.Done():
+		return false, nil
+	}
+}
+
 // prepare input date for MILP solver
 func (v *MILPSolver) preProcess() {
 
 	// create map and lookup arrays for accelerators
-	accMap := core.GetAccelerators()
+	accMap := v.system.GetAccelerators()
 	v.numAccelerators = len(accMap)
 	v.accIndex = make(map[string]int)
 	v.accLookup = make([]string, v.numAccelerators)
@@ -70,10 +121,11 @@ func (v *MILPSolver) preProcess() {
 	// set cost values
 	v.instanceCost = make([]float64, v.numAccelerators)
 	index := 0
-	for accName, acc := range accMap {
+	for _, accName := range slices.Sorted(maps.Keys(accMap)) {
+		acc := accMap[accName]
 		v.accIndex[accName] = index
 		v.accLookup[index] = accName
-		v.instanceCost[index] = float64(acc.Spec().Cost)
+		v.instanceCost[index] = float64(acc.Cost())
 		index++
 	}
 
@@ -82,19 +134,21 @@ func (v *MILPSolver) preProcess() {
 	// fmt.Println(lpsolveUtils.Pretty1D("unitCost", v.instanceCost))
 
 	// create map and lookup arrays for accelerator types
-	capMap := core.GetCapacities()
+	capMap := v.system.GetCapacityUnits()
 	v.numAcceleratorTypes = len(capMap)
 	v.accTypeIndex = make(map[string]int)
 	v.accTypeLookup = make([]string, v.numAcceleratorTypes)
 
-	// set available accelerator types
+	// set available accelerator types, less units of pinned allocations
+	pinned, _ := pinnedUnits(v.system)
 	v.unitsAvail = make([]int, v.numAcceleratorTypes)
 	v.acceleratorTypesMatrix = make([][]int, v.numAcceleratorTypes)
 	index = 0
-	for accTypeName, accTypeCount := range capMap {
+	for _, accTypeName := range slices.Sorted(maps.Keys(capMap)) {
+		accTypeCount := capMap[accTypeName]
 		v.accTypeIndex[accTypeName] = index
 		v.accTypeLookup[index] = accTypeName
-		v.unitsAvail[index] = accTypeCount
+		v.unitsAvail[index] = max(accTypeCount-pinned[accTypeName], 0)
 		v.acceleratorTypesMatrix[index] = make([]int, v.numAccelerators)
 		index++
 	}
@@ -104,7 +158,7 @@ func (v *MILPSolver) preProcess() {
 		accType := acc.Type()
 		if accIndex, exists := v.accIndex[accName]; exists {
 			accTypeIndex := v.accTypeIndex[accType]
-			v.acceleratorTypesMatrix[accTypeIndex][accIndex] = acc.Spec().Multiplicity
+			v.acceleratorTypesMatrix[accTypeIndex][accIndex] = v.system.GetUnits(acc)
 		}
 	}
 
@@ -113,11 +167,14 @@ func (v *MILPSolver) preProcess() {
 	// fmt.Println(lpsolveUtils.Pretty1D("unitsAvailByType", v.unitsAvail))
 	// fmt.Println(lpsolveUtils.Pretty2D("acceleratorTypesMatrix", v.acceleratorTypesMatrix))
 
-	// create map and lookup arrays for servers
+	// create map and lookup arrays for servers, but pinned servers
 	index = 0
 	v.serverIndex = make(map[string]int)
-	srvMap := core.GetServers()
-	for srvName := range srvMap {
+	srvMap := v.system.GetServers()
+	for _, srvName := range slices.Sorted(maps.Keys(srvMap)) {
+		if srvMap[srvName].Pinned() {
+			continue
+		}
 		v.serverIndex[srvName] = index
 		index++
 	}
@@ -135,19 +192,19 @@ func (v *MILPSolver) preProcess() {
 		v.numInstancesPerReplica[i] = make([]int, v.numAccelerators)
 		v.ratePerReplica[i] = make([]float64, v.numAccelerators)
 	}
-	modelMap := core.GetModels()
+	modelMap := v.system.GetModels()
 	for srvName, srv := range srvMap {
 		if i, exists := v.serverIndex[srvName]; exists {
-			load := srv.Load()
+			load := srv.ServedLoad()
 			if load == nil {
 				continue
 			}
-			v.arrivalRates[i] = float64(load.ArrivalRate / 60 / 1000)
+			v.arrivalRates[i] = float64(config.PerMinToPerMsec(load.ArrivalRate))
 			m := modelMap[srv.ModelName()]
 			for accName, j := range v.accIndex {
 				//acc := accMap[accName]
 				v.numInstancesPerReplica[i][j] = m.NumInstances(accName)
-				if alloc := srv.AllAllocations()[accName]; alloc != nil {
+				if alloc := candidateAllocations(v.system, v.optimizerSpec, srv)[accName]; alloc != nil {
 					v.ratePerReplica[i][j] = float64(alloc.MaxArrvRatePerReplica())
 				}
 			}
@@ -163,19 +220,34 @@ func (v *MILPSolver) preProcess() {
 
 // call MILP solver to optimize problem
 func (v *MILPSolver) optimize(isLimited bool, isMulti bool, useCplex bool) error {
-	problemType := lpsolveConfig.SINGLE
-	if isMulti {
-		problemType = lpsolveConfig.MULTI
-	}
-	p, err := v.createProblem(problemType, isLimited, useCplex)
+	p, err := v.solveProblem(isLimited, isMulti, useCplex, 0)
 	if err != nil {
 		return err
 	}
+	v.printResults(problemType(isMulti), p)
+	return nil
+}
+
+// create and solve a problem from the input data, with a solver timeout in seconds (default if not positive)
+//   - the solver is not updated, so that the problem may be solved in the background
+func (v *MILPSolver) solveProblem(isLimited bool, isMulti bool, useCplex bool, timeoutSec int) (lpsolve.Problem, error) {
+	p, err := v.createProblem(problemType(isMulti), isLimited, useCplex)
+	if err != nil {
+		return nil, err
+	}
+	p.SetSolverTimeout(timeoutSec)
 	if err := p.Solve(); err != nil {
-		return err
+		return nil, err
 	}
-	v.printResults(problemType, p)
-	return nil
+	return p, nil
+}
+
+// type of problem, assigning one or more accelerators to a server
+func problemType(isMulti bool) lpsolveConfig.ProblemType {
+	if isMulti {
+		return lpsolveConfig.MULTI
+	}
+	return lpsolveConfig.SINGLE
 }
 
 func (v *MILPSolver) createProblem(problemType lpsolveConfig.ProblemType, isLimited bool, useCplex bool) (lpsolve.Problem, error) {
@@ -276,7 +348,7 @@ func (v *MILPSolver) postProcess() {
 				continue
 			}
 			accName := v.accLookup[j]
-			sc := core.GetServer(v.serverLookup[i])
+			sc := v.system.GetServer(v.serverLookup[i])
 			// TODO: Fix this
 			if alloc := sc.AllAllocations()[accName]; alloc != nil {
 				sc.SetAllocation(alloc)
@@ -0,0 +1,38 @@
+package solver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/llm-inferno/optimizer/pkg/config"
+	"github.com/llm-inferno/optimizer/pkg/testutil"
+)
+
+// A solve abandoned as its context is done yields no solution, and leaves servers unallocated, however soon the
+// run of the solver completes
+func TestMILPSolveWithinDiscardsAbandonedRun(t *testing.T) {
+	spec := testutil.SystemSpec()
+	spec.Servers.Spec = append(spec.Servers.Spec, testutil.ServerSpec("a", "Premium", 600), testutil.ServerSpec("b", "Premium", 600))
+	spec.Capacity.Count = []config.AcceleratorCount{{Type: "A100", Count: 40}, {Type: "G2", Count: 40}}
+	system := newTestSystem(t, spec)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for run := 0; run < 20; run++ {
+		mip := NewMILPSolver(system, &config.OptimizerSpec{})
+		solved, err := mip.SolveWithin(ctx, time.Minute)
+		if solved || err != nil {
+			t.Fatalf("run %d: solved=%v, err=%v, want abandoned with no error", run, solved, err)
+		}
+		if mip.numReplicas != nil {
+			t.Fatalf("run %d: solver updated with replicas %v", run, mip.numReplicas)
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+	for _, name := range []string{"a", "b"} {
+		if alloc := system.GetServer(name).Allocation(); alloc != nil {
+			t.Errorf("allocation of %s=%v, want none", name, alloc)
+		}
+	}
+}
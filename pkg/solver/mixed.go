@@ -0,0 +1,110 @@
+package solver
+
+import (
+	"math"
+
+	"github.com/llm-inferno/optimizer/pkg/core"
+)
+
+// mix accelerators for unallocated servers if allowed, returning servers that did not receive any allocation
+func (s *Solver) mixAccelerators(entries []*serverEntry, available *capacityPool) []*serverEntry {
+	if !s.optimizerSpec.AllowMixedAccelerators || len(entries) == 0 {
+		return entries
+	}
+	return s.allocateMixed(entries, available)
+}
+
+// Allocate to servers which could not get an allocation on a single accelerator type, mixing two accelerators,
+// returning servers that did not receive any allocation
+//   - a server gets as many replicas as available of one candidate allocation (fewer than required),
+//     and enough replicas of another candidate allocation, on a different accelerator type, to serve the rest of its load
+//   - the pair of candidate allocations with the least total value is selected
+func (s *Solver) allocateMixed(entries []*serverEntry, available *capacityPool) (unallocatedEntries []*serverEntry) {
+	unallocatedEntries = make([]*serverEntry, 0)
+	for _, entry := range entries {
+		server := s.system.GetServer(entry.serverName)
+		if server == nil {
+			continue
+		}
+		var best *mixedCandidate
+		for _, first := range entry.allocations {
+			for _, second := range entry.allocations {
+				if c := s.mixAllocations(server, first, second, available); c != nil &&
+					(best == nil || c.alloc.Value() < best.alloc.Value()) {
+					best = c
+				}
+			}
+		}
+		if best == nil {
+			unallocatedEntries = append(unallocatedEntries, entry)
+			continue
+		}
+		d := s.decision(greedyMixed, server, -1, best.alloc, best.units[best.accType], available)
+		d.Action = decisionAllocated
+		s.record(d)
+		for _, leg := range best.alloc.Legs() {
+			accType, unitsPerReplica := replicaUnits(s.system, server, leg)
+			available.take(server, accType, leg.NumReplicas(), unitsPerReplica, best.power[accType])
+		}
+		server.SetAllocation(best.alloc)
+	}
+	return unallocatedEntries
+}
+
+// A mixed allocation and the accelerator units and power it uses, by type
+type mixedCandidate struct {
+	alloc   *core.Allocation
+	accType string // type of the accelerator of the first allocation
+	units   map[string]int
+	power   map[string]float32
+}
+
+// mix replicas of two allocations of a server on different accelerator types, given available accelerator units
+// and power; nil if not feasible
+//   - the first allocation gets as many replicas as available, at least one and fewer than its number of replicas
+func (s *Solver) mixAllocations(server *core.Server, first, second *core.Allocation, available *capacityPool) *mixedCandidate {
+	if first.NumReplicas() <= 1 || second.NumReplicas() <= 0 {
+		return nil
+	}
+	firstType, firstUnits := replicaUnits(s.system, server, first)
+	secondType, secondUnits := replicaUnits(s.system, server, second)
+	if firstType == "" || secondType == "" || firstType == secondType || firstUnits <= 0 || secondUnits <= 0 {
+		return nil
+	}
+
+	// replicas of the first allocation, a multiple of the shard factor
+	firstPower := replicaPower(first)
+	numFirst := server.ShardReplicasFloor(min(available.replicas(server, firstType, firstUnits, firstPower), first.NumReplicas()-1))
+	if numFirst <= 0 {
+		return nil
+	}
+
+	// replicas of the second allocation serving the rest of the load
+	fraction := 1 - float64(numFirst)/float64(first.NumReplicas())
+	numSecond := server.ShardReplicas(int(math.Ceil(fraction * float64(second.NumReplicas()))))
+	if !available.fits(server, secondType, numSecond, secondUnits) {
+		return nil
+	}
+	power := map[string]float32{firstType: float32(numFirst) * firstPower, secondType: float32(numSecond) * replicaPower(second)}
+	if !available.powerFits(power) {
+		return nil
+	}
+	if maxReplicas := server.MaxNumReplicas(); maxReplicas > 0 && numFirst+numSecond > maxReplicas {
+		return nil
+	}
+	return &mixedCandidate{
+		alloc:   core.CreateMixedAllocation(first, numFirst, second, numSecond),
+		accType: firstType,
+		units:   map[string]int{firstType: numFirst * firstUnits, secondType: numSecond * secondUnits},
+		power:   power,
+	}
+}
+
+// type and number of accelerator units used by a replica of an allocation of a server
+func replicaUnits(system *core.System, server *core.Server, alloc *core.Allocation) (string, int) {
+	accType, units := allocationUnits(system, server, alloc)
+	if alloc.NumReplicas() <= 0 {
+		return accType, 0
+	}
+	return accType, units / alloc.NumReplicas()
+}
@@ -2,16 +2,19 @@ package solver
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/llm-inferno/optimizer/pkg/config"
+	"github.com/llm-inferno/optimizer/pkg/core"
 )
 
 type Optimizer struct {
 	spec             *config.OptimizerSpec
 	solver           *Solver
 	solutionTimeMsec int64
+	numKept          int // number of servers kept on their current accelerators when re-optimizing
 }
 
 // Create optimizer from spec
@@ -21,19 +24,82 @@ func NewOptimizerFromSpec(spec *config.OptimizerSpec) *Optimizer {
 	}
 }
 
-func (o *Optimizer) Optimize() error {
+// Optimize allocations of servers in a system, within the time allowed by the context
+//   - ErrTimeout if the context is done before completion, with a partial but valid solution
+func (o *Optimizer) Optimize(ctx context.Context, system *core.System) error {
 	if o.spec == nil {
 		return fmt.Errorf("missing optimizer spec")
 	}
-	o.solver = NewSolver(o.spec)
+	o.solver = NewSolver(system, o.spec)
+	o.numKept = 0
 
 	startTime := time.Now()
-	err := o.solver.Solve()
+	err := o.solver.Solve(ctx)
 	endTime := time.Now()
 	o.solutionTimeMsec = endTime.Sub(startTime).Milliseconds()
 	return err
 }
 
+// Optimize, then keep servers on their current accelerators within the churn budget of the spec
+func (o *Optimizer) Reoptimize(ctx context.Context, system *core.System) error {
+	if err := o.Optimize(ctx, system); err != nil {
+		return err
+	}
+	startTime := time.Now()
+	o.numKept = o.solver.ReduceChurn(o.spec.ChurnBudget)
+	o.solutionTimeMsec += time.Since(startTime).Milliseconds()
+	return nil
+}
+
+// Number of servers kept on their current accelerators when re-optimizing
+func (o *Optimizer) NumKept() int {
+	return o.numKept
+}
+
+func (o *Optimizer) Spec() *config.OptimizerSpec {
+	return o.spec
+}
+
+// Number of improving moves applied by local search after solving
+func (o *Optimizer) NumImprovingMoves() int {
+	if o.solver == nil {
+		return 0
+	}
+	return o.solver.NumImprovingMoves()
+}
+
+// Number of accelerator types in use before and after consolidation (zero if not consolidated)
+func (o *Optimizer) NumAcceleratorTypes() (before int, after int) {
+	if o.solver == nil {
+		return 0, 0
+	}
+	return o.solver.NumAcceleratorTypes()
+}
+
+// Least rate headroom of servers, and number of replicas added to increase it (zero if headroom not maximized)
+func (o *Optimizer) Headroom() (minHeadroom float32, added int) {
+	if o.solver == nil {
+		return 0, 0
+	}
+	return o.solver.Headroom()
+}
+
+// Servers admitted, and servers shed with the binding constraint, in the last optimization (nil if not admission control)
+func (o *Optimizer) Admission() (admitted []string, shed []config.ServerStatus) {
+	if o.solver == nil {
+		return nil, nil
+	}
+	return o.solver.Admission()
+}
+
+// Decisions of the greedy algorithm in the last optimization, in order (nil if not debugging)
+func (o *Optimizer) Trace() []config.GreedyDecision {
+	if o.solver == nil {
+		return nil
+	}
+	return o.solver.Trace()
+}
+
 func (o *Optimizer) SolutionTimeMsec() int64 {
 	return o.solutionTimeMsec
 }
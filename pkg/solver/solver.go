@@ -2,8 +2,14 @@ package solver
 
 import (
 	"bytes"
+	"cmp"
+	"context"
 	"fmt"
+	"maps"
 	"math"
+	"math/rand"
+	"slices"
+	"time"
 
 	"github.com/llm-inferno/optimizer/pkg/config"
 	"github.com/llm-inferno/optimizer/pkg/core"
@@ -11,6 +17,7 @@ import (
 
 // Solver of allocation assignment problem
 type Solver struct {
+	system        *core.System
 	optimizerSpec *config.OptimizerSpec
 
 	// current allocation for all servers
@@ -18,60 +25,190 @@ type Solver struct {
 
 	// difference in allocation for all servers
 	diffAllocation map[string]*core.AllocationDiff
+
+	// number of improving moves applied by local search
+	numImprovingMoves int
+
+	// number of accelerator types in use before and after consolidation
+	numTypesBefore int
+	numTypesAfter  int
+
+	// least rate headroom of servers, and number of replicas added to increase it (max-headroom objective)
+	minHeadroom   float32
+	addedReplicas int
+
+	// servers admitted, and servers shed with the binding constraint (admission control)
+	admitted []string
+	shed     []config.ServerStatus
+
+	// decisions of the greedy algorithm, if debugging
+	trace []config.GreedyDecision
+
+	// context of the current solve, bounding its expensive phases
+	ctx context.Context
+
+	// random number generator seeded by the optimizer spec, source of all randomness of the solver
+	rng *rand.Rand
 }
 
-func NewSolver(optimizerSpec *config.OptimizerSpec) *Solver {
+func NewSolver(system *core.System, optimizerSpec *config.OptimizerSpec) *Solver {
 	return &Solver{
+		system:            system,
 		optimizerSpec:     optimizerSpec,
 		currentAllocation: make(map[string]*core.Allocation),
 		diffAllocation:    make(map[string]*core.AllocationDiff),
+		ctx:               context.Background(),
+		rng:               rand.New(rand.NewSource(optimizerSpec.Seed)),
 	}
 }
 
+// Random number generator of the solver, seeded by the optimizer spec
+//   - randomized components of the solver (e.g. tie-breaking, restarts) draw from it, and iterate
+//     over servers and accelerators in a fixed order, so that the same seed and inputs yield the same solution
+func (s *Solver) Rand() *rand.Rand {
+	return s.rng
+}
+
 // Find optimal allocation for all service classes
-func (s *Solver) Solve() error {
+//   - the MILP solver and local search stop when the context is done, keeping a partial but valid solution,
+//     in which case ErrTimeout is returned
+func (s *Solver) Solve(ctx context.Context) error {
+	s.ctx = ctx
+	s.trace = nil
+	s.admitted, s.shed = nil, nil
+
 	// take snapshot of current allocations
 	s.currentAllocation = make(map[string]*core.Allocation)
-	for serverName, server := range core.GetServers() {
+	for serverName, server := range s.system.GetServers() {
 		if alloc := server.CurAllocation(); alloc != nil {
 			s.currentAllocation[serverName] = alloc
 		}
 	}
 
-	// find solution
+	// honor pinned allocations, then find solution for other servers
+	s.pinAllocations()
 	if s.optimizerSpec.Unlimited {
 		s.SolveUnlimited()
-	} else if s.optimizerSpec.MILPSolver {
+	} else if s.optimizerSpec.AdmissionControl {
+		s.SolveAdmission()
+	} else if s.optimizerSpec.MILPSolver || config.AlgorithmEnum(s.optimizerSpec.Algorithm) == config.MILP {
 		if err := s.SolveMILP(); err != nil {
 			return err
 		}
+		s.postOptimize()
+	} else if s.optimizerSpec.MaxThroughput || s.optimizerSpec.MaxTotalCost > 0 {
+		s.SolveMaxThroughput()
 	} else {
 		s.SolveGreedy()
+		s.postOptimize()
+	}
+
+	// give no allocation to servers with zero load, if scaling to zero
+	if s.optimizerSpec.ScaleToZero {
+		for _, server := range s.system.GetServers() {
+			if server.ScalableToZero() && !server.Pinned() {
+				server.ScaleToZero()
+			}
+		}
 	}
 
 	// TODO: cleanup after trying MIP solver
 
 	s.diffAllocation = make(map[string]*core.AllocationDiff)
-	for serverName, server := range core.GetServers() {
+	for serverName, server := range s.system.GetServers() {
 		curAlloc := s.currentAllocation[serverName]
 		desiredAlloc := server.Allocation()
 		if allocDiff := core.CreateAllocationDiff(curAlloc, desiredAlloc); allocDiff != nil {
 			s.diffAllocation[serverName] = allocDiff
 		}
 	}
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("%w: %w", core.ErrTimeout, err)
+	}
 	return nil
 }
 
+// Candidate allocations of a server, by accelerator name, allowed by the spot policy
+func (s *Solver) candidateAllocations(server *core.Server) map[string]*core.Allocation {
+	return candidateAllocations(s.system, s.optimizerSpec, server)
+}
+
+// Compare candidate allocations of a server by value, allocations on draining accelerators last among equal values
+func (s *Solver) compareAllocations(a, b *core.Allocation) int {
+	if c := cmp.Compare(a.Value(), b.Value()); c != 0 {
+		return c
+	}
+	return cmp.Compare(s.draining(a), s.draining(b))
+}
+
+// 1 if an allocation is on a draining accelerator, 0 otherwise
+func (s *Solver) draining(alloc *core.Allocation) int {
+	if acc := s.system.GetAccelerator(alloc.Accelerator()); acc != nil && acc.Draining() {
+		return 1
+	}
+	return 0
+}
+
+// Candidate allocations of a server, by accelerator name, allowed by the spot policy of an optimizer spec
+//   - avoid-critical: critical servers (priority value up to the threshold) exclude interruptible accelerators
+//   - spot-only: all servers exclude accelerators which are not interruptible
+//   - none for servers with zero load and no warm pool, if scaling to zero
+//   - the pinned allocation only for pinned servers, regardless of the spot policy and scaling to zero
+func candidateAllocations(system *core.System, spec *config.OptimizerSpec, server *core.Server) map[string]*core.Allocation {
+	if server.Pinned() {
+		return server.AllAllocations()
+	}
+	if spec.ScaleToZero && server.ScalableToZero() {
+		return nil
+	}
+	allAllocs := server.AllAllocations()
+	policy := config.SpotPolicyEnum(spec.SpotPolicy)
+	criticalPriority := spec.CriticalPriority
+	if criticalPriority <= 0 {
+		criticalPriority = config.DefaultCriticalPriority
+	}
+	if policy == config.AllowAllSpot || (policy == config.AvoidCriticalSpot && server.Priority() > criticalPriority) {
+		return allAllocs
+	}
+	allocs := make(map[string]*core.Allocation, len(allAllocs))
+	for gName, alloc := range allAllocs {
+		if acc := system.GetAccelerator(gName); acc != nil && acc.Interruptible() == (policy == config.SpotOnly) {
+			allocs[gName] = alloc
+		}
+	}
+	return allocs
+}
+
+// Give pinned servers their pinned allocations, honored as given by all solvers, which allocate to other servers
+// within the capacity left
+//   - a server with a pinned allocation which is not feasible (e.g. on an accelerator not in the system) is left
+//     unallocated, with the reason
+func (s *Solver) pinAllocations() {
+	for _, server := range s.system.GetServers() {
+		if !server.Pinned() {
+			continue
+		}
+		server.RemoveAllocation()
+		for _, alloc := range server.AllAllocations() {
+			server.SetAllocation(alloc)
+		}
+	}
+}
+
 // Find optimal allocations assuming unlimited accelerator capacity
 // (separable objective function: best allocation for each server)
 func (s *Solver) SolveUnlimited() {
-	for _, server := range core.GetServers() {
+	for _, server := range s.system.GetServers() {
+		if server.Pinned() {
+			continue
+		}
 		server.RemoveAllocation()
-		// select allocation with minimum value
+		// select allocation with minimum value, the first by accelerator name if tied
 		minVal := float32(math.MaxFloat32)
 		var minAlloc *core.Allocation
-		for _, alloc := range server.AllAllocations() {
-			if alloc.Value() < minVal {
+		allAllocs := s.candidateAllocations(server)
+		for _, gName := range slices.Sorted(maps.Keys(allAllocs)) {
+			if alloc := allAllocs[gName]; alloc.Value() < minVal {
 				minVal = alloc.Value()
 				minAlloc = alloc
 			}
@@ -82,9 +219,66 @@ func (s *Solver) SolveUnlimited() {
 	}
 }
 
+// Find optimal allocations using an MILP solver, falling back to greedy if the time budget is exceeded
+//   - the time budget is cut short when the context of the solve is done
 func (s *Solver) SolveMILP() error {
-	mip := NewMILPSolver(s.optimizerSpec)
-	return mip.Solve()
+	budgetMsec := s.optimizerSpec.MILPTimeBudget
+	if budgetMsec <= 0 {
+		budgetMsec = config.DefaultMILPTimeBudget
+	}
+	budget := time.Duration(budgetMsec) * time.Millisecond
+
+	mip := NewMILPSolver(s.system, s.optimizerSpec)
+	solved, err := mip.SolveWithin(s.ctx, budget)
+	if err != nil {
+		return err
+	}
+	if !solved {
+		fmt.Printf("warning: MILP solver exceeded time budget of %v, falling back to greedy \n", budget)
+		s.SolveGreedy()
+	}
+	return nil
+}
+
+// improve a cost-minimizing solution by local search, if enabled,
+// then reduce the number of accelerator types in use, if the objective is to consolidate,
+// or give remaining capacity to servers with the least headroom, if the objective is to maximize headroom
+func (s *Solver) postOptimize() {
+	s.numImprovingMoves = 0
+	if s.optimizerSpec.PostOptimize {
+		s.numImprovingMoves = s.Improve()
+	}
+	s.numTypesBefore, s.numTypesAfter = 0, 0
+	s.minHeadroom, s.addedReplicas = 0, 0
+	switch config.ObjectiveEnum(s.optimizerSpec.Objective) {
+	case config.ConsolidateObjective:
+		tolerance := s.optimizerSpec.ConsolidationTolerance
+		if tolerance <= 0 {
+			tolerance = config.DefaultConsolidationTolerance
+		}
+		s.numTypesBefore, s.numTypesAfter = s.Consolidate(tolerance)
+	case config.MaxHeadroomObjective:
+		s.minHeadroom, s.addedReplicas = s.MaximizeHeadroom()
+	}
+}
+
+func (s *Solver) NumImprovingMoves() int {
+	return s.numImprovingMoves
+}
+
+// Number of accelerator types in use before and after consolidation (zero if not consolidated)
+func (s *Solver) NumAcceleratorTypes() (before int, after int) {
+	return s.numTypesBefore, s.numTypesAfter
+}
+
+// Least rate headroom of servers, and number of replicas added to increase it (zero if headroom not maximized)
+func (s *Solver) Headroom() (minHeadroom float32, added int) {
+	return s.minHeadroom, s.addedReplicas
+}
+
+// Servers admitted, and servers shed with the binding constraint, in the last optimization (nil if not admission control)
+func (s *Solver) Admission() (admitted []string, shed []config.ServerStatus) {
+	return s.admitted, s.shed
 }
 
 func (s *Solver) AllocationDiff() map[string]*core.AllocationDiff {
@@ -0,0 +1,97 @@
+package solver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/llm-inferno/optimizer/pkg/config"
+	"github.com/llm-inferno/optimizer/pkg/core"
+	"github.com/llm-inferno/optimizer/pkg/metrics"
+	"github.com/llm-inferno/optimizer/pkg/testutil"
+)
+
+// Repeated optimizes of the same system, recalculating allocations each time, hit cached allocations for all but the
+// first calculation; the hit rate is reported as hits per lookup
+func BenchmarkRepeatedOptimizeCacheHitRate(b *testing.B) {
+	spec := testutil.SystemSpec()
+	spec.ServiceClasses.Spec = append(spec.ServiceClasses.Spec, testutil.ServiceClassSpec("Standard", 2))
+	for _, name := range []string{"a", "b", "c", "d"} {
+		spec.Servers.Spec = append(spec.Servers.Spec, testutil.ServerSpec(name+"-premium", "Premium", 600),
+			testutil.ServerSpec(name+"-standard", "Standard", 1200))
+	}
+	spec.Capacity = testutil.Capacity(40)
+	system := testutil.SetFromSpec(b, core.NewSystem(), spec)
+	core.ClearAllocationCache()
+	hits, misses := metrics.AllocationCacheLookups.Value("hit"), metrics.AllocationCacheLookups.Value("miss")
+
+	b.ResetTimer()
+	for range b.N {
+		system.Calculate()
+		if err := NewSolver(system, &config.OptimizerSpec{}).Solve(context.Background()); err != nil {
+			b.Fatalf("solve: %v", err)
+		}
+	}
+	b.StopTimer()
+	hits = metrics.AllocationCacheLookups.Value("hit") - hits
+	misses = metrics.AllocationCacheLookups.Value("miss") - misses
+	if lookups := hits + misses; lookups > 0 {
+		b.ReportMetric(hits/lookups, "hits/lookup")
+	}
+}
+
+// create a test system from a spec and calculate allocations of its servers, failing the test if not valid
+func newTestSystem(t *testing.T, spec *config.SystemSpec) *core.System {
+	t.Helper()
+	system := testutil.SetFromSpec(t, core.NewSystem(), spec)
+	system.Calculate()
+	return system
+}
+
+// solve a test system with an optimizer spec, failing the test on error
+func solveTestSystem(t *testing.T, system *core.System, optimizerSpec *config.OptimizerSpec) *Solver {
+	t.Helper()
+	solver := NewSolver(system, optimizerSpec)
+	if err := solver.Solve(context.Background()); err != nil {
+		t.Fatalf("solve: %v", err)
+	}
+	return solver
+}
+
+// number of replicas allocated to a server of a system, zero if none
+func numReplicas(system *core.System, serverName string) int {
+	if alloc := system.GetServer(serverName).Allocation(); alloc != nil {
+		return alloc.NumReplicas()
+	}
+	return 0
+}
+
+// Repeated solves of the same system with the same seed yield byte-identical solutions, despite ties among servers
+// and accelerators and the random iteration order of maps
+func TestSolveReproducibleWithSeed(t *testing.T) {
+	solve := func() []byte {
+		spec := testutil.SystemSpec()
+		spec.Accelerators.Spec[0].Cost = 25 // same cost as G2, tied
+		spec.ServiceClasses.Spec = append(spec.ServiceClasses.Spec, testutil.ServiceClassSpec("Standard", 2))
+		for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+			spec.Servers.Spec = append(spec.Servers.Spec, testutil.ServerSpec(name+"-premium", "Premium", 600),
+				testutil.ServerSpec(name+"-standard", "Standard", 600))
+		}
+		spec.Capacity.Count = []config.AcceleratorCount{{Type: "A100", Count: 20}, {Type: "G2", Count: 20}}
+		system := newTestSystem(t, spec)
+		solveTestSystem(t, system, &config.OptimizerSpec{Seed: 42, SaturationPolicy: "RoundRobin", PostOptimize: true})
+		data, err := json.Marshal(system.GenerateSolution())
+		if err != nil {
+			t.Fatalf("marshal solution: %v", err)
+		}
+		return data
+	}
+
+	want := solve()
+	for run := 1; run < 10; run++ {
+		if got := solve(); !bytes.Equal(got, want) {
+			t.Fatalf("run %d: solution differs from first run\ngot:  %s\nwant: %s", run, got, want)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package solver
+
+import (
+	"cmp"
+	"maps"
+	"math"
+	"slices"
+
+	"github.com/llm-inferno/optimizer/pkg/core"
+)
+
+// Entry for a server, used during throughput maximization
+type throughputEntry struct {
+	server      *core.Server
+	weight      float32            // weight of served throughput, based on priority
+	demand      float32            // arrival rate to be served (req/min)
+	candidates  []*core.Allocation // candidate allocations, ordered by value
+	alloc       *core.Allocation   // selected candidate allocation (nil if not yet selected)
+	accType     string             // type of accelerator of selected allocation
+	unitsPerRep int                // number of accelerator units per replica of selected allocation
+	numReplicas int                // number of allocated replicas
+	step        int                // number of replicas allocated at a time: the shard factor of the server (at least one)
+	overBudget  bool               // a replica could not be allocated within the cost budget
+}
+
+// Find allocations maximizing the total priority-weighted served throughput, given limited accelerator capacity
+//   - replicas are allocated one at a time (a shard at a time for sharded servers) to the server with the largest
+//     marginal gain per accelerator unit
+//   - a server is not allocated beyond the number of replicas needed to serve its load at SLO
+//   - if a cost budget is given, the total cost of allocations is kept within it,
+//     and servers not given any replica for lack of budget are reported as such
+func (s *Solver) SolveMaxThroughput() {
+	budget := s.optimizerSpec.MaxTotalCost
+	if budget <= 0 {
+		budget = math.MaxFloat32
+	}
+
+	// make a copy of count of available accelerator types, less units and cost of pinned allocations
+	available := make(map[string]int)
+	maps.Copy(available, s.system.GetCapacityUnits())
+	pinned, pinnedCost := pinnedUnits(s.system)
+	for accType, units := range pinned {
+		available[accType] -= units
+	}
+	budget -= pinnedCost
+
+	// create entries for all servers with load
+	serverNames := slices.Sorted(maps.Keys(s.system.GetServers()))
+	entries := make([]*throughputEntry, 0)
+	for _, serverName := range serverNames {
+		server := s.system.GetServer(serverName)
+		if server.Pinned() {
+			continue
+		}
+		server.RemoveAllocation()
+		load := server.ServedLoad()
+		allAllocs := s.candidateAllocations(server)
+		if len(allAllocs) == 0 && len(server.AllAllocations()) > 0 {
+			server.SetUnallocatedError(core.ErrSpotPolicy)
+		}
+		if load == nil || load.ArrivalRate <= 0 || len(allAllocs) == 0 {
+			continue
+		}
+		e := &throughputEntry{
+			server:     server,
+			weight:     priorityWeight(server.Priority()),
+			demand:     load.ArrivalRate,
+			step:       max(server.ShardFactor(), 1),
+			candidates: make([]*core.Allocation, 0, len(allAllocs)),
+		}
+		for _, alloc := range allAllocs {
+			if alloc.NumReplicas() > 0 && alloc.MaxRPM() > 0 {
+				e.candidates = append(e.candidates, alloc)
+			}
+		}
+		slices.SortFunc(e.candidates, func(a, b *core.Allocation) int {
+			if c := s.compareAllocations(a, b); c != 0 {
+				return c
+			}
+			return cmp.Compare(a.Accelerator(), b.Accelerator())
+		})
+		entries = append(entries, e)
+	}
+
+	// allocate one step of replicas at a time to the entry with the largest marginal gain
+	for {
+		var best *throughputEntry
+		var bestAlloc *core.Allocation
+		bestGain := float32(0)
+		for _, e := range entries {
+			alloc, gain := e.nextReplica(s.system, available, budget)
+			if alloc != nil && gain > bestGain {
+				best, bestAlloc, bestGain = e, alloc, gain
+			}
+		}
+		if best == nil {
+			break
+		}
+		if best.alloc == nil {
+			acc := s.system.GetAccelerator(bestAlloc.Accelerator())
+			model := s.system.GetModel(best.server.ModelName())
+			best.alloc = bestAlloc
+			best.accType = acc.Type()
+			best.unitsPerRep = model.NumInstances(acc.Name()) * s.system.GetUnits(acc)
+		}
+		budget -= stepCost(best.alloc, best.numReplicas, best.step)
+		best.numReplicas += best.step
+		available[best.accType] -= best.step * best.unitsPerRep
+	}
+
+	// set allocations of servers
+	for _, e := range entries {
+		if e.alloc == nil || e.numReplicas == 0 {
+			if e.overBudget {
+				e.server.SetUnallocatedError(core.ErrBudgetExhausted)
+			}
+			continue
+		}
+		alloc := e.alloc.Clone()
+		alloc.Rescale(e.numReplicas)
+		e.server.SetAllocation(alloc)
+	}
+}
+
+// Candidate allocation and marginal gain (weighted served req/min per accelerator unit) of adding one step of replicas
+// within available accelerator units and remaining cost budget
+func (e *throughputEntry) nextReplica(system *core.System, available map[string]int, budget float32) (*core.Allocation, float32) {
+	if e.alloc != nil {
+		if e.numReplicas >= e.alloc.NumReplicas() || available[e.accType] < e.step*e.unitsPerRep {
+			return nil, 0
+		}
+		if stepCost(e.alloc, e.numReplicas, e.step) > budget {
+			e.overBudget = true
+			return nil, 0
+		}
+		return e.alloc, e.gain(e.alloc, e.unitsPerRep)
+	}
+	var bestAlloc *core.Allocation
+	bestGain := float32(0)
+	model := system.GetModel(e.server.ModelName())
+	if model == nil {
+		return nil, 0
+	}
+	for _, alloc := range e.candidates {
+		acc := system.GetAccelerator(alloc.Accelerator())
+		if acc == nil {
+			continue
+		}
+		unitsPerRep := model.NumInstances(acc.Name()) * system.GetUnits(acc)
+		if unitsPerRep <= 0 || available[acc.Type()] < e.step*unitsPerRep {
+			continue
+		}
+		if stepCost(alloc, 0, e.step) > budget {
+			e.overBudget = true
+			continue
+		}
+		if gain := e.gain(alloc, unitsPerRep); gain > bestGain {
+			bestAlloc, bestGain = alloc, gain
+		}
+	}
+	return bestAlloc, bestGain
+}
+
+// Weighted increase in served throughput per accelerator unit of adding one step of replicas of an allocation
+func (e *throughputEntry) gain(alloc *core.Allocation, unitsPerRep int) float32 {
+	served := float32(e.numReplicas) * alloc.MaxRPM()
+	increment := min(float32(e.step)*alloc.MaxRPM(), e.demand-served)
+	if increment <= 0 {
+		return 0
+	}
+	return e.weight * increment / float32(e.step*unitsPerRep)
+}
+
+// Marginal cost of adding a step of replicas to a number of replicas of an allocation
+//   - replicas after the first cost less than the first if they share model weights
+func stepCost(alloc *core.Allocation, numReplicas int, step int) float32 {
+	return alloc.CostOfReplicas(numReplicas+step) - alloc.CostOfReplicas(numReplicas)
+}
+
+// Weight of a service class priority (smaller priority values have larger weights)
+func priorityWeight(priority int) float32 {
+	return 1 / float32(max(priority, 1))
+}
@@ -0,0 +1,66 @@
+package solver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/llm-inferno/optimizer/pkg/config"
+	"github.com/llm-inferno/optimizer/pkg/core"
+	"github.com/llm-inferno/optimizer/pkg/testutil"
+)
+
+// Given capacity too tight to serve all loads, maximizing throughput serves more total load than minimizing cost,
+// which only allocates servers whose load can be fully served
+func TestMaxThroughputServesMoreThanCostMin(t *testing.T) {
+	solve := func(optimizerSpec *config.OptimizerSpec) float32 {
+		spec := testutil.SystemSpec()
+		spec.Servers.Spec = append(spec.Servers.Spec,
+			testutil.ServerSpec("a", "Premium", 600), testutil.ServerSpec("b", "Premium", 600))
+		// each server needs 5 replicas on G2 to serve its load
+		spec.Capacity.Count = []config.AcceleratorCount{{Type: "G2", Count: 7}}
+		system := testutil.SetFromSpec(t, core.NewSystem(), spec)
+		system.Calculate()
+		if err := NewSolver(system, optimizerSpec).Solve(context.Background()); err != nil {
+			t.Fatalf("solve: %v", err)
+		}
+
+		served := float32(0)
+		for _, server := range system.GetServers() {
+			if alloc := server.Allocation(); alloc != nil {
+				served += min(float32(alloc.NumReplicas())*alloc.MaxRPM(), server.Load().ArrivalRate)
+			}
+		}
+		return served
+	}
+
+	costMin := solve(&config.OptimizerSpec{})
+	throughput := solve(&config.OptimizerSpec{MaxThroughput: true})
+	if costMin <= 0 || throughput <= costMin {
+		t.Errorf("served req/min: maxThroughput=%v, cost-min=%v, want maxThroughput more", throughput, costMin)
+	}
+}
+
+// Under a cost budget, replicas sharing model weights are charged their marginal cost, the first replica costing
+// more than the others, so that the total cost of allocations stays within the budget
+func TestMaxTotalCostChargesMarginalCostOfSharedWeights(t *testing.T) {
+	spec := testutil.SystemSpec()
+	for i := range spec.Models.PerfData {
+		spec.Models.PerfData[i].SharedWeights = true
+		spec.Models.PerfData[i].SharedCost = 0.5
+	}
+	server := testutil.ServerSpec("shared", "Premium", 3000)
+	server.AllowedAccelerators = []string{"G2"}
+	spec.Servers.Spec = append(spec.Servers.Spec, server)
+	spec.Capacity.Count = []config.AcceleratorCount{{Type: "G2", Count: 20}}
+	system := newTestSystem(t, spec)
+	solveTestSystem(t, system, &config.OptimizerSpec{MaxTotalCost: 40})
+
+	// G2 costs 25 for the first replica and 12.5 for each other replica: two replicas (37.5) fit the budget
+	alloc := system.GetServer("shared").Allocation()
+	if alloc == nil {
+		t.Fatal("server not allocated")
+	}
+	if alloc.NumReplicas() != 2 || alloc.Cost() > 40 {
+		t.Errorf("replicas=%d, cost=%v, want 2 replicas within budget=40", alloc.NumReplicas(), alloc.Cost())
+	}
+}
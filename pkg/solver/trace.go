@@ -0,0 +1,56 @@
+package solver
+
+import (
+	"github.com/llm-inferno/optimizer/pkg/config"
+	"github.com/llm-inferno/optimizer/pkg/core"
+)
+
+// phases of the greedy algorithm
+const (
+	greedyAllocate   = "allocate"
+	greedyMixed      = "mixed"
+	greedyBestEffort = "best-effort"
+)
+
+// actions of decisions of the greedy algorithm
+const (
+	decisionAllocated   = "allocated"
+	decisionSkipped     = "skipped"
+	decisionUnallocated = "unallocated"
+)
+
+// decision of the greedy algorithm about a candidate allocation of a server, given the units it uses,
+// with the units available to the server before taking them
+//   - the accelerator and units are those of the first accelerator of a mixed allocation
+func (s *Solver) decision(phase string, server *core.Server, index int, alloc *core.Allocation, units int,
+	available *capacityPool) config.GreedyDecision {
+	d := config.GreedyDecision{
+		Phase:       phase,
+		Server:      server.Name(),
+		Index:       index,
+		Accelerator: alloc.Accelerator(),
+		NumReplicas: alloc.NumReplicas(),
+		Units:       units,
+		Power:       alloc.Power(),
+		Value:       alloc.Value(),
+	}
+	if acc := s.system.GetAccelerator(alloc.Accelerator()); acc != nil {
+		d.Type = acc.Type()
+		d.AvailableUnits = available.units(server, d.Type)
+	}
+	return d
+}
+
+// record a decision of the greedy algorithm, if debugging
+func (s *Solver) record(d config.GreedyDecision) {
+	if !s.optimizerSpec.Debug {
+		return
+	}
+	d.Step = len(s.trace) + 1
+	s.trace = append(s.trace, d)
+}
+
+// Decisions of the greedy algorithm in the last solve, in order (nil if not debugging)
+func (s *Solver) Trace() []config.GreedyDecision {
+	return s.trace
+}
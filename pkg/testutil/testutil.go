@@ -0,0 +1,72 @@
+// Package testutil provides the data of a test system, shared by the tests of the optimizer packages.
+package testutil
+
+import (
+	"testing"
+
+	"github.com/llm-inferno/optimizer/pkg/config"
+)
+
+// spec of a test system: accelerators A100 and G2 (one unit each), model granite_13b measured on both,
+// service class Premium with targets of the model, and no servers or capacity
+func SystemSpec() *config.SystemSpec {
+	return &config.SystemSpec{
+		Accelerators: config.AcceleratorData{Spec: []config.AcceleratorSpec{
+			{Name: "A100", Type: "A100", Multiplicity: 1, Cost: 40,
+				Power: config.PowerSpec{Idle: 150, Full: 400, MidPower: 320, MidUtil: 0.6}},
+			{Name: "G2", Type: "G2", Multiplicity: 1, Cost: 25,
+				Power: config.PowerSpec{Idle: 180, Full: 600, MidPower: 500, MidUtil: 0.6}},
+		}},
+		Models: config.ModelData{PerfData: []config.ModelAcceleratorPerfData{
+			{Name: "granite_13b", Acc: "A100", AccCount: 1, MaxBatchSize: 32, AtTokens: 512,
+				DecodeParms:  config.DecodeParms{Alpha: 20.58, Beta: 0.41},
+				PrefillParms: config.PrefillParms{Gamma: 200, Delta: 0.021}},
+			{Name: "granite_13b", Acc: "G2", AccCount: 1, MaxBatchSize: 38, AtTokens: 512,
+				DecodeParms:  config.DecodeParms{Alpha: 17.15, Beta: 0.34},
+				PrefillParms: config.PrefillParms{Gamma: 170, Delta: 0.017}},
+		}},
+		ServiceClasses: config.ServiceClassData{Spec: []config.ServiceClassSpec{
+			ServiceClassSpec("Premium", 1),
+		}},
+	}
+}
+
+// test service class with a priority and targets of granite_13b
+func ServiceClassSpec(name string, priority int) config.ServiceClassSpec {
+	return config.ServiceClassSpec{
+		Name:         name,
+		Priority:     priority,
+		ModelTargets: []config.ModelTarget{{Model: "granite_13b", SLO_ITL: 40, SLO_TTFT: 500}},
+	}
+}
+
+// test server of granite_13b in a service class, with a load
+func ServerSpec(name string, class string, arrivalRate float32) config.ServerSpec {
+	return config.ServerSpec{
+		Name:  name,
+		Class: class,
+		Model: "granite_13b",
+		CurrentAlloc: config.AllocationData{
+			Load: config.ServerLoadSpec{ArrivalRate: arrivalRate, AvgInTokens: 128, AvgOutTokens: 512},
+		},
+	}
+}
+
+// capacity of a count of each accelerator type of the test system
+func Capacity(count int) config.CapacityData {
+	return config.CapacityData{Count: []config.AcceleratorCount{{Type: "A100", Count: count}, {Type: "G2", Count: count}}}
+}
+
+// A system set from a spec
+type System interface {
+	SetFromSpec(d *config.SystemSpec) (*config.OptimizerSpec, error)
+}
+
+// set a system from a spec, returning the system, failing the test if not valid
+func SetFromSpec[S System](t testing.TB, system S, spec *config.SystemSpec) S {
+	t.Helper()
+	if _, err := system.SetFromSpec(spec); err != nil {
+		t.Fatalf("cannot set system from spec: %v", err)
+	}
+	return system
+}
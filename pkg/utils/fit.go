@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Result of a linear least squares fit y = Intercept + Slope * x
+type LinearFitResult struct {
+	Intercept float32 // value at x = 0
+	Slope     float32 // increase of y per unit of x
+	RSquared  float32 // coefficient of determination: fraction of the variance of y explained by the fit (one if exact)
+}
+
+// Fit a line to points (xs[i], ys[i]) by ordinary least squares
+//   - an error is returned if there are not as many values of x as of y, or fewer than two distinct values of x
+//   - the coefficient of determination is one if all values of y are the same and fitted exactly
+func LinearFit(xs []float32, ys []float32) (*LinearFitResult, error) {
+	if len(xs) != len(ys) {
+		return nil, fmt.Errorf("%d values of x and %d values of y", len(xs), len(ys))
+	}
+	if len(xs) < 2 {
+		return nil, errors.New("fewer than two distinct values of x")
+	}
+	n := float64(len(xs))
+	var sumX, sumY float64
+	for i := range xs {
+		sumX += float64(xs[i])
+		sumY += float64(ys[i])
+	}
+	meanX, meanY := sumX/n, sumY/n
+	var sxx, sxy, syy float64
+	for i := range xs {
+		dx, dy := float64(xs[i])-meanX, float64(ys[i])-meanY
+		sxx += dx * dx
+		sxy += dx * dy
+		syy += dy * dy
+	}
+	if sxx == 0 {
+		return nil, errors.New("fewer than two distinct values of x")
+	}
+	slope := sxy / sxx
+	intercept := meanY - slope*meanX
+
+	// residual sum of squares
+	var ssr float64
+	for i := range xs {
+		r := float64(ys[i]) - (intercept + slope*float64(xs[i]))
+		ssr += r * r
+	}
+	rSquared := 1.0
+	if syy > 0 {
+		rSquared = 1 - ssr/syy
+	}
+	return &LinearFitResult{Intercept: float32(intercept), Slope: float32(slope), RSquared: float32(rSquared)}, nil
+}
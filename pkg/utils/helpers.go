@@ -1,6 +1,11 @@
 package utils
 
-import "encoding/json"
+import (
+	"bytes"
+	"encoding/json"
+
+	"gopkg.in/yaml.v3"
+)
 
 // unmarshal a byte array to its corresponding object
 func FromDataToSpec[T interface{}](byteValue []byte, t T) (*T, error) {
@@ -10,3 +15,35 @@ func FromDataToSpec[T interface{}](byteValue []byte, t T) (*T, error) {
 	}
 	return &d, nil
 }
+
+// unmarshal a YAML byte array to its corresponding object
+func FromYAMLToSpec[T interface{}](byteValue []byte, t T) (*T, error) {
+	var d T
+	if err := yaml.Unmarshal(byteValue, &d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// unmarshal a JSON or YAML byte array to its corresponding object, detecting the format
+func FromBytes[T interface{}](byteValue []byte, t T) (*T, error) {
+	if IsJSON(byteValue) {
+		return FromDataToSpec(byteValue, t)
+	}
+	return FromYAMLToSpec(byteValue, t)
+}
+
+// check if a byte array looks like a JSON document (an object or an array)
+func IsJSON(byteValue []byte) bool {
+	trimmed := bytes.TrimSpace(byteValue)
+	return len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[')
+}
+
+// unmarshal a JSON or YAML byte array onto an existing object, detecting the format
+//   - fields not present in the byte array keep their values (merge patch), arrays are replaced as a whole
+func OverlayBytes(byteValue []byte, obj any) error {
+	if IsJSON(byteValue) {
+		return json.Unmarshal(byteValue, obj)
+	}
+	return yaml.Unmarshal(byteValue, obj)
+}
@@ -0,0 +1,158 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+)
+
+// Options of a binary search
+type SearchOptions struct {
+	AbsTolerance  float32 // absolute tolerance of the function value from the target (not positive if none)
+	RelTolerance  float32 // tolerance of the function value from the target, relative to the target (not positive if none)
+	MaxIterations int     // maximum number of iterations (bisections) of the search
+	Samples       int     // number of points sampled inside the range to check that the function is monotonic
+}
+
+// Result of a binary search
+type SearchResult struct {
+	X          float32 // value found in range
+	Indicator  int     // whether the target is below (-1), within (0), or above (+1) the bounded region
+	Residual   float32 // f(X) - yTarget
+	Converged  bool    // f(X) is within tolerance of the target
+	Iterations int     // number of iterations (bisections) performed
+	XBelow     float32 // last evaluated value in range with f(XBelow) <= yTarget (X if none)
+	Monotonic  bool    // samples of the function over the range are monotonic
+}
+
+// check if a function value is within tolerance of a target value
+func (o *SearchOptions) within(y, yTarget float32) bool {
+	diff := math.Abs(float64(y - yTarget))
+	if diff == 0 {
+		return true
+	}
+	if o.AbsTolerance > 0 && diff <= float64(o.AbsTolerance) {
+		return true
+	}
+	return o.RelTolerance > 0 && yTarget != 0 && diff <= float64(o.RelTolerance)*math.Abs(float64(yTarget))
+}
+
+// Binary search: find xStar in a range [xMin, xMax] such that f(xStar)=yTarget, within tolerance.
+// Function f() is expected to be monotonically increasing or decreasing over the range.
+//   - the result indicates whether the target is below (-1), within (0), or above (+1) the bounded region
+//   - the residual and convergence of the result allow callers to detect a search which ran out of iterations
+//   - the function is sampled at points evenly spaced over the range, in addition to its boundaries;
+//     if the samples are not monotonic, the search falls back to a scan of the samples for the first interval
+//     crossing the target, searching within it, and the result is marked as not monotonic
+//   - an error is returned if the function cannot be evaluated
+func BinarySearch(xMin float32, xMax float32, yTarget float32,
+	eval func(float32) (float32, error), opts *SearchOptions) (*SearchResult, error) {
+
+	if xMin > xMax {
+		return nil, fmt.Errorf("invalid range [%v, %v]", xMin, xMax)
+	}
+
+	// sample the function over the range, including its boundaries
+	numPoints := max(opts.Samples, 0) + 2
+	xs := make([]float32, numPoints)
+	ys := make([]float32, numPoints)
+	for i := range numPoints {
+		xs[i] = xMin + (xMax-xMin)*float32(i)/float32(numPoints-1)
+		y, err := eval(xs[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid function evaluation at %v: %w", xs[i], err)
+		}
+		ys[i] = y
+	}
+	last := numPoints - 1
+	for _, i := range []int{0, last} {
+		if opts.within(ys[i], yTarget) {
+			result := &SearchResult{X: xs[i], Residual: ys[i] - yTarget, Converged: true, XBelow: xs[i], Monotonic: true}
+			if other := last - i; result.Residual > 0 && ys[other] <= yTarget {
+				result.XBelow = xs[other]
+			}
+			return result, nil
+		}
+	}
+
+	increasing := ys[0] < ys[last]
+	monotonic := isMonotonic(ys, increasing)
+	lo, hi := 0, last
+	if !monotonic {
+		// coarse scan: first interval of samples where the function crosses the target
+		lo = -1
+		for i := range last {
+			if (ys[i]-yTarget)*(ys[i+1]-yTarget) <= 0 {
+				lo, hi = i, i+1
+				break
+			}
+		}
+		if lo < 0 {
+			// no crossing: all samples are on the same side of the target
+			if (ys[0] > yTarget) == increasing {
+				return &SearchResult{X: xMin, Indicator: -1, Residual: ys[0] - yTarget, XBelow: xMin}, nil
+			}
+			return &SearchResult{X: xMax, Indicator: +1, Residual: ys[last] - yTarget, XBelow: xMax}, nil
+		}
+	} else {
+		if increasing && yTarget < ys[0] || !increasing && yTarget > ys[0] {
+			// target is below the bounded region
+			return &SearchResult{X: xMin, Indicator: -1, Residual: ys[0] - yTarget, XBelow: xMin, Monotonic: true}, nil
+		}
+		if increasing && yTarget > ys[last] || !increasing && yTarget < ys[last] {
+			// target is above the bounded region
+			return &SearchResult{X: xMax, Indicator: +1, Residual: ys[last] - yTarget, XBelow: xMax, Monotonic: true}, nil
+		}
+	}
+	result, err := bisect(xs[lo], xs[hi], ys[lo], ys[hi], yTarget, eval, opts)
+	if err != nil {
+		return nil, err
+	}
+	result.Monotonic = monotonic
+	return result, nil
+}
+
+// check if sampled function values are monotonic in the given direction
+func isMonotonic(ys []float32, increasing bool) bool {
+	for i := 1; i < len(ys); i++ {
+		if increasing && ys[i] < ys[i-1] || !increasing && ys[i] > ys[i-1] {
+			return false
+		}
+	}
+	return true
+}
+
+// bisect a bracket [xLo, xHi], with function values yLo and yHi on both sides of the target,
+// keeping the end of the bracket where the function is below the target
+func bisect(xLo, xHi, yLo, yHi, yTarget float32, eval func(float32) (float32, error),
+	opts *SearchOptions) (*SearchResult, error) {
+
+	increasing := yLo < yHi
+	result := &SearchResult{}
+	if yLo <= yTarget {
+		result.XBelow = xLo
+	} else {
+		result.XBelow = xHi
+	}
+	for result.Iterations < max(opts.MaxIterations, 1) {
+		result.Iterations++
+		result.X = 0.5 * (xLo + xHi)
+		yStar, err := eval(result.X)
+		if err != nil {
+			return nil, fmt.Errorf("invalid function evaluation at %v: %w", result.X, err)
+		}
+		result.Residual = yStar - yTarget
+		if yStar <= yTarget {
+			result.XBelow = result.X
+		}
+		if opts.within(yStar, yTarget) {
+			result.Converged = true
+			break
+		}
+		if increasing && yTarget < yStar || !increasing && yTarget > yStar {
+			xHi = result.X
+		} else {
+			xLo = result.X
+		}
+	}
+	return result, nil
+}
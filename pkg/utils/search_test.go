@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"math"
+	"testing"
+)
+
+// The result of a binary search reports where the target is, how close the value found is to the target, and
+// whether the samples of the function are monotonic, falling back to a scan of the samples if they are not
+func TestBinarySearch(t *testing.T) {
+	defaults := SearchOptions{RelTolerance: 1e-6, MaxIterations: 100, Samples: 4}
+	tests := []struct {
+		name          string
+		f             func(float32) float32
+		xMin, xMax    float32
+		target        float32
+		opts          SearchOptions
+		wantIndicator int
+		wantConverged bool
+		wantMonotonic bool
+		wantX         float32 // expected value found, within tolerance
+		wantXBelow    float32 // expected last value below the target, within tolerance
+		tolerance     float32
+	}{
+		{
+			name: "increasing", f: func(x float32) float32 { return x * x }, xMax: 10, target: 30, opts: defaults,
+			wantConverged: true, wantMonotonic: true,
+			wantX: float32(math.Sqrt(30)), wantXBelow: float32(math.Sqrt(30)), tolerance: 1e-3,
+		},
+		{
+			name: "decreasing", f: func(x float32) float32 { return 100 - x*x }, xMax: 10, target: 30, opts: defaults,
+			wantConverged: true, wantMonotonic: true,
+			wantX: float32(math.Sqrt(70)), wantXBelow: float32(math.Sqrt(70)), tolerance: 1e-3,
+		},
+		{
+			name: "target below range", f: func(x float32) float32 { return x }, xMin: 1, xMax: 10, target: 0.5,
+			opts: defaults, wantIndicator: -1, wantMonotonic: true, wantX: 1, wantXBelow: 1,
+		},
+		{
+			name: "target above range", f: func(x float32) float32 { return x }, xMin: 1, xMax: 10, target: 20,
+			opts: defaults, wantIndicator: +1, wantMonotonic: true, wantX: 10, wantXBelow: 10,
+		},
+		{
+			// samples 16, 4, 0, 4, 16, 36 at 0, 2, ..., 10: the first crossing of 9 is in [0, 2], at 1, while a
+			// search of the whole range would find 7
+			name: "non-monotonic", f: func(x float32) float32 { return (x - 4) * (x - 4) }, xMax: 10, target: 9,
+			opts: defaults, wantConverged: true, wantX: 1, wantXBelow: 1, tolerance: 1e-3,
+		},
+		{
+			// bisections at 0.5, 0.25, 0.375 without reaching 1/3 exactly
+			name: "out of iterations", f: func(x float32) float32 { return x }, xMax: 1, target: 1.0 / 3,
+			opts:          SearchOptions{MaxIterations: 3, Samples: 0},
+			wantMonotonic: true, wantX: 0.375, wantXBelow: 0.25,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eval := func(x float32) (float32, error) { return tt.f(x), nil }
+			result, err := BinarySearch(tt.xMin, tt.xMax, tt.target, eval, &tt.opts)
+			if err != nil {
+				t.Fatalf("search: %v", err)
+			}
+			if result.Indicator != tt.wantIndicator || result.Converged != tt.wantConverged ||
+				result.Monotonic != tt.wantMonotonic {
+				t.Errorf("indicator=%d, converged=%v, monotonic=%v, want indicator=%d, converged=%v, monotonic=%v",
+					result.Indicator, result.Converged, result.Monotonic,
+					tt.wantIndicator, tt.wantConverged, tt.wantMonotonic)
+			}
+			if math.Abs(float64(result.X-tt.wantX)) > float64(tt.tolerance) {
+				t.Errorf("x=%v, want %v", result.X, tt.wantX)
+			}
+			if math.Abs(float64(result.XBelow-tt.wantXBelow)) > float64(tt.tolerance) {
+				t.Errorf("x below=%v, want %v", result.XBelow, tt.wantXBelow)
+			}
+			if residual := tt.f(result.X) - tt.target; result.Residual != residual {
+				t.Errorf("residual=%v, want %v", result.Residual, residual)
+			}
+			if result.Indicator == 0 && tt.f(result.XBelow) > tt.target {
+				t.Errorf("f(x below)=%v above the target %v", tt.f(result.XBelow), tt.target)
+			}
+			if !tt.wantConverged && result.Indicator == 0 && result.Iterations != tt.opts.MaxIterations {
+				t.Errorf("iterations=%d, want %d", result.Iterations, tt.opts.MaxIterations)
+			}
+		})
+	}
+}
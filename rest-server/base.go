@@ -1,14 +1,40 @@
 package rest
 
 import (
+	"fmt"
 	"os"
+	"sync"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/llm-inferno/optimizer/pkg/core"
 )
 
-// global pointer to system
-var system *core.System
+// global pointer to system, guarded as it may be replaced (stateless mode)
+var (
+	currentSystem *core.System
+	systemMutex   sync.RWMutex
+)
+
+// serializes optimizations of the current system (systems of batch optimizations are independent)
+var optimizeMutex sync.Mutex
+
+// max time of an optimization, bounding the context of the request
+var maxOptimizationTime = DefaultMaxOptimizationTime
+
+// get the current system
+func getSystem() *core.System {
+	systemMutex.RLock()
+	defer systemMutex.RUnlock()
+	return currentSystem
+}
+
+// replace the current system
+func setSystem(system *core.System) {
+	systemMutex.Lock()
+	defer systemMutex.Unlock()
+	currentSystem = system
+}
 
 // Base REST server
 type BaseServer struct {
@@ -16,15 +42,17 @@ type BaseServer struct {
 }
 
 func NewBaseServer() *BaseServer {
-	return &BaseServer{
+	server := &BaseServer{
 		router: gin.Default(),
 	}
+	server.router.GET("/openapi", server.getOpenAPI)
+	return server
 }
 
 // start server
 func (server *BaseServer) Run() {
 	// instantiate a clean system
-	system = core.NewSystem()
+	setSystem(core.NewSystem())
 
 	host := ""
 	port := "8080"
@@ -34,5 +62,12 @@ func (server *BaseServer) Run() {
 	if p := os.Getenv(RestPortEnvName); p != "" {
 		port = p
 	}
+	if t := os.Getenv(MaxOptimizationTimeEnvName); t != "" {
+		if d, err := time.ParseDuration(t); err == nil && d > 0 {
+			maxOptimizationTime = d
+		} else {
+			fmt.Printf("warning: invalid %s=%s, using %v \n", MaxOptimizationTimeEnvName, t, maxOptimizationTime)
+		}
+	}
 	server.router.Run(host + ":" + port)
 }
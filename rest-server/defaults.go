@@ -1,5 +1,7 @@
 package rest
 
+import "time"
+
 /**
  * Environment variables
  */
@@ -8,9 +10,30 @@ package rest
 const RestHostEnvName = "INFERNO_HOST"
 const RestPortEnvName = "INFERNO_PORT"
 
+// max time of an optimization, as a duration string (e.g. "30s")
+const MaxOptimizationTimeEnvName = "INFERNO_MAX_OPTIMIZATION_TIME"
+
 /**
  * Parameters
  */
 
 // argument for statefull
 const DefaultStatefull = "-F"
+
+// default max time of an optimization, after which a partial solution is returned
+const DefaultMaxOptimizationTime = 60 * time.Second
+
+// quiet time after the last load update before re-optimizing a streamed system
+const StreamDebounce = 500 * time.Millisecond
+
+// header of the idempotency key of an apply request, replaying the result of a prior request with the same key
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// header marking a response replayed for a prior request with the same idempotency key
+const IdempotentReplayedHeader = "Idempotent-Replayed"
+
+// max number of idempotency keys remembered (the oldest is forgotten when full)
+const MaxIdempotencyKeys = 1000
+
+// max number of solutions kept in the history in memory (the oldest is forgotten when full)
+const MaxHistoryEntries = 256
@@ -1,22 +1,65 @@
 package rest
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
+	"sync"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/llm-inferno/optimizer/pkg/config"
 	"github.com/llm-inferno/optimizer/pkg/core"
 	"github.com/llm-inferno/optimizer/pkg/manager"
+	"github.com/llm-inferno/optimizer/pkg/metrics"
 	"github.com/llm-inferno/optimizer/pkg/solver"
+	"github.com/llm-inferno/optimizer/pkg/utils"
 )
 
 // Handlers for REST API calls
 
+// data of a request which validates itself
+type validator interface {
+	Validate() error
+}
+
+// bind the body of a request to an object, as YAML if the content type is YAML, JSON otherwise, and validate it
+//   - the object is validated if it has a Validate method, followed by additional checks, if any
+//   - on failure, the response is a bad request with the error message, and the error is returned
+func bindData(c *gin.Context, obj any, checks ...func() error) error {
+	var err error
+	switch c.ContentType() {
+	case "application/yaml", "application/x-yaml", "text/yaml":
+		err = c.ShouldBindYAML(obj)
+	default:
+		err = c.ShouldBindJSON(obj)
+	}
+	if err != nil {
+		err = fmt.Errorf("invalid request body: %w", err)
+	} else if v, ok := obj.(validator); ok {
+		err = v.Validate()
+	}
+	for _, check := range checks {
+		if err != nil {
+			break
+		}
+		err = check()
+	}
+	if err != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return err
+	}
+	return nil
+}
+
 func setAccelerators(c *gin.Context) {
+	system := getSystem()
 	var acceleratorData config.AcceleratorData
-	if err := c.BindJSON(&acceleratorData); err != nil {
+	if err := bindData(c, &acceleratorData,
+		func() error { return system.CheckAcceleratorCurrencies(acceleratorData.Spec...) }); err != nil {
 		return
 	}
 	system.SetAcceleratorsFromSpec(&acceleratorData)
@@ -24,29 +67,24 @@ func setAccelerators(c *gin.Context) {
 }
 
 func getAccelerators(c *gin.Context) {
-	accMap := system.Accelerators()
-	gpus := make([]config.AcceleratorSpec, len(accMap))
-	i := 0
-	for _, acc := range accMap {
-		gpus[i] = *acc.Spec()
-		i++
-	}
-	c.IndentedJSON(http.StatusOK, gpus)
+	c.IndentedJSON(http.StatusOK, readSnapshot(c).Spec.Accelerators.Spec)
 }
 
 func getAccelerator(c *gin.Context) {
 	name := c.Param("name")
-	acc := system.Accelerator(name)
-	if acc == nil {
+	accelerators := readSnapshot(c).Spec.Accelerators.Spec
+	i := slices.IndexFunc(accelerators, func(acc config.AcceleratorSpec) bool { return acc.Name == name })
+	if i < 0 {
 		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "accelerator " + name + " not found"})
 		return
 	}
-	c.IndentedJSON(http.StatusOK, acc.Spec())
+	c.IndentedJSON(http.StatusOK, accelerators[i])
 }
 
 func addAccelerator(c *gin.Context) {
+	system := getSystem()
 	var acc config.AcceleratorSpec
-	if err := c.BindJSON(&acc); err != nil {
+	if err := bindData(c, &acc, func() error { return system.CheckAcceleratorCurrencies(acc) }); err != nil {
 		return
 	}
 	system.AddAcceleratorFromSpec(acc)
@@ -54,18 +92,29 @@ func addAccelerator(c *gin.Context) {
 }
 
 func removeAccelerator(c *gin.Context) {
+	system := getSystem()
 	name := c.Param("name")
 	acc := system.Accelerator(name)
 	if err := system.RemoveAccelerator(name); err != nil {
 		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "accelerator " + name + " not found"})
 		return
 	}
+	if _, exists := system.Capacity(acc.Type()); exists && system.CheckAcceleratorTypes(acc.Type()) != nil {
+		fmt.Printf("warning: capacity of accelerator type %s still set, with no accelerator of the type \n", acc.Type())
+	}
 	c.IndentedJSON(http.StatusOK, acc.Spec())
 }
 
 func setCapacities(c *gin.Context) {
+	system := getSystem()
 	var capacityData config.CapacityData
-	if err := c.BindJSON(&capacityData); err != nil {
+	if err := bindData(c, &capacityData, func() error {
+		types := make([]string, len(capacityData.Count))
+		for i, count := range capacityData.Count {
+			types[i] = count.Type
+		}
+		return system.CheckAcceleratorTypes(types...)
+	}); err != nil {
 		return
 	}
 	system.SetCapacityFromSpec(&capacityData)
@@ -73,37 +122,24 @@ func setCapacities(c *gin.Context) {
 }
 
 func getCapacities(c *gin.Context) {
-	capMap := system.Capacities()
-	capacities := make([]config.AcceleratorCount, len(capMap))
-	i := 0
-	for k, v := range capMap {
-		capacities[i] = config.AcceleratorCount{
-			Type:  k,
-			Count: v,
-		}
-		i++
-	}
-	c.IndentedJSON(http.StatusOK, config.CapacityData{
-		Count: capacities,
-	})
+	c.IndentedJSON(http.StatusOK, readSnapshot(c).Spec.Capacity)
 }
 
 func getCapacity(c *gin.Context) {
 	t := c.Param("type")
-	cap, exists := system.Capacity(t)
-	if !exists {
+	counts := readSnapshot(c).Spec.Capacity.Count
+	i := slices.IndexFunc(counts, func(count config.AcceleratorCount) bool { return count.Type == t })
+	if i < 0 {
 		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "capacity for " + t + " not found"})
 		return
 	}
-	c.IndentedJSON(http.StatusOK, config.AcceleratorCount{
-		Type:  t,
-		Count: cap,
-	})
+	c.IndentedJSON(http.StatusOK, counts[i])
 }
 
 func setCapacity(c *gin.Context) {
+	system := getSystem()
 	var count config.AcceleratorCount
-	if err := c.BindJSON(&count); err != nil {
+	if err := bindData(c, &count, func() error { return system.CheckAcceleratorTypes(count.Type) }); err != nil {
 		return
 	}
 	system.SetCountFromSpec(count)
@@ -111,6 +147,7 @@ func setCapacity(c *gin.Context) {
 }
 
 func removeCapacity(c *gin.Context) {
+	system := getSystem()
 	t := c.Param("type")
 	cap, _ := system.Capacity(t)
 	if !system.RemoveCapacity(t) {
@@ -124,42 +161,47 @@ func removeCapacity(c *gin.Context) {
 }
 
 func setModels(c *gin.Context) {
+	system := getSystem()
 	var modelData config.ModelData
-	if err := c.BindJSON(&modelData); err != nil {
+	if err := bindData(c, &modelData); err != nil {
+		return
+	}
+	if err := system.SetModelsFromSpec(&modelData); err != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
 		return
 	}
-	system.SetModelsFromSpec(&modelData)
 	c.IndentedJSON(http.StatusOK, modelData)
 }
 
 func getModels(c *gin.Context) {
-	modelMap := system.Models()
-	modelNames := make([]string, len(modelMap))
-	i := 0
-	for _, model := range modelMap {
-		modelNames[i] = model.Name()
-		i++
-	}
-	c.IndentedJSON(http.StatusOK, modelNames)
+	c.IndentedJSON(http.StatusOK, readSnapshot(c).Models)
 }
 
 func getModel(c *gin.Context) {
 	name := c.Param("name")
-	model := system.Model(name)
-	if model == nil {
+	snapshot := readSnapshot(c)
+	if !slices.Contains(snapshot.Models, name) {
 		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "model " + name + " not found"})
 		return
 	}
-	c.IndentedJSON(http.StatusOK, model.Spec())
+	modelData := config.ModelData{PerfData: make([]config.ModelAcceleratorPerfData, 0)}
+	for _, perfData := range snapshot.Spec.Models.PerfData {
+		if perfData.Name == name {
+			modelData.PerfData = append(modelData.PerfData, perfData)
+		}
+	}
+	c.IndentedJSON(http.StatusOK, modelData)
 }
 
 func addModel(c *gin.Context) {
+	system := getSystem()
 	name := c.Param("name")
 	system.AddModel(name)
 	c.IndentedJSON(http.StatusOK, name)
 }
 
 func removeModel(c *gin.Context) {
+	system := getSystem()
 	name := c.Param("name")
 	if err := system.RemoveModel(name); err != nil {
 		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "model " + name + " not found"})
@@ -169,8 +211,9 @@ func removeModel(c *gin.Context) {
 }
 
 func setServiceClasses(c *gin.Context) {
+	system := getSystem()
 	var serviceClassData config.ServiceClassData
-	if err := c.BindJSON(&serviceClassData); err != nil {
+	if err := bindData(c, &serviceClassData); err != nil {
 		return
 	}
 	system.SetServiceClassesFromSpec(&serviceClassData)
@@ -178,27 +221,22 @@ func setServiceClasses(c *gin.Context) {
 }
 
 func getServiceClasses(c *gin.Context) {
-	svcMap := system.ServiceClasses()
-	svcs := &config.ServiceClassData{
-		Spec: []config.ServiceClassSpec{},
-	}
-	for _, svc := range svcMap {
-		svcs.Spec = append(svcs.Spec, svc.Spec())
-	}
-	c.IndentedJSON(http.StatusOK, svcs)
+	c.IndentedJSON(http.StatusOK, readSnapshot(c).Spec.ServiceClasses)
 }
 
 func getServiceClass(c *gin.Context) {
 	name := c.Param("name")
-	svc := system.ServiceClass(name)
-	if svc == nil {
+	svcs := readSnapshot(c).Spec.ServiceClasses.Spec
+	i := slices.IndexFunc(svcs, func(svc config.ServiceClassSpec) bool { return svc.Name == name })
+	if i < 0 {
 		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "service class " + name + " not found"})
 		return
 	}
-	c.IndentedJSON(http.StatusOK, svc.Spec())
+	c.IndentedJSON(http.StatusOK, svcs[i])
 }
 
 func addServiceClass(c *gin.Context) {
+	system := getSystem()
 	name := c.Param("name")
 	priority := config.DefaultServiceClassPriority
 	if prioStr := c.Param("priority"); prioStr != "" {
@@ -209,24 +247,24 @@ func addServiceClass(c *gin.Context) {
 			priority = prioInt
 		}
 	}
-	system.AddServiceClass(name, priority)
-	svc := system.ServiceClass(name)
-	c.IndentedJSON(http.StatusOK, svc.Spec())
+	c.IndentedJSON(http.StatusOK, system.AddServiceClass(name, priority))
 }
 
 func removeServiceClass(c *gin.Context) {
+	system := getSystem()
 	name := c.Param("name")
-	svc := system.ServiceClass(name)
-	if err := system.RemoveServiceClass(name); err != nil {
+	spec, err := system.RemoveServiceClass(name)
+	if err != nil {
 		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "service class " + name + " not found"})
 		return
 	}
-	c.IndentedJSON(http.StatusOK, svc.Spec())
+	c.IndentedJSON(http.StatusOK, spec)
 }
 
 func addServiceClassModelTargets(c *gin.Context) {
+	system := getSystem()
 	var svcSpec config.ServiceClassSpec
-	if err := c.BindJSON(&svcSpec); err != nil {
+	if err := bindData(c, &svcSpec); err != nil {
 		return
 	}
 	svcName := svcSpec.Name
@@ -235,7 +273,10 @@ func addServiceClassModelTargets(c *gin.Context) {
 		c.IndentedJSON(http.StatusNotFound, "service class "+svcName+" not found")
 		return
 	}
-	if !svc.UpdateModelTargets(&svcSpec) {
+	system.Lock()
+	updated := svc.UpdateModelTargets(&svcSpec)
+	system.Unlock()
+	if !updated {
 		c.IndentedJSON(http.StatusBadRequest, "inconsistent specs: svcName="+svcName+" ; svcPrio="+strconv.Itoa(svcSpec.Priority))
 		return
 	}
@@ -245,49 +286,73 @@ func addServiceClassModelTargets(c *gin.Context) {
 func getServiceClassModelTarget(c *gin.Context) {
 	name := c.Param("name")
 	model := c.Param("model")
-	svc := system.ServiceClass(name)
-	if svc == nil {
+	svcs := readSnapshot(c).Spec.ServiceClasses.Spec
+	i := slices.IndexFunc(svcs, func(svc config.ServiceClassSpec) bool { return svc.Name == name })
+	if i < 0 {
 		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "service class " + name + " not found"})
 		return
 	}
-	target := svc.ModelTarget(model)
-	if target == nil {
-		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "model " + model + " not found"})
+	targets := svcs[i].ModelTargets
+	if j := slices.IndexFunc(targets, func(target config.ModelTarget) bool { return target.Model == model }); j >= 0 {
+		c.IndentedJSON(http.StatusOK, targets[j])
 		return
 	}
-	c.IndentedJSON(http.StatusOK, config.ModelTarget{
-		Model:    model,
-		SLO_ITL:  target.ITL,
-		SLO_TTFT: target.TTFT,
-		SLO_TPS:  target.TPS,
-	})
+	if defaultTarget := svcs[i].DefaultTarget; defaultTarget != nil {
+		target := *defaultTarget
+		target.Model = model
+		c.IndentedJSON(http.StatusOK, target)
+		return
+	}
+	c.IndentedJSON(http.StatusNotFound, gin.H{"message": "model " + model + " not found"})
 }
 
-func removeServiceClassModelTarget(c *gin.Context) {
+// update fields of a model target of a service class present in the body of the request, keeping other fields
+func updateServiceClassModelTarget(c *gin.Context) {
+	system := getSystem()
 	name := c.Param("name")
 	model := c.Param("model")
-	svc := system.ServiceClass(name)
-	if svc == nil {
-		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "service class " + name + " not found"})
+	body, err := c.GetRawData()
+	if err != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
 		return
 	}
-	target := svc.ModelTarget(model)
-	if target == nil {
-		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "model " + model + " not found"})
+	spec, err := system.PatchServiceClassModelTarget(name, model, func(spec *config.ModelTarget) error {
+		if err := utils.OverlayBytes(body, spec); err != nil {
+			return err
+		}
+		return spec.Validate()
+	})
+	if err != nil {
+		c.IndentedJSON(patchErrorStatus(err), gin.H{"message": err.Error()})
 		return
 	}
-	svc.RemoveModelTarget(model)
-	c.IndentedJSON(http.StatusOK, config.ModelTarget{
-		Model:    model,
-		SLO_ITL:  target.ITL,
-		SLO_TTFT: target.TTFT,
-		SLO_TPS:  target.TPS,
-	})
+	c.IndentedJSON(http.StatusOK, spec)
+}
+
+// HTTP status of an error patching a spec
+func patchErrorStatus(err error) int {
+	if errors.Is(err, core.ErrNoServer) || errors.Is(err, core.ErrNoServiceClass) || errors.Is(err, core.ErrNoTarget) {
+		return http.StatusNotFound
+	}
+	return http.StatusBadRequest
+}
+
+func removeServiceClassModelTarget(c *gin.Context) {
+	system := getSystem()
+	name := c.Param("name")
+	model := c.Param("model")
+	target, err := system.RemoveServiceClassModelTarget(name, model)
+	if err != nil {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": err.Error()})
+		return
+	}
+	c.IndentedJSON(http.StatusOK, target)
 }
 
 func setServers(c *gin.Context) {
+	system := getSystem()
 	var serverData config.ServerData
-	if err := c.BindJSON(&serverData); err != nil {
+	if err := bindData(c, &serverData); err != nil {
 		return
 	}
 	system.SetServersFromSpec(&serverData)
@@ -295,39 +360,204 @@ func setServers(c *gin.Context) {
 }
 
 func getServers(c *gin.Context) {
-	srvMap := system.Servers()
-	servers := make([]config.ServerSpec, len(srvMap))
-	i := 0
-	for _, server := range srvMap {
-		servers[i] = *server.Spec()
-		i++
+	c.IndentedJSON(http.StatusOK, readSnapshot(c).Spec.Servers)
+}
+
+func getServer(c *gin.Context) {
+	name := c.Param("name")
+	servers := readSnapshot(c).Spec.Servers.Spec
+	i := slices.IndexFunc(servers, func(server config.ServerSpec) bool { return server.Name == name })
+	if i < 0 {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "server " + name + " not found"})
+		return
 	}
-	serverData := &config.ServerData{
-		Spec: servers,
+	c.IndentedJSON(http.StatusOK, servers[i])
+}
+
+// get the current load of a server
+func getServerLoad(c *gin.Context) {
+	system := getSystem()
+	name := c.Param("name")
+	load, err := system.ServerLoad(name)
+	if err != nil {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": err.Error()})
+		return
 	}
-	c.IndentedJSON(http.StatusOK, serverData)
+	c.IndentedJSON(http.StatusOK, load)
 }
 
-func getServer(c *gin.Context) {
+// set the current load of a server, returning the load
+func setServerLoad(c *gin.Context) {
+	system := getSystem()
 	name := c.Param("name")
-	server := system.Server(name)
-	if server == nil {
+	var load config.ServerLoadSpec
+	if err := bindData(c, &load); err != nil {
+		return
+	}
+	if err := system.SetServerLoad(name, load); err != nil {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": err.Error()})
+		return
+	}
+	c.IndentedJSON(http.StatusOK, load)
+}
+
+func getServerAllocations(c *gin.Context) {
+	system := getSystem()
+	name := c.Param("name")
+	allocs, exists := system.CandidateAllocations(name)
+	if !exists {
 		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "server " + name + " not found"})
 		return
 	}
-	c.IndentedJSON(http.StatusOK, server.Spec())
+	candidates := make([]config.CandidateAllocationData, len(allocs))
+	for i, alloc := range allocs {
+		candidates[i] = config.CandidateAllocationData{
+			AllocationData: *alloc.AllocationData(),
+			Value:          alloc.Value(),
+			MaxRPM:         alloc.MaxRPM(),
+		}
+	}
+	c.IndentedJSON(http.StatusOK, candidates)
+}
+
+// check the feasibility of a server, given its load and SLO targets, on a copy of the current system
+func checkFeasibility(c *gin.Context) {
+	system := getSystem()
+	var request config.FeasibilityRequest
+	if err := bindData(c, &request, func() error { return system.CheckServerReferences(&request.Server) }); err != nil {
+		return
+	}
+	result, err := system.CheckFeasibility(&request)
+	if err != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+	c.IndentedJSON(http.StatusOK, result)
+}
+
+func scaleServer(c *gin.Context) {
+	system := getSystem()
+	name := c.Param("name")
+	var load config.ServerLoadSpec
+	if err := bindData(c, &load); err != nil {
+		return
+	}
+	recommendation, err := system.RecommendScale(name, &load)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, core.ErrNoServer) || errors.Is(err, core.ErrNoAllocation) {
+			status = http.StatusNotFound
+		}
+		c.IndentedJSON(status, gin.H{"message": err.Error()})
+		return
+	}
+	c.IndentedJSON(http.StatusOK, recommendation)
+}
+
+func evaluateAllocation(c *gin.Context) {
+	system := getSystem()
+	name := c.Param("name")
+	var data config.AllocationData
+	if err := bindData(c, &data); err != nil {
+		return
+	}
+	evaluation, err := system.EvaluateAllocation(name, &data)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, core.ErrNoServer) {
+			status = http.StatusNotFound
+		}
+		c.IndentedJSON(status, gin.H{"message": err.Error()})
+		return
+	}
+	c.IndentedJSON(http.StatusOK, evaluation)
+}
+
+// validate the consistency of data of the system, e.g. capacity of accelerator types with no accelerator
+func validateSystem(c *gin.Context) {
+	errs := getSystem().Validate()
+	issues := make([]config.ConsistencyIssue, 0, len(errs))
+	for _, err := range errs {
+		issue := config.ConsistencyIssue{Reason: err.Error()}
+		var e *core.ConsistencyError
+		if errors.As(err, &e) {
+			issue = config.ConsistencyIssue{Kind: e.Kind, Name: e.Name, Reason: e.Err.Error()}
+		}
+		issues = append(issues, issue)
+	}
+	c.IndentedJSON(http.StatusOK, issues)
+}
+
+// sensitivity of the cost of a server, or all servers, to their SLO targets (no state is changed)
+func getSensitivity(c *gin.Context) {
+	system := getSystem()
+	var request config.SensitivityRequest
+	if err := bindData(c, &request); err != nil {
+		return
+	}
+	result, err := system.Sensitivity(&request)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, core.ErrNoServer) {
+			status = http.StatusNotFound
+		}
+		c.IndentedJSON(status, gin.H{"message": err.Error()})
+		return
+	}
+	c.IndentedJSON(http.StatusOK, result)
+}
+
+// size allocations of an accelerator to a server at each max batch size (no state is changed)
+func sweepBatchSize(c *gin.Context) {
+	system := getSystem()
+	name := c.Param("name")
+	acc := c.Param("acc")
+	points, err := system.SweepBatchSize(name, acc)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, core.ErrNoServer) || errors.Is(err, core.ErrNoAccelerator) {
+			status = http.StatusNotFound
+		}
+		c.IndentedJSON(status, gin.H{"message": err.Error()})
+		return
+	}
+	c.IndentedJSON(http.StatusOK, points)
 }
 
 func addServer(c *gin.Context) {
+	system := getSystem()
 	var server config.ServerSpec
-	if err := c.BindJSON(&server); err != nil {
+	if err := bindData(c, &server, func() error { return system.CheckServerReferences(&server) }); err != nil {
 		return
 	}
 	system.AddServerFromSpec(server)
 	c.IndentedJSON(http.StatusOK, server)
 }
 
+// update fields of a server present in the body of the request, keeping other fields
+func updateServer(c *gin.Context) {
+	system := getSystem()
+	name := c.Param("name")
+	body, err := c.GetRawData()
+	if err != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+	spec, err := system.PatchServer(name, func(spec *config.ServerSpec) error {
+		if err := utils.OverlayBytes(body, spec); err != nil {
+			return err
+		}
+		return spec.Validate()
+	})
+	if err != nil {
+		c.IndentedJSON(patchErrorStatus(err), gin.H{"message": err.Error()})
+		return
+	}
+	c.IndentedJSON(http.StatusOK, spec)
+}
+
 func removeServer(c *gin.Context) {
+	system := getSystem()
 	name := c.Param("name")
 	server := system.Server(name)
 	if err := system.RemoveServer(name); err != nil {
@@ -340,22 +570,29 @@ func removeServer(c *gin.Context) {
 func getModelAcceleratorPerf(c *gin.Context) {
 	name := c.Param("name")
 	acc := c.Param("acc")
-	model := system.Model(name)
-	if model == nil {
+	snapshot := readSnapshot(c)
+	if !slices.Contains(snapshot.Models, name) {
 		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "model " + name + " not found"})
 		return
 	}
-	perfData := model.PerfData(acc)
-	if perfData == nil {
+	perfData := snapshot.Spec.Models.PerfData
+	i := slices.IndexFunc(perfData, func(d config.ModelAcceleratorPerfData) bool { return d.Name == name && d.Acc == acc })
+	if i < 0 {
 		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "accelerator " + acc + " not found"})
 		return
 	}
-	c.IndentedJSON(http.StatusOK, perfData)
+	c.IndentedJSON(http.StatusOK, perfData[i])
 }
 
 func addModelAcceleratorPerf(c *gin.Context) {
+	system := getSystem()
 	var perfData config.ModelAcceleratorPerfData
-	if err := c.BindJSON(&perfData); err != nil {
+	if err := bindData(c, &perfData, func() error {
+		if system.Accelerator(perfData.Acc) == nil {
+			return fmt.Errorf("%w: %s", core.ErrNoAccelerator, perfData.Acc)
+		}
+		return nil
+	}); err != nil {
 		return
 	}
 	modelName := perfData.Name
@@ -364,67 +601,329 @@ func addModelAcceleratorPerf(c *gin.Context) {
 		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "model " + modelName + " not found"})
 		return
 	}
-	model.AddPerfDataFromSpec(&perfData)
+	system.Lock()
+	err := model.AddPerfDataFromSpec(&perfData)
+	system.Unlock()
+	if err != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
 	c.IndentedJSON(http.StatusOK, perfData)
 }
 
 func removeModelAcceleratorPerf(c *gin.Context) {
-	name := c.Param("name")
-	acc := c.Param("acc")
-	model := system.Model(name)
-	if model == nil {
-		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "model " + name + " not found"})
+	system := getSystem()
+	perfData, err := system.RemoveModelPerfData(c.Param("name"), c.Param("acc"))
+	if err != nil {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": err.Error()})
 		return
 	}
-	perfData := model.PerfData(acc)
-	if perfData == nil {
-		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "accelerator " + acc + " not found"})
-		return
-	}
-	model.RemovePerfData(acc)
 	c.IndentedJSON(http.StatusOK, perfData)
 }
 
 func optimize(c *gin.Context) {
+	system := getSystem()
 	var optimizerSpec config.OptimizerSpec
-	if err := c.BindJSON(&optimizerSpec); err != nil {
+	if err := bindData(c, &optimizerSpec); err != nil {
 		return
 	}
-	optimizer := solver.NewOptimizerFromSpec(&optimizerSpec)
-	manager := manager.NewManager(system, optimizer)
-	system.Calculate()
-	if err := manager.Optimize(); err != nil {
-		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "optimization error: " + err.Error()})
+	optimizeMutex.Lock()
+	defer optimizeMutex.Unlock()
+	startTime := time.Now()
+	hash := inputHash(system, &optimizerSpec)
+	solution, err := optimizeSystem(c.Request.Context(), system, &optimizerSpec)
+	if err != nil {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": err.Error()})
 		return
 	}
-	solution := system.GenerateSolution()
+	recordSolutionMetrics(system, solution, time.Since(startTime))
+	recordHistory(hash, &optimizerSpec, solution)
 	fmt.Println(system)
 	c.IndentedJSON(http.StatusOK, solution)
 }
 
 func optimizeOne(c *gin.Context) {
 	var systemData config.SystemData
-	if err := c.BindJSON(&systemData); err != nil {
+	if err := bindData(c, &systemData); err != nil {
 		return
 	}
+	optimizeMutex.Lock()
+	defer optimizeMutex.Unlock()
+	startTime := time.Now()
+
 	// start with fresh system
-	system = core.NewSystem()
-	optimizerSpec := system.SetFromSpec(&systemData.Spec)
-	optimizer := solver.NewOptimizerFromSpec(optimizerSpec)
-	manager := manager.NewManager(system, optimizer)
-	system.Calculate()
-	if err := manager.Optimize(); err != nil {
-		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "optimization error: " + err.Error()})
+	system := core.NewSystem()
+	optimizerSpec, err := system.SetFromSpec(&systemData.Spec)
+	if err != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
 		return
 	}
-	solution := system.GenerateSolution()
+	setSystem(system)
+	hash := inputHash(system, optimizerSpec)
+	solution, err := optimizeSystem(c.Request.Context(), system, optimizerSpec)
+	if err != nil {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": err.Error()})
+		return
+	}
+	recordSolutionMetrics(system, solution, time.Since(startTime))
+	recordHistory(hash, optimizerSpec, solution)
 	fmt.Println(system)
 	c.IndentedJSON(http.StatusOK, solution)
 }
 
+// optimize multiple independent systems, each given all its data, returning their results in order
+func optimizeBatch(c *gin.Context) {
+	var batchData []config.SystemData
+	if err := bindData(c, &batchData); err != nil {
+		return
+	}
+
+	// optimize systems concurrently, as they are independent
+	results := make([]config.OptimizationResult, len(batchData))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	numWorkers := min(max(config.MaxOptimizationWorkers, 1), len(batchData))
+	for w := 0; w < numWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = optimizeFromSpec(c.Request.Context(), &batchData[i].Spec)
+			}
+		}()
+	}
+	for i := range batchData {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	c.IndentedJSON(http.StatusOK, results)
+}
+
+// optimize a fresh system given all its data, returning the result
+func optimizeFromSpec(ctx context.Context, spec *config.SystemSpec) config.OptimizationResult {
+	startTime := time.Now()
+	system := core.NewSystem()
+	err := spec.Validate()
+	var optimizerSpec *config.OptimizerSpec
+	if err == nil {
+		optimizerSpec, err = system.SetFromSpec(spec)
+	}
+	var solution *config.AllocationSolution
+	if err == nil {
+		solution, err = optimizeSystem(ctx, system, optimizerSpec)
+	}
+	result := config.OptimizationResult{TimeMsec: time.Since(startTime).Milliseconds()}
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Solution = solution
+	// the metrics of the last solution are of the current system, not changed by independent systems
+	metrics.OptimizationDuration.Observe(time.Since(startTime).Seconds())
+	return result
+}
+
+// apply changes to the servers of the current system, then re-optimize it, using the current allocations
+// of servers as a warm start
+func optimizeDelta(c *gin.Context) {
+	system := getSystem()
+	var delta config.SystemDelta
+	if err := bindData(c, &delta); err != nil {
+		return
+	}
+	optimizerSpec := delta.Optimizer
+	if optimizerSpec == nil {
+		optimizerSpec = &config.OptimizerSpec{}
+	}
+	optimizeMutex.Lock()
+	defer optimizeMutex.Unlock()
+	startTime := time.Now()
+	if err := system.ApplyDelta(&delta); err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, core.ErrNoServer) {
+			status = http.StatusNotFound
+		}
+		c.IndentedJSON(status, gin.H{"message": err.Error()})
+		return
+	}
+	hash := inputHash(system, optimizerSpec)
+	solution, err := reoptimizeSystem(c.Request.Context(), system, optimizerSpec)
+	if err != nil {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": err.Error()})
+		return
+	}
+	recordSolutionMetrics(system, solution, time.Since(startTime))
+	recordHistory(hash, optimizerSpec, solution)
+	c.IndentedJSON(http.StatusOK, solution)
+}
+
+// optimize a system given an optimizer spec, returning its solution
+//   - the optimization is bounded by the context of the request and the max optimization time of the server
+//   - a partial solution is returned if the optimization timed out, as noted in its metadata
+func optimizeSystem(ctx context.Context, system *core.System, optimizerSpec *config.OptimizerSpec) (*config.AllocationSolution, error) {
+	return solveSystem(ctx, system, optimizerSpec, (*manager.Manager).Optimize)
+}
+
+// re-optimize a system given an optimizer spec, keeping servers on their current accelerators
+// within the churn budget of the spec, returning its solution
+func reoptimizeSystem(ctx context.Context, system *core.System, optimizerSpec *config.OptimizerSpec) (*config.AllocationSolution, error) {
+	return solveSystem(ctx, system, optimizerSpec, (*manager.Manager).Reoptimize)
+}
+
+// solve a system with an optimization run by a manager, returning its solution
+func solveSystem(ctx context.Context, system *core.System, optimizerSpec *config.OptimizerSpec,
+	optimize func(*manager.Manager, context.Context) error) (*config.AllocationSolution, error) {
+	ctx, cancel := context.WithTimeout(ctx, maxOptimizationTime)
+	defer cancel()
+	optimizer := solver.NewOptimizerFromSpec(optimizerSpec)
+	manager := manager.NewManager(system, optimizer)
+	if optimizerSpec.OptimizeForPeak {
+		system.SetPeakLoads()
+	}
+	// a timeout calculating allocations is also returned by the optimization, as the context remains done
+	system.CalculateContext(ctx)
+	if err := optimize(manager, ctx); err != nil && !errors.Is(err, core.ErrTimeout) {
+		return nil, fmt.Errorf("optimization error: %w", err)
+	}
+	return system.GenerateSolution(), nil
+}
+
+// plan allocations of servers of the current system for each time window of their load profiles
+//   - the current allocations and loads of servers are not changed
+func planWindows(c *gin.Context) {
+	var optimizerSpec config.OptimizerSpec
+	if err := bindData(c, &optimizerSpec); err != nil {
+		return
+	}
+	optimizeMutex.Lock()
+	defer optimizeMutex.Unlock()
+	ctx, cancel := context.WithTimeout(c.Request.Context(), maxOptimizationTime)
+	defer cancel()
+	manager := manager.NewManager(getSystem(), solver.NewOptimizerFromSpec(&optimizerSpec))
+	c.IndentedJSON(http.StatusOK, manager.PlanWindows(ctx))
+}
+
+// project the cost and capacity needs of the current system, with arrival rates of servers scaled by factors,
+// optimizing a copy of the system
+func forecast(c *gin.Context) {
+	var request config.ForecastRequest
+	if err := bindData(c, &request); err != nil {
+		return
+	}
+	factor := request.Factor
+	if factor == 0 {
+		factor = 1
+	}
+	optimizerSpec := request.Optimizer
+	if optimizerSpec == nil {
+		optimizerSpec = &config.OptimizerSpec{}
+	}
+
+	clone, err := getSystem().Clone()
+	if err != nil {
+		c.IndentedJSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+	clone.ScaleLoads(factor, request.Factors)
+	solution, err := optimizeSystem(c.Request.Context(), clone, optimizerSpec)
+	if err != nil {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": err.Error()})
+		return
+	}
+
+	result := config.ForecastResult{
+		Shortfall:   clone.CapacityShortfall(),
+		Unallocated: solution.Unallocated,
+		Solution:    solution,
+	}
+	for _, allocData := range solution.Spec {
+		result.TotalCost += allocData.Cost
+	}
+	c.IndentedJSON(http.StatusOK, result)
+}
+
+// minimum capacity of accelerator types needed to satisfy SLOs of all servers at minimum cost,
+// optimizing a copy of the system with unlimited capacity
+func getRequiredCapacity(c *gin.Context) {
+	clone, err := getSystem().Clone()
+	if err != nil {
+		c.IndentedJSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+	if _, err := optimizeSystem(c.Request.Context(), clone, &config.OptimizerSpec{Unlimited: true}); err != nil {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": err.Error()})
+		return
+	}
+	c.IndentedJSON(http.StatusOK, clone.UsedCapacity())
+}
+
+func plan(c *gin.Context) {
+	system := getSystem()
+	diffs := system.PlanDiff()
+	diffData := make(map[string]config.AllocationDiffData, len(diffs))
+	for serverName, diff := range diffs {
+		diffData[serverName] = *diff.AllocationDiffData()
+	}
+	c.IndentedJSON(http.StatusOK, diffData)
+}
+
+// utilization of accelerator types by the allocations of the last solution
+func getUtilization(c *gin.Context) {
+	system := getSystem()
+	c.IndentedJSON(http.StatusOK, system.UtilizationData())
+}
+
+// apply desired allocations of all servers
 func applyAllocation(c *gin.Context) {
-	for _, server := range system.Servers() {
-		server.ApplyDesiredAlloc()
+	applyWithKey(c, config.ApplyRequest{})
+}
+
+// apply desired allocations of servers in ascending order of transition penalty, up to a max number of transitions
+func apply(c *gin.Context) {
+	var request config.ApplyRequest
+	if c.Request.ContentLength != 0 {
+		if err := bindData(c, &request); err != nil {
+			return
+		}
+	}
+	applyWithKey(c, request)
+}
+
+// replace the current system with an empty one, clearing state kept across requests
+//   - waits for an optimization of the current system in progress, if any
+//   - cached allocations and responses to apply requests with idempotency keys are cleared
+func reset(c *gin.Context) {
+	optimizeMutex.Lock()
+	defer optimizeMutex.Unlock()
+	setSystem(core.NewSystem())
+	core.ClearAllocationCache()
+	clearApplyResponses()
+	if err := historyStore.Clear(); err != nil {
+		c.IndentedJSON(http.StatusInternalServerError, gin.H{"message": "failed to clear history: " + err.Error()})
+		return
+	}
+	c.IndentedJSON(http.StatusOK, gin.H{"message": "system reset"})
+}
+
+func getMetrics(c *gin.Context) {
+	c.Header("Content-Type", "text/plain; version=0.0.4")
+	c.Status(http.StatusOK)
+	metrics.Write(c.Writer)
+}
+
+// record metrics about an optimization and its solution
+func recordSolutionMetrics(system *core.System, solution *config.AllocationSolution, duration time.Duration) {
+	metrics.OptimizationDuration.Observe(duration.Seconds())
+	metrics.AllocatedServers.Set("", float64(len(solution.Spec)))
+	metrics.UnallocatedServers.Set("", float64(len(solution.Unallocated)))
+	var totalCost float32
+	for _, allocData := range solution.Spec {
+		totalCost += allocData.Cost
+	}
+	metrics.SolutionCost.Set("", float64(totalCost))
+	metrics.AcceleratorUtilization.Reset()
+	for typeName, utilization := range system.Utilization() {
+		metrics.AcceleratorUtilization.Set(typeName, float64(utilization))
 	}
-	c.IndentedJSON(http.StatusOK, "Done")
 }
@@ -0,0 +1,175 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/llm-inferno/optimizer/pkg/config"
+	"github.com/llm-inferno/optimizer/pkg/core"
+	"github.com/llm-inferno/optimizer/pkg/metrics"
+	"github.com/llm-inferno/optimizer/pkg/testutil"
+)
+
+// Reset replaces the system with an empty one, and clears cached allocations and responses to apply requests, so
+// that an idempotency key may be reused with a different request
+func TestResetClearsState(t *testing.T) {
+	server := newTestServer(t)
+
+	// cache an allocation of a server
+	spec := testutil.SystemSpec()
+	spec.Servers.Spec = []config.ServerSpec{testutil.ServerSpec("premium-granite", "Premium", 600)}
+	allocate := func() {
+		system := testutil.SetFromSpec(t, core.NewSystem(), spec)
+		if _, err := core.CreateAllocation(system, "premium-granite", "G2"); err != nil {
+			t.Fatalf("allocation: %v", err)
+		}
+	}
+	allocate()
+
+	// record a solution in the history
+	setSystem(testutil.SetFromSpec(t, core.NewSystem(), spec))
+	if w := doRequest(server, http.MethodPost, "/optimize", "application/json", "{}"); w.Code != http.StatusOK {
+		t.Fatalf("optimize: status=%d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+	history := func() []config.HistoryEntry {
+		var entries []config.HistoryEntry
+		w := doRequest(server, http.MethodGet, "/history", "", "")
+		if err := json.Unmarshal(w.Body.Bytes(), &entries); err != nil {
+			t.Fatalf("history: %v: %s", err, w.Body)
+		}
+		return entries
+	}
+	if len(history()) == 0 {
+		t.Fatal("no solution in history before reset")
+	}
+
+	// record a response to an apply request with an idempotency key
+	apply := func(body string) int {
+		return doRequestWithHeader(server, http.MethodPost, "/apply", body, IdempotencyKeyHeader, "key").Code
+	}
+	if code := apply(`{"maxConcurrentTransitions": 1}`); code != http.StatusOK {
+		t.Fatalf("apply: status=%d, want %d", code, http.StatusOK)
+	}
+
+	before := getSystem()
+	w := doRequest(server, http.MethodPost, "/reset", "", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("reset: status=%d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+	if getSystem() == before {
+		t.Error("system not replaced")
+	}
+	if entries := history(); len(entries) != 0 {
+		t.Errorf("history after reset has %d entries, want none", len(entries))
+	}
+	var accelerators []config.AcceleratorSpec
+	w = doRequest(server, http.MethodGet, "/getAccelerators", "", "")
+	if err := json.Unmarshal(w.Body.Bytes(), &accelerators); err != nil || len(accelerators) != 0 {
+		t.Errorf("accelerators after reset=%s, want none", w.Body)
+	}
+
+	hits := metrics.AllocationCacheLookups.Value("hit")
+	allocate()
+	if metrics.AllocationCacheLookups.Value("hit") != hits {
+		t.Error("allocation cached before reset still cached")
+	}
+	if code := apply(`{"maxConcurrentTransitions": 2}`); code != http.StatusOK {
+		t.Errorf("apply with key used before reset: status=%d, want %d", code, http.StatusOK)
+	}
+}
+
+// Reads of models, perf data and targets do not race with perf data being added and removed, and with optimizations
+// in progress (run with -race)
+func TestConcurrentReadsAndWrites(t *testing.T) {
+	server := newTestServer(t)
+	spec := testutil.SystemSpec()
+	spec.Servers.Spec = []config.ServerSpec{testutil.ServerSpec("premium-granite", "Premium", 600)}
+	spec.Capacity = testutil.Capacity(8)
+	setSystem(testutil.SetFromSpec(t, core.NewSystem(), spec))
+
+	perfData, err := json.Marshal(spec.Models.PerfData[1])
+	if err != nil {
+		t.Fatalf("cannot marshal perf data: %v", err)
+	}
+	writes := []func() *httptest.ResponseRecorder{
+		func() *httptest.ResponseRecorder {
+			return doRequest(server, http.MethodPost, "/addModelAcceleratorPerf", "application/json", string(perfData))
+		},
+		func() *httptest.ResponseRecorder {
+			return doRequest(server, http.MethodGet, "/removeModelAcceleratorPerf/granite_13b/G2", "", "")
+		},
+		func() *httptest.ResponseRecorder {
+			return doRequest(server, http.MethodPost, "/optimize", "application/json", "{}")
+		},
+		func() *httptest.ResponseRecorder {
+			return doRequest(server, http.MethodGet, "/addServiceClass/Standard/2", "", "")
+		},
+		func() *httptest.ResponseRecorder {
+			return doRequest(server, http.MethodGet, "/removeServiceClass/Standard", "", "")
+		},
+	}
+	reads := []string{"/getModels", "/getModel/granite_13b", "/getModelAcceleratorPerf/granite_13b/A100",
+		"/getServiceClassModelTarget/Premium/granite_13b", "/getServiceClasses", "/getServers", "/snapshot"}
+
+	var wg sync.WaitGroup
+	for _, write := range writes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 20 {
+				write()
+			}
+		}()
+	}
+	for _, path := range reads {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 20 {
+				if w := doRequest(server, http.MethodGet, path, "", ""); w.Code != http.StatusOK {
+					t.Errorf("GET %s: status=%d, want %d: %s", path, w.Code, http.StatusOK, w.Body)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// Optimizations of independent systems in a batch do not change the metrics of the last solution of the current system
+func TestOptimizeBatchKeepsSolutionMetrics(t *testing.T) {
+	server := newTestServer(t)
+	spec := testutil.SystemSpec()
+	spec.Servers.Spec = []config.ServerSpec{testutil.ServerSpec("premium-granite", "Premium", 600)}
+	spec.Capacity = testutil.Capacity(8)
+	setSystem(testutil.SetFromSpec(t, core.NewSystem(), spec))
+	if w := doRequest(server, http.MethodPost, "/optimize", "application/json", "{}"); w.Code != http.StatusOK {
+		t.Fatalf("optimize: status=%d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+	allocated, cost := metrics.AllocatedServers.Value(""), metrics.SolutionCost.Value("")
+
+	batchSpec := testutil.SystemSpec()
+	batchSpec.Servers.Spec = []config.ServerSpec{
+		testutil.ServerSpec("premium-granite", "Premium", 600),
+		testutil.ServerSpec("premium-granite-2", "Premium", 300),
+	}
+	batchSpec.Capacity = testutil.Capacity(16)
+	body, err := json.Marshal([]config.SystemData{{Spec: *batchSpec}})
+	if err != nil {
+		t.Fatalf("cannot marshal batch: %v", err)
+	}
+	w := doRequest(server, http.MethodPost, "/optimize/batch", "application/json", string(body))
+	var results []config.OptimizationResult
+	if err := json.Unmarshal(w.Body.Bytes(), &results); err != nil || len(results) != 1 || results[0].Solution == nil {
+		t.Fatalf("batch: status=%d, body=%s", w.Code, w.Body)
+	}
+	if len(results[0].Solution.Spec) == int(allocated) {
+		t.Fatalf("batch solution allocates %d servers, as the current system", len(results[0].Solution.Spec))
+	}
+	if metrics.AllocatedServers.Value("") != allocated || metrics.SolutionCost.Value("") != cost {
+		t.Errorf("allocated servers=%v, cost=%v after batch, want %v, %v",
+			metrics.AllocatedServers.Value(""), metrics.SolutionCost.Value(""), allocated, cost)
+	}
+}
@@ -0,0 +1,166 @@
+package rest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/llm-inferno/optimizer/pkg/config"
+	"github.com/llm-inferno/optimizer/pkg/core"
+)
+
+// store of the history of solutions, in memory unless replaced (see SetHistoryStore)
+var historyStore HistoryStore = NewMemoryHistory(MaxHistoryEntries)
+
+// replace the store of the history of solutions, e.g. with a persistent one, before running the server
+func SetHistoryStore(store HistoryStore) {
+	historyStore = store
+}
+
+// History of solutions in memory: a ring buffer of the most recent entries
+type MemoryHistory struct {
+	mutex   sync.Mutex
+	entries []*config.HistoryEntry // ring buffer of entries, the oldest at start once full
+	start   int                    // index of the oldest entry
+	lastID  int64                  // ID of the last entry added
+}
+
+// create a history in memory, keeping a max number of entries
+func NewMemoryHistory(maxEntries int) *MemoryHistory {
+	return &MemoryHistory{entries: make([]*config.HistoryEntry, 0, max(maxEntries, 1))}
+}
+
+// add an entry, forgetting the oldest if full
+func (h *MemoryHistory) Add(entry *config.HistoryEntry) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.lastID++
+	entry.ID = h.lastID
+	if len(h.entries) < cap(h.entries) {
+		h.entries = append(h.entries, entry)
+		return nil
+	}
+	h.entries[h.start] = entry
+	h.start = (h.start + 1) % len(h.entries)
+	return nil
+}
+
+// get entries generated in a time range (inclusive), oldest first
+func (h *MemoryHistory) Range(from time.Time, to time.Time) ([]*config.HistoryEntry, error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	entries := make([]*config.HistoryEntry, 0)
+	for i := range h.entries {
+		entry := h.entries[(h.start+i)%len(h.entries)]
+		if !entry.Time.Before(from) && !entry.Time.After(to) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// get an entry by ID (nil if forgotten or never added)
+func (h *MemoryHistory) Get(id int64) (*config.HistoryEntry, error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	for _, entry := range h.entries {
+		if entry.ID == id {
+			return entry, nil
+		}
+	}
+	return nil, nil
+}
+
+// remove all entries, without reusing their IDs
+func (h *MemoryHistory) Clear() error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	clear(h.entries)
+	h.entries = h.entries[:0]
+	h.start = 0
+	return nil
+}
+
+// hash of the inputs of an optimization: the specs of a system, before optimizing it, and of the optimizer
+func inputHash(system *core.System, optimizerSpec *config.OptimizerSpec) string {
+	inputs := struct {
+		Spec      *config.SystemSpec    `json:"system"`
+		Optimizer *config.OptimizerSpec `json:"optimizer"`
+	}{system.Spec(), optimizerSpec}
+	data, err := json.Marshal(inputs)
+	if err != nil {
+		return ""
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:8])
+}
+
+// record a solution in the history, given the hash of its inputs and the optimizer spec used
+//   - a failure of the store is reported but does not fail the optimization
+func recordHistory(hash string, optimizerSpec *config.OptimizerSpec, solution *config.AllocationSolution) {
+	entry := &config.HistoryEntry{
+		Time:      time.Now(),
+		InputHash: hash,
+		Optimizer: *optimizerSpec,
+		Summary: config.SolutionSummary{
+			TotalCost:   solution.TotalCost,
+			Allocated:   len(solution.Spec),
+			Unallocated: len(solution.Unallocated),
+		},
+		Solution: solution,
+	}
+	if err := historyStore.Add(entry); err != nil {
+		fmt.Println("failed to record solution in history: " + err.Error())
+	}
+}
+
+// get the history of solutions generated in a time range, given by optional from and to query parameters (RFC 3339)
+func getHistory(c *gin.Context) {
+	from, to := time.Time{}, time.Now()
+	for _, param := range []struct {
+		name string
+		t    *time.Time
+	}{{"from", &from}, {"to", &to}} {
+		value := c.Query(param.name)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"message": "invalid " + param.name + ": " + err.Error()})
+			return
+		}
+		*param.t = t
+	}
+	entries, err := historyStore.Range(from, to)
+	if err != nil {
+		c.IndentedJSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+	c.IndentedJSON(http.StatusOK, entries)
+}
+
+// get an entry of the history of solutions by ID
+func getHistoryEntry(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": "invalid id " + c.Param("id")})
+		return
+	}
+	entry, err := historyStore.Get(id)
+	if err != nil {
+		c.IndentedJSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+	if entry == nil {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "history entry " + c.Param("id") + " not found"})
+		return
+	}
+	c.IndentedJSON(http.StatusOK, entry)
+}
@@ -0,0 +1,83 @@
+package rest
+
+import (
+	"net/http"
+	"slices"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/llm-inferno/optimizer/pkg/config"
+)
+
+// Response to an apply request with an idempotency key
+type appliedResponse struct {
+	route   string              // route of the request
+	request config.ApplyRequest // body of the request
+	status  int                 // status of the response
+	result  *config.ApplyResult // body of the response
+}
+
+// responses to apply requests by idempotency key, replayed when a request is retried
+var applyResponses = struct {
+	mutex     sync.Mutex
+	responses map[string]*appliedResponse
+	keys      []string // keys in order of first use
+}{
+	responses: make(map[string]*appliedResponse),
+}
+
+// apply desired allocations of the current system, responding with the applied, pending, and failed servers
+//   - the status is 207 (Multi-Status) if some servers failed to apply their desired allocations
+//   - a request with the idempotency key of a prior request is not applied again, the prior response is replayed;
+//     reusing a key for a different request is an error
+func applyWithKey(c *gin.Context, request config.ApplyRequest) {
+	key := c.GetHeader(IdempotencyKeyHeader)
+	if key == "" {
+		status, result := applyDesiredAllocs(request)
+		c.IndentedJSON(status, result)
+		return
+	}
+
+	applyResponses.mutex.Lock()
+	defer applyResponses.mutex.Unlock()
+	if prior := applyResponses.responses[key]; prior != nil {
+		if prior.route != c.FullPath() || prior.request != request {
+			c.IndentedJSON(http.StatusUnprocessableEntity,
+				gin.H{"message": "idempotency key " + key + " already used by a different request"})
+			return
+		}
+		c.Header(IdempotentReplayedHeader, "true")
+		c.IndentedJSON(prior.status, prior.result)
+		return
+	}
+	status, result := applyDesiredAllocs(request)
+	if len(applyResponses.keys) >= MaxIdempotencyKeys {
+		delete(applyResponses.responses, applyResponses.keys[0])
+		applyResponses.keys = slices.Delete(applyResponses.keys, 0, 1)
+	}
+	applyResponses.responses[key] = &appliedResponse{
+		route:   c.FullPath(),
+		request: request,
+		status:  status,
+		result:  result,
+	}
+	applyResponses.keys = append(applyResponses.keys, key)
+	c.IndentedJSON(status, result)
+}
+
+// forget responses to apply requests, e.g. as the system is reset
+func clearApplyResponses() {
+	applyResponses.mutex.Lock()
+	defer applyResponses.mutex.Unlock()
+	clear(applyResponses.responses)
+	applyResponses.keys = nil
+}
+
+// apply desired allocations of the current system, returning the status and result
+func applyDesiredAllocs(request config.ApplyRequest) (int, *config.ApplyResult) {
+	result := getSystem().ApplyDesiredAllocs(request.MaxConcurrentTransitions)
+	if len(result.Failed) > 0 {
+		return http.StatusMultiStatus, result
+	}
+	return http.StatusOK, result
+}
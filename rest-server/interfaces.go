@@ -1,6 +1,24 @@
 package rest
 
+import (
+	"time"
+
+	"github.com/llm-inferno/optimizer/pkg/config"
+)
+
 // interface to a REST server
 type RESTServer interface {
 	Run()
 }
+
+// interface to a store of the history of solutions, in memory or persistent
+type HistoryStore interface {
+	// add an entry, assigning its ID
+	Add(entry *config.HistoryEntry) error
+	// get entries generated in a time range (inclusive), in order of ID
+	Range(from time.Time, to time.Time) ([]*config.HistoryEntry, error)
+	// get an entry by ID (nil if not stored)
+	Get(id int64) (*config.HistoryEntry, error)
+	// remove all entries, e.g. when the system is reset
+	Clear() error
+}
@@ -0,0 +1,175 @@
+package rest
+
+import (
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/llm-inferno/optimizer/pkg/config"
+)
+
+// Documentation of a route: summary, and data types of its request and response bodies (nil if none)
+type routeDoc struct {
+	summary  string
+	request  any
+	response any
+}
+
+// Documentation of routes, by method and path
+//   - routes registered in a server and missing here are documented with no bodies
+var routeDocs = map[string]routeDoc{
+	"POST /setAccelerators":        {"set accelerators", config.AcceleratorData{}, config.AcceleratorData{}},
+	"GET /getAccelerators":         {"get accelerators", nil, []config.AcceleratorSpec{}},
+	"GET /getAccelerator/:name":    {"get an accelerator", nil, config.AcceleratorSpec{}},
+	"POST /addAccelerator":         {"add an accelerator", config.AcceleratorSpec{}, config.AcceleratorSpec{}},
+	"GET /removeAccelerator/:name": {"remove an accelerator", nil, config.AcceleratorSpec{}},
+
+	"POST /setCapacities":       {"set counts of accelerator types", config.CapacityData{}, config.CapacityData{}},
+	"GET /getCapacities":        {"get counts of accelerator types", nil, config.CapacityData{}},
+	"GET /getCapacity/:type":    {"get count of an accelerator type", nil, config.AcceleratorCount{}},
+	"POST /setCapacity":         {"set count of an accelerator type", config.AcceleratorCount{}, config.AcceleratorCount{}},
+	"GET /removeCapacity/:type": {"remove count of an accelerator type", nil, config.AcceleratorCount{}},
+	"GET /capacity/required":    {"get counts of accelerator types needed to satisfy SLOs of all servers at minimum cost", nil, config.CapacityData{}},
+
+	"POST /setModels":        {"set models", config.ModelData{}, config.ModelData{}},
+	"GET /getModels":         {"get model names", nil, []string{}},
+	"GET /getModel/:name":    {"get performance data of a model", nil, config.ModelData{}},
+	"GET /addModel/:name":    {"add a model", nil, ""},
+	"GET /removeModel/:name": {"remove a model", nil, ""},
+
+	"POST /setServiceClasses":                      {"set service classes", config.ServiceClassData{}, config.ServiceClassData{}},
+	"GET /getServiceClasses":                       {"get service classes", nil, config.ServiceClassData{}},
+	"GET /getServiceClass/:name":                   {"get a service class", nil, config.ServiceClassSpec{}},
+	"GET /addServiceClass/:name/:priority":         {"add a service class", nil, config.ServiceClassSpec{}},
+	"GET /removeServiceClass/:name":                {"remove a service class", nil, config.ServiceClassSpec{}},
+	"POST /addServiceClassModelTargets":            {"add model targets to a service class", config.ServiceClassSpec{}, config.ServiceClassSpec{}},
+	"GET /getServiceClassModelTarget/:name/:model": {"get a model target of a service class", nil, config.ModelTarget{}},
+	"PATCH /updateServiceClassModelTarget/:name/:model": {"update fields of a model target of a service class",
+		config.ModelTarget{}, config.ModelTarget{}},
+	"GET /removeServiceClassModelTarget/:name/:model": {"remove a model target of a service class", nil, config.ModelTarget{}},
+
+	"POST /setServers":                {"set servers", config.ServerData{}, config.ServerData{}},
+	"GET /getServers":                 {"get servers", nil, config.ServerData{}},
+	"GET /getServer/:name":            {"get a server", nil, config.ServerSpec{}},
+	"GET /servers/:name/load":         {"get the current load of a server", nil, config.ServerLoadSpec{}},
+	"PUT /servers/:name/load":         {"set the current load of a server", config.ServerLoadSpec{}, config.ServerLoadSpec{}},
+	"GET /getServerAllocations/:name": {"get candidate allocations of a server", nil, []config.CandidateAllocationData{}},
+	"POST /scaleServer/:name":         {"recommend scaling a server to a new load", config.ServerLoadSpec{}, config.ScaleRecommendation{}},
+	"POST /feasibility":               {"check feasibility of a server given its load and SLO targets", config.FeasibilityRequest{}, config.FeasibilityResult{}},
+	"POST /sensitivity":               {"get the sensitivity of the cost of servers to their SLO targets", config.SensitivityRequest{}, config.SensitivityResult{}},
+	"GET /validate":                   {"validate the consistency of data of the system", nil, []config.ConsistencyIssue{}},
+	"POST /evaluateAllocation/:name":  {"evaluate SLO compliance of an allocation given for a server", config.AllocationData{}, config.AllocationEvaluation{}},
+	"GET /sweepBatchSize/:name/:acc":  {"size allocations of an accelerator to a server at each max batch size", nil, []config.BatchSizePoint{}},
+	"POST /addServer":                 {"add a server", config.ServerSpec{}, config.ServerSpec{}},
+	"PATCH /updateServer/:name":       {"update fields of a server", config.ServerSpec{}, config.ServerSpec{}},
+	"GET /removeServer/:name":         {"remove a server", nil, config.ServerSpec{}},
+
+	"GET /getModelAcceleratorPerf/:name/:acc":    {"get performance data of a model on an accelerator", nil, config.ModelAcceleratorPerfData{}},
+	"POST /addModelAcceleratorPerf":              {"add performance data of a model on an accelerator", config.ModelAcceleratorPerfData{}, config.ModelAcceleratorPerfData{}},
+	"GET /removeModelAcceleratorPerf/:name/:acc": {"remove performance data of a model on an accelerator", nil, config.ModelAcceleratorPerfData{}},
+	"POST /models/perf/import": {"import performance data of models on accelerators, fitting decode parameters to benchmarks",
+		[]config.PerfImportEntry{}, []config.PerfImportResult{}},
+
+	"POST /optimize":       {"optimize the current system", config.OptimizerSpec{}, config.AllocationSolution{}},
+	"POST /optimizeOne":    {"optimize a system given all its data", config.SystemData{}, config.AllocationSolution{}},
+	"POST /optimize/batch": {"optimize independent systems", []config.SystemData{}, []config.OptimizationResult{}},
+	"POST /optimizeDelta":  {"apply changes to servers of the current system, then re-optimize it", config.SystemDelta{}, config.AllocationSolution{}},
+	"GET /optimize/stream": {"stream optimizations as loads of servers are updated (WebSocket)", config.StreamRequest{},
+		map[string]config.AllocationDiffData{}},
+	"GET /plan":            {"preview changes from current to desired allocations", nil, map[string]config.AllocationDiffData{}},
+	"POST /planWindows":    {"plan allocations for each time window of load profiles", config.OptimizerSpec{}, []config.WindowPlan{}},
+	"POST /forecast":       {"project cost and capacity needs under scaled loads", config.ForecastRequest{}, config.ForecastResult{}},
+	"GET /utilization":     {"get utilization of accelerator types by the allocations of the last solution", nil, []config.AcceleratorUtilization{}},
+	"GET /snapshot":        {"get a snapshot of the specs of the system and its last solution", nil, config.SystemSnapshot{}},
+	"GET /history":         {"get the history of solutions generated in a time range", nil, []config.HistoryEntry{}},
+	"GET /history/:id":     {"get an entry of the history of solutions", nil, config.HistoryEntry{}},
+	"GET /applyAllocation": {"apply desired allocations of all servers", nil, config.ApplyResult{}},
+	"POST /apply":          {"apply desired allocations in batches of transitions", config.ApplyRequest{}, config.ApplyResult{}},
+	"POST /reset":          {"clear the current system", nil, nil},
+	"GET /metrics":         {"get metrics in the Prometheus text format", nil, nil},
+	"GET /openapi":         {"get the OpenAPI document of the server", nil, nil},
+}
+
+// path parameters of routes, e.g. :name
+var pathParamPattern = regexp.MustCompile(`:([^/]+)`)
+
+// OpenAPI document of the routes of a server, with schemas of their data types derived from the config types
+func OpenAPI(routes gin.RoutesInfo) map[string]any {
+	b := config.NewSchemaBuilder("#/components/schemas/")
+	paths := make(map[string]any)
+	routes = slices.Clone(routes)
+	slices.SortFunc(routes, func(a, b gin.RouteInfo) int {
+		return strings.Compare(a.Path+" "+a.Method, b.Path+" "+b.Method)
+	})
+	for _, route := range routes {
+		doc := routeDocs[route.Method+" "+route.Path]
+		operation := map[string]any{}
+		if doc.summary != "" {
+			operation["summary"] = doc.summary
+		}
+
+		params := make([]any, 0)
+		for _, match := range pathParamPattern.FindAllStringSubmatch(route.Path, -1) {
+			params = append(params, map[string]any{
+				"name":     match[1],
+				"in":       "path",
+				"required": true,
+				"schema":   map[string]any{"type": "string"},
+			})
+		}
+		if len(params) > 0 {
+			operation["parameters"] = params
+		}
+
+		if doc.request != nil {
+			operation["requestBody"] = map[string]any{
+				"content": bodyContent(b.SchemaOf(doc.request)),
+			}
+		}
+		response := map[string]any{"description": "OK"}
+		if doc.response != nil {
+			response["content"] = bodyContent(b.SchemaOf(doc.response))
+		}
+		operation["responses"] = map[string]any{
+			"200": response,
+		}
+
+		path := pathParamPattern.ReplaceAllString(route.Path, "{$1}")
+		item, _ := paths[path].(map[string]any)
+		if item == nil {
+			item = make(map[string]any)
+			paths[path] = item
+		}
+		item[strings.ToLower(route.Method)] = operation
+	}
+	return map[string]any{
+		"openapi": "3.1.0",
+		"info": map[string]any{
+			"title":   "Inferno optimizer",
+			"version": "1.0.0",
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": b.Defs(),
+		},
+	}
+}
+
+// content of a request or response body, in JSON or YAML
+func bodyContent(schema map[string]any) map[string]any {
+	return map[string]any{
+		"application/json": map[string]any{"schema": schema},
+		"application/yaml": map[string]any{"schema": schema},
+	}
+}
+
+// OpenAPI document of the routes of the server
+func (server *BaseServer) OpenAPI() map[string]any {
+	return OpenAPI(server.router.Routes())
+}
+
+func (server *BaseServer) getOpenAPI(c *gin.Context) {
+	c.IndentedJSON(http.StatusOK, server.OpenAPI())
+}
@@ -0,0 +1,139 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/llm-inferno/optimizer/pkg/config"
+)
+
+// Columns of perf data in CSV, by header name, setting a field of an entry to import from a (non-empty) value
+//   - name and acc identify the model and accelerator of a row, and batchSize and decodeTime a measured decode time
+var perfCSVColumns = map[string]func(e *config.PerfImportEntry, value string) error{
+	"accCount":      intColumn(func(e *config.PerfImportEntry) *int { return &e.AccCount }),
+	"maxBatchSize":  intColumn(func(e *config.PerfImportEntry) *int { return &e.MaxBatchSize }),
+	"atTokens":      intColumn(func(e *config.PerfImportEntry) *int { return &e.AtTokens }),
+	"alpha":         floatColumn(func(e *config.PerfImportEntry) *float32 { return &e.DecodeParms.Alpha }),
+	"beta":          floatColumn(func(e *config.PerfImportEntry) *float32 { return &e.DecodeParms.Beta }),
+	"gamma":         floatColumn(func(e *config.PerfImportEntry) *float32 { return &e.PrefillParms.Gamma }),
+	"delta":         floatColumn(func(e *config.PerfImportEntry) *float32 { return &e.PrefillParms.Delta }),
+	"tpDegree":      intColumn(func(e *config.PerfImportEntry) *int { return &e.TPDegree }),
+	"tpScaling":     floatColumn(func(e *config.PerfImportEntry) *float32 { return &e.TPScaling }),
+	"unitCost":      floatColumn(func(e *config.PerfImportEntry) *float32 { return &e.UnitCost }),
+	"warmupSeconds": floatColumn(func(e *config.PerfImportEntry) *float32 { return &e.WarmupSeconds }),
+	"batchWindow":   floatColumn(func(e *config.PerfImportEntry) *float32 { return &e.BatchWindow }),
+}
+
+// setter of an integer column
+func intColumn(field func(*config.PerfImportEntry) *int) func(*config.PerfImportEntry, string) error {
+	return func(e *config.PerfImportEntry, value string) error {
+		v, err := strconv.Atoi(value)
+		*field(e) = v
+		return err
+	}
+}
+
+// setter of a float column
+func floatColumn(field func(*config.PerfImportEntry) *float32) func(*config.PerfImportEntry, string) error {
+	return func(e *config.PerfImportEntry, value string) error {
+		v, err := strconv.ParseFloat(value, 32)
+		*field(e) = float32(v)
+		return err
+	}
+}
+
+// Read perf data to import from CSV with a header row, one entry per model and accelerator, in order of first row
+//   - name and acc columns are required, other columns of perf data are optional (see perfCSVColumns),
+//     and taken from the first row of an entry
+//   - each row with batchSize and decodeTime (msec per output token) adds a measured decode time to its entry
+func readPerfCSV(r io.Reader) ([]config.PerfImportEntry, error) {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("missing header row")
+	}
+	header := rows[0]
+	for _, required := range []string{"name", "acc"} {
+		if !slices.Contains(header, required) {
+			return nil, fmt.Errorf("missing column %s", required)
+		}
+	}
+	for _, column := range header {
+		if _, exists := perfCSVColumns[column]; !exists &&
+			!slices.Contains([]string{"name", "acc", "batchSize", "decodeTime"}, column) {
+			return nil, fmt.Errorf("unknown column %s", column)
+		}
+	}
+
+	entries := make([]config.PerfImportEntry, 0)
+	index := make(map[[2]string]int) // index of entries, by model and accelerator
+	for i, row := range rows[1:] {
+		values := make(map[string]string, len(header))
+		for j, column := range header {
+			values[column] = row[j]
+		}
+		key := [2]string{values["name"], values["acc"]}
+		k, exists := index[key]
+		if !exists {
+			entry := config.PerfImportEntry{}
+			entry.Name, entry.Acc = key[0], key[1]
+			for column, value := range values {
+				if set := perfCSVColumns[column]; set != nil && value != "" {
+					if err := set(&entry, value); err != nil {
+						return nil, fmt.Errorf("row %d, column %s: %w", i+2, column, err)
+					}
+				}
+			}
+			k = len(entries)
+			index[key] = k
+			entries = append(entries, entry)
+		}
+		if values["batchSize"] == "" && values["decodeTime"] == "" {
+			continue
+		}
+		batchSize, err := strconv.Atoi(values["batchSize"])
+		if err != nil {
+			return nil, fmt.Errorf("row %d, column batchSize: %w", i+2, err)
+		}
+		decodeTime, err := strconv.ParseFloat(values["decodeTime"], 32)
+		if err != nil {
+			return nil, fmt.Errorf("row %d, column decodeTime: %w", i+2, err)
+		}
+		entries[k].Points = append(entries[k].Points, config.DecodePoint{BatchSize: batchSize, DecodeTime: float32(decodeTime)})
+	}
+	return entries, nil
+}
+
+// import perf data of models on accelerators given in CSV, or as an array in JSON or YAML, with results of entries
+//   - status is multi-status if any entry is not imported
+func importPerfData(c *gin.Context) {
+	system := getSystem()
+	var entries []config.PerfImportEntry
+	if c.ContentType() == "text/csv" {
+		body, err := c.GetRawData()
+		if err == nil {
+			entries, err = readPerfCSV(bytes.NewReader(body))
+		}
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"message": "invalid request body: " + err.Error()})
+			return
+		}
+	} else if err := bindData(c, &entries); err != nil {
+		return
+	}
+	results := system.ImportPerfData(entries)
+	status := http.StatusOK
+	if slices.ContainsFunc(results, func(r config.PerfImportResult) bool { return r.Error != "" }) {
+		status = http.StatusMultiStatus
+	}
+	c.IndentedJSON(status, results)
+}
@@ -0,0 +1,120 @@
+package rest
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/llm-inferno/optimizer/pkg/config"
+	"github.com/llm-inferno/optimizer/pkg/core"
+	"github.com/llm-inferno/optimizer/pkg/testutil"
+)
+
+// create a statefull REST server of a system with the accelerators of the test system, and no models, with no
+// state kept from previous requests
+func newTestServer(t *testing.T) *StateFullServer {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	gin.DefaultWriter = io.Discard
+	core.ClearAllocationCache()
+	clearApplyResponses()
+	spec := &config.SystemSpec{Accelerators: testutil.SystemSpec().Accelerators}
+	setSystem(testutil.SetFromSpec(t, core.NewSystem(), spec))
+	return NewStateFullServer()
+}
+
+// send a request with a body of a content type to a server, returning the recorded response
+func doRequest(server *StateFullServer, method string, path string, contentType string, body string) *httptest.ResponseRecorder {
+	return doRequestWithHeader(server, method, path, body, "Content-Type", contentType)
+}
+
+// send a request with a body and a header (if given) to a server, returning the recorded response
+func doRequestWithHeader(server *StateFullServer, method string, path string, body string,
+	header string, value string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if value != "" {
+		req.Header.Set(header, value)
+	}
+	w := httptest.NewRecorder()
+	server.router.ServeHTTP(w, req)
+	return w
+}
+
+// Perf data imported from CSV has decode parameters fitted to measured decode times, with the fit quality of each
+// pair: exact for points on a line, poor for scattered points; pairs of unknown accelerators are not imported
+func TestImportPerfDataFromCSV(t *testing.T) {
+	server := newTestServer(t)
+	csv := `name,acc,atTokens,gamma,delta,batchSize,decodeTime
+granite_13b,A100,512,200,0.021,1,20.5
+granite_13b,A100,,,,8,24
+granite_13b,A100,,,,16,28
+granite_13b,A100,,,,32,36
+granite_13b,G2,512,170,0.017,1,10
+granite_13b,G2,,,,8,30
+granite_13b,G2,,,,16,12
+granite_13b,G2,,,,32,35
+granite_13b,H100,512,150,0.01,1,10
+granite_13b,H100,,,,32,20
+`
+	w := doRequest(server, http.MethodPost, "/models/perf/import", "text/csv", csv)
+	if w.Code != http.StatusMultiStatus {
+		t.Fatalf("status=%d, want %d: %s", w.Code, http.StatusMultiStatus, w.Body)
+	}
+	var results []config.PerfImportResult
+	if err := json.Unmarshal(w.Body.Bytes(), &results); err != nil {
+		t.Fatalf("cannot parse results: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("results=%+v, want one per model and accelerator", results)
+	}
+	if r := results[0]; r.Acc != "A100" || !r.Fitted || r.Points != 4 || r.RSquared < 0.999 || r.PoorFit || r.Error != "" {
+		t.Errorf("result of A100=%+v, want fitted exactly from 4 points", r)
+	}
+	if r := results[1]; r.Acc != "G2" || !r.Fitted || !r.PoorFit || r.Error != "" {
+		t.Errorf("result of G2=%+v, want imported with a poor fit", r)
+	}
+	if r := results[2]; r.Acc != "H100" || r.Error == "" {
+		t.Errorf("result of H100=%+v, want not imported", r)
+	}
+
+	// decode time = 20 + 0.5 * batchSize on A100, the max batch size is the largest measured
+	model := getSystem().Model("granite_13b")
+	if model == nil {
+		t.Fatal("model not added")
+	}
+	perf := model.PerfData("A100")
+	if perf == nil || math.Abs(float64(perf.DecodeParms.Alpha-20)) > 1e-3 ||
+		math.Abs(float64(perf.DecodeParms.Beta-0.5)) > 1e-4 || perf.MaxBatchSize != 32 {
+		t.Errorf("perf data of A100=%+v, want alpha=20, beta=0.5, maxBatchSize=32", perf)
+	}
+	if model.PerfData("H100") != nil {
+		t.Error("perf data of unknown accelerator H100 imported")
+	}
+}
+
+// Perf data imported as a JSON array with given decode parameters is imported as is, not fitted
+func TestImportPerfDataFromJSON(t *testing.T) {
+	server := newTestServer(t)
+	body := `[{"name": "granite_13b", "acc": "G2", "accCount": 1, "maxBatchSize": 38, "atTokens": 512,
+		"decodeParms": {"alpha": 18, "beta": 0.3}, "prefillParms": {"gamma": 170, "delta": 0.017}}]`
+	w := doRequest(server, http.MethodPost, "/models/perf/import", "application/json", body)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status=%d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+	var results []config.PerfImportResult
+	if err := json.Unmarshal(w.Body.Bytes(), &results); err != nil {
+		t.Fatalf("cannot parse results: %v", err)
+	}
+	if len(results) != 1 || results[0].Fitted || results[0].Error != "" {
+		t.Fatalf("results=%+v, want one imported and not fitted", results)
+	}
+	perf := getSystem().Model("granite_13b").PerfData("G2")
+	if perf == nil || perf.DecodeParms.Alpha != 18 || perf.DecodeParms.Beta != 0.3 {
+		t.Errorf("perf data of G2=%+v, want alpha=18, beta=0.3", perf)
+	}
+}
@@ -0,0 +1,24 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/llm-inferno/optimizer/pkg/config"
+)
+
+// header of responses served from a snapshot of the system, giving the version of the snapshot
+const snapshotVersionHeader = "X-Snapshot-Version"
+
+// snapshot of the system to serve a read from, without waiting for an optimization in progress,
+// setting its version in the response header
+func readSnapshot(c *gin.Context) *config.SystemSnapshot {
+	snapshot := getSystem().Snapshot()
+	c.Header(snapshotVersionHeader, snapshot.Version)
+	return snapshot
+}
+
+// get a snapshot of the specs of the system and its last solution
+func getSnapshot(c *gin.Context) {
+	c.IndentedJSON(http.StatusOK, readSnapshot(c))
+}
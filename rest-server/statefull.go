@@ -22,6 +22,7 @@ func NewStateFullServer() *StateFullServer {
 	server.router.GET("/getCapacity/:type", getCapacity)
 	server.router.POST("/setCapacity", setCapacity)
 	server.router.GET("/removeCapacity/:type", removeCapacity)
+	server.router.GET("/capacity/required", getRequiredCapacity)
 
 	server.router.POST("/setModels", setModels)
 	server.router.GET("/getModels", getModels)
@@ -37,21 +38,46 @@ func NewStateFullServer() *StateFullServer {
 
 	server.router.POST("/addServiceClassModelTargets", addServiceClassModelTargets)
 	server.router.GET("/getServiceClassModelTarget/:name/:model", getServiceClassModelTarget)
+	server.router.PATCH("/updateServiceClassModelTarget/:name/:model", updateServiceClassModelTarget)
 	server.router.GET("/removeServiceClassModelTarget/:name/:model", removeServiceClassModelTarget)
 
 	server.router.POST("/setServers", setServers)
 	server.router.GET("/getServers", getServers)
 	server.router.GET("/getServer/:name", getServer)
+	server.router.GET("/servers/:name/load", getServerLoad)
+	server.router.PUT("/servers/:name/load", setServerLoad)
+	server.router.GET("/getServerAllocations/:name", getServerAllocations)
+	server.router.POST("/scaleServer/:name", scaleServer)
+	server.router.POST("/feasibility", checkFeasibility)
+	server.router.POST("/sensitivity", getSensitivity)
+	server.router.GET("/validate", validateSystem)
+	server.router.POST("/evaluateAllocation/:name", evaluateAllocation)
+	server.router.GET("/sweepBatchSize/:name/:acc", sweepBatchSize)
 	server.router.POST("/addServer", addServer)
+	server.router.PATCH("/updateServer/:name", updateServer)
 	server.router.GET("/removeServer/:name", removeServer)
 
 	server.router.GET("/getModelAcceleratorPerf/:name/:acc", getModelAcceleratorPerf)
 	server.router.POST("/addModelAcceleratorPerf", addModelAcceleratorPerf)
 	server.router.GET("/removeModelAcceleratorPerf/:name/:acc", removeModelAcceleratorPerf)
+	server.router.POST("/models/perf/import", importPerfData)
 
 	server.router.POST("/optimize", optimize)
 	server.router.POST("/optimizeOne", optimizeOne)
+	server.router.POST("/optimize/batch", optimizeBatch)
+	server.router.POST("/optimizeDelta", optimizeDelta)
+	server.router.GET("/optimize/stream", optimizeStream)
+	server.router.GET("/plan", plan)
+	server.router.POST("/forecast", forecast)
+	server.router.POST("/planWindows", planWindows)
+	server.router.GET("/utilization", getUtilization)
+	server.router.GET("/snapshot", getSnapshot)
+	server.router.GET("/history", getHistory)
+	server.router.GET("/history/:id", getHistoryEntry)
+	server.router.GET("/metrics", getMetrics)
 	server.router.GET("/applyAllocation", applyAllocation)
+	server.router.POST("/apply", apply)
+	server.router.POST("/reset", reset)
 
 	return server
 }
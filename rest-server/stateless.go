@@ -12,6 +12,8 @@ func NewStateLessServer() *StateLessServer {
 	}
 
 	server.router.POST("/optimizeOne", optimizeOne)
+	server.router.POST("/optimize/batch", optimizeBatch)
+	server.router.GET("/metrics", getMetrics)
 
 	server.router.GET("/getAccelerators", getAccelerators)
 	server.router.GET("/getAccelerator/:name", getAccelerator)
@@ -29,6 +31,8 @@ func NewStateLessServer() *StateLessServer {
 
 	server.router.GET("/getServers", getServers)
 	server.router.GET("/getServer/:name", getServer)
+	server.router.GET("/getServerAllocations/:name", getServerAllocations)
+	server.router.POST("/scaleServer/:name", scaleServer)
 
 	server.router.GET("/getModelAcceleratorPerf/:name/:acc", getModelAcceleratorPerf)
 
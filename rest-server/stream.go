@@ -0,0 +1,124 @@
+package rest
+
+import (
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/llm-inferno/optimizer/pkg/config"
+	"github.com/llm-inferno/optimizer/pkg/core"
+	"golang.org/x/net/websocket"
+)
+
+// Stream re-optimizations of the current system over a WebSocket, as loads of servers are updated
+//   - the client sends StreamRequest messages, with updated loads of servers and optionally the optimizer spec
+//   - updates are debounced, then the system is re-optimized, and the changes in desired allocations
+//     since the last message (or the start of the stream) are sent as a map of server names to AllocationDiffData
+//   - a message is sent only if there are changes
+func optimizeStream(c *gin.Context) {
+	// accept clients with no origin, such as control planes
+	server := websocket.Server{Handler: streamOptimizations}
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+func streamOptimizations(ws *websocket.Conn) {
+	defer ws.Close()
+	system := getSystem()
+
+	var (
+		mutex         sync.Mutex
+		optimizerSpec config.OptimizerSpec
+		timer         *time.Timer
+	)
+	lastAllocs := desiredAllocations(system)
+
+	reoptimize := func() {
+		mutex.Lock()
+		spec := optimizerSpec
+		mutex.Unlock()
+
+		// serialized with other optimizations, including earlier ones of this stream
+		optimizeMutex.Lock()
+		defer optimizeMutex.Unlock()
+		startTime := time.Now()
+		hash := inputHash(system, &spec)
+		solution, err := optimizeSystem(ws.Request().Context(), system, &spec)
+		if err != nil {
+			websocket.JSON.Send(ws, gin.H{"message": err.Error()})
+			return
+		}
+		recordSolutionMetrics(system, solution, time.Since(startTime))
+		recordHistory(hash, &spec, solution)
+
+		allocs := desiredAllocations(system)
+		if diffData := allocationChanges(lastAllocs, allocs); len(diffData) > 0 {
+			websocket.JSON.Send(ws, diffData)
+		}
+		lastAllocs = allocs
+	}
+
+	for {
+		var request config.StreamRequest
+		if err := websocket.JSON.Receive(ws, &request); err != nil {
+			break
+		}
+		mutex.Lock()
+		if request.Optimizer != nil {
+			optimizerSpec = *request.Optimizer
+		}
+		setServerLoads(system, request.Loads)
+		if timer == nil {
+			timer = time.AfterFunc(StreamDebounce, reoptimize)
+		} else {
+			timer.Reset(StreamDebounce)
+		}
+		mutex.Unlock()
+	}
+
+	mutex.Lock()
+	if timer != nil {
+		timer.Stop()
+	}
+	mutex.Unlock()
+}
+
+// set loads of servers of a system, ignoring servers which don't exist
+func setServerLoads(system *core.System, updates []config.ServerLoadUpdate) {
+	servers := system.Servers()
+	system.Lock()
+	defer system.Unlock()
+	for _, update := range updates {
+		if server := servers[update.Name]; server != nil {
+			load := update.Load
+			server.SetLoad(&load)
+		}
+	}
+}
+
+// copies of desired allocations of servers of a system, by server name
+func desiredAllocations(system *core.System) map[string]*core.Allocation {
+	servers := system.Servers()
+	system.Lock()
+	defer system.Unlock()
+	allocs := make(map[string]*core.Allocation)
+	for serverName, server := range servers {
+		if alloc := server.Allocation(); alloc != nil {
+			allocs[serverName] = alloc.Clone()
+		}
+	}
+	return allocs
+}
+
+// changes between two sets of allocations of servers, by server name
+func allocationChanges(oldAllocs, newAllocs map[string]*core.Allocation) map[string]config.AllocationDiffData {
+	diffData := make(map[string]config.AllocationDiffData)
+	serverNames := slices.Concat(slices.Collect(maps.Keys(oldAllocs)), slices.Collect(maps.Keys(newAllocs)))
+	for _, serverName := range serverNames {
+		if diff := core.CreateAllocationDiff(oldAllocs[serverName], newAllocs[serverName]); diff != nil && diff.Changed() {
+			diffData[serverName] = *diff.AllocationDiffData()
+		}
+	}
+	return diffData
+}